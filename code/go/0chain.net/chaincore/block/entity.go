@@ -46,6 +46,29 @@ var (
 	StateChangeSizeMetric metrics.Histogram
 )
 
+// stateHashRound - the round from which the block hash covers the client
+// state hash, zero if it never does
+var stateHashRound int64
+
+// SetStateHashRound - set the round from which the block hash covers the
+// client state hash, zero if it never does.
+func SetStateHashRound(round int64) {
+	atomic.StoreInt64(&stateHashRound, round)
+}
+
+// GetStateHashRound - the round from which the block hash covers the client
+// state hash, zero if it never does.
+func GetStateHashRound() int64 {
+	return atomic.LoadInt64(&stateHashRound)
+}
+
+// coversStateHash - whether the hash of the block of given round covers its
+// client state hash
+func coversStateHash(round int64) bool {
+	from := GetStateHashRound()
+	return from > 0 && round >= from
+}
+
 var (
 	ErrBlockHashMismatch      = common.NewError("block_hash_mismatch", "block hash mismatch")
 	ErrBlockStateHashMismatch = common.NewError("block_state_hash_mismatch", "block state hash mismatch")
@@ -482,6 +505,12 @@ func (b *Block) getHashData() string {
 	hashBuilder.WriteString(merkleRoot)
 	hashBuilder.WriteString(":")
 	hashBuilder.WriteString(rMerkleRoot)
+	// from the activation round the state root is covered by the hash, so
+	// that the notarization tickets authenticate the client state
+	if coversStateHash(b.Round) {
+		hashBuilder.WriteString(":")
+		hashBuilder.WriteString(util.ToHex(b.ClientStateHash))
+	}
 
 	if b.MagicBlock != nil {
 		if b.MagicBlock.Hash == "" {
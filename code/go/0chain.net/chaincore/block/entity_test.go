@@ -3232,3 +3232,36 @@ func TestBlock_DoReadLock(t *testing.T) {
 		})
 	}
 }
+
+func TestBlock_ComputeHashCoversClientState(t *testing.T) {
+	defer SetStateHashRound(GetStateHashRound())
+
+	b := NewBlock("", 10)
+	b.ClientStateHash = util.Key("client state hash")
+	SetStateHashRound(0)
+	hash := b.ComputeHash()
+
+	tests := []struct {
+		name           string
+		stateHashRound int64
+		covered        bool
+	}{
+		{name: "never", stateHashRound: 0, covered: false},
+		{name: "before activation", stateHashRound: 11, covered: false},
+		{name: "activation round", stateHashRound: 10, covered: true},
+		{name: "after activation", stateHashRound: 1, covered: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetStateHashRound(tt.stateHashRound)
+			b.ClientStateHash = util.Key("client state hash")
+			covered := b.ComputeHash()
+			b.ClientStateHash = util.Key("forged state hash")
+			forged := b.ComputeHash()
+
+			assert.Equal(t, tt.covered, covered != forged)
+			// the hash of the blocks before the activation is unchanged
+			assert.Equal(t, !tt.covered, covered == hash)
+		})
+	}
+}
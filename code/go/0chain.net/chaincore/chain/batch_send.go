@@ -0,0 +1,70 @@
+package chain
+
+import (
+	bcstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/currency"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/minersc"
+)
+
+// addBatchTransfers adds the transfers of a batch send transaction to the
+// state context. The balance of the sender is checked against the whole batch
+// and the fee up front, so either all of the transfers are made or none.
+func (c *Chain) addBatchTransfers(sctx bcstate.StateContextI, txn *transaction.Transaction) error {
+	data, _, err := getBatchSendData(sctx, txn)
+	if err != nil {
+		return err
+	}
+
+	total := txn.Value
+	if c.ChainConfig.IsFeeEnabled() {
+		if total, err = currency.AddCoin(total, txn.Fee); err != nil {
+			return err
+		}
+	}
+	s, err := c.GetStateById(sctx.GetState(), txn.ClientID)
+	if !isValid(err) {
+		return err
+	}
+	if s == nil || s.Balance < total {
+		return transaction.ErrInsufficientBalance
+	}
+
+	transfers := make([]event.TransactionTransfer, 0, len(data.Transfers))
+	for i, bt := range data.Transfers {
+		if err := sctx.AddTransfer(state.NewTransfer(txn.ClientID, bt.ToClientID, bt.Amount)); err != nil {
+			return err
+		}
+		transfers = append(transfers, event.TransactionTransfer{
+			TransactionHash: txn.Hash,
+			Index:           i,
+			BlockNumber:     sctx.GetBlock().Round,
+			ClientId:        txn.ClientID,
+			ToClientId:      bt.ToClientID,
+			Amount:          bt.Amount,
+		})
+	}
+	sctx.EmitEvent(event.TypeStats, event.TagAddTransactionTransfers, txn.Hash, transfers)
+	return nil
+}
+
+// getBatchSendData returns the transfers of the batch send transaction
+// checked against the max number of transfers set in the state, and the cost
+// of a transfer set in the state.
+func getBatchSendData(sctx bcstate.CommonStateContextI, txn *transaction.Transaction) (
+	data *transaction.BatchSendData, transferCost int, err error) {
+
+	if data, err = txn.GetBatchSendData(); err != nil {
+		return nil, 0, err
+	}
+	maxTransfers, transferCost, err := minersc.GetBatchSendConfig(sctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = data.CheckMaxTransfers(maxTransfers); err != nil {
+		return nil, 0, err
+	}
+	return data, transferCost, nil
+}
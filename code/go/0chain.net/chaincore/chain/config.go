@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	"0chain.net/chaincore/block"
 	"0chain.net/chaincore/config"
 	"0chain.net/chaincore/currency"
 
@@ -442,6 +443,7 @@ func (c *ConfigImpl) FromViper() error {
 	conf.ThresholdByStake = viper.GetInt("server_chain.block.consensus.threshold_by_stake")
 	conf.OwnerID = viper.GetString("server_chain.owner")
 	conf.ValidationBatchSize = viper.GetInt("server_chain.block.validation.batch_size")
+	block.SetStateHashRound(viper.GetInt64("server_chain.block.state_hash_round"))
 	conf.RoundRange = viper.GetInt64("server_chain.round_range")
 	conf.TxnMaxPayload = viper.GetInt("server_chain.transaction.payload.max_size")
 	var err error
@@ -510,6 +512,7 @@ func (c *ConfigImpl) FromViper() error {
 	conf.ClientSignatureScheme = viper.GetString("server_chain.client.signature_scheme")
 
 	conf.DbsEvents.Enabled = viper.GetBool("server_chain.dbs.events.enabled")
+	conf.DbsEvents.Driver = viper.GetString("server_chain.dbs.events.driver")
 	conf.DbsEvents.Name = viper.GetString("server_chain.dbs.events.name")
 	conf.DbsEvents.User = viper.GetString("server_chain.dbs.events.user")
 	conf.DbsEvents.Password = viper.GetString("server_chain.dbs.events.password")
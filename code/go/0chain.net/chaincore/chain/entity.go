@@ -32,6 +32,7 @@ import (
 	"0chain.net/core/encryption"
 	"0chain.net/core/logging"
 	"0chain.net/core/util"
+	"0chain.net/core/viper"
 	"0chain.net/smartcontract/dbs/event"
 	"0chain.net/smartcontract/minersc"
 )
@@ -165,6 +166,9 @@ type Chain struct {
 	viewChanger                  ViewChanger
 	afterFetcher                 AfterFetcher
 	magicBlockSaver              MagicBlockSaver
+	roundSummaryGetter           RoundSummaryGetter
+
+	stateArchive StateArchiveConfig
 
 	pruneStats *util.PruneStats
 
@@ -202,6 +206,9 @@ type Chain struct {
 	computeBlockStateC chan struct{}
 
 	OnBlockAdded func(b *block.Block)
+	// OnBlockFinalized is called with the finalized block and its events
+	// before the events are handed over to the events database
+	OnBlockFinalized func(b *block.Block, events []event.Event)
 }
 
 // SyncBlockReq represents a request to sync blocks, it will be
@@ -422,6 +429,7 @@ func Provider() datastore.Entity {
 	c := &Chain{}
 	c.ChainConfig = NewConfigImpl(&ConfigData{})
 	c.ChainConfig.FromViper() //nolint: errcheck
+	c.stateArchive = ReadStateArchiveConfig()
 
 	config.Configuration().ChainConfig = c.ChainConfig
 
@@ -493,6 +501,8 @@ func (c *Chain) Initialize() {
 	c.MagicBlockStorage = round.NewRoundStartingStorage()
 	c.OnBlockAdded = func(b *block.Block) {
 	}
+	c.OnBlockFinalized = func(b *block.Block, events []event.Event) {
+	}
 }
 
 /*SetupEntity - setup the entity */
@@ -505,26 +515,62 @@ func SetupEntity(store datastore.Store, workdir string) {
 	SetupStateDB(workdir)
 }
 
-var stateDB *util.PNodeDB
+// state db backends
+const (
+	StateDBBackendRocksDB   = "rocksdb"
+	StateDBBackendGoLevelDB = "goleveldb"
+)
+
+var stateDB util.PersistentNodeDB
 
 //SetupStateDB - setup the state db
 func SetupStateDB(workdir string) {
+	backend := viper.GetString("server_chain.state.backend")
+	datadir := StateDBDir(backend, workdir)
+	db, err := OpenStateDB(backend, datadir, stateDBLogDir(workdir))
+	if err != nil {
+		panic(err)
+	}
+	stateDB = db
 
-	datadir := "data/rocksdb/state"
-	logsdir := "/0chain/log/rocksdb/state"
+	if ac := ReadStateArchiveConfig(); ac.Enabled && !ac.IsFullArchive() {
+		err := cstate.SetArchivedKeys(ac.KeepPrefixes,
+			filepath.Join(filepath.Dir(datadir), "state_archived_keys"))
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// StateDBDir - the state db directory of given backend in the work directory
+func StateDBDir(backend, workdir string) string {
+	datadir := filepath.Join("data", backend, "state")
 	if len(workdir) > 0 {
 		datadir = filepath.Join(workdir, datadir)
-		logsdir = filepath.Join(workdir, "log/rocksdb/state")
 	}
+	return datadir
+}
 
-	db, err := util.NewPNodeDB(datadir, logsdir)
-	if err != nil {
-		panic(err)
+func stateDBLogDir(workdir string) string {
+	if len(workdir) > 0 {
+		return filepath.Join(workdir, "log/rocksdb/state")
+	}
+	return "/0chain/log/rocksdb/state"
+}
+
+// OpenStateDB - open the state db of given backend in the data directory
+func OpenStateDB(backend, datadir, logsdir string) (util.PersistentNodeDB, error) {
+	switch backend {
+	case StateDBBackendRocksDB:
+		return util.NewPNodeDB(datadir, logsdir)
+	case StateDBBackendGoLevelDB:
+		return util.NewKVNodeDB(datadir)
+	default:
+		return nil, common.NewErrorf("open_state_db", "unknown state db backend: %q", backend)
 	}
-	stateDB = db
 }
 
-// CloseStateDB closes the state db (rocksdb)
+// CloseStateDB closes the state db
 func CloseStateDB() {
 	logging.Logger.Info("Closing StateDB")
 	stateDB.Close()
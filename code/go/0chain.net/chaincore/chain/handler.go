@@ -104,6 +104,12 @@ func handlersMap(c Chainer) map[string]func(http.ResponseWriter, *http.Request)
 				transactionEntityMetadata,
 			),
 		),
+		"/v1/transaction/simulate": common.UserRateLimit(
+			datastore.ToJSONEntityReqResponse(
+				memorystore.WithConnectionEntityJSONHandler(SimulateTransactionHandler, transactionEntityMetadata),
+				transactionEntityMetadata,
+			),
+		),
 		"/_diagnostics/state_dump": common.UserRateLimit(
 			StateDumpHandler,
 		),
@@ -1315,10 +1321,41 @@ func PutTransaction(ctx context.Context, entity datastore.Entity) (interface{},
 	if err := txn.ValidateNonce(); err != nil {
 		return nil, err
 	}
+	if txn.IsExpired(sc.GetCurrentRound(), common.Now()) {
+		return nil, transaction.ErrTxnExpired
+	}
 
 	return transaction.PutTransaction(ctx, txn)
 }
 
+// SimulateTransactionHandler - executes the transaction against the state of
+// the latest finalized block without persisting anything, the hash and the
+// signature of a signed transaction are verified
+func SimulateTransactionHandler(ctx context.Context, entity datastore.Entity) (interface{}, error) {
+	txn, ok := entity.(*transaction.Transaction)
+	if !ok {
+		return nil, fmt.Errorf("simulate_transaction: invalid request %T", entity)
+	}
+
+	sc := GetServerChain()
+	if sc.TxnMaxPayload() > 0 && len(txn.TransactionData) > sc.TxnMaxPayload() {
+		s := fmt.Sprintf("transaction payload exceeds the max payload (%d)", sc.TxnMaxPayload())
+		return nil, common.NewError("txn_exceed_max_payload", s)
+	}
+	if err := txn.ComputeProperties(); err != nil {
+		return nil, err
+	}
+	if txn.Signature != "" {
+		if err := txn.VerifyHash(ctx); err != nil {
+			return nil, err
+		}
+		if err := txn.VerifySignature(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return sc.SimulateTransaction(ctx, txn)
+}
+
 //RoundInfoHandler collects and writes information about current round
 func RoundInfoHandler(c Chainer) common.ReqRespHandlerf {
 	return func(w http.ResponseWriter, r *http.Request) {
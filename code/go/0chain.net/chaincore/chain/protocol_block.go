@@ -11,11 +11,11 @@ import (
 	"0chain.net/core/datastore"
 	"0chain.net/core/encryption"
 	"0chain.net/core/maths"
-	"0chain.net/core/util"
 
 	"0chain.net/chaincore/block"
 	"0chain.net/core/common"
 	"0chain.net/core/logging"
+	"0chain.net/smartcontract/dbs/event"
 	"go.uber.org/zap"
 )
 
@@ -346,9 +346,9 @@ func (c *Chain) finalizeBlock(ctx context.Context, fb *block.Block, bsh BlockSta
 		return
 	}
 
-	deletedNode := fb.ClientState.GetDeletes()
+	deletedNode := c.stateArchive.deadNodesToPrune(fb.ClientState)
 	c.rebaseState(fb)
-	err := c.stateDB.(*util.PNodeDB).RecordDeadNodes(deletedNode, fb.Round)
+	err := c.stateDB.RecordDeadNodes(deletedNode, fb.Round)
 	if err != nil {
 		logging.Logger.Error("finalize block - record dead nodes failed",
 			zap.Int64("round", fb.Round),
@@ -366,6 +366,8 @@ func (c *Chain) finalizeBlock(ctx context.Context, fb *block.Block, bsh BlockSta
 
 	c.SetLatestOwnFinalizedBlockRound(fb.Round)
 	c.SetLatestFinalizedBlock(fb)
+	// the events database writes into the events of the block
+	c.OnBlockFinalized(fb, append([]event.Event(nil), fb.Events...))
 
 	if len(fb.Events) > 0 && c.GetEventDb() != nil {
 		c.GetEventDb().AddEvents(ctx, fb.Events)
@@ -179,6 +179,14 @@ func (c *Chain) EstimateTransactionCost(ctx context.Context,
 		return cost, err
 	}
 
+	if txn.TransactionType == transaction.TxnTypeBatchSend {
+		data, transferCost, err := getBatchSendData(sctx, txn)
+		if err != nil {
+			return math.MaxInt32, err
+		}
+		return data.Cost(transferCost), nil
+	}
+
 	return 0, nil
 }
 
@@ -201,24 +209,37 @@ func (c *Chain) NewStateContext(
 	)
 }
 
-func (c *Chain) updateState(ctx context.Context, b *block.Block, bState util.MerklePatriciaTrieI, txn *transaction.Transaction) (events []event.Event, err error) {
+func (c *Chain) updateState(ctx context.Context, b *block.Block, bState util.MerklePatriciaTrieI, txn *transaction.Transaction) ([]event.Event, error) {
+	events, _, err := c.executeTxn(ctx, b, bState, txn, CreateTxnMPT)
+	return events, err
+}
+
+// executeTxn applies the transaction to the given state, the changes are made
+// to the state created by txnMPT and merged into the given state on success.
+// It returns the state context of the transaction as well.
+func (c *Chain) executeTxn(ctx context.Context, b *block.Block, bState util.MerklePatriciaTrieI,
+	txn *transaction.Transaction, txnMPT func(util.MerklePatriciaTrieI) util.MerklePatriciaTrieI) (
+	events []event.Event, balances *bcstate.StateContext, err error) {
 	// check if the block's ClientState has root value
 	_, err = bState.GetNodeDB().GetNode(bState.GetRoot())
 	if err != nil {
-		return nil, common.NewErrorf("update_state_failed",
+		return nil, nil, common.NewErrorf("update_state_failed",
 			"block state root is incorrect, block hash: %v, state hash: %v, root: %v, round: %d",
 			b.Hash, util.ToHex(b.ClientStateHash), util.ToHex(bState.GetRoot()), b.Round)
 	}
 
 	var (
-		clientState = CreateTxnMPT(bState) // begin transaction
+		clientState = txnMPT(bState) // begin transaction
 		sctx        = c.NewStateContext(b, clientState, txn, nil)
 		startRoot   = sctx.GetState().GetRoot()
 	)
-	defer func() { events = sctx.GetEvents() }()
+	defer func() {
+		events = sctx.GetEvents()
+		balances = sctx
+	}()
 
 	if err := c.validateNonce(sctx, txn.ClientID, txn.Nonce); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	//we should check that client has enough funds to pay for transaction before heavy computations are executed
@@ -237,7 +258,7 @@ func (c *Chain) updateState(ctx context.Context, b *block.Block, bState util.Mer
 		if err != nil {
 			logging.Logger.Error("Error while decoding the JSON from transaction",
 				zap.Any("input", txn.TransactionData), zap.Any("error", err))
-			return nil, err
+			return nil, nil, err
 		}
 
 		t := time.Now()
@@ -255,7 +276,7 @@ func (c *Chain) updateState(ctx context.Context, b *block.Block, bState util.Mer
 				zap.Duration("time_spent", time.Since(t)),
 				zap.Any("txn", txn))
 			//return original error, to handle upwards
-			return events, err
+			return events, nil, err
 		default:
 			if err != nil {
 				sctx.EmitError(err)
@@ -269,7 +290,7 @@ func (c *Chain) updateState(ctx context.Context, b *block.Block, bState util.Mer
 						zap.String("prev block", b.PrevBlock.Hash),
 						zap.Duration("time_spent", time.Since(t)),
 						zap.Any("txn", txn))
-					return events, err
+					return events, nil, err
 				}
 
 				logging.Logger.Debug("Error executing the SC, chargeable error",
@@ -282,7 +303,7 @@ func (c *Chain) updateState(ctx context.Context, b *block.Block, bState util.Mer
 					zap.Any("txn", txn))
 
 				//refresh client state context, so all changes made by broken smart contract are rejected, it will be used to add fee
-				clientState = CreateTxnMPT(bState) // begin transaction
+				clientState = txnMPT(bState) // begin transaction
 				sctx = c.NewStateContext(b, clientState, txn, nil)
 
 				output = err.Error()
@@ -316,9 +337,17 @@ func (c *Chain) updateState(ctx context.Context, b *block.Block, bState util.Mer
 				zap.Any("current_root", sctx.GetState().GetRoot()))
 			return
 		}
+	case transaction.TxnTypeBatchSend:
+		if err = c.addBatchTransfers(sctx, txn); err != nil {
+			logging.Logger.Error("Failed to add batch transfers",
+				zap.String("txn_hash", txn.Hash),
+				zap.String("client_id", txn.ClientID),
+				zap.Error(err))
+			return
+		}
 	default:
 		logging.Logger.Error("Invalid transaction type", zap.Int("txn type", txn.TransactionType))
-		return nil, fmt.Errorf("invalid transaction type: %v", txn.TransactionType)
+		return nil, nil, fmt.Errorf("invalid transaction type: %v", txn.TransactionType)
 	}
 
 	if c.ChainConfig.IsFeeEnabled() {
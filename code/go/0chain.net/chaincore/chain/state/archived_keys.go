@@ -0,0 +1,147 @@
+package state
+
+import (
+	"bufio"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"0chain.net/core/datastore"
+	"0chain.net/core/logging"
+	"0chain.net/core/util"
+	"go.uber.org/zap"
+)
+
+// The archivedKeyRegistry keeps the trie paths of the state keys under the
+// prefixes kept by the state archive. The state keys are hashed into the trie
+// paths, so the kept values can't be told apart by the trie paths alone. The
+// paths are persisted, to know the keys written before a restart.
+type archivedKeyRegistry struct {
+	enabled  int32 // set if any prefix is kept, read without the mutex
+	mutex    sync.RWMutex
+	prefixes []string
+	paths    map[string]struct{}
+	sorted   []string // sorted paths, nil if stale
+	file     *os.File
+}
+
+var archivedKeys = &archivedKeyRegistry{paths: make(map[string]struct{})}
+
+// SetArchivedKeys - set the prefixes of the state keys kept by the state
+// archive and the file their trie paths are persisted to, the paths
+// registered before are loaded from the file.
+func SetArchivedKeys(prefixes []string, file string) error {
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	paths := make(map[string]struct{})
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if path := strings.TrimSpace(scanner.Text()); path != "" {
+			paths[path] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return err
+	}
+
+	archivedKeys.mutex.Lock()
+	defer archivedKeys.mutex.Unlock()
+	if archivedKeys.file != nil {
+		archivedKeys.file.Close()
+	}
+	archivedKeys.prefixes = prefixes
+	archivedKeys.paths = paths
+	archivedKeys.sorted = nil
+	archivedKeys.file = f
+	var enabled int32
+	if len(prefixes) > 0 {
+		enabled = 1
+	}
+	atomic.StoreInt32(&archivedKeys.enabled, enabled)
+	return nil
+}
+
+// IsArchivedPath - true if the trie path is of a state key kept by the
+// state archive.
+func IsArchivedPath(path util.Path) bool {
+	archivedKeys.mutex.RLock()
+	defer archivedKeys.mutex.RUnlock()
+	_, ok := archivedKeys.paths[string(path)]
+	return ok
+}
+
+// HasArchivedPathPrefix - true if the trie path of any state key kept by
+// the state archive starts with given prefix.
+func HasArchivedPathPrefix(prefix util.Path) bool {
+	sorted := archivedKeys.sortedPaths()
+	i := sort.SearchStrings(sorted, string(prefix))
+	return i < len(sorted) && strings.HasPrefix(sorted[i], string(prefix))
+}
+
+func (r *archivedKeyRegistry) sortedPaths() []string {
+	r.mutex.RLock()
+	sorted := r.sorted
+	r.mutex.RUnlock()
+	if sorted != nil {
+		return sorted
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.sorted == nil {
+		r.sorted = make([]string, 0, len(r.paths))
+		for path := range r.paths {
+			r.sorted = append(r.sorted, path)
+		}
+		sort.Strings(r.sorted)
+	}
+	return r.sorted
+}
+
+// register the trie path of the state key, if the key is kept by the state
+// archive
+func (r *archivedKeyRegistry) register(key datastore.Key, path string) {
+	// the state archive is off, it's called for every state access
+	if atomic.LoadInt32(&r.enabled) == 0 {
+		return
+	}
+
+	r.mutex.RLock()
+	kept := r.keeps(key)
+	_, known := r.paths[path]
+	r.mutex.RUnlock()
+	if !kept || known {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.paths[path]; ok {
+		return
+	}
+	r.paths[path] = struct{}{}
+	r.sorted = nil
+	if r.file == nil {
+		return
+	}
+	if _, err := r.file.WriteString(path + "\n"); err != nil {
+		logging.Logger.Error("archived keys - persist the key path",
+			zap.String("key", key),
+			zap.Error(err))
+	}
+}
+
+func (r *archivedKeyRegistry) keeps(key datastore.Key) bool {
+	for _, p := range r.prefixes {
+		if strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+	return false
+}
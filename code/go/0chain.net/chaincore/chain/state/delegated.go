@@ -0,0 +1,86 @@
+package state
+
+import (
+	"0chain.net/chaincore/currency"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+)
+
+// delegatedAppender is implemented by the state contexts able to keep the
+// transfers and the mints of a delegated call checked by the call context.
+type delegatedAppender interface {
+	appendTransfer(t *state.Transfer)
+	appendMint(m *state.Mint)
+}
+
+// delegatedStateContext is the state context of a smart contract call made by
+// a smart contract on behalf of another client, e.g. a multisig wallet. The
+// call sees the delegated transaction, its transfers and mints are checked
+// against the delegated transaction. The state, the events, the transfers
+// and the mints are shared with the parent context.
+type delegatedStateContext struct {
+	StateContextI
+	txn   *transaction.Transaction
+	spent currency.Coin
+}
+
+// NewDelegatedStateContext returns the state context of the smart contract
+// call of the delegated transaction inside the balances.
+func NewDelegatedStateContext(balances StateContextI,
+	txn *transaction.Transaction) StateContextI {
+
+	return &delegatedStateContext{StateContextI: balances, txn: txn}
+}
+
+// GetTransaction returns the delegated transaction
+func (dc *delegatedStateContext) GetTransaction() *transaction.Transaction {
+	return dc.txn
+}
+
+// AddTransfer adds a transfer of the delegated call, the transfers of the
+// delegated client can't exceed the value of the delegated transaction.
+func (dc *delegatedStateContext) AddTransfer(t *state.Transfer) error {
+	switch t.ClientID {
+	case dc.txn.ClientID:
+		spent, err := currency.AddCoin(dc.spent, t.Amount)
+		if err != nil {
+			return err
+		}
+		if spent > dc.txn.Value {
+			return state.ErrInvalidTransfer
+		}
+		dc.spent = spent
+	case dc.txn.ToClientID:
+	default:
+		return state.ErrInvalidTransfer
+	}
+	if da, ok := dc.StateContextI.(delegatedAppender); ok {
+		da.appendTransfer(t)
+		return nil
+	}
+	return dc.StateContextI.AddTransfer(t)
+}
+
+// AddMint adds a mint of the delegated call made to an approved minter
+func (dc *delegatedStateContext) AddMint(m *state.Mint) error {
+	if !isApprovedMinter(m, dc.txn.ToClientID) {
+		return state.ErrInvalidMint
+	}
+	if da, ok := dc.StateContextI.(delegatedAppender); ok {
+		da.appendMint(m)
+		return nil
+	}
+	return dc.StateContextI.AddMint(m)
+}
+
+func (sc *StateContext) appendTransfer(t *state.Transfer) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.transfers = append(sc.transfers, t)
+}
+
+func (sc *StateContext) appendMint(m *state.Mint) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.mints = append(sc.mints, m)
+}
@@ -0,0 +1,47 @@
+package state
+
+import (
+	"sync"
+
+	"0chain.net/core/util"
+)
+
+// RunNested runs the call on a copy of the state of the balances. The state
+// changes, the events, the transfers and the mints of the call are merged
+// into the balances only if the call succeeds, a failed call leaves the
+// balances unchanged. The call runs on the balances directly if their state
+// can't be copied.
+func RunNested(balances StateContextI, call func(balances StateContextI) error) error {
+	sc, ok := balances.(*StateContext)
+	if !ok {
+		return call(balances)
+	}
+
+	tdb := util.NewLevelNodeDB(util.NewMemoryNodeDB(), sc.state.GetNodeDB(), false)
+	nested := &StateContext{
+		block:                         sc.block,
+		state:                         util.NewMerklePatriciaTrie(tdb, sc.state.GetVersion(), sc.state.GetRoot()),
+		txn:                           sc.txn,
+		getSharders:                   sc.getSharders,
+		getLastestFinalizedMagicBlock: sc.getLastestFinalizedMagicBlock,
+		getLatestFinalizedBlock:       sc.getLatestFinalizedBlock,
+		getChainCurrentMagicBlock:     sc.getChainCurrentMagicBlock,
+		getSignature:                  sc.getSignature,
+		eventDb:                       sc.eventDb,
+		mutex:                         new(sync.Mutex),
+	}
+	if err := call(nested); err != nil {
+		return err
+	}
+
+	if err := sc.state.MergeMPTChanges(nested.state); err != nil {
+		return err
+	}
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.transfers = append(sc.transfers, nested.transfers...)
+	sc.signedTransfers = append(sc.signedTransfers, nested.signedTransfers...)
+	sc.mints = append(sc.mints, nested.mints...)
+	sc.events = append(sc.events, nested.events...)
+	return nil
+}
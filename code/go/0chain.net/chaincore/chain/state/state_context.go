@@ -209,7 +209,7 @@ func (sc *StateContext) AddSignedTransfer(st *state.SignedTransfer) {
 func (sc *StateContext) AddMint(m *state.Mint) error {
 	sc.mutex.Lock()
 	defer sc.mutex.Unlock()
-	if !sc.isApprovedMinter(m) {
+	if !isApprovedMinter(m, sc.txn.ToClientID) {
 		return state.ErrInvalidMint
 	}
 	sc.mints = append(sc.mints, m)
@@ -217,9 +217,9 @@ func (sc *StateContext) AddMint(m *state.Mint) error {
 	return nil
 }
 
-func (sc *StateContext) isApprovedMinter(m *state.Mint) bool {
+func isApprovedMinter(m *state.Mint, toClientID string) bool {
 	for _, minter := range approvedMinters {
-		if m.Minter == minter && sc.txn.ToClientID == minter {
+		if m.Minter == minter && toClientID == minter {
 			return true
 		}
 	}
@@ -376,17 +376,20 @@ func (sc *StateContext) GetSignatureScheme() encryption.SignatureScheme {
 
 func (sc *StateContext) GetTrieNode(key datastore.Key, v util.MPTSerializable) error {
 	key_hash := encryption.Hash(key)
+	archivedKeys.register(key, key_hash)
 	return sc.state.GetNodeValue(util.Path(key_hash), v)
 }
 
 func (sc *StateContext) InsertTrieNode(key datastore.Key, node util.MPTSerializable) (datastore.Key, error) {
 	key_hash := encryption.Hash(key)
+	archivedKeys.register(key, key_hash)
 	byteKey, err := sc.state.Insert(util.Path(key_hash), node)
 	return datastore.Key(byteKey), err
 }
 
 func (sc *StateContext) DeleteTrieNode(key datastore.Key) (datastore.Key, error) {
 	key_hash := encryption.Hash(key)
+	archivedKeys.register(key, key_hash)
 	byteKey, err := sc.state.Delete(util.Path(key_hash))
 	return datastore.Key(byteKey), err
 }
@@ -0,0 +1,239 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+
+	"0chain.net/chaincore/block"
+	bcstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/util"
+	"0chain.net/core/viper"
+)
+
+// StateArchiveConfig - the archive mode of the state. In archive mode the
+// state of past rounds is kept, either entirely or for the values stored
+// under given key prefixes, so that queries can be run against it.
+type StateArchiveConfig struct {
+	Enabled bool
+	// KeepPrefixes - the prefixes of the state keys whose past values are
+	// kept, all the state is kept when empty
+	KeepPrefixes []string
+}
+
+// ReadStateArchiveConfig - read the state archive mode configurations.
+func ReadStateArchiveConfig() StateArchiveConfig {
+	return StateArchiveConfig{
+		Enabled:      viper.GetBool("server_chain.state.archive.enabled"),
+		KeepPrefixes: viper.GetStringSlice("server_chain.state.archive.keep_prefixes"),
+	}
+}
+
+// IsFullArchive - true if all the past state is kept, nothing is pruned.
+func (ac *StateArchiveConfig) IsFullArchive() bool {
+	return ac.Enabled && len(ac.KeepPrefixes) == 0
+}
+
+// deadNodesToPrune - the dead nodes of the finalized state changes that can
+// be pruned. The past values of the kept keys are leaf nodes, and the full and
+// extension nodes are kept if their subtrees contain kept leaves, so that the
+// leaves stay reachable from the past state roots.
+func (ac *StateArchiveConfig) deadNodesToPrune(mpt util.MerklePatriciaTrieI) []util.Node {
+	_, changes, deletes, startRoot := mpt.GetChanges()
+	if !ac.Enabled {
+		return deletes
+	}
+	if ac.IsFullArchive() {
+		return nil
+	}
+
+	// the leaves of the changed keys lead to the dead intermediate nodes
+	var paths []util.Path
+	for _, n := range deletes {
+		if leaf, ok := n.(*util.LeafNode); ok {
+			paths = append(paths, leafPath(leaf))
+		}
+	}
+	for _, c := range changes {
+		if leaf, ok := c.New.(*util.LeafNode); ok {
+			paths = append(paths, leafPath(leaf))
+		}
+	}
+	positions := trieNodePositions(mpt.GetNodeDB(), startRoot, paths)
+
+	prune := make([]util.Node, 0, len(deletes))
+	for _, n := range deletes {
+		switch node := n.(type) {
+		case *util.LeafNode:
+			if !ac.keepsPath(leafPath(node)) {
+				prune = append(prune, n)
+			}
+		default:
+			// the nodes not found on the paths are kept, nothing is known
+			// about their subtrees
+			if pos, ok := positions[n.GetHash()]; ok && !ac.keepsSubtree(pos) {
+				prune = append(prune, n)
+			}
+		}
+	}
+	return prune
+}
+
+// keepsPath - true if the value at the trie path is kept, the paths of the
+// smart contracts keys are the hashes of the keys, the client states are
+// stored at the client ids
+func (ac *StateArchiveConfig) keepsPath(path util.Path) bool {
+	for _, p := range ac.KeepPrefixes {
+		if bytes.HasPrefix(path, []byte(p)) {
+			return true
+		}
+	}
+	return bcstate.IsArchivedPath(path)
+}
+
+// keepsSubtree - true if the subtree at the trie position may contain the
+// leaves of kept values
+func (ac *StateArchiveConfig) keepsSubtree(pos util.Path) bool {
+	for _, p := range ac.KeepPrefixes {
+		if bytes.HasPrefix(pos, []byte(p)) || bytes.HasPrefix([]byte(p), pos) {
+			return true
+		}
+	}
+	return bcstate.HasArchivedPathPrefix(pos)
+}
+
+func leafPath(leaf *util.LeafNode) util.Path {
+	return append(append(util.Path(nil), leaf.Prefix...), leaf.Path...)
+}
+
+// trieNodePositions - the positions in the trie of the nodes on given paths,
+// by the node hashes
+func trieNodePositions(ndb util.NodeDB, root util.Key, paths []util.Path) map[string]util.Path {
+	positions := make(map[string]util.Path)
+	for _, path := range paths {
+		key, depth := root, 0
+		for key != nil {
+			node, err := ndb.GetNode(key)
+			if err != nil {
+				break
+			}
+			positions[node.GetHash()] = path[:depth]
+
+			key = nil
+			switch n := node.(type) {
+			case *util.FullNode:
+				if depth < len(path) {
+					key = n.GetChild(path[depth])
+					depth++
+				}
+			case *util.ExtensionNode:
+				if bytes.HasPrefix(path[depth:], n.Path) {
+					key = n.NodeKey
+					depth += len(n.Path)
+				}
+			}
+		}
+	}
+	return positions
+}
+
+// RecordDeadStateNodes - record the dead state nodes of the block for
+// pruning, but the nodes kept by the state archive mode.
+func (c *Chain) RecordDeadStateNodes(b *block.Block) error {
+	return c.stateDB.RecordDeadNodes(c.stateArchive.deadNodesToPrune(b.ClientState), b.Round)
+}
+
+// The RoundSummaryGetter resolves the finalized block of past rounds, used to
+// get the state roots of the rounds.
+type RoundSummaryGetter interface {
+	// GetRoundBlockSummary returns the summary of the finalized block of
+	// given round.
+	GetRoundBlockSummary(ctx context.Context, round int64) (*block.BlockSummary, error)
+}
+
+// SetRoundSummaryGetter - set the resolver of the past rounds blocks.
+func (c *Chain) SetRoundSummaryGetter(rsg RoundSummaryGetter) {
+	c.roundSummaryGetter = rsg
+}
+
+// GetStateArchiveConfig - the state archive mode of the chain.
+func (c *Chain) GetStateArchiveConfig() StateArchiveConfig {
+	return c.stateArchive
+}
+
+// GetStateAtRound - get the state of the finalized block of given round and
+// the block summary, the latest finalized block state by default.
+func (c *Chain) GetStateAtRound(ctx context.Context, round int64) (*block.BlockSummary,
+	util.MerklePatriciaTrieI, error) {
+
+	lfb := c.GetLatestFinalizedBlock()
+	if lfb == nil || lfb.ClientState == nil {
+		return nil, nil, common.NewError("state_at_round", "no finalized block state")
+	}
+	if round <= 0 || round == lfb.Round {
+		return lfb.GetSummary(), lfb.ClientState, nil
+	}
+	if round > lfb.Round {
+		return nil, nil, common.NewErrorf("state_at_round",
+			"round %d is not finalized yet, latest finalized round %d", round, lfb.Round)
+	}
+	if c.roundSummaryGetter == nil {
+		return nil, nil, common.NewError("state_at_round", "past rounds state is not supported")
+	}
+
+	bs, err := c.roundSummaryGetter.GetRoundBlockSummary(ctx, round)
+	if err != nil {
+		return nil, nil, common.NewErrorf("state_at_round", "round %d: %v", round, err)
+	}
+	if !c.HasClientStateStored(bs.ClientStateHash) {
+		return nil, nil, common.NewErrorf("state_at_round", "state of round %d is pruned", round)
+	}
+	return bs, util.NewMerklePatriciaTrie(c.stateDB, util.Sequence(bs.Round), bs.ClientStateHash), nil
+}
+
+// GetQueryStateContextAtRound - get the query state context evaluating the
+// queries against the state of given round.
+func (c *Chain) GetQueryStateContextAtRound(ctx context.Context, round int64) (
+	bcstate.TimedQueryStateContextI, error) {
+
+	bs, mpt, err := c.GetStateAtRound(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	b := block.NewBlock(c.GetKey(), bs.Round)
+	b.Hash = bs.Hash
+	b.CreationDate = bs.CreationDate
+	b.ClientStateHash = bs.ClientStateHash
+	b.MagicBlock = bs.MagicBlock
+
+	sctx := c.NewStateContext(b, CreateTxnMPT(mpt), &transaction.Transaction{}, c.GetEventDb())
+	return bcstate.NewTimedQueryStateContext(sctx, func() common.Timestamp {
+		return bs.CreationDate
+	}), nil
+}
+
+// getBalanceAtRound - get the client state at the state of given round.
+func (c *Chain) getBalanceAtRound(ctx context.Context, clientID string, round int64) (*state.State, error) {
+	bs, mpt, err := c.GetStateAtRound(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+
+	c.stateMutex.RLock()
+	defer c.stateMutex.RUnlock()
+	s := &state.State{}
+	switch err := mpt.GetNodeValue(util.Path(clientID), s); err {
+	case nil:
+		_ = s.ComputeProperties()
+		return s, nil
+	case util.ErrValueNotPresent:
+		return nil, common.NewErrorf("get_balance_error", "client %s not found at round %d",
+			clientID, bs.Round)
+	case util.ErrNodeNotFound:
+		return nil, common.NewErrorf("get_balance_error", "state of round %d is pruned", bs.Round)
+	default:
+		return nil, err
+	}
+}
@@ -0,0 +1,155 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	bcstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/currency"
+	"0chain.net/chaincore/state"
+	"0chain.net/core/encryption"
+	"0chain.net/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRoundSummaries map[int64]*block.BlockSummary
+
+func (trs testRoundSummaries) GetRoundBlockSummary(_ context.Context, round int64) (*block.BlockSummary, error) {
+	bs, ok := trs[round]
+	if !ok {
+		return nil, errors.New("round not found")
+	}
+	return bs, nil
+}
+
+func TestStateArchiveConfig_deadNodesToPrune(t *testing.T) {
+	var (
+		mndb      = util.NewMemoryNodeDB()
+		keptPath  = util.Path("abc" + strings.Repeat("1", 61))
+		scKey     = "kept:sc"
+		scPath    = util.Path(encryption.Hash(scKey))
+		prunedOne = util.Path("d1" + strings.Repeat("2", 62))
+		prunedTwo = util.Path("d2" + strings.Repeat("3", 62))
+		paths     = []util.Path{keptPath, scPath, prunedOne, prunedTwo}
+	)
+	require.NotEqual(t, byte('d'), scPath[0])
+
+	// the state keys under the kept prefixes are registered by their hashes
+	file := filepath.Join(t.TempDir(), "archived_keys")
+	require.NoError(t, os.WriteFile(file, []byte(string(scPath)+"\n"), 0644))
+	require.NoError(t, bcstate.SetArchivedKeys([]string{"kept:"}, file))
+
+	update := func(version int64, root util.Key) util.MerklePatriciaTrieI {
+		mpt := util.NewMerklePatriciaTrie(util.NewLevelNodeDB(util.NewMemoryNodeDB(), mndb, false),
+			util.Sequence(version), root)
+		for _, path := range paths {
+			_, err := mpt.Insert(path, &util.SecureSerializableValue{Buffer: []byte(fmt.Sprintf("%s:%d", path, version))})
+			require.NoError(t, err)
+		}
+		return mpt
+	}
+
+	mpt := update(1, nil)
+	require.NoError(t, mpt.SaveChanges(context.TODO(), mndb, false))
+	// the nodes on the paths from the root to the leaves
+	pathNodes := make(map[string][]util.Node)
+	for _, path := range paths {
+		nodes, err := mpt.GetPathNodes(path)
+		require.NoError(t, err)
+		pathNodes[string(path)] = nodes
+	}
+	root, full, leafOne, leafTwo := pathNodes[string(prunedOne)][0], pathNodes[string(prunedOne)][1],
+		pathNodes[string(prunedOne)][2], pathNodes[string(prunedTwo)][2]
+	require.Equal(t, full, pathNodes[string(prunedTwo)][1])
+
+	mpt = update(2, mpt.GetRoot())
+	_, _, deletes, _ := mpt.GetChanges()
+	require.Contains(t, deletes, root)
+
+	tests := []struct {
+		name   string
+		config StateArchiveConfig
+		want   []util.Node
+	}{
+		{name: "disabled", config: StateArchiveConfig{KeepPrefixes: []string{"abc"}}, want: deletes},
+		{name: "full archive", config: StateArchiveConfig{Enabled: true}, want: nil},
+		{
+			name:   "kept prefixes",
+			config: StateArchiveConfig{Enabled: true, KeepPrefixes: []string{"abc"}},
+			want:   []util.Node{full, leafOne, leafTwo},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.config.deadNodesToPrune(mpt)
+			if tt.want == nil {
+				assert.Empty(t, got)
+				return
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func TestChain_getBalanceAtRound(t *testing.T) {
+	var (
+		clientID = strings.Repeat("1a", 32)
+		mndb     = util.NewMemoryNodeDB()
+		root     util.Key
+		rounds   = testRoundSummaries{}
+	)
+
+	// the balance is 10 at round 1, 20 at round 2 and 30 at round 3
+	for r := int64(1); r <= 3; r++ {
+		mpt := util.NewMerklePatriciaTrie(util.NewLevelNodeDB(util.NewMemoryNodeDB(), mndb, false),
+			util.Sequence(r), root)
+		_, err := mpt.Insert(util.Path(clientID), &state.State{
+			TxnHashBytes: make([]byte, 32), Round: r, Balance: currency.Coin(r * 10)})
+		require.NoError(t, err)
+		require.NoError(t, mpt.SaveChanges(context.TODO(), mndb, false))
+		root = mpt.GetRoot()
+		rounds[r] = &block.BlockSummary{Round: r, ClientStateHash: root}
+	}
+	// the state of round 2 is pruned
+	rounds[2] = &block.BlockSummary{Round: 2, ClientStateHash: util.Key("pruned")}
+
+	lfb := block.NewBlock("", 3)
+	lfb.ClientStateHash = root
+	lfb.ClientState = util.NewMerklePatriciaTrie(mndb, 3, root)
+	c := &Chain{stateDB: mndb, stateMutex: &sync.RWMutex{}, LatestFinalizedBlock: lfb}
+
+	tests := []struct {
+		name    string
+		getter  RoundSummaryGetter
+		round   int64
+		want    currency.Coin
+		wantErr string
+	}{
+		{name: "latest", getter: rounds, round: 3, want: 30},
+		{name: "past round", getter: rounds, round: 1, want: 10},
+		{name: "pruned", getter: rounds, round: 2, wantErr: "pruned"},
+		{name: "not finalized", getter: rounds, round: 4, wantErr: "not finalized"},
+		{name: "no past rounds", round: 1, wantErr: "not supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c.SetRoundSummaryGetter(tt.getter)
+			s, err := c.getBalanceAtRound(context.TODO(), clientID, tt.round)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Balance)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package chain
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+
+	"0chain.net/chaincore/config"
+	"0chain.net/core/logging"
+)
+
+const stateUsage = `usage: %[1]s state migrate --root STATE_HASH [--from rocksdb] [--to goleveldb] [options]
+
+Copy the state db of the work directory into another backend and verify the
+state of given root is complete in it. The %[1]s must be stopped while the
+state db is migrated, set server_chain.state.backend to use the new one.
+`
+
+// StateMain - the 'state' command of the miner and the sharder migrating the
+// state db between the backends, name is the name of the node binary.
+func StateMain(name string, args []string) {
+	usage := fmt.Sprintf(stateUsage, name)
+	if len(args) == 0 || args[0] != "migrate" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("state migrate", flag.ExitOnError)
+	deploymentMode := fs.Int("deployment_mode", 2, "deployment_mode")
+	from := fs.String("from", StateDBBackendRocksDB, "source state db backend")
+	to := fs.String("to", StateDBBackendGoLevelDB, "target state db backend")
+	rootHex := fs.String("root", "", "state hash of the latest finalized block")
+	workdir := fs.String("work_dir", "", "work_dir")
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args[1:])
+
+	root, err := hex.DecodeString(*rootHex)
+	if err != nil || len(root) == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	config.Configuration().DeploymentMode = byte(*deploymentMode)
+	config.SetupDefaultConfig()
+	config.SetupConfig(*workdir)
+	logging.InitLogging("production", *workdir)
+
+	count, err := MigrateStateDB(context.Background(), *workdir, *from, *to, root)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "state migrate failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("state migrated from %s to %s: %d nodes\n", *from, *to, count)
+}
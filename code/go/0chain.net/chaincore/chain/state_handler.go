@@ -9,10 +9,13 @@ import (
 	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"0chain.net/smartcontract/faucetsc"
+	"0chain.net/smartcontract/governancesc"
 	"0chain.net/smartcontract/minersc"
+	"0chain.net/smartcontract/multisigsc"
 	"0chain.net/smartcontract/rest"
 	"0chain.net/smartcontract/storagesc"
 	"0chain.net/smartcontract/vestingsc"
@@ -49,7 +52,9 @@ func SetupScRestApiHandlers() {
 	SetupSwagger()
 	if c.EventDb != nil {
 		faucetsc.SetupRestHandler(restHandler)
+		governancesc.SetupRestHandler(restHandler)
 		minersc.SetupRestHandler(restHandler)
+		multisigsc.SetupRestHandler(restHandler)
 		storagesc.SetupRestHandler(restHandler)
 		vestingsc.SetupRestHandler(restHandler)
 		zcnsc.SetupRestHandler(restHandler)
@@ -140,9 +145,17 @@ func (c *Chain) GetNodeFromSCState(ctx context.Context, r *http.Request) (interf
 	return retObj, nil
 }
 
-// GetBalanceHandler - get the balance of a client
+// GetBalanceHandler - get the balance of a client, at the state of given
+// round if the round parameter is set
 func (c *Chain) GetBalanceHandler(ctx context.Context, r *http.Request) (interface{}, error) {
 	clientID := r.FormValue("client_id")
+	if rs := r.FormValue(rest.RoundParam); rs != "" {
+		round, err := strconv.ParseInt(rs, 10, 64)
+		if err != nil || round <= 0 {
+			return nil, common.NewErrBadRequest("invalid round: " + rs)
+		}
+		return c.getBalanceAtRound(ctx, clientID, round)
+	}
 	if c.GetEventDb() == nil {
 		return nil, common.NewError("get_balance_error", "event database not enabled")
 	}
@@ -199,8 +212,12 @@ func GetFunctionNames(address string) []string {
 		endpoints = faucetsc.GetEndpoints(nil)
 	case vestingsc.ADDRESS:
 		endpoints = vestingsc.GetEndpoints(nil)
+	case multisigsc.Address:
+		endpoints = multisigsc.GetEndpoints(nil)
 	case zcnsc.ADDRESS:
 		endpoints = zcnsc.GetEndpoints(nil)
+	case governancesc.ADDRESS:
+		endpoints = governancesc.GetEndpoints(nil)
 	default:
 		return []string{}
 	}
@@ -0,0 +1,57 @@
+package chain
+
+import (
+	"context"
+	"os"
+
+	"go.uber.org/zap"
+
+	"0chain.net/core/common"
+	"0chain.net/core/logging"
+	"0chain.net/core/util"
+)
+
+// MigrateStateDB - copy the state db of the work directory from a backend
+// into another one and verify the state of given root is complete in the
+// new backend. The node must be stopped while the state db is migrated.
+func MigrateStateDB(ctx context.Context, workdir, from, to string, root util.Key) (int64, error) {
+	if from == to {
+		return 0, common.NewErrorf("migrate_state_db", "same source and target backend: %s", from)
+	}
+	if len(root) == 0 {
+		return 0, common.NewError("migrate_state_db", "state root is required")
+	}
+
+	fromDir, toDir := StateDBDir(from, workdir), StateDBDir(to, workdir)
+	if _, err := os.Stat(fromDir); err != nil {
+		return 0, common.NewErrorf("migrate_state_db", "source state db: %v", err)
+	}
+	if _, err := os.Stat(toDir); err == nil {
+		return 0, common.NewErrorf("migrate_state_db", "target state db %s already exists", toDir)
+	}
+
+	fdb, err := OpenStateDB(from, fromDir, stateDBLogDir(workdir))
+	if err != nil {
+		return 0, common.NewErrorf("migrate_state_db", "opening %s state db: %v", from, err)
+	}
+	defer fdb.Close()
+
+	tdb, err := OpenStateDB(to, toDir, stateDBLogDir(workdir))
+	if err != nil {
+		return 0, common.NewErrorf("migrate_state_db", "opening %s state db: %v", to, err)
+	}
+	defer tdb.Close()
+
+	count, err := util.MigrateNodeDB(ctx, fdb, tdb, root)
+	if err != nil {
+		return count, common.NewErrorf("migrate_state_db", "%s to %s, %d nodes copied: %v",
+			from, to, count, err)
+	}
+
+	logging.Logger.Info("migrate state db",
+		zap.String("from", fromDir),
+		zap.String("to", toDir),
+		zap.String("root", util.ToHex(root)),
+		zap.Int64("nodes", count))
+	return count, nil
+}
@@ -0,0 +1,78 @@
+package chain
+
+import (
+	"encoding/hex"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/common"
+	"0chain.net/core/stateproof"
+	"0chain.net/core/util"
+)
+
+// GetStateProof - get the proof of the value stored under given path of the
+// client state of the block. The state of the block must still be stored.
+func (c *Chain) GetStateProof(b *block.Block, path util.Path) (*stateproof.Proof, error) {
+	if len(b.ClientStateHash) == 0 {
+		return nil, common.NewErrorf("state_proof", "block of round %d has no state", b.Round)
+	}
+	if !c.HasClientStateStored(b.ClientStateHash) {
+		return nil, common.NewErrorf("state_proof", "state of round %d is not stored", b.Round)
+	}
+
+	mpt := util.NewMerklePatriciaTrie(c.GetStateDB(), util.Sequence(b.Round), b.ClientStateHash)
+	nodes, err := mpt.GetPathNodes(path)
+	if err == nil && len(nodes) == 0 {
+		err = util.ErrValueNotPresent
+	}
+	if err != nil {
+		if err == util.ErrValueNotPresent {
+			return nil, common.NewErrorf("state_proof", "key %s not found in round %d",
+				string(path), b.Round)
+		}
+		return nil, common.NewErrorf("state_proof", "round %d: %v", b.Round, err)
+	}
+
+	proof := &stateproof.Proof{
+		Key:   string(path),
+		Nodes: make([]string, 0, len(nodes)),
+		Block: stateProofBlockHeader(b),
+	}
+	for _, n := range nodes {
+		proof.Nodes = append(proof.Nodes, hex.EncodeToString(n.Encode()))
+	}
+	if leaf, ok := nodes[len(nodes)-1].(*util.LeafNode); ok {
+		proof.Value = hex.EncodeToString(leaf.GetValueBytes())
+	}
+	return proof, nil
+}
+
+// stateProofBlockHeader - the block header light clients verify the state
+// proof against.
+func stateProofBlockHeader(b *block.Block) *stateproof.BlockHeader {
+	h := &stateproof.BlockHeader{
+		Hash:                  b.Hash,
+		MinerID:               b.MinerID,
+		PrevHash:              b.PrevHash,
+		CreationDate:          int64(b.CreationDate),
+		Round:                 b.Round,
+		RoundRandomSeed:       b.GetRoundRandomSeed(),
+		StateChangesCount:     b.StateChangesCount,
+		MerkleTreeRoot:        b.GetMerkleTree().GetRoot(),
+		ReceiptMerkleTreeRoot: b.GetReceiptsMerkleTree().GetRoot(),
+		ClientStateHash:       util.ToHex(b.ClientStateHash),
+		StateHashRound:        block.GetStateHashRound(),
+	}
+	if b.MagicBlock != nil {
+		h.MagicBlockHash = b.MagicBlock.Hash
+		if h.MagicBlockHash == "" {
+			h.MagicBlockHash = b.MagicBlock.GetHash()
+		}
+	}
+	for _, t := range b.GetVerificationTickets() {
+		h.VerificationTickets = append(h.VerificationTickets, &stateproof.Ticket{
+			VerifierID: t.VerifierID,
+			Signature:  t.Signature,
+		})
+	}
+	return h
+}
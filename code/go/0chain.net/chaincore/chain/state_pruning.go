@@ -23,6 +23,10 @@ func init() {
 }
 
 func (c *Chain) pruneClientState(ctx context.Context) {
+	if c.stateArchive.IsFullArchive() {
+		return // nothing is recorded to be pruned
+	}
+
 	lfb := c.GetLatestFinalizedBlock()
 	if lfb == nil {
 		return
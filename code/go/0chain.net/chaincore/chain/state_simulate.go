@@ -0,0 +1,163 @@
+package chain
+
+import (
+	"context"
+	"sort"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/util"
+	"0chain.net/smartcontract/dbs/event"
+)
+
+// TxnSimulation is the result of the execution of a transaction against the
+// state of the latest finalized block, nothing of it is persisted.
+type TxnSimulation struct {
+	Hash            string                  `json:"hash"`
+	ClientID        string                  `json:"client_id"`
+	Nonce           int64                   `json:"nonce"`
+	Signed          bool                    `json:"signed"`
+	Round           int64                   `json:"round"`
+	Status          int                     `json:"status"`
+	Output          string                  `json:"output"`
+	Error           string                  `json:"error,omitempty"`
+	Cost            int                     `json:"cost"`
+	Events          []event.Event           `json:"events"`
+	Transfers       []*state.Transfer       `json:"transfers"`
+	SignedTransfers []*state.SignedTransfer `json:"signed_transfers"`
+	Mints           []*state.Mint           `json:"mints"`
+	ReadKeys        []string                `json:"read_keys"`
+	WrittenKeys     []string                `json:"written_keys"`
+}
+
+// SimulateTransaction executes the transaction against a throwaway copy of
+// the state of the latest finalized block. The nonce of a transaction
+// without it is the next nonce of the client.
+func (c *Chain) SimulateTransaction(ctx context.Context, txn *transaction.Transaction) (
+	*TxnSimulation, error) {
+
+	lfb := c.GetLatestFinalizedBlock()
+	if lfb == nil || lfb.ClientState == nil {
+		return nil, common.NewError("simulate_transaction", "chain is not ready yet")
+	}
+
+	if txn.CreationDate == 0 {
+		txn.CreationDate = common.Now()
+	}
+	if txn.Nonce == 0 {
+		s, err := c.GetStateById(lfb.ClientState, txn.ClientID)
+		if !isValid(err) {
+			return nil, common.NewError("simulate_transaction",
+				"can't get client state: "+err.Error())
+		}
+		txn.Nonce = s.Nonce + 1
+	}
+	if txn.Hash == "" {
+		txn.Hash = txn.ComputeHash()
+	}
+
+	cost, err := c.EstimateTransactionCost(ctx, lfb, lfb.ClientState, txn)
+	if err != nil {
+		return nil, common.NewError("simulate_transaction",
+			"can't estimate cost: "+err.Error())
+	}
+
+	b := block.NewBlock(c.GetKey(), lfb.Round+1)
+	b.PrevBlock = lfb
+	b.PrevHash = lfb.Hash
+	b.CreationDate = txn.CreationDate
+	b.LatestFinalizedMagicBlockHash = lfb.LatestFinalizedMagicBlockHash
+	b.LatestFinalizedMagicBlockRound = lfb.LatestFinalizedMagicBlockRound
+
+	var (
+		bState = CreateTxnMPT(lfb.ClientState)
+		rec    *recordingMPT
+	)
+	events, sctx, err := c.executeTxn(ctx, b, bState, txn,
+		func(mpt util.MerklePatriciaTrieI) util.MerklePatriciaTrieI {
+			// the state of a failed smart contract call is discarded,
+			// the keys of the last state are the ones to persist
+			rec = newRecordingMPT(CreateTxnMPT(mpt))
+			return rec
+		})
+
+	sim := &TxnSimulation{
+		Hash:     txn.Hash,
+		ClientID: txn.ClientID,
+		Nonce:    txn.Nonce,
+		Signed:   txn.Signature != "",
+		Round:    lfb.Round,
+		Status:   txn.Status,
+		Output:   txn.TransactionOutput,
+		Cost:     cost,
+		Events:   events,
+	}
+	if err != nil {
+		sim.Status = transaction.TxnFail
+		sim.Error = err.Error()
+	}
+	if sctx != nil {
+		sim.Transfers = sctx.GetTransfers()
+		sim.SignedTransfers = sctx.GetSignedTransfers()
+		sim.Mints = sctx.GetMints()
+	}
+	if rec != nil {
+		sim.ReadKeys = rec.readKeys()
+		sim.WrittenKeys = rec.writtenKeys()
+	}
+	return sim, nil
+}
+
+// recordingMPT records the paths of the state read and written through it
+type recordingMPT struct {
+	util.MerklePatriciaTrieI
+	read    map[string]struct{}
+	written map[string]struct{}
+}
+
+func newRecordingMPT(mpt util.MerklePatriciaTrieI) *recordingMPT {
+	return &recordingMPT{
+		MerklePatriciaTrieI: mpt,
+		read:                make(map[string]struct{}),
+		written:             make(map[string]struct{}),
+	}
+}
+
+func (r *recordingMPT) GetNodeValue(path util.Path, v util.MPTSerializable) error {
+	r.read[string(path)] = struct{}{}
+	return r.MerklePatriciaTrieI.GetNodeValue(path, v)
+}
+
+func (r *recordingMPT) GetNodeValueRaw(path util.Path) ([]byte, error) {
+	r.read[string(path)] = struct{}{}
+	return r.MerklePatriciaTrieI.GetNodeValueRaw(path)
+}
+
+func (r *recordingMPT) Insert(path util.Path, value util.MPTSerializable) (util.Key, error) {
+	r.written[string(path)] = struct{}{}
+	return r.MerklePatriciaTrieI.Insert(path, value)
+}
+
+func (r *recordingMPT) Delete(path util.Path) (util.Key, error) {
+	r.written[string(path)] = struct{}{}
+	return r.MerklePatriciaTrieI.Delete(path)
+}
+
+func (r *recordingMPT) readKeys() []string {
+	return sortedKeys(r.read)
+}
+
+func (r *recordingMPT) writtenKeys() []string {
+	return sortedKeys(r.written)
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,35 @@
+package chain
+
+import (
+	"testing"
+
+	"0chain.net/chaincore/state"
+	"0chain.net/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordingMPT(t *testing.T) {
+	var (
+		mpt = util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 0, nil)
+		rec = newRecordingMPT(CreateTxnMPT(mpt))
+		s   = &state.State{}
+	)
+
+	_, err := rec.Insert(util.Path("b1"), &state.State{Balance: 1})
+	require.NoError(t, err)
+	_, err = rec.Insert(util.Path("a1"), &state.State{Balance: 2})
+	require.NoError(t, err)
+	_, err = rec.Delete(util.Path("b1"))
+	require.NoError(t, err)
+
+	require.NoError(t, rec.GetNodeValue(util.Path("a1"), s))
+	require.Equal(t, util.ErrValueNotPresent, rec.GetNodeValue(util.Path("c1"), s))
+
+	require.Equal(t, []string{"a1", "c1"}, rec.readKeys())
+	require.Equal(t, []string{"a1", "b1"}, rec.writtenKeys())
+
+	// the recorded changes merge as the changes of the underlying state
+	require.NoError(t, mpt.MergeMPTChanges(rec))
+	require.NoError(t, mpt.GetNodeValue(util.Path("a1"), s))
+	require.EqualValues(t, 2, s.Balance)
+}
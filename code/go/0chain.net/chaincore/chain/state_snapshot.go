@@ -0,0 +1,115 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+
+	"go.uber.org/zap"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/logging"
+	"0chain.net/core/util"
+)
+
+// ExportStateSnapshot - write the full client state of the finalized block
+// into a snapshot in given directory.
+func (c *Chain) ExportStateSnapshot(ctx context.Context, b *block.Block, dir string,
+	chunkSize int64) (*util.SnapshotManifest, error) {
+
+	if !c.HasClientStateStored(b.ClientStateHash) {
+		return nil, common.NewErrorf("export_state_snapshot",
+			"state of round %d is not stored", b.Round)
+	}
+	mpt := util.NewMerklePatriciaTrie(c.GetStateDB(), util.Sequence(b.Round), b.ClientStateHash)
+	sm := &util.SnapshotManifest{
+		ChainID:   c.GetKey(),
+		Round:     b.Round,
+		BlockHash: b.Hash,
+	}
+	if err := util.ExportSnapshot(ctx, mpt, dir, chunkSize, sm); err != nil {
+		return nil, common.NewErrorf("export_state_snapshot", "round %d: %v", b.Round, err)
+	}
+	return sm, nil
+}
+
+// BootstrapStateFromSnapshot - import the state snapshot in given directory
+// into the state db. The snapshot block is fetched from sharders and the
+// snapshot root is verified against its client state hash before the
+// import. The snapshot block, having its state initialized, is set as the
+// latest finalized block, so that the node syncs from it instead of the
+// genesis block. It returns the snapshot block.
+func (c *Chain) BootstrapStateFromSnapshot(ctx context.Context, dir string) (*block.Block, error) {
+	sm, err := util.ReadSnapshotManifest(dir)
+	if err != nil {
+		return nil, common.NewErrorf("bootstrap_state", "reading manifest: %v", err)
+	}
+	if sm.ChainID != c.GetKey() {
+		return nil, common.NewErrorf("bootstrap_state", "chain id mismatch: %s, expected %s",
+			sm.ChainID, c.GetKey())
+	}
+
+	b, err := c.GetNotarizedBlockFromSharders(ctx, sm.BlockHash, sm.Round)
+	if err != nil {
+		return nil, common.NewErrorf("bootstrap_state", "fetching block of round %d: %v",
+			sm.Round, err)
+	}
+	if err := verifySnapshotBlock(sm, b); err != nil {
+		return nil, err
+	}
+
+	if _, err := util.ImportSnapshot(ctx, dir, c.GetStateDB()); err != nil {
+		return nil, common.NewErrorf("bootstrap_state", "import: %v", err)
+	}
+	if err := c.InitBlockState(b); err != nil {
+		return nil, common.NewErrorf("bootstrap_state", "init block state: %v", err)
+	}
+	b.SetStateStatus(block.StateSuccessful)
+	if err := c.setSnapshotBlockAsLFB(ctx, b); err != nil {
+		return nil, err
+	}
+
+	logging.Logger.Info("bootstrap state from snapshot",
+		zap.Int64("round", b.Round),
+		zap.String("block", b.Hash),
+		zap.String("state", sm.Root),
+		zap.Int64("nodes", sm.Nodes))
+	return b, nil
+}
+
+// setSnapshotBlockAsLFB - set the snapshot block and its magic block as the
+// latest finalized ones. The block and its round are already added to the
+// chain by the fetching from sharders.
+func (c *Chain) setSnapshotBlockAsLFB(ctx context.Context, b *block.Block) error {
+	lfmb, err := c.GetNotarizedBlockFromSharders(ctx, b.LatestFinalizedMagicBlockHash,
+		b.LatestFinalizedMagicBlockRound)
+	if err != nil {
+		return common.NewErrorf("bootstrap_state", "fetching magic block of round %d: %v",
+			b.LatestFinalizedMagicBlockRound, err)
+	}
+	if lfmb.MagicBlock == nil {
+		return common.NewErrorf("bootstrap_state", "block %s has no magic block", lfmb.Hash)
+	}
+	if err := c.UpdateMagicBlock(lfmb.MagicBlock); err != nil {
+		return common.NewErrorf("bootstrap_state", "update magic block: %v", err)
+	}
+	c.SetLatestFinalizedMagicBlock(lfmb)
+	c.SetLatestFinalizedBlock(b)
+	c.SetCurrentRound(b.Round)
+	return nil
+}
+
+// verifySnapshotBlock - check the snapshot is the state of given block.
+func verifySnapshotBlock(sm *util.SnapshotManifest, b *block.Block) error {
+	if b.Round != sm.Round || b.Hash != datastore.Key(sm.BlockHash) {
+		return common.NewErrorf("bootstrap_state", "snapshot block mismatch: round %d, hash %s",
+			b.Round, b.Hash)
+	}
+	root, err := sm.GetRoot()
+	if err != nil || !bytes.Equal(root, b.ClientStateHash) {
+		return common.NewErrorf("bootstrap_state", "snapshot root %s doesn't match block state %s",
+			sm.Root, util.ToHex(b.ClientStateHash))
+	}
+	return nil
+}
@@ -28,6 +28,9 @@ func SetupDefaultConfig() {
 	viper.SetDefault("server_chain.round_range", 10000000)
 	viper.SetDefault("server_chain.transaction.payload.max_size", 32)
 	viper.SetDefault("server_chain.state.prune_below_count", 100)
+	viper.SetDefault("server_chain.state.backend", "rocksdb")
+	viper.SetDefault("server_chain.state.archive.enabled", false)
+	viper.SetDefault("server_chain.dbs.events.driver", "postgres")
 	viper.SetDefault("server_chain.block.consensus.threshold_by_count", 66)
 	viper.SetDefault("server_chain.block.generation.timeout", 37)
 	viper.SetDefault("server_chain.state.sync.timeout", 10)
@@ -188,6 +191,7 @@ type ChainConfig interface {
 
 type DbAccess struct {
 	Enabled  bool   `json:"enabled"`
+	Driver   string `json:"driver"`
 	Name     string `json:"name"`
 	User     string `json:"user"`
 	Password string `json:"password"`
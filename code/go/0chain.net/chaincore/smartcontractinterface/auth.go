@@ -11,3 +11,16 @@ func AuthorizeWithOwner(funcName string, hasAccess func() bool) error {
 	}
 	return nil
 }
+
+// GovernanceAddress is the address of the governance smart contract, it
+// calls the settings functions of the smart contracts to apply the passed
+// proposals.
+const GovernanceAddress = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e1"
+
+// AuthorizeWithOwnerOrGovernance authorizes the owner or the governance smart
+// contract to call a settings function.
+func AuthorizeWithOwnerOrGovernance(funcName, clientID string, hasAccess func() bool) error {
+	return AuthorizeWithOwner(funcName, func() bool {
+		return clientID == GovernanceAddress || hasAccess()
+	})
+}
@@ -0,0 +1,99 @@
+package transaction
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"0chain.net/chaincore/currency"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+)
+
+// The max number of transfers of a batch send transaction and the cost of a
+// transfer are set in the miner SC configurations, these are the defaults.
+const (
+	// DefaultBatchSendMaxTransfers is the default max number of transfers of
+	// a batch send transaction
+	DefaultBatchSendMaxTransfers = 500
+	// DefaultBatchSendTransferCost is the default cost of a transfer of a
+	// batch send transaction
+	DefaultBatchSendTransferCost = 10
+)
+
+// BatchTransfer is a transfer of a batch send transaction
+type BatchTransfer struct {
+	ToClientID datastore.Key `json:"to_client_id"`
+	Amount     currency.Coin `json:"amount"`
+}
+
+// BatchSendData is the data of a batch send transaction, the value of the
+// transaction is the sum of the amounts of its transfers
+type BatchSendData struct {
+	Transfers []BatchTransfer `json:"transfers"`
+}
+
+// GetBatchSendData decodes and validates the transfers of a batch send
+// transaction
+func (t *Transaction) GetBatchSendData() (*BatchSendData, error) {
+	if t.TransactionType != TxnTypeBatchSend {
+		return nil, common.InvalidRequest("not a batch send transaction")
+	}
+
+	var data BatchSendData
+	if err := json.Unmarshal([]byte(t.TransactionData), &data); err != nil {
+		return nil, common.InvalidRequest("invalid batch send data: " + err.Error())
+	}
+	if err := data.validate(t); err != nil {
+		return nil, common.InvalidRequest("invalid batch send data: " + err.Error())
+	}
+	return &data, nil
+}
+
+func (bsd *BatchSendData) validate(t *Transaction) error {
+	if len(bsd.Transfers) == 0 {
+		return fmt.Errorf("no transfers")
+	}
+
+	var (
+		total      currency.Coin
+		recipients = make(map[datastore.Key]struct{}, len(bsd.Transfers))
+		err        error
+	)
+	for i, bt := range bsd.Transfers {
+		switch {
+		case !encryption.IsHash(bt.ToClientID):
+			return fmt.Errorf("transfer %d: to client id must be a hexadecimal hash", i)
+		case bt.ToClientID == t.ClientID:
+			return fmt.Errorf("transfer %d: from and to client should be different", i)
+		case bt.Amount == 0:
+			return fmt.Errorf("transfer %d: zero amount", i)
+		}
+		if _, ok := recipients[bt.ToClientID]; ok {
+			return fmt.Errorf("transfer %d: duplicate recipient %s", i, bt.ToClientID)
+		}
+		recipients[bt.ToClientID] = struct{}{}
+		if total, err = currency.AddCoin(total, bt.Amount); err != nil {
+			return err
+		}
+	}
+	if total != t.Value {
+		return fmt.Errorf("value %v of the transaction is not the sum %v of the transfers",
+			t.Value, total)
+	}
+	return nil
+}
+
+// CheckMaxTransfers checks the number of the transfers doesn't exceed the max
+// number of transfers of a batch send transaction.
+func (bsd *BatchSendData) CheckMaxTransfers(maxTransfers int) error {
+	if len(bsd.Transfers) > maxTransfers {
+		return common.InvalidRequest(fmt.Sprintf("too many transfers, max %d", maxTransfers))
+	}
+	return nil
+}
+
+// Cost of the transaction, proportional to the number of its transfers.
+func (bsd *BatchSendData) Cost(transferCost int) int {
+	return len(bsd.Transfers) * transferCost
+}
@@ -0,0 +1,94 @@
+package transaction
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"0chain.net/chaincore/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBatchSendData(t *testing.T) {
+	var (
+		from = strings.Repeat("a", 64)
+		to1  = strings.Repeat("b", 64)
+		to2  = strings.Repeat("c", 64)
+	)
+	newTxn := func(value currency.Coin, transfers ...BatchTransfer) *Transaction {
+		data, err := json.Marshal(BatchSendData{Transfers: transfers})
+		require.NoError(t, err)
+		return &Transaction{
+			ClientID:        from,
+			Value:           value,
+			TransactionType: TxnTypeBatchSend,
+			TransactionData: string(data),
+		}
+	}
+	tests := []struct {
+		name    string
+		txn     *Transaction
+		wantErr string
+	}{
+		{
+			name: "ok",
+			txn:  newTxn(30, BatchTransfer{to1, 10}, BatchTransfer{to2, 20}),
+		},
+		{
+			name:    "not_batch_send",
+			txn:     &Transaction{TransactionType: TxnTypeSend},
+			wantErr: "not a batch send transaction",
+		},
+		{
+			name:    "no_transfers",
+			txn:     newTxn(0),
+			wantErr: "no transfers",
+		},
+		{
+			name:    "invalid_recipient",
+			txn:     newTxn(10, BatchTransfer{"b", 10}),
+			wantErr: "must be a hexadecimal hash",
+		},
+		{
+			name:    "to_self",
+			txn:     newTxn(10, BatchTransfer{from, 10}),
+			wantErr: "from and to client should be different",
+		},
+		{
+			name:    "zero_amount",
+			txn:     newTxn(0, BatchTransfer{to1, 0}),
+			wantErr: "zero amount",
+		},
+		{
+			name:    "duplicate_recipient",
+			txn:     newTxn(20, BatchTransfer{to1, 10}, BatchTransfer{to1, 10}),
+			wantErr: "duplicate recipient",
+		},
+		{
+			name:    "value_mismatch",
+			txn:     newTxn(10, BatchTransfer{to1, 10}, BatchTransfer{to2, 20}),
+			wantErr: "is not the sum",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.txn.GetBatchSendData()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, data.Transfers, 2)
+			require.Equal(t, 2*DefaultBatchSendTransferCost, data.Cost(DefaultBatchSendTransferCost))
+		})
+	}
+}
+
+func TestBatchSendData_CheckMaxTransfers(t *testing.T) {
+	data := &BatchSendData{Transfers: make([]BatchTransfer, 3)}
+	require.NoError(t, data.CheckMaxTransfers(3))
+	err := data.CheckMaxTransfers(2)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "too many transfers, max 2")
+}
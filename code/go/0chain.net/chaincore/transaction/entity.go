@@ -73,6 +73,11 @@ type Transaction struct {
 	TransactionOutput string `json:"transaction_output,omitempty" msgpack:"o,omitempty"`
 	OutputHash        string `json:"txn_output_hash" msgpack:"oh"`
 	Status            int    `json:"transaction_status" msgpack:"sot"`
+
+	// ValidAfter and ValidUntil are the optional bounds of the rounds and the
+	// times the transaction can be included in a block
+	ValidAfter *ValidityBound `json:"valid_after,omitempty" msgpack:"va,omitempty"`
+	ValidUntil *ValidityBound `json:"valid_until,omitempty" msgpack:"vu,omitempty"`
 }
 
 type FeeStats struct {
@@ -184,6 +189,17 @@ func (t *Transaction) ValidateWrtTimeForBlock(ctx context.Context, ts common.Tim
 	if t.ClientID == t.ToClientID {
 		return common.InvalidRequest("from and to client should be different")
 	}
+	if err = t.validateValidityBounds(); err != nil {
+		return err
+	}
+	if t.IsExpired(0, ts) {
+		return ErrTxnExpired
+	}
+	if t.TransactionType == TxnTypeBatchSend {
+		if _, err = t.GetBatchSendData(); err != nil {
+			return err
+		}
+	}
 	err = t.VerifyHash(ctx)
 	if err != nil {
 		return err
@@ -272,6 +288,9 @@ func (t *Transaction) HashData() string {
 	s.WriteString(strconv.FormatUint(uint64(t.Value), 10))
 	s.WriteString(":")
 	s.WriteString(encryption.Hash(t.TransactionData))
+	if t.hasValidityWindow() {
+		s.WriteString(t.validityHashData())
+	}
 	return s.String()
 }
 
@@ -454,6 +473,8 @@ func (t *Transaction) Clone() *Transaction {
 		TransactionOutput: t.TransactionOutput,
 		OutputHash:        t.OutputHash,
 		Status:            t.Status,
+		ValidAfter:        t.ValidAfter.clone(),
+		ValidUntil:        t.ValidUntil.clone(),
 	}
 
 	if ent := t.CollectionMemberField.EntityCollection; ent != nil {
@@ -7,6 +7,8 @@ const (
 
 	TxnTypeLockIn = 2 // A transaction to lock tokens, state is maintained on the account and the parent lock in transaction
 
+	TxnTypeBatchSend = 4 // A transaction to send tokens to several accounts at once, the transfers are in the transaction data
+
 	// Any txn type that refers to a parent txn should have an odd value
 	TxnTypeStorageWrite = 101 // A transaction to write data to the blobber
 	TxnTypeStorageRead  = 103 // A transaction to read data from the blobber
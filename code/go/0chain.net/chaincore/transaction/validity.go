@@ -0,0 +1,112 @@
+package transaction
+
+import (
+	"strconv"
+	"strings"
+
+	"0chain.net/core/common"
+)
+
+var (
+	// ErrTxnNotYetValid is returned for a transaction before its validity window
+	ErrTxnNotYetValid = common.NewError("txn_not_yet_valid", "transaction is not valid yet")
+	// ErrTxnExpired is returned for a transaction after its validity window,
+	// it can't be included in a block anymore
+	ErrTxnExpired = common.NewError("txn_expired", "transaction validity window has passed")
+)
+
+// ValidityBound is a bound of the validity window of a transaction, by round,
+// by timestamp or by both of them. Zero values are not bounds.
+type ValidityBound struct {
+	Round     int64            `json:"round,omitempty" msgpack:"r,omitempty"`
+	Timestamp common.Timestamp `json:"timestamp,omitempty" msgpack:"t,omitempty"`
+}
+
+func (vb *ValidityBound) isEmpty() bool {
+	return vb.Round == 0 && vb.Timestamp == 0
+}
+
+func (vb *ValidityBound) clone() *ValidityBound {
+	if vb == nil {
+		return nil
+	}
+	c := *vb
+	return &c
+}
+
+// hasValidityWindow returns true if the transaction has any of the bounds
+func (t *Transaction) hasValidityWindow() bool {
+	return t.ValidAfter != nil || t.ValidUntil != nil
+}
+
+// validityHashData is the part of the hash data of a transaction with a
+// validity window, the hash of a transaction without it doesn't change
+func (t *Transaction) validityHashData() string {
+	s := strings.Builder{}
+	for _, vb := range []*ValidityBound{t.ValidAfter, t.ValidUntil} {
+		s.WriteString(":")
+		if vb == nil {
+			continue
+		}
+		s.WriteString(strconv.FormatInt(vb.Round, 10))
+		s.WriteString(",")
+		s.WriteString(strconv.FormatInt(int64(vb.Timestamp), 10))
+	}
+	return s.String()
+}
+
+// validateValidityBounds checks the bounds of the validity window make sense
+func (t *Transaction) validateValidityBounds() error {
+	after, until := t.ValidAfter, t.ValidUntil
+	for _, vb := range []*ValidityBound{after, until} {
+		if vb == nil {
+			continue
+		}
+		if vb.isEmpty() {
+			return common.InvalidRequest("validity bound without round and timestamp")
+		}
+		if vb.Round < 0 || vb.Timestamp < 0 {
+			return common.InvalidRequest("negative validity bound")
+		}
+	}
+	if after == nil || until == nil {
+		return nil
+	}
+	if after.Round > 0 && until.Round > 0 && after.Round > until.Round {
+		return common.InvalidRequest("valid_after round is greater than valid_until round")
+	}
+	if after.Timestamp > 0 && until.Timestamp > 0 && after.Timestamp > until.Timestamp {
+		return common.InvalidRequest("valid_after timestamp is greater than valid_until timestamp")
+	}
+	return nil
+}
+
+// IsExpired returns true if the validity window of the transaction has passed
+// at the given round and time. A zero round skips the round bound.
+func (t *Transaction) IsExpired(round int64, ts common.Timestamp) bool {
+	vb := t.ValidUntil
+	if vb == nil {
+		return false
+	}
+	return (vb.Round > 0 && round > vb.Round) || (vb.Timestamp > 0 && ts > vb.Timestamp)
+}
+
+func (t *Transaction) isNotYetValid(round int64, ts common.Timestamp) bool {
+	vb := t.ValidAfter
+	if vb == nil {
+		return false
+	}
+	return (vb.Round > 0 && round < vb.Round) || (vb.Timestamp > 0 && ts < vb.Timestamp)
+}
+
+// ValidateWindow checks the transaction can be included in a block of the
+// given round and creation date
+func (t *Transaction) ValidateWindow(round int64, ts common.Timestamp) error {
+	if t.IsExpired(round, ts) {
+		return ErrTxnExpired
+	}
+	if t.isNotYetValid(round, ts) {
+		return ErrTxnNotYetValid
+	}
+	return nil
+}
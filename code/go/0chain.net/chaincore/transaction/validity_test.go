@@ -0,0 +1,73 @@
+package transaction
+
+import (
+	"testing"
+
+	"0chain.net/core/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransaction_ValidateWindow(t *testing.T) {
+	tests := []struct {
+		name  string
+		after *ValidityBound
+		until *ValidityBound
+		round int64
+		ts    common.Timestamp
+		err   error
+	}{
+		{name: "no_window", round: 10, ts: 100},
+		{name: "in_round_window", after: &ValidityBound{Round: 5}, until: &ValidityBound{Round: 10}, round: 10, ts: 100},
+		{name: "before_round", after: &ValidityBound{Round: 11}, round: 10, ts: 100, err: ErrTxnNotYetValid},
+		{name: "after_round", until: &ValidityBound{Round: 9}, round: 10, ts: 100, err: ErrTxnExpired},
+		{name: "in_time_window", after: &ValidityBound{Timestamp: 100}, until: &ValidityBound{Timestamp: 100}, round: 10, ts: 100},
+		{name: "before_time", after: &ValidityBound{Timestamp: 101}, round: 10, ts: 100, err: ErrTxnNotYetValid},
+		{name: "after_time", until: &ValidityBound{Timestamp: 99}, round: 10, ts: 100, err: ErrTxnExpired},
+		{name: "round_and_time", until: &ValidityBound{Round: 20, Timestamp: 99}, round: 10, ts: 100, err: ErrTxnExpired},
+		{name: "unknown_round", until: &ValidityBound{Round: 9}, ts: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := &Transaction{ValidAfter: tt.after, ValidUntil: tt.until}
+			require.Equal(t, tt.err, txn.ValidateWindow(tt.round, tt.ts))
+		})
+	}
+}
+
+func TestTransaction_validateValidityBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		after   *ValidityBound
+		until   *ValidityBound
+		wantErr string
+	}{
+		{name: "ok", after: &ValidityBound{Round: 1, Timestamp: 10}, until: &ValidityBound{Round: 1, Timestamp: 20}},
+		{name: "empty", until: &ValidityBound{}, wantErr: "without round and timestamp"},
+		{name: "negative", after: &ValidityBound{Round: -1}, wantErr: "negative validity bound"},
+		{name: "rounds", after: &ValidityBound{Round: 2}, until: &ValidityBound{Round: 1}, wantErr: "valid_after round"},
+		{name: "timestamps", after: &ValidityBound{Timestamp: 2}, until: &ValidityBound{Timestamp: 1}, wantErr: "valid_after timestamp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := &Transaction{ValidAfter: tt.after, ValidUntil: tt.until}
+			err := txn.validateValidityBounds()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestTransaction_HashDataValidity(t *testing.T) {
+	txn := &Transaction{ClientID: "c", CreationDate: 100, Nonce: 1}
+	hashData := txn.HashData()
+
+	// the bounds are hashed only when set
+	txn.ValidUntil = &ValidityBound{Round: 10}
+	require.Equal(t, hashData+"::10,0", txn.HashData())
+	txn.ValidAfter = &ValidityBound{Timestamp: 90}
+	require.Equal(t, hashData+":0,90:10,0", txn.HashData())
+}
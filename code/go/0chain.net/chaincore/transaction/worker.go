@@ -11,13 +11,14 @@ import (
 	"go.uber.org/zap"
 )
 
-//SetupWorkers - setup workers */
-func SetupWorkers(ctx context.Context) {
-	go CleanupWorker(ctx)
+//SetupWorkers - setup workers, the current round is used to delete the
+// transactions with expired validity windows */
+func SetupWorkers(ctx context.Context, currentRound func() int64) {
+	go CleanupWorker(ctx, currentRound)
 }
 
 /*CleanupWorker - a worker to delete transactiosn that are no longer valid */
-func CleanupWorker(ctx context.Context) {
+func CleanupWorker(ctx context.Context, currentRound func() int64) {
 	ticker := time.NewTicker(time.Second)
 	cctx := memorystore.WithEntityConnection(ctx, transactionEntityMetadata)
 	defer memorystore.Close(cctx)
@@ -41,7 +42,8 @@ func CleanupWorker(ctx context.Context) {
 				logging.Logger.Error("Error in deleting txn in redis", zap.Error(err))
 			}
 		}
-		if !common.Within(int64(txn.CreationDate), TXN_TIME_TOLERANCE-1) {
+		if !common.Within(int64(txn.CreationDate), TXN_TIME_TOLERANCE-1) ||
+			txn.IsExpired(currentRound(), common.Now()) {
 			invalidTxns = append(invalidTxns, txn)
 		}
 		err := transactionEntityMetadata.GetStore().Read(ctx, txn.Hash, txn)
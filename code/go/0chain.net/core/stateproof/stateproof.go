@@ -0,0 +1,283 @@
+// Package stateproof verifies proofs of the client state served by sharders
+// offline. It depends on the standard library and sha3 only, so that light
+// clients and bridge authorizers can import it without the node dependencies.
+//
+// From the activation round configured by the chain the block hash covers
+// the client state hash of the block, so the notarization tickets of the
+// block authenticate the state root the proof is verified against. A proof
+// verified without the tickets only proves the value is in the state of a
+// block with the given hash.
+package stateproof
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// node types of the state MPT
+const (
+	nodeTypeLeafNode      = 2
+	nodeTypeFullNode      = 4
+	nodeTypeExtensionNode = 8
+	nodeTypesAll          = 1 | nodeTypeLeafNode | nodeTypeFullNode | nodeTypeExtensionNode
+)
+
+const (
+	separator = ':'
+	// type byte, version and origin
+	nodePrefixSize = 1 + 8 + 8
+)
+
+var (
+	// ErrInvalidProof - the proof nodes don't prove the value
+	ErrInvalidProof = errors.New("invalid state proof")
+	// ErrInvalidBlock - the block header doesn't match its hash
+	ErrInvalidBlock = errors.New("invalid block header")
+	// ErrNotNotarized - not enough valid notarization tickets
+	ErrNotNotarized = errors.New("block not notarized")
+	// ErrStateNotCovered - the block hash doesn't cover the client state
+	// hash, the block is from before the activation round
+	ErrStateNotCovered = errors.New("state hash not covered by block hash")
+)
+
+// Ticket - a notarization ticket of the block, the signature of the block
+// hash by the verifier.
+type Ticket struct {
+	VerifierID string `json:"verifier_id"`
+	Signature  string `json:"signature"`
+}
+
+// BlockHeader - the block fields its hash is computed from, the client state
+// hash and the notarization tickets of the block. The StateHashRound is the
+// round from which the chain covers the client state hash by the block hash,
+// zero if it doesn't.
+type BlockHeader struct {
+	Hash                  string    `json:"hash"`
+	MinerID               string    `json:"miner_id"`
+	PrevHash              string    `json:"prev_hash"`
+	CreationDate          int64     `json:"creation_date"`
+	Round                 int64     `json:"round"`
+	RoundRandomSeed       int64     `json:"round_random_seed"`
+	StateChangesCount     int       `json:"state_changes_count"`
+	MerkleTreeRoot        string    `json:"merkle_tree_root"`
+	ReceiptMerkleTreeRoot string    `json:"receipt_merkle_tree_root"`
+	MagicBlockHash        string    `json:"magic_block_hash,omitempty"`
+	ClientStateHash       string    `json:"state_hash"`
+	StateHashRound        int64     `json:"state_hash_round,omitempty"`
+	VerificationTickets   []*Ticket `json:"verification_tickets"`
+}
+
+// ComputeHash - compute the block hash from the header fields the same way
+// the block computes it.
+func (h *BlockHeader) ComputeHash() string {
+	fields := []string{
+		h.MinerID,
+		h.PrevHash,
+		strconv.FormatInt(h.CreationDate, 10),
+		strconv.FormatInt(h.Round, 10),
+		strconv.FormatInt(h.RoundRandomSeed, 10),
+		strconv.Itoa(h.StateChangesCount),
+		h.MerkleTreeRoot,
+		h.ReceiptMerkleTreeRoot,
+	}
+	if h.CoversStateHash() {
+		fields = append(fields, h.ClientStateHash)
+	}
+	if h.MagicBlockHash != "" {
+		fields = append(fields, h.MagicBlockHash)
+	}
+	return hex.EncodeToString(rawHash([]byte(strings.Join(fields, string(separator)))))
+}
+
+// CoversStateHash - whether the block hash covers the client state hash.
+func (h *BlockHeader) CoversStateHash() bool {
+	return h.StateHashRound > 0 && h.Round >= h.StateHashRound
+}
+
+// Proof - the proof of the value stored under given key of the client state
+// of the block.
+type Proof struct {
+	// Key - the path of the value in the state MPT
+	Key string `json:"key"`
+	// Value - the hex encoded value as stored in the state
+	Value string `json:"value"`
+	// Nodes - the hex encoded MPT nodes from the state root to the value
+	Nodes []string `json:"nodes"`
+	// Block - the header of the block the state belongs to
+	Block *BlockHeader `json:"block"`
+}
+
+// TicketVerifier - verify the signature of the block hash by given verifier,
+// the light client checks it with the miner keys of the magic block it trusts.
+type TicketVerifier func(verifierID, signature, hash string) error
+
+// Verify - verify the proof and return the proven value. The block header is
+// checked against its hash and, when the ticket verifier is given, the block
+// must have at least threshold valid tickets of distinct verifiers and its
+// hash must cover the state hash. Without the ticket verifier the state root
+// is not authenticated.
+func Verify(p *Proof, verifyTicket TicketVerifier, threshold int) ([]byte, error) {
+	if p.Block == nil {
+		return nil, fmt.Errorf("%w: missing block header", ErrInvalidBlock)
+	}
+	if err := VerifyBlockHeader(p.Block, verifyTicket, threshold); err != nil {
+		return nil, err
+	}
+	// the tickets authenticate the state root only if the hash covers it, a
+	// wrong state hash round doesn't match the hash the tickets sign
+	if verifyTicket != nil && !p.Block.CoversStateHash() {
+		return nil, fmt.Errorf("%w: round %d, state hash round %d", ErrStateNotCovered,
+			p.Block.Round, p.Block.StateHashRound)
+	}
+	root, err := hex.DecodeString(p.Block.ClientStateHash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: state hash: %v", ErrInvalidBlock, err)
+	}
+	value, err := hex.DecodeString(p.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: value: %v", ErrInvalidProof, err)
+	}
+	nodes := make([][]byte, 0, len(p.Nodes))
+	for i, n := range p.Nodes {
+		node, err := hex.DecodeString(n)
+		if err != nil {
+			return nil, fmt.Errorf("%w: node %d: %v", ErrInvalidProof, i, err)
+		}
+		nodes = append(nodes, node)
+	}
+	if err := VerifyState(root, p.Key, value, nodes); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// VerifyBlockHeader - check the header hash and, when the ticket verifier is
+// given, the notarization of the block.
+func VerifyBlockHeader(h *BlockHeader, verifyTicket TicketVerifier, threshold int) error {
+	if hash := h.ComputeHash(); hash != h.Hash {
+		return fmt.Errorf("%w: hash %s, computed %s", ErrInvalidBlock, h.Hash, hash)
+	}
+	if verifyTicket == nil {
+		return nil
+	}
+
+	var valid = make(map[string]bool, len(h.VerificationTickets))
+	for _, t := range h.VerificationTickets {
+		if t == nil || valid[t.VerifierID] {
+			continue
+		}
+		if err := verifyTicket(t.VerifierID, t.Signature, h.Hash); err != nil {
+			continue
+		}
+		valid[t.VerifierID] = true
+	}
+	if len(valid) < threshold || len(valid) == 0 {
+		return fmt.Errorf("%w: %d valid tickets, threshold %d", ErrNotNotarized,
+			len(valid), threshold)
+	}
+	return nil
+}
+
+// VerifyState - check the encoded nodes are the path of the state MPT with
+// given root to the given value stored under the key.
+func VerifyState(root []byte, key string, value []byte, nodes [][]byte) error {
+	var (
+		path     = []byte(key)
+		expected = root
+	)
+	for i, node := range nodes {
+		if expected == nil {
+			return fmt.Errorf("%w: unexpected node %d", ErrInvalidProof, i)
+		}
+		if len(node) < nodePrefixSize {
+			return fmt.Errorf("%w: node %d: invalid encoding", ErrInvalidProof, i)
+		}
+		// the node hash covers the origin and the node data, but not the
+		// type and the version
+		if !bytes.Equal(rawHash(node[9:]), expected) {
+			return fmt.Errorf("%w: node %d: hash mismatch", ErrInvalidProof, i)
+		}
+
+		var (
+			data = node[nodePrefixSize:]
+			err  error
+		)
+		switch node[0] & nodeTypesAll {
+		case nodeTypeLeafNode:
+			err = verifyLeafNode(data, path, value)
+			expected = nil
+		case nodeTypeFullNode:
+			expected, path, err = nextFullNodeChild(data, path)
+		case nodeTypeExtensionNode:
+			expected, path, err = nextExtensionNodeChild(data, path)
+		default:
+			err = fmt.Errorf("unexpected node type %d", node[0])
+		}
+		if err != nil {
+			return fmt.Errorf("%w: node %d: %v", ErrInvalidProof, i, err)
+		}
+	}
+	if expected != nil {
+		return fmt.Errorf("%w: incomplete path", ErrInvalidProof)
+	}
+	return nil
+}
+
+func verifyLeafNode(data, path, value []byte) error {
+	fields := bytes.SplitN(data, []byte{separator}, 3)
+	if len(fields) != 3 {
+		return errors.New("invalid leaf node encoding")
+	}
+	if !bytes.Equal(fields[1], path) {
+		return errors.New("leaf path mismatch")
+	}
+	if !bytes.Equal(fields[2], value) {
+		return errors.New("value mismatch")
+	}
+	return nil
+}
+
+func nextFullNodeChild(data, path []byte) ([]byte, []byte, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("path ends at full node")
+	}
+	idx := strings.IndexByte("0123456789abcdef", path[0])
+	if idx < 0 {
+		return nil, nil, fmt.Errorf("invalid path element %q", path[0])
+	}
+	children := bytes.SplitN(data, []byte{separator}, 17)
+	if len(children) != 17 {
+		return nil, nil, errors.New("invalid full node encoding")
+	}
+	if len(children[idx]) == 0 {
+		return nil, nil, errors.New("missing child")
+	}
+	child, err := hex.DecodeString(string(children[idx]))
+	if err != nil {
+		return nil, nil, err
+	}
+	return child, path[1:], nil
+}
+
+func nextExtensionNodeChild(data, path []byte) ([]byte, []byte, error) {
+	idx := bytes.IndexByte(data, separator)
+	if idx < 0 {
+		return nil, nil, errors.New("invalid extension node encoding")
+	}
+	if !bytes.HasPrefix(path, data[:idx]) {
+		return nil, nil, errors.New("extension path mismatch")
+	}
+	return data[idx+1:], path[idx:], nil
+}
+
+func rawHash(data []byte) []byte {
+	h := sha3.New256()
+	h.Write(data)
+	return h.Sum(nil)
+}
@@ -0,0 +1,200 @@
+package stateproof
+
+import (
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"0chain.net/core/util"
+)
+
+type testValue struct {
+	data []byte
+}
+
+func (v *testValue) MarshalMsg([]byte) ([]byte, error) {
+	return v.data, nil
+}
+
+func (v *testValue) UnmarshalMsg(b []byte) ([]byte, error) {
+	v.data = b
+	return nil, nil
+}
+
+func testKey(i int) util.Path {
+	return util.Path(hex.EncodeToString(rawHash([]byte("key " + strconv.Itoa(i)))))
+}
+
+func makeTestProof(t *testing.T, mpt util.MerklePatriciaTrieI, key util.Path) *Proof {
+	nodes, err := mpt.GetPathNodes(key)
+	require.NoError(t, err)
+
+	h := &BlockHeader{
+		MinerID:           "miner",
+		PrevHash:          "prev",
+		CreationDate:      1650000000,
+		Round:             10,
+		RoundRandomSeed:   42,
+		StateChangesCount: 3,
+		MerkleTreeRoot:    "txns",
+		ClientStateHash:   util.ToHex(mpt.GetRoot()),
+		StateHashRound:    5,
+	}
+	signTestHeader(h)
+
+	p := &Proof{Key: string(key), Block: h}
+	for _, n := range nodes {
+		p.Nodes = append(p.Nodes, hex.EncodeToString(n.Encode()))
+	}
+	p.Value = hex.EncodeToString(nodes[len(nodes)-1].(*util.LeafNode).GetValueBytes())
+	return p
+}
+
+func signTestHeader(h *BlockHeader) {
+	h.Hash = h.ComputeHash()
+	// the test signature of a block is its hash
+	h.VerificationTickets = []*Ticket{
+		{VerifierID: "m1", Signature: h.Hash},
+		{VerifierID: "m2", Signature: h.Hash},
+		{VerifierID: "m2", Signature: h.Hash},
+		{VerifierID: "m3", Signature: "bad"},
+	}
+}
+
+func verifyTestTicket(verifierID, signature, hash string) error {
+	if signature != hash {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func TestVerify(t *testing.T) {
+	mpt := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), util.Sequence(0), nil)
+	for i := 0; i < 300; i++ {
+		mpt.SetVersion(util.Sequence(i % 7))
+		_, err := mpt.Insert(testKey(i), &testValue{[]byte("value: " + strconv.Itoa(i))})
+		require.NoError(t, err)
+	}
+	// keys sharing a long prefix are proven through an extension node
+	for i := 0; i < 3; i++ {
+		key := util.Path("abcdef" + string(testKey(1000 + i)[6:]))
+		_, err := mpt.Insert(key, &testValue{[]byte("prefixed " + strconv.Itoa(i))})
+		require.NoError(t, err)
+	}
+
+	for _, i := range []int{0, 1, 150, 299} {
+		p := makeTestProof(t, mpt, testKey(i))
+		value, err := Verify(p, verifyTestTicket, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "value: "+strconv.Itoa(i), string(value))
+	}
+	for i := 0; i < 3; i++ {
+		p := makeTestProof(t, mpt, util.Path("abcdef"+string(testKey(1000 + i)[6:])))
+		value, err := Verify(p, verifyTestTicket, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "prefixed "+strconv.Itoa(i), string(value))
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(p *Proof)
+		err    error
+	}{
+		{
+			name:   "value",
+			tamper: func(p *Proof) { p.Value = hex.EncodeToString([]byte("value: 1000")) },
+			err:    ErrInvalidProof,
+		},
+		{
+			name:   "key",
+			tamper: func(p *Proof) { p.Key = string(testKey(1)) },
+			err:    ErrInvalidProof,
+		},
+		{
+			name: "node",
+			tamper: func(p *Proof) {
+				n, _ := hex.DecodeString(p.Nodes[1])
+				n[len(n)-1] ^= 1
+				p.Nodes[1] = hex.EncodeToString(n)
+			},
+			err: ErrInvalidProof,
+		},
+		{
+			name:   "missing node",
+			tamper: func(p *Proof) { p.Nodes = p.Nodes[:len(p.Nodes)-1] },
+			err:    ErrInvalidProof,
+		},
+		{
+			name:   "state hash",
+			tamper: func(p *Proof) { p.Block.ClientStateHash = hex.EncodeToString(rawHash(nil)) },
+			err:    ErrInvalidBlock,
+		},
+		{
+			name: "forged state",
+			tamper: func(p *Proof) {
+				// a forged state root with a header rehashed to match it
+				p.Block.ClientStateHash = hex.EncodeToString(rawHash(nil))
+				p.Block.Hash = p.Block.ComputeHash()
+			},
+			err: ErrNotNotarized,
+		},
+		{
+			name:   "state hash round",
+			tamper: func(p *Proof) { p.Block.StateHashRound = 0 },
+			err:    ErrInvalidBlock,
+		},
+		{
+			name: "before activation",
+			tamper: func(p *Proof) {
+				p.Block.StateHashRound = p.Block.Round + 1
+				signTestHeader(p.Block)
+			},
+			err: ErrStateNotCovered,
+		},
+		{
+			name:   "block header",
+			tamper: func(p *Proof) { p.Block.Round++ },
+			err:    ErrInvalidBlock,
+		},
+		{
+			name:   "notarization",
+			tamper: func(p *Proof) { p.Block.VerificationTickets[0].Signature = "bad" },
+			err:    ErrNotNotarized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := makeTestProof(t, mpt, testKey(10))
+			tt.tamper(p)
+			_, err := Verify(p, verifyTestTicket, 2)
+			assert.True(t, errors.Is(err, tt.err), "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestVerifyBeforeActivation(t *testing.T) {
+	mpt := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), util.Sequence(0), nil)
+	_, err := mpt.Insert(testKey(0), &testValue{[]byte("value: 0")})
+	require.NoError(t, err)
+
+	p := makeTestProof(t, mpt, testKey(0))
+	p.Block.StateHashRound = 0
+	signTestHeader(p.Block)
+
+	// the hash of the block doesn't cover its state hash
+	hash := p.Block.Hash
+	p.Block.ClientStateHash = hex.EncodeToString(rawHash(nil))
+	assert.Equal(t, hash, p.Block.ComputeHash())
+	p.Block.ClientStateHash = util.ToHex(mpt.GetRoot())
+
+	value, err := Verify(p, nil, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "value: 0", string(value))
+
+	_, err = Verify(p, verifyTestTicket, 2)
+	assert.True(t, errors.Is(err, ErrStateNotCovered), "unexpected error: %v", err)
+}
@@ -1,5 +1,10 @@
 package util
 
+import (
+	"context"
+	"encoding/binary"
+)
+
 //go:generate msgp -v -io=false -tests=false -unexported=true
 
 type deadNodes struct {
@@ -14,3 +19,22 @@ func (d *deadNodes) decode(data []byte) error {
 func (d *deadNodes) encode() ([]byte, error) {
 	return d.MarshalMsg(nil)
 }
+
+// deadNodesStore - a node db keeping the encoded dead nodes records by the
+// version the nodes died in
+type deadNodesStore interface {
+	// iterateDeadNodes calls the handler for each record in the version
+	// order, the data is only valid until the handler returns
+	iterateDeadNodes(ctx context.Context, handler func(version uint64, data []byte) error) error
+	putDeadNodes(version uint64, data []byte) error
+}
+
+func uint64ToBytes(r uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, r)
+	return b
+}
+
+func bytesToUint64(data []byte) uint64 {
+	return binary.BigEndian.Uint64(data)
+}
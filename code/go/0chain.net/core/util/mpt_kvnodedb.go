@@ -0,0 +1,281 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	lutil "github.com/syndtr/goleveldb/leveldb/util"
+
+	"0chain.net/core/logging"
+	"go.uber.org/zap"
+)
+
+// key prefixes separating the nodes and the dead nodes records in the
+// single key space of the KVNodeDB
+const (
+	kvNodePrefix      = 'n'
+	kvDeadNodesPrefix = 'd'
+)
+
+// KVNodeDB - a node db persisted in the pure Go embedded goleveldb store, an
+// alternative to the rocksdb based PNodeDB
+type KVNodeDB struct {
+	db *leveldb.DB
+	wo *opt.WriteOptions
+}
+
+// NewKVNodeDB - create a new KVNodeDB
+func NewKVNodeDB(stateDir string) (*KVNodeDB, error) {
+	db, err := leveldb.OpenFile(stateDir, &opt.Options{
+		Compression: opt.SnappyCompression,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &KVNodeDB{db: db, wo: &opt.WriteOptions{Sync: false}}, nil
+}
+
+func kvNodeKey(key Key) []byte {
+	return append([]byte{kvNodePrefix}, key...)
+}
+
+func kvDeadNodesKey(version uint64) []byte {
+	return append([]byte{kvDeadNodesPrefix}, uint64ToBytes(version)...)
+}
+
+/*GetNode - implement interface */
+func (kvdb *KVNodeDB) GetNode(key Key) (Node, error) {
+	data, err := kvdb.db.Get(kvNodeKey(key), nil)
+	if err == leveldb.ErrNotFound || (err == nil && len(data) == 0) {
+		return nil, ErrNodeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return CreateNode(bytes.NewReader(data))
+}
+
+/*PutNode - implement interface */
+func (kvdb *KVNodeDB) PutNode(key Key, node Node) error {
+	err := kvdb.db.Put(kvNodeKey(key), node.Encode(), kvdb.wo)
+	if DebugMPTNode {
+		logging.Logger.Debug("node put to KVNodeDB",
+			zap.String("key", ToHex(key)), zap.Error(err),
+			zap.Int64("Origin", int64(node.GetOrigin())),
+			zap.Int64("Version", int64(node.GetVersion())))
+	}
+	return err
+}
+
+/*DeleteNode - implement interface */
+func (kvdb *KVNodeDB) DeleteNode(key Key) error {
+	return kvdb.db.Delete(kvNodeKey(key), kvdb.wo)
+}
+
+/*MultiGetNode - get multiple nodes */
+func (kvdb *KVNodeDB) MultiGetNode(keys []Key) ([]Node, error) {
+	var nodes []Node
+	var err error
+	for _, key := range keys {
+		node, nerr := kvdb.GetNode(key)
+		if nerr != nil {
+			err = nerr
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, err
+}
+
+/*MultiPutNode - implement interface */
+func (kvdb *KVNodeDB) MultiPutNode(keys []Key, nodes []Node) error {
+	ts := time.Now()
+	wb := new(leveldb.Batch)
+	for idx, key := range keys {
+		wb.Put(kvNodeKey(key), nodes[idx].Encode())
+	}
+	err := kvdb.db.Write(wb, kvdb.wo)
+	if err != nil {
+		logging.Logger.Error("kv node db save nodes failed",
+			zap.Int("nodes", len(keys)),
+			zap.Duration("duration", time.Since(ts)),
+			zap.Error(err))
+	}
+	return err
+}
+
+/*MultiDeleteNode - implement interface */
+func (kvdb *KVNodeDB) MultiDeleteNode(keys []Key) error {
+	wb := new(leveldb.Batch)
+	for _, key := range keys {
+		wb.Delete(kvNodeKey(key))
+	}
+	return kvdb.db.Write(wb, kvdb.wo)
+}
+
+/*Iterate - implement interface */
+func (kvdb *KVNodeDB) Iterate(ctx context.Context, handler NodeDBIteratorHandler) error {
+	it := kvdb.db.NewIterator(lutil.BytesPrefix([]byte{kvNodePrefix}),
+		&opt.ReadOptions{DontFillCache: true})
+	defer it.Release()
+	for it.Next() {
+		key, value := append(Key(nil), it.Key()[1:]...), it.Value()
+		node, err := CreateNode(bytes.NewReader(value))
+		if err != nil {
+			logging.Logger.Error("iterate - create node", zap.String("key", ToHex(key)), zap.Error(err))
+			continue
+		}
+		if err := handler(ctx, key, node); err != nil {
+			logging.Logger.Error("iterate - create node handler error", zap.String("key", ToHex(key)), zap.Error(err))
+			return err
+		}
+	}
+	return it.Error()
+}
+
+/*Size - count number of keys in the db */
+func (kvdb *KVNodeDB) Size(ctx context.Context) int64 {
+	var count int64
+	it := kvdb.db.NewIterator(lutil.BytesPrefix([]byte{kvNodePrefix}),
+		&opt.ReadOptions{DontFillCache: true})
+	defer it.Release()
+	for it.Next() {
+		count++
+	}
+	if err := it.Error(); err != nil {
+		logging.Logger.Error("count", zap.Error(err))
+		return -1
+	}
+	return count
+}
+
+// RecordDeadNodes records dead nodes with version
+func (kvdb *KVNodeDB) RecordDeadNodes(nodes []Node, version int64) error {
+	dn := deadNodes{make(map[string]bool, len(nodes))}
+	for _, n := range nodes {
+		dn.Nodes[n.GetHash()] = true
+	}
+	d, err := dn.encode()
+	if err != nil {
+		return err
+	}
+	return kvdb.putDeadNodes(uint64(version), d)
+}
+
+func (kvdb *KVNodeDB) putDeadNodes(version uint64, data []byte) error {
+	return kvdb.db.Put(kvDeadNodesKey(version), data, kvdb.wo)
+}
+
+func (kvdb *KVNodeDB) iterateDeadNodes(ctx context.Context,
+	handler func(version uint64, data []byte) error) error {
+
+	it := kvdb.db.NewIterator(lutil.BytesPrefix([]byte{kvDeadNodesPrefix}),
+		&opt.ReadOptions{DontFillCache: true})
+	defer it.Release()
+
+	for it.Next() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err := handler(bytesToUint64(it.Key()[1:]), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// PruneBelowVersion - delete the dead nodes recorded below given version
+func (kvdb *KVNodeDB) PruneBelowVersion(ctx context.Context, version int64) error {
+	const maxPruneNodes = 1000
+
+	var (
+		ps    = GetPruneStats(ctx)
+		count int64
+
+		keys       = make([]Key, 0, maxPruneNodes)
+		deadNodesB = new(leveldb.Batch)
+	)
+
+	it := kvdb.db.NewIterator(&lutil.Range{
+		Start: kvDeadNodesKey(0),
+		Limit: kvDeadNodesKey(uint64(version)),
+	}, &opt.ReadOptions{DontFillCache: true})
+	defer it.Release()
+
+	for it.Next() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		roundNum := bytesToUint64(it.Key()[1:])
+		dn := deadNodes{}
+		if err := dn.decode(it.Value()); err != nil {
+			logging.Logger.Warn("prune state iterator - iterator decode node keys failed",
+				zap.Error(err),
+				zap.Uint64("round", roundNum))
+			continue
+		}
+		for k := range dn.Nodes {
+			kk, err := fromHex(k)
+			if err != nil {
+				logging.Logger.Warn("prune state - iterator decode key failed",
+					zap.Error(err),
+					zap.Uint64("round", roundNum))
+				continue
+			}
+			keys = append(keys, kk)
+			count++
+		}
+		deadNodesB.Delete(append([]byte(nil), it.Key()...))
+
+		if len(keys) >= maxPruneNodes {
+			if err := kvdb.MultiDeleteNode(keys); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	if err := kvdb.MultiDeleteNode(keys); err != nil {
+		return err
+	}
+	if err := kvdb.db.Write(deadNodesB, kvdb.wo); err != nil {
+		return err
+	}
+	kvdb.Flush()
+
+	if ps != nil {
+		ps.Deleted = count
+	}
+	return nil
+}
+
+/*Flush - flush the in memory writes of the db to its tables */
+func (kvdb *KVNodeDB) Flush() {
+	// opening a transaction flushes the memory db
+	tr, err := kvdb.db.OpenTransaction()
+	if err != nil {
+		logging.Logger.Error("kv node db flush failed", zap.Error(err))
+		return
+	}
+	if err := tr.Commit(); err != nil {
+		logging.Logger.Error("kv node db flush failed", zap.Error(err))
+	}
+}
+
+// Close closes the goleveldb
+func (kvdb *KVNodeDB) Close() {
+	if err := kvdb.db.Close(); err != nil {
+		logging.Logger.Error("kv node db close failed", zap.Error(err))
+	}
+}
@@ -0,0 +1,137 @@
+package util
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKVNodeDB(t *testing.T) *KVNodeDB {
+	kvdb, err := NewKVNodeDB(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(kvdb.Close)
+	return kvdb
+}
+
+func makeTestKVNodes(n int) ([]Key, []Node) {
+	keys := make([]Key, 0, n)
+	nodes := make([]Node, 0, n)
+	for i := 0; i < n; i++ {
+		node := NewLeafNode(Path("prefix"), Path(strconv.Itoa(i)), Sequence(i), &Txn{"value " + strconv.Itoa(i)})
+		keys, nodes = append(keys, node.GetHashBytes()), append(nodes, node)
+	}
+	return keys, nodes
+}
+
+func TestKVNodeDB_Nodes(t *testing.T) {
+	kvdb := newTestKVNodeDB(t)
+	keys, nodes := makeTestKVNodes(100)
+
+	require.NoError(t, kvdb.PutNode(keys[0], nodes[0]))
+	require.NoError(t, kvdb.MultiPutNode(keys[1:], nodes[1:]))
+	assert.EqualValues(t, 100, kvdb.Size(context.TODO()))
+
+	node, err := kvdb.GetNode(keys[10])
+	require.NoError(t, err)
+	assert.Equal(t, nodes[10].GetHash(), node.GetHash())
+
+	got, err := kvdb.MultiGetNode(keys[:20])
+	require.NoError(t, err)
+	assert.Len(t, got, 20)
+
+	require.NoError(t, kvdb.DeleteNode(keys[10]))
+	require.NoError(t, kvdb.MultiDeleteNode(keys[20:30]))
+	_, err = kvdb.GetNode(keys[10])
+	assert.Equal(t, ErrNodeNotFound, err)
+	assert.EqualValues(t, 89, kvdb.Size(context.TODO()))
+
+	var iterated int
+	require.NoError(t, kvdb.Iterate(context.TODO(), func(ctx context.Context, key Key, node Node) error {
+		assert.Equal(t, key, Key(node.GetHashBytes()))
+		iterated++
+		return nil
+	}))
+	assert.Equal(t, 89, iterated)
+}
+
+func TestKVNodeDB_PruneBelowVersion(t *testing.T) {
+	kvdb := newTestKVNodeDB(t)
+	keys, nodes := makeTestKVNodes(30)
+	require.NoError(t, kvdb.MultiPutNode(keys, nodes))
+
+	// nodes i*10..i*10+9 died in round i+1
+	for i := 0; i < 3; i++ {
+		require.NoError(t, kvdb.RecordDeadNodes(nodes[i*10:i*10+10], int64(i+1)))
+	}
+
+	ctx := WithPruneStats(context.TODO())
+	require.NoError(t, kvdb.PruneBelowVersion(ctx, 3))
+	assert.EqualValues(t, 20, GetPruneStats(ctx).Deleted)
+	assert.EqualValues(t, 10, kvdb.Size(context.TODO()))
+	for i, key := range keys {
+		_, err := kvdb.GetNode(key)
+		assert.Equal(t, i >= 20, err == nil, "node %d", i)
+	}
+
+	// the pruned rounds records are removed
+	require.NoError(t, kvdb.PruneBelowVersion(context.TODO(), 4))
+	assert.Zero(t, kvdb.Size(context.TODO()))
+}
+
+func TestMigrateNodeDB(t *testing.T) {
+	mndb := NewMemoryNodeDB()
+	mpt := NewMerklePatriciaTrie(mndb, Sequence(1), nil)
+	for i := 0; i < 200; i++ {
+		_, err := mpt.Insert(Path(snapshotTestKey(i)), &Txn{"value " + strconv.Itoa(i)})
+		require.NoError(t, err)
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		kvdb := newTestKVNodeDB(t)
+		count, err := MigrateNodeDB(context.TODO(), mndb, kvdb, mpt.GetRoot())
+		require.NoError(t, err)
+		assert.Equal(t, mndb.Size(context.TODO()), count)
+		assert.Equal(t, count, kvdb.Size(context.TODO()))
+
+		migrated := NewMerklePatriciaTrie(kvdb, Sequence(1), mpt.GetRoot())
+		var v Txn
+		require.NoError(t, migrated.GetNodeValue(Path(snapshotTestKey(10)), &v))
+		assert.Equal(t, "value 10", v.Data)
+	})
+
+	t.Run("dead nodes", func(t *testing.T) {
+		src := newTestKVNodeDB(t)
+		keys, nodes := makeTestKVNodes(30)
+		require.NoError(t, src.MultiPutNode(keys, nodes))
+		for i := 0; i < 3; i++ {
+			require.NoError(t, src.RecordDeadNodes(nodes[i*10:i*10+10], int64(i+1)))
+		}
+
+		kvdb := newTestKVNodeDB(t)
+		count, err := MigrateNodeDB(context.TODO(), src, kvdb, nil)
+		require.NoError(t, err)
+		assert.EqualValues(t, 30, count)
+
+		// the nodes dead in the source db are pruned in the target one
+		ctx := WithPruneStats(context.TODO())
+		require.NoError(t, kvdb.PruneBelowVersion(ctx, 3))
+		assert.EqualValues(t, 20, GetPruneStats(ctx).Deleted)
+		assert.EqualValues(t, 10, kvdb.Size(context.TODO()))
+	})
+
+	t.Run("hash mismatch", func(t *testing.T) {
+		src := NewMemoryNodeDB()
+		keys, nodes := makeTestKVNodes(2)
+		require.NoError(t, src.PutNode(keys[0], nodes[1]))
+		_, err := MigrateNodeDB(context.TODO(), src, newTestKVNodeDB(t), nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("incomplete state", func(t *testing.T) {
+		_, err := MigrateNodeDB(context.TODO(), NewMemoryNodeDB(), newTestKVNodeDB(t), mpt.GetRoot())
+		assert.Equal(t, ErrMissingNodes, err)
+	})
+}
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -58,6 +59,14 @@ type NodeDB interface {
 	PruneBelowVersion(ctx context.Context, version int64) error
 }
 
+// PersistentNodeDB - a node db persisted on disk, the rocksdb based PNodeDB
+// or the goleveldb based KVNodeDB.
+type PersistentNodeDB interface {
+	NodeDB
+	Flush()
+	Close()
+}
+
 // StrKey - data type for the key used to store the node into some storage
 // (this is needed as hashmap keys can't be []byte.
 type StrKey string
@@ -395,7 +404,7 @@ func (lndb *LevelNodeDB) SetPrev(prevDB NodeDB) {
 }
 
 func (lndb *LevelNodeDB) isCurrentPersistent() (ok bool) {
-	_, ok = lndb.current.(*PNodeDB)
+	_, ok = lndb.current.(PersistentNodeDB)
 	return
 }
 
@@ -553,8 +562,80 @@ func MergeState(ctx context.Context, fndb NodeDB, tndb NodeDB) error {
 	if err != nil {
 		return err
 	}
-	if pndb, ok := tndb.(*PNodeDB); ok {
+	if pndb, ok := tndb.(PersistentNodeDB); ok {
 		pndb.Flush()
 	}
 	return nil
 }
+
+// migrateDeadNodes - copy the dead nodes records as is, so that the nodes
+// dead in the source db are still pruned in the target one
+func migrateDeadNodes(ctx context.Context, fndb NodeDB, tndb NodeDB) error {
+	fdn, ok := fndb.(deadNodesStore)
+	if !ok {
+		return nil
+	}
+	tdn, ok := tndb.(deadNodesStore)
+	if !ok {
+		return errors.New("target node db doesn't keep the dead nodes")
+	}
+	return fdn.iterateDeadNodes(ctx, func(version uint64, data []byte) error {
+		return tdn.putDeadNodes(version, data)
+	})
+}
+
+// MigrateNodeDB - copy all the nodes of a node db into another one, checking
+// every node matches its key, and the dead nodes records not pruned yet. When
+// the root is given, the state it roots must be complete in the target db.
+// It returns the number of nodes copied.
+func MigrateNodeDB(ctx context.Context, fndb NodeDB, tndb NodeDB, root Key) (int64, error) {
+	var (
+		keys  = make([]Key, 0, BatchSize)
+		nodes = make([]Node, 0, BatchSize)
+		count int64
+	)
+	flush := func() error {
+		if err := tndb.MultiPutNode(keys, nodes); err != nil {
+			return err
+		}
+		count += int64(len(keys))
+		keys, nodes = keys[:0], nodes[:0]
+		return nil
+	}
+
+	err := fndb.Iterate(ctx, func(ctx context.Context, key Key, node Node) error {
+		if !bytes.Equal(node.GetHashBytes(), key) {
+			return fmt.Errorf("node hash mismatch: key %s, hash %s", ToHex(key), node.GetHash())
+		}
+		keys, nodes = append(keys, key), append(nodes, node)
+		if len(keys) < BatchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		return count, err
+	}
+	if err := migrateDeadNodes(ctx, fndb, tndb); err != nil {
+		return count, err
+	}
+	if pndb, ok := tndb.(PersistentNodeDB); ok {
+		pndb.Flush()
+	}
+
+	if len(root) == 0 {
+		return count, nil
+	}
+	mpt := NewMerklePatriciaTrie(tndb, Sequence(0), root)
+	missing, err := mpt.HasMissingNodes(ctx)
+	if err != nil {
+		return count, err
+	}
+	if missing {
+		return count, ErrMissingNodes
+	}
+	return count, nil
+}
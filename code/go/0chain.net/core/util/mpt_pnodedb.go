@@ -1,9 +1,11 @@
+//go:build !norocksdb
+// +build !norocksdb
+
 package util
 
 import (
 	"bytes"
 	"context"
-	"encoding/binary"
 	"sync"
 	"time"
 
@@ -142,7 +144,25 @@ func (pndb *PNodeDB) saveDeadNodes(dn *deadNodes, version int64) error {
 		return err
 	}
 
-	return pndb.db.PutCF(pndb.wo, pndb.deadNodesCFH, uint64ToBytes(uint64(version)), d)
+	return pndb.putDeadNodes(uint64(version), d)
+}
+
+func (pndb *PNodeDB) putDeadNodes(version uint64, data []byte) error {
+	return pndb.db.PutCF(pndb.wo, pndb.deadNodesCFH, uint64ToBytes(version), data)
+}
+
+func (pndb *PNodeDB) iterateDeadNodes(ctx context.Context,
+	handler func(version uint64, data []byte) error) error {
+
+	var err error
+	pndb.iteratorDeadNodes(ctx, func(key, value []byte) bool {
+		err = handler(bytesToUint64(key), value)
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
 }
 
 // RecordDeadNodes records dead nodes with version
@@ -249,16 +269,6 @@ func (pndb *PNodeDB) PruneBelowVersion(ctx context.Context, version int64) error
 	}
 }
 
-func uint64ToBytes(r uint64) []byte {
-	b := make([]byte, 8)
-	binary.BigEndian.PutUint64(b, r)
-	return b
-}
-
-func bytesToUint64(data []byte) uint64 {
-	return binary.BigEndian.Uint64(data)
-}
-
 /*MultiDeleteNode - implement interface */
 func (pndb *PNodeDB) multiDeleteDeadNodes(rounds []uint64) error {
 	wb := gorocksdb.NewWriteBatch()
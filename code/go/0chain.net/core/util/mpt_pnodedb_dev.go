@@ -1,5 +1,5 @@
-//go:build dev
-// +build dev
+//go:build dev && !norocksdb
+// +build dev,!norocksdb
 
 package util
 
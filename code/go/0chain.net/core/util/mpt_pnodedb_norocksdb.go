@@ -0,0 +1,23 @@
+//go:build norocksdb
+// +build norocksdb
+
+package util
+
+import (
+	"errors"
+)
+
+// ErrPNodeDBNotSupported - the node is built without the rocksdb based
+// PNodeDB, the KVNodeDB is the only persistent node db available
+var ErrPNodeDBNotSupported = errors.New("rocksdb state db is not supported by the norocksdb build")
+
+/*PNodeDB - a node db that is persisted, not available in the norocksdb build */
+type PNodeDB struct {
+	PersistentNodeDB
+}
+
+// NewPNodeDB - the rocksdb based PNodeDB is not available in the norocksdb
+// build
+func NewPNodeDB(stateDir, logDir string) (*PNodeDB, error) {
+	return nil, ErrPNodeDBNotSupported
+}
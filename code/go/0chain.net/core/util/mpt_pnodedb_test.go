@@ -1,3 +1,6 @@
+//go:build !norocksdb
+// +build !norocksdb
+
 package util
 
 import (
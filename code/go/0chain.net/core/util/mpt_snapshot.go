@@ -0,0 +1,367 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/sha3"
+
+	"0chain.net/core/logging"
+)
+
+// SnapshotVersion - current version of the state snapshot format.
+const SnapshotVersion = 1
+
+// SnapshotManifestFile - name of the snapshot manifest file, it's written
+// last, so a snapshot directory without the manifest is incomplete.
+const SnapshotManifestFile = "manifest.json"
+
+// DefaultSnapshotChunkSize - default size of uncompressed nodes of a chunk.
+const DefaultSnapshotChunkSize = 64 << 20
+
+// snapshot errors
+var (
+	// ErrSnapshotCorrupted - snapshot content doesn't match the manifest.
+	ErrSnapshotCorrupted = errors.New("state snapshot corrupted")
+	// ErrSnapshotVersion - snapshot is written by newer format version.
+	ErrSnapshotVersion = errors.New("unsupported state snapshot version")
+)
+
+// SnapshotChunk - a compressed file of the MPT nodes of a snapshot.
+type SnapshotChunk struct {
+	Name  string `json:"name"`
+	Nodes int64  `json:"nodes"`
+	Size  int64  `json:"size"`
+	Hash  string `json:"hash"` // SHA3-256 of the compressed file
+}
+
+// SnapshotManifest - describes a state snapshot.
+type SnapshotManifest struct {
+	Version   int             `json:"version"`
+	ChainID   string          `json:"chain_id"`
+	Round     int64           `json:"round"`
+	BlockHash string          `json:"block_hash"`
+	Root      string          `json:"root"`
+	Nodes     int64           `json:"nodes"`
+	Chunks    []SnapshotChunk `json:"chunks"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// GetRoot - returns the snapshot MPT root key.
+func (sm *SnapshotManifest) GetRoot() (Key, error) {
+	return hex.DecodeString(sm.Root)
+}
+
+// ReadSnapshotManifest - read the manifest of the snapshot in given directory.
+func ReadSnapshotManifest(dir string) (*SnapshotManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, SnapshotManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var sm SnapshotManifest
+	if err := json.Unmarshal(data, &sm); err != nil {
+		return nil, fmt.Errorf("%w: manifest: %v", ErrSnapshotCorrupted, err)
+	}
+	if sm.Version > SnapshotVersion {
+		return nil, fmt.Errorf("%w: %d", ErrSnapshotVersion, sm.Version)
+	}
+	return &sm, nil
+}
+
+type snapshotChunkWriter struct {
+	dir   string
+	file  *os.File
+	sum   hash.Hash
+	cw    *countingWriter
+	zw    *zlib.Writer
+	chunk SnapshotChunk
+	raw   int64
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+func (scw *snapshotChunkWriter) open(idx int) (err error) {
+	name := fmt.Sprintf("chunk-%06d.zlib", idx)
+	if scw.file, err = os.Create(filepath.Join(scw.dir, name)); err != nil {
+		return err
+	}
+	scw.sum = sha3.New256()
+	scw.cw = &countingWriter{w: io.MultiWriter(scw.file, scw.sum)}
+	scw.zw = zlib.NewWriter(scw.cw)
+	scw.chunk = SnapshotChunk{Name: name}
+	scw.raw = 0
+	return nil
+}
+
+func (scw *snapshotChunkWriter) write(key Key, node Node) error {
+	data := node.Encode()
+	if len(key) > 0xff {
+		return fmt.Errorf("snapshot: key is too long: %d", len(key))
+	}
+	var head [5]byte
+	head[0] = byte(len(key))
+	binary.LittleEndian.PutUint32(head[1:], uint32(len(data)))
+	for _, p := range [][]byte{head[:1], key, head[1:], data} {
+		if _, err := scw.zw.Write(p); err != nil {
+			return err
+		}
+	}
+	scw.chunk.Nodes++
+	scw.raw += int64(len(key) + len(data) + len(head))
+	return nil
+}
+
+func (scw *snapshotChunkWriter) close() (SnapshotChunk, error) {
+	if err := scw.zw.Close(); err != nil {
+		scw.file.Close()
+		return SnapshotChunk{}, err
+	}
+	if err := scw.file.Close(); err != nil {
+		return SnapshotChunk{}, err
+	}
+	scw.chunk.Size = scw.cw.n
+	scw.chunk.Hash = hex.EncodeToString(scw.sum.Sum(nil))
+	scw.file = nil
+	return scw.chunk, nil
+}
+
+// ExportSnapshot - write all the nodes reachable from the MPT root into
+// compressed chunks of the directory followed by the manifest. The round,
+// block hash and chain id of the manifest are provided by the caller.
+func ExportSnapshot(ctx context.Context, mpt MerklePatriciaTrieI, dir string,
+	chunkSize int64, sm *SnapshotManifest) error {
+
+	root := mpt.GetRoot()
+	if len(root) == 0 {
+		return errors.New("snapshot: empty state")
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultSnapshotChunkSize
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	// never leave a manifest of another snapshot beside the new chunks
+	if err := os.Remove(filepath.Join(dir, SnapshotManifestFile)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	sm.Version = SnapshotVersion
+	sm.Root = ToHex(root)
+	sm.Nodes = 0
+	sm.Chunks = nil
+
+	scw := &snapshotChunkWriter{dir: dir}
+	if err := scw.open(0); err != nil {
+		return err
+	}
+	defer func() {
+		if scw.file != nil {
+			scw.file.Close()
+		}
+	}()
+
+	handler := func(ctx context.Context, path Path, key Key, node Node) error {
+		if node == nil {
+			return fmt.Errorf("%w: %s", ErrMissingNodes, ToHex(key))
+		}
+		if err := scw.write(key, node); err != nil {
+			return err
+		}
+		sm.Nodes++
+		if scw.raw < chunkSize {
+			return nil
+		}
+		chunk, err := scw.close()
+		if err != nil {
+			return err
+		}
+		sm.Chunks = append(sm.Chunks, chunk)
+		return scw.open(len(sm.Chunks))
+	}
+	err := mpt.Iterate(ctx, handler, NodeTypeLeafNode|NodeTypeFullNode|NodeTypeExtensionNode)
+	if err != nil {
+		return err
+	}
+	chunk, err := scw.close()
+	if err != nil {
+		return err
+	}
+	if chunk.Nodes > 0 {
+		sm.Chunks = append(sm.Chunks, chunk)
+	} else if err := os.Remove(filepath.Join(dir, chunk.Name)); err != nil {
+		return err
+	}
+
+	sm.CreatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(sm, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(dir, SnapshotManifestFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, SnapshotManifestFile))
+}
+
+func verifySnapshotChunk(dir string, chunk *SnapshotChunk) error {
+	f, err := os.Open(filepath.Join(dir, chunk.Name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	sum := sha3.New256()
+	n, err := io.Copy(sum, f)
+	if err != nil {
+		return err
+	}
+	if n != chunk.Size || hex.EncodeToString(sum.Sum(nil)) != chunk.Hash {
+		return fmt.Errorf("%w: chunk %s checksum mismatch", ErrSnapshotCorrupted, chunk.Name)
+	}
+	return nil
+}
+
+func importSnapshotChunk(ctx context.Context, dir string, chunk *SnapshotChunk, ndb NodeDB) error {
+	f, err := os.Open(filepath.Join(dir, chunk.Name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	zr, err := zlib.NewReader(bufio.NewReader(f))
+	if err != nil {
+		return fmt.Errorf("%w: chunk %s: %v", ErrSnapshotCorrupted, chunk.Name, err)
+	}
+	defer zr.Close()
+	r := bufio.NewReader(zr)
+
+	var (
+		keys  = make([]Key, 0, BatchSize)
+		nodes = make([]Node, 0, BatchSize)
+		count int64
+	)
+	flush := func() error {
+		if len(keys) == 0 {
+			return nil
+		}
+		if err := ndb.MultiPutNode(keys, nodes); err != nil {
+			return err
+		}
+		keys, nodes = keys[:0], nodes[:0]
+		return nil
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		kl, err := r.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: chunk %s: %v", ErrSnapshotCorrupted, chunk.Name, err)
+		}
+		key := make(Key, kl)
+		var nl [4]byte
+		if _, err := io.ReadFull(r, key); err != nil {
+			return fmt.Errorf("%w: chunk %s: %v", ErrSnapshotCorrupted, chunk.Name, err)
+		}
+		if _, err := io.ReadFull(r, nl[:]); err != nil {
+			return fmt.Errorf("%w: chunk %s: %v", ErrSnapshotCorrupted, chunk.Name, err)
+		}
+		data := make([]byte, binary.LittleEndian.Uint32(nl[:]))
+		if _, err := io.ReadFull(r, data); err != nil {
+			return fmt.Errorf("%w: chunk %s: %v", ErrSnapshotCorrupted, chunk.Name, err)
+		}
+		node, err := CreateNode(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("%w: chunk %s: %v", ErrSnapshotCorrupted, chunk.Name, err)
+		}
+		if !bytes.Equal(node.GetHashBytes(), key) {
+			return fmt.Errorf("%w: chunk %s: node hash mismatch %s", ErrSnapshotCorrupted,
+				chunk.Name, ToHex(key))
+		}
+		keys = append(keys, key)
+		nodes = append(nodes, node)
+		count++
+		if len(keys) == BatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if count != chunk.Nodes {
+		return fmt.Errorf("%w: chunk %s: %d nodes, expected %d", ErrSnapshotCorrupted,
+			chunk.Name, count, chunk.Nodes)
+	}
+	return flush()
+}
+
+// ImportSnapshot - verify the snapshot in given directory and put its nodes
+// into the node db. The checksums of all the chunks are verified before any
+// node is written, the hash of every node is verified against its key and
+// the state is checked to be complete after the import.
+func ImportSnapshot(ctx context.Context, dir string, ndb NodeDB) (*SnapshotManifest, error) {
+	sm, err := ReadSnapshotManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	root, err := sm.GetRoot()
+	if err != nil || len(root) == 0 {
+		return nil, fmt.Errorf("%w: invalid root %q", ErrSnapshotCorrupted, sm.Root)
+	}
+
+	var nodes int64
+	for i := range sm.Chunks {
+		if err := verifySnapshotChunk(dir, &sm.Chunks[i]); err != nil {
+			return nil, err
+		}
+		nodes += sm.Chunks[i].Nodes
+	}
+	if nodes != sm.Nodes {
+		return nil, fmt.Errorf("%w: %d nodes in chunks, expected %d", ErrSnapshotCorrupted, nodes, sm.Nodes)
+	}
+
+	for i := range sm.Chunks {
+		if err := importSnapshotChunk(ctx, dir, &sm.Chunks[i], ndb); err != nil {
+			return nil, err
+		}
+		logging.Logger.Debug("import snapshot - chunk imported",
+			zap.String("chunk", sm.Chunks[i].Name),
+			zap.Int64("nodes", sm.Chunks[i].Nodes))
+	}
+
+	mpt := NewMerklePatriciaTrie(ndb, Sequence(sm.Round), root)
+	missing, err := mpt.HasMissingNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if missing {
+		return nil, fmt.Errorf("%w: imported state has missing nodes", ErrSnapshotCorrupted)
+	}
+	return sm, nil
+}
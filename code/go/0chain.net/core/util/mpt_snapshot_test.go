@@ -0,0 +1,113 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestSnapshotMPT(t *testing.T, n int) MerklePatriciaTrieI {
+	mpt := NewMerklePatriciaTrie(NewMemoryNodeDB(), Sequence(1), nil)
+	for i := 0; i < n; i++ {
+		_, err := mpt.Insert(Path(snapshotTestKey(i)), &Txn{"value " + strconv.Itoa(i)})
+		require.NoError(t, err)
+	}
+	return mpt
+}
+
+func snapshotTestKey(i int) string {
+	return ToHex([]byte("key " + strconv.Itoa(i)))
+}
+
+func exportTestSnapshot(t *testing.T, mpt MerklePatriciaTrieI, chunkSize int64) (string, *SnapshotManifest) {
+	dir, err := os.MkdirTemp("", "state_snapshot")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	sm := &SnapshotManifest{ChainID: "chain", Round: 10, BlockHash: "block"}
+	require.NoError(t, ExportSnapshot(context.TODO(), mpt, dir, chunkSize, sm))
+	return dir, sm
+}
+
+func TestSnapshot_ExportImport(t *testing.T) {
+	mpt := makeTestSnapshotMPT(t, 200)
+	dir, sm := exportTestSnapshot(t, mpt, 1024)
+
+	assert.Equal(t, ToHex(mpt.GetRoot()), sm.Root)
+	assert.True(t, len(sm.Chunks) > 1)
+	assert.True(t, sm.Nodes > 200)
+
+	ndb := NewMemoryNodeDB()
+	got, err := ImportSnapshot(context.TODO(), dir, ndb)
+	require.NoError(t, err)
+	assert.Equal(t, sm.Root, got.Root)
+	assert.EqualValues(t, 10, got.Round)
+	assert.Equal(t, "block", got.BlockHash)
+
+	imported := NewMerklePatriciaTrie(ndb, Sequence(10), mpt.GetRoot())
+	for i := 0; i < 200; i++ {
+		var v Txn
+		require.NoError(t, imported.GetNodeValue(Path(snapshotTestKey(i)), &v))
+		assert.Equal(t, "value "+strconv.Itoa(i), v.Data)
+	}
+}
+
+func TestSnapshot_Errors(t *testing.T) {
+	mpt := makeTestSnapshotMPT(t, 50)
+
+	t.Run("empty state", func(t *testing.T) {
+		empty := NewMerklePatriciaTrie(NewMemoryNodeDB(), Sequence(1), nil)
+		assert.Error(t, ExportSnapshot(context.TODO(), empty, t.TempDir(), 0, &SnapshotManifest{}))
+	})
+
+	t.Run("no manifest", func(t *testing.T) {
+		_, err := ImportSnapshot(context.TODO(), t.TempDir(), NewMemoryNodeDB())
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("corrupted chunk", func(t *testing.T) {
+		dir, sm := exportTestSnapshot(t, mpt, 0)
+		name := filepath.Join(dir, sm.Chunks[0].Name)
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		data[len(data)/2] ^= 0xff
+		require.NoError(t, os.WriteFile(name, data, 0644))
+
+		ndb := NewMemoryNodeDB()
+		_, err = ImportSnapshot(context.TODO(), dir, ndb)
+		assert.True(t, errors.Is(err, ErrSnapshotCorrupted))
+		assert.Zero(t, ndb.Size(context.TODO()), "nothing is imported")
+	})
+
+	t.Run("missing chunk", func(t *testing.T) {
+		dir, sm := exportTestSnapshot(t, mpt, 256)
+		require.NoError(t, os.Remove(filepath.Join(dir, sm.Chunks[len(sm.Chunks)-1].Name)))
+		_, err := ImportSnapshot(context.TODO(), dir, NewMemoryNodeDB())
+		assert.Error(t, err)
+	})
+
+	t.Run("missing nodes", func(t *testing.T) {
+		ndb := NewMemoryNodeDB()
+		broken := NewMerklePatriciaTrie(ndb, Sequence(1), nil)
+		for i := 0; i < 50; i++ {
+			_, err := broken.Insert(Path(snapshotTestKey(i)), &Txn{"value"})
+			require.NoError(t, err)
+		}
+		var leaf Key
+		require.NoError(t, ndb.Iterate(context.TODO(), func(ctx context.Context, key Key, node Node) error {
+			if _, ok := node.(*LeafNode); ok && leaf == nil {
+				leaf = key
+			}
+			return nil
+		}))
+		require.NoError(t, ndb.DeleteNode(leaf))
+		err := ExportSnapshot(context.TODO(), broken, t.TempDir(), 0, &SnapshotManifest{})
+		assert.True(t, errors.Is(err, ErrMissingNodes))
+	})
+}
@@ -87,6 +87,7 @@ func SetupMinerChain(c *chain.Chain) {
 	minerChain.mergeBlockVRFSharesWorker = common.NewWithContextFunc(1)
 	minerChain.verifyCachedVRFSharesWorker = common.NewWithContextFunc(1)
 	minerChain.generateBlockWorker = common.NewWithContextFunc(1)
+	minerChain.txnPool = newTxnPool(getTxnPoolConfig())
 }
 
 /*GetMinerChain - get the miner's chain */
@@ -159,6 +160,7 @@ type Chain struct {
 	mergeBlockVRFSharesWorker            *common.WithContextFunc
 	verifyCachedVRFSharesWorker          *common.WithContextFunc
 	generateBlockWorker                  *common.WithContextFunc
+	txnPool                              *txnPool
 }
 
 func (mc *Chain) sendRestartRoundEvent(ctx context.Context) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"0chain.net/chaincore/block"
@@ -23,6 +24,7 @@ func SetupHandlers() {
 	http.HandleFunc("/_chain_stats", common.UserRateLimit(ChainStatsWriter))
 	http.HandleFunc("/_diagnostics/wallet_stats", common.UserRateLimit(GetWalletStats))
 	http.HandleFunc("/v1/miner/get/stats", common.UserRateLimit(common.ToJSONResponse(MinerStatsHandler)))
+	http.HandleFunc("/v1/miner/get/txn_pool", common.UserRateLimit(common.ToJSONResponse(TxnPoolHandler)))
 }
 
 /*ChainStatsHandler - a handler to provide block statistics */
@@ -200,3 +202,16 @@ func MinerStatsHandler(ctx context.Context, r *http.Request) (interface{}, error
 		NetworkTime:        networkTimes,
 	}, nil
 }
+
+// TxnPoolHandler - a handler to provide the stats and the pending transactions
+// of the transaction pool in the order they are included in the blocks
+func TxnPoolHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	limit := 100
+	if l := r.FormValue("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil || limit < 0 {
+			return nil, common.NewErrBadRequest("invalid limit")
+		}
+	}
+	return GetMinerChain().txnPool.snapshot(r.FormValue("client_id"), limit), nil
+}
@@ -37,6 +37,11 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "state" {
+		chain.StateMain("miner", os.Args[2:])
+		return
+	}
+
 	var (
 		workdir       string
 		redisHost     string
@@ -51,6 +56,7 @@ func main() {
 	delayFile := flag.String("delay_file", "", "delay_file")
 	magicBlockFile := flag.String("magic_block_file", "", "magic_block_file")
 	initialStatesFile := flag.String("initial_states", "", "initial_states")
+	stateSnapshot := flag.String("state_snapshot", "", "state snapshot directory to bootstrap the state from")
 
 	flag.StringVar(&workdir, "work_dir", "", "work_dir")
 	flag.StringVar(&redisHost, "redis_host", "", "default redis pool host")
@@ -232,6 +238,16 @@ func main() {
 		logging.Logger.Error("failed to wait sharders", zap.Error(err))
 	}
 
+	if *stateSnapshot != "" {
+		// the snapshot block becomes the LFB the miner syncs from
+		lfb, err := mc.BootstrapStateFromSnapshot(ctx, *stateSnapshot)
+		if err != nil {
+			logging.Logger.Panic("bootstrap state from snapshot", zap.Error(err))
+		}
+		logging.Logger.Info("starting from the state snapshot", zap.Int64("round", lfb.Round),
+			zap.String("block", lfb.Hash))
+	}
+
 	if err = mc.UpdateLatestMagicBlockFromSharders(ctx); err != nil {
 		logging.Logger.Panic(fmt.Sprintf("can't update LFMB from sharders, err: %v", err))
 	}
@@ -462,5 +478,5 @@ func initWorkers(ctx context.Context) {
 	serverChain := chain.GetServerChain()
 	serverChain.SetupWorkers(ctx)
 	//miner.SetupWorkers(ctx)
-	transaction.SetupWorkers(ctx)
+	transaction.SetupWorkers(ctx, serverChain.GetCurrentRound)
 }
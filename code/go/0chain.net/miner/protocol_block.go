@@ -18,12 +18,14 @@ import (
 	"0chain.net/chaincore/client"
 	"0chain.net/chaincore/config"
 	"0chain.net/chaincore/node"
+	"0chain.net/chaincore/smartcontract"
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/core/encryption"
 	"0chain.net/core/logging"
 	"0chain.net/core/util"
+	"0chain.net/smartcontract/governancesc"
 	"0chain.net/smartcontract/minersc"
 	"0chain.net/smartcontract/storagesc"
 )
@@ -108,6 +110,21 @@ func (mc *Chain) storageScCommitSettingChangesTx(b *block.Block, bState util.Mer
 	return scTxn
 }
 
+func (mc *Chain) governanceScFinalizeProposalsTx(b *block.Block, bState util.MerklePatriciaTrieI) *transaction.Transaction {
+	scTxn := transaction.Provider().(*transaction.Transaction)
+	scTxn.ClientID = b.MinerID
+	scTxn.Nonce = mc.getCurrentSelfNonce(b.MinerID, bState)
+	scTxn.ToClientID = governancesc.ADDRESS
+	scTxn.CreationDate = b.CreationDate
+	scTxn.TransactionType = transaction.TxnTypeSmartContract
+	scTxn.TransactionData = fmt.Sprintf(`{"name":"finalize_proposals","input":{"round":%v}}`, b.Round)
+	scTxn.Fee = 0
+	if _, err := scTxn.Sign(node.Self.GetSignatureScheme()); err != nil {
+		panic(err)
+	}
+	return scTxn
+}
+
 func (mc *Chain) createBlockRewardTxn(b *block.Block, bState util.MerklePatriciaTrieI) *transaction.Transaction {
 	brTxn := transaction.Provider().(*transaction.Transaction)
 	brTxn.ClientID = b.MinerID
@@ -142,6 +159,9 @@ func (mc *Chain) validateTransaction(b *block.Block, bState util.MerklePatriciaT
 	if !common.WithinTime(int64(b.CreationDate), int64(txn.CreationDate), transaction.TXN_TIME_TOLERANCE) {
 		return ErrNotTimeTolerant
 	}
+	if err := txn.ValidateWindow(b.Round, b.CreationDate); err != nil {
+		return err
+	}
 	state, err := mc.GetStateById(bState, txn.ClientID)
 
 	if err != nil {
@@ -461,6 +481,9 @@ func (mc *Chain) ValidateTransactions(ctx context.Context, b *block.Block) error
 					return
 				}
 				err := txn.ValidateWrtTimeForBlock(ctx, b.CreationDate, !aggregate)
+				if err == nil {
+					err = txn.ValidateWindow(b.Round, b.CreationDate)
+				}
 				if err != nil {
 					cancel = true
 					logging.Logger.Error("validate transactions", zap.Any("round", b.Round), zap.Any("block", b.Hash), zap.String("txn", datastore.ToJSON(txn).String()), zap.Error(err))
@@ -596,9 +619,11 @@ func (mc *Chain) updateFinalizedBlock(ctx context.Context, b *block.Block) {
 	invalidTxns := tii.checkForInvalidTxns(b.Txns)
 
 	transaction.RemoveFromPool(ctx, txns)
+	mc.txnPool.remove(txns)
 
 	if len(invalidTxns) > 0 {
 		transaction.RemoveFromPool(ctx, invalidTxns)
+		mc.txnPool.remove(invalidTxns)
 	}
 }
 
@@ -677,15 +702,18 @@ func txnProcessorHandlerFunc(mc *Chain, b *block.Block) txnProcessorHandler {
 			})
 			tii.futureTxns[txn.ClientID] = list
 			return false
-		case ErrNotTimeTolerant:
+		case ErrNotTimeTolerant, transaction.ErrTxnExpired:
 			tii.invalidTxns = append(tii.invalidTxns, txn)
 			if debugTxn {
 				logging.Logger.Info("generate block (debug transaction) error - "+
-					"txn creation not within tolerance",
+					"txn creation not within tolerance or validity window passed",
 					zap.String("txn", txn.Hash), zap.Int32("idx", tii.idx),
-					zap.Any("now", common.Now()))
+					zap.Any("now", common.Now()), zap.Error(err))
 			}
 			return false
+		case transaction.ErrTxnNotYetValid:
+			// stays in the pool for a later block
+			return false
 		}
 
 		if debugTxn {
@@ -731,6 +759,8 @@ type TxnIterInfo struct {
 	eTxns       []datastore.Entity
 	invalidTxns []datastore.Entity
 	pastTxns    []datastore.Entity
+	// droppedTxns are the transactions replaced or evicted by the pool
+	droppedTxns []datastore.Entity
 	futureTxns  map[datastore.Key][]*transaction.Transaction
 	currentTxns []*transaction.Transaction
 
@@ -795,11 +825,11 @@ func newTxnIterInfo(blockSize int32) *TxnIterInfo {
 	}
 }
 
+// txnIterHandlerFunc collects the transactions of the collection into the
+// transaction pool
 func txnIterHandlerFunc(mc *Chain,
 	b *block.Block,
 	lfb *block.Block,
-	bState util.MerklePatriciaTrieI,
-	txnProcessor txnProcessorHandler,
 	tii *TxnIterInfo) func(context.Context, datastore.CollectionEntity) bool {
 	return func(ctx context.Context, qe datastore.CollectionEntity) bool {
 		tii.count++
@@ -833,28 +863,70 @@ func txnIterHandlerFunc(mc *Chain,
 			logging.Logger.Debug("Bad transaction cost", zap.Error(err))
 			return true
 		}
-		if tii.cost+cost >= mc.ChainConfig.MaxBlockCost() {
+		if cost >= mc.ChainConfig.MaxBlockCost() {
 			logging.Logger.Debug("generate block (too big cost, skipping)")
 			return true
 		}
 
-		if txnProcessor(ctx, bState, txn, tii) {
-			tii.cost += cost
-			if tii.idx >= mc.ChainConfig.BlockSize() || tii.byteSize >= mc.MaxByteSize() {
-				logging.Logger.Debug("generate block (too big block size)",
-					zap.Bool("idx >= block size", tii.idx >= mc.ChainConfig.BlockSize()),
-					zap.Bool("byteSize >= mc.NMaxByteSize", tii.byteSize >= mc.ChainConfig.MaxByteSize()),
-					zap.Int32("idx", tii.idx),
-					zap.Int32("block size", mc.ChainConfig.BlockSize()),
-					zap.Int64("byte size", tii.byteSize),
-					zap.Int64("max byte size", mc.ChainConfig.MaxByteSize()),
-					zap.Int32("count", tii.count),
-					zap.Int("txns", len(b.Txns)))
-				return false
+		dropped, err := mc.txnPool.add(txn, cost)
+		for _, dtxn := range dropped {
+			tii.droppedTxns = append(tii.droppedTxns, dtxn)
+		}
+		if err != nil {
+			tii.droppedTxns = append(tii.droppedTxns, txn)
+			if txn.DebugTxn() {
+				logging.Logger.Info("generate block (debug transaction) not added to the pool",
+					zap.String("txn", txn.Hash), zap.Error(err))
 			}
 		}
-		return true
+
+		maxSize := mc.txnPool.conf.MaxSize
+		return maxSize == 0 || tii.count < int32(maxSize)
+	}
+}
+
+// processPoolTxns processes the transactions of the pool in the order of
+// their priority until the block is full
+func (mc *Chain) processPoolTxns(ctx context.Context, b *block.Block,
+	bState util.MerklePatriciaTrieI, txnProcessor txnProcessorHandler,
+	tii *TxnIterInfo) error {
+
+	for _, pt := range mc.txnPool.candidates() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if mc.GetCurrentRound() > b.Round {
+			tii.roundMismatch = true
+			return nil
+		}
+		if tii.roundTimeoutCount != mc.GetRoundTimeoutCount() {
+			tii.roundTimeout = true
+			return nil
+		}
+		if tii.cost+pt.cost >= mc.ChainConfig.MaxBlockCost() {
+			// a cheaper transaction can still fit
+			continue
+		}
+
+		// the pool keeps its copy, the generated block modifies its own
+		if !txnProcessor(ctx, bState, pt.txn.Clone(), tii) {
+			continue
+		}
+		tii.cost += pt.cost
+		if tii.idx >= mc.ChainConfig.BlockSize() || tii.byteSize >= mc.MaxByteSize() {
+			logging.Logger.Debug("generate block (too big block size)",
+				zap.Bool("idx >= block size", tii.idx >= mc.ChainConfig.BlockSize()),
+				zap.Bool("byteSize >= mc.NMaxByteSize", tii.byteSize >= mc.ChainConfig.MaxByteSize()),
+				zap.Int32("idx", tii.idx),
+				zap.Int32("block size", mc.ChainConfig.BlockSize()),
+				zap.Int64("byte size", tii.byteSize),
+				zap.Int64("max byte size", mc.ChainConfig.MaxByteSize()),
+				zap.Int32("count", tii.count),
+				zap.Int("txns", len(b.Txns)))
+			return nil
+		}
 	}
+	return nil
 }
 
 /*GenerateBlock - This works on generating a block
@@ -879,7 +951,7 @@ func (mc *Chain) generateBlock(ctx context.Context, b *block.Block,
 		txnProcessor   = txnProcessorHandlerFunc(mc, b)
 		blockState     = block.CreateStateWithPreviousBlock(b.PrevBlock, mc.GetStateDB(), b.Round)
 		beginState     = blockState.GetRoot()
-		txnIterHandler = txnIterHandlerFunc(mc, b, lfb, blockState, txnProcessor, iterInfo)
+		txnIterHandler = txnIterHandlerFunc(mc, b, lfb, iterInfo)
 	)
 
 	iterInfo.roundTimeoutCount = mc.GetRoundTimeoutCount()
@@ -899,6 +971,23 @@ func (mc *Chain) generateBlock(ctx context.Context, b *block.Block,
 	collectionName := txn.GetCollectionName()
 	logging.Logger.Info("generate block starting iteration", zap.Int64("round", b.Round), zap.String("prev_block", b.PrevHash), zap.String("prev_state_hash", util.ToHex(b.PrevBlock.ClientStateHash)))
 	err := transactionEntityMetadata.GetStore().IterateCollection(cctx, transactionEntityMetadata, collectionName, txnIterHandler)
+	if !iterInfo.roundMismatch && !iterInfo.roundTimeout && err != context.Canceled {
+		mc.txnPool.prune(b.Round, b.CreationDate)
+		if perr := mc.processPoolTxns(ctx, b, blockState, txnProcessor, iterInfo); perr != nil {
+			err = perr
+		}
+	}
+	if len(iterInfo.droppedTxns) > 0 {
+		logging.Logger.Info("generate block (txns dropped by the pool)", zap.Any("round", b.Round),
+			zap.Int("num_dropped_txns", len(iterInfo.droppedTxns)))
+		go func() {
+			if err := mc.deleteTxns(iterInfo.droppedTxns); err != nil {
+				logging.Logger.Warn("generate block - delete dropped txns failed", zap.Error(err))
+			}
+		}()
+	}
+	mc.txnPool.remove(iterInfo.invalidTxns)
+	mc.txnPool.remove(iterInfo.pastTxns)
 	if len(iterInfo.invalidTxns) > 0 {
 		var keys []string
 		for _, txn := range iterInfo.pastTxns {
@@ -1013,6 +1102,16 @@ func (mc *Chain) generateBlock(ctx context.Context, b *block.Block,
 		}
 	}
 
+	// passed governance proposals are applied before the settings changes
+	// are committed
+	if period := config.SmartContractConfig.GetInt64("smart_contracts.governancesc.finalize_period"); period > 0 &&
+		b.Round%period == 0 && smartcontract.GetSmartContract(governancesc.ADDRESS) != nil {
+		err = mc.processTxn(ctx, mc.governanceScFinalizeProposalsTx(b, blockState), b, blockState, iterInfo.clients)
+		if err != nil {
+			logging.Logger.Error("generate block (finalize proposals)", zap.Int64("round", b.Round), zap.Error(err))
+		}
+	}
+
 	if mc.SmartContractSettingUpdatePeriod() != 0 &&
 		b.Round%mc.SmartContractSettingUpdatePeriod() == 0 {
 		err = mc.processTxn(ctx, mc.storageScCommitSettingChangesTx(b, blockState), b, blockState, iterInfo.clients)
@@ -819,6 +819,13 @@ func (mc *Chain) computeBlockProposalDynamicWaitTime(r round.RoundI) time.Durati
 func (mc *Chain) CollectBlocksForVerification(ctx context.Context, r *Round) {
 	verifyAndSend := func(ctx context.Context, r *Round, b *block.Block) bool {
 		logging.Logger.Debug("verifyAndSend - started", zap.Any("block", b.Hash))
+		if !r.CanTicket(b) {
+			logging.Logger.Error("verifyAndSend - another block of the generator is ticketed",
+				zap.Int64("round", r.Number), zap.String("block", b.Hash),
+				zap.String("miner", b.MinerID), zap.Int64("RRS", b.GetRoundRandomSeed()))
+			b.SetBlockState(block.StateVerificationRejected)
+			return false
+		}
 		b.SetBlockState(block.StateVerificationAccepted)
 		miner := mc.GetMiners(r.GetRoundNumber()).GetNode(b.MinerID)
 		if miner == nil || miner.ProtocolStats == nil {
@@ -868,6 +875,7 @@ func (mc *Chain) CollectBlocksForVerification(ctx context.Context, r *Round) {
 		if bnb == nil || bnb.Hash == b.Hash {
 			logging.Logger.Info("verifyAndSend - sending verification ticket", zap.Int64("round", r.Number), zap.String("block", b.Hash),
 				zap.Int("block_rank", b.RoundRank), zap.Int64("RRS", b.RoundRandomSeed))
+			r.SetTicketed(b)
 			go mc.SendVerificationTicket(ctx, b, bvt)
 			r.SetOwnVerificationTicket(bvt)
 		}
@@ -2,6 +2,7 @@ package miner
 
 import (
 	"context"
+	"strconv"
 	"sync"
 	"time"
 
@@ -25,6 +26,9 @@ type Round struct {
 	vrfShare              *round.VRFShare
 	vrfSharesCache        *vrfSharesCache
 	ownVerificationTicket *block.BlockVerificationTicket
+	// ticketedBlocks are the hashes of the blocks ticketed by the miner by
+	// the generator and the round random seed, they are kept on restart
+	ticketedBlocks map[string]string
 }
 
 func (r *Round) SetGenerationCancelf(generationCancelf context.CancelFunc) {
@@ -78,6 +82,30 @@ func (r *Round) SetOwnVerificationTicket(ownVerificationTicket *block.BlockVerif
 	r.roundGuard.Unlock()
 }
 
+func ticketedBlockKey(b *block.Block) string {
+	return b.MinerID + ":" + strconv.FormatInt(b.GetRoundRandomSeed(), 10)
+}
+
+// CanTicket reports whether the miner hasn't ticketed another block of the
+// generator of the block for its round random seed. A generator proposes
+// one block for a seed, the tickets of two such blocks are slashed.
+func (r *Round) CanTicket(b *block.Block) bool {
+	r.roundGuard.RLock()
+	defer r.roundGuard.RUnlock()
+	hash, ok := r.ticketedBlocks[ticketedBlockKey(b)]
+	return !ok || hash == b.Hash
+}
+
+// SetTicketed records the block is ticketed by the miner.
+func (r *Round) SetTicketed(b *block.Block) {
+	r.roundGuard.Lock()
+	defer r.roundGuard.Unlock()
+	if r.ticketedBlocks == nil {
+		r.ticketedBlocks = make(map[string]string)
+	}
+	r.ticketedBlocks[ticketedBlockKey(b)] = b.Hash
+}
+
 type vrfSharesCache struct {
 	vrfShares map[string]*round.VRFShare
 	mutex     *sync.Mutex
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"0chain.net/chaincore/block"
 	"0chain.net/chaincore/node"
 	"0chain.net/chaincore/round"
 	"github.com/stretchr/testify/require"
@@ -135,3 +136,18 @@ func setupVRFSharesCache(t *testing.T, n int) *vrfSharesCache {
 
 	return vrfc
 }
+
+func TestRoundCanTicket(t *testing.T) {
+	newBlock := func(minerID, hash string, rrs int64) *block.Block {
+		b := block.NewBlock("", 1)
+		b.MinerID, b.Hash, b.RoundRandomSeed = minerID, hash, rrs
+		return b
+	}
+	var r Round
+	r.SetTicketed(newBlock("m1", "a", 1))
+
+	require.True(t, r.CanTicket(newBlock("m1", "a", 1)))
+	require.False(t, r.CanTicket(newBlock("m1", "b", 1)))
+	require.True(t, r.CanTicket(newBlock("m1", "b", 2)))
+	require.True(t, r.CanTicket(newBlock("m2", "b", 1)))
+}
@@ -0,0 +1,403 @@
+package miner
+
+import (
+	"container/heap"
+	"sort"
+	"sync"
+
+	"github.com/spf13/viper"
+
+	"0chain.net/chaincore/currency"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+)
+
+const (
+	// DefaultTxnPoolMaxSize is the default number of pending transactions the
+	// pool keeps
+	DefaultTxnPoolMaxSize = 10000
+	// DefaultTxnPoolMaxPerClient is the default number of pending transactions
+	// of a client the pool keeps
+	DefaultTxnPoolMaxPerClient = 100
+	// DefaultTxnPoolReplaceFeeBump is the default fee increase, in percent, a
+	// transaction needs to replace the pending one with the same nonce
+	DefaultTxnPoolReplaceFeeBump = 10
+)
+
+var (
+	// ErrTxnPoolUnderpriced is returned when the fee of a transaction is too
+	// low to replace the pending transaction with the same nonce
+	ErrTxnPoolUnderpriced = common.NewError("txn_pool_underpriced",
+		"fee is too low to replace the pending transaction")
+	// ErrTxnPoolClientLimit is returned when the client has too many pending
+	// transactions with lower nonces
+	ErrTxnPoolClientLimit = common.NewError("txn_pool_client_limit",
+		"too many pending transactions of the client")
+	// ErrTxnPoolFull is returned when the pool is full of transactions with
+	// a higher priority
+	ErrTxnPoolFull = common.NewError("txn_pool_full",
+		"transaction pool is full")
+)
+
+// txnPoolConfig are the limits of the transaction pool
+type txnPoolConfig struct {
+	MaxSize        int `json:"max_size"`
+	MaxPerClient   int `json:"max_per_client"`
+	ReplaceFeeBump int `json:"replace_fee_bump"`
+}
+
+func getTxnPoolConfig() txnPoolConfig {
+	viper.SetDefault("server_chain.transaction.pool.max_size", DefaultTxnPoolMaxSize)
+	viper.SetDefault("server_chain.transaction.pool.max_per_client", DefaultTxnPoolMaxPerClient)
+	viper.SetDefault("server_chain.transaction.pool.replace_fee_bump", DefaultTxnPoolReplaceFeeBump)
+	return txnPoolConfig{
+		MaxSize:        viper.GetInt("server_chain.transaction.pool.max_size"),
+		MaxPerClient:   viper.GetInt("server_chain.transaction.pool.max_per_client"),
+		ReplaceFeeBump: viper.GetInt("server_chain.transaction.pool.replace_fee_bump"),
+	}
+}
+
+// txnPoolStats are the counters of the transaction pool
+type txnPoolStats struct {
+	Size     int   `json:"size"`
+	Clients  int   `json:"clients"`
+	Added    int64 `json:"added"`
+	Replaced int64 `json:"replaced"`
+	Rejected int64 `json:"rejected"`
+	Evicted  int64 `json:"evicted"`
+	Removed  int64 `json:"removed"`
+}
+
+// poolTxn is a pending transaction and its estimated cost
+type poolTxn struct {
+	txn  *transaction.Transaction
+	cost int
+}
+
+// feePerCost is the priority of the transaction
+func (pt *poolTxn) feePerCost() float64 {
+	cost := pt.cost
+	if cost < 1 {
+		cost = 1
+	}
+	return float64(pt.txn.Fee) / float64(cost)
+}
+
+// lowerPriority reports whether the transaction a goes after the b one, the
+// older transactions go first on the same fee per cost
+func lowerPriority(a, b *poolTxn) bool {
+	if fa, fb := a.feePerCost(), b.feePerCost(); fa != fb {
+		return fa < fb
+	}
+	if a.txn.CreationDate != b.txn.CreationDate {
+		return a.txn.CreationDate > b.txn.CreationDate
+	}
+	return a.txn.Hash > b.txn.Hash
+}
+
+// clientTxns are the pending transactions of a client by nonce
+type clientTxns struct {
+	pending map[int64]*poolTxn
+	last    *poolTxn // with the highest nonce
+	index   int      // in the heap of the last transactions
+}
+
+// txnPool keeps the pending transactions a miner chooses the transactions of
+// the blocks it generates from. It keeps a transaction per nonce of a client,
+// a transaction with the same nonce and a higher fee replaces the pending
+// one. The transactions are ordered by the fee per unit of cost keeping the
+// nonce order of the transactions of a client.
+type txnPool struct {
+	mutex    sync.RWMutex
+	conf     txnPoolConfig
+	byHash   map[datastore.Key]*poolTxn
+	byClient map[datastore.Key]*clientTxns
+	lasts    lastTxns
+	stats    txnPoolStats
+}
+
+func newTxnPool(conf txnPoolConfig) *txnPool {
+	return &txnPool{
+		conf:     conf,
+		byHash:   make(map[datastore.Key]*poolTxn),
+		byClient: make(map[datastore.Key]*clientTxns),
+	}
+}
+
+// add adds the transaction to the pool, it returns the pending transactions
+// dropped in favour of it, the replaced one or the evicted ones
+func (tp *txnPool) add(txn *transaction.Transaction, cost int) (
+	dropped []*transaction.Transaction, err error) {
+
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+
+	if _, ok := tp.byHash[txn.Hash]; ok {
+		return nil, nil
+	}
+
+	var (
+		pt      = &poolTxn{txn: txn, cost: cost}
+		pending map[int64]*poolTxn
+	)
+	if ct, ok := tp.byClient[txn.ClientID]; ok {
+		pending = ct.pending
+	}
+	if prev, ok := pending[txn.Nonce]; ok {
+		if !tp.replaces(txn.Fee, prev.txn.Fee) {
+			tp.stats.Rejected++
+			return nil, ErrTxnPoolUnderpriced
+		}
+		tp.delete(prev)
+		tp.stats.Replaced++
+		dropped = append(dropped, prev.txn)
+	} else if tp.conf.MaxPerClient > 0 && len(pending) >= tp.conf.MaxPerClient {
+		// keep the lowest nonces of the client, the higher ones can't be
+		// executed before them anyway
+		last := tp.byClient[txn.ClientID].last
+		if txn.Nonce > last.txn.Nonce {
+			tp.stats.Rejected++
+			return nil, ErrTxnPoolClientLimit
+		}
+		tp.delete(last)
+		tp.stats.Evicted++
+		dropped = append(dropped, last.txn)
+	}
+	tp.insert(pt)
+	tp.stats.Added++
+
+	for tp.conf.MaxSize > 0 && len(tp.byHash) > tp.conf.MaxSize {
+		evicted := tp.lowest()
+		tp.delete(evicted)
+		if evicted == pt {
+			tp.stats.Added--
+			tp.stats.Rejected++
+			return dropped, ErrTxnPoolFull
+		}
+		tp.stats.Evicted++
+		dropped = append(dropped, evicted.txn)
+	}
+	return dropped, nil
+}
+
+// replaces reports whether the fee is high enough to replace the pending
+// transaction with the given fee
+func (tp *txnPool) replaces(fee, pendingFee currency.Coin) bool {
+	if fee <= pendingFee {
+		return false
+	}
+	bump := float64(pendingFee) * float64(tp.conf.ReplaceFeeBump) / 100
+	return float64(fee) >= float64(pendingFee)+bump
+}
+
+func (tp *txnPool) insert(pt *poolTxn) {
+	tp.byHash[pt.txn.Hash] = pt
+	ct, ok := tp.byClient[pt.txn.ClientID]
+	if !ok {
+		ct = &clientTxns{pending: map[int64]*poolTxn{pt.txn.Nonce: pt}, last: pt}
+		tp.byClient[pt.txn.ClientID] = ct
+		heap.Push(&tp.lasts, ct)
+		return
+	}
+	ct.pending[pt.txn.Nonce] = pt
+	if pt.txn.Nonce > ct.last.txn.Nonce {
+		ct.last = pt
+		heap.Fix(&tp.lasts, ct.index)
+	}
+}
+
+func (tp *txnPool) delete(pt *poolTxn) {
+	delete(tp.byHash, pt.txn.Hash)
+	ct := tp.byClient[pt.txn.ClientID]
+	delete(ct.pending, pt.txn.Nonce)
+	if len(ct.pending) == 0 {
+		heap.Remove(&tp.lasts, ct.index)
+		delete(tp.byClient, pt.txn.ClientID)
+		return
+	}
+	if ct.last != pt {
+		return
+	}
+	// the pending transactions of a client are bounded by the max per client
+	ct.last = nil
+	for _, p := range ct.pending {
+		if ct.last == nil || p.txn.Nonce > ct.last.txn.Nonce {
+			ct.last = p
+		}
+	}
+	heap.Fix(&tp.lasts, ct.index)
+}
+
+// lowest returns the transaction to evict, the one with the lowest priority
+// among the last transactions of the clients, to not leave nonce gaps
+func (tp *txnPool) lowest() *poolTxn {
+	return tp.lasts[0].last
+}
+
+// remove removes the transactions from the pool, the ones included in the
+// finalized blocks or the invalid ones
+func (tp *txnPool) remove(txns []datastore.Entity) {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+
+	for _, txn := range txns {
+		if pt, ok := tp.byHash[txn.GetKey()]; ok {
+			tp.delete(pt)
+			tp.stats.Removed++
+		}
+	}
+}
+
+// prune removes the transactions not within the time tolerance anymore and
+// the ones with passed validity windows
+func (tp *txnPool) prune(round int64, now common.Timestamp) {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+
+	for _, pt := range tp.byHash {
+		if !common.WithinTime(int64(now), int64(pt.txn.CreationDate), transaction.TXN_TIME_TOLERANCE) ||
+			pt.txn.IsExpired(round, now) {
+			tp.delete(pt)
+			tp.stats.Removed++
+		}
+	}
+}
+
+// candidates returns the pending transactions in the order of their
+// priority, the transactions of a client go in the order of their nonces
+func (tp *txnPool) candidates() []*poolTxn {
+	tp.mutex.RLock()
+	defer tp.mutex.RUnlock()
+
+	return tp.sorted("")
+}
+
+func (tp *txnPool) sorted(clientID datastore.Key) []*poolTxn {
+	var (
+		queues = make(txnQueues, 0, len(tp.byClient))
+		list   = make([]*poolTxn, 0, len(tp.byHash))
+	)
+	for id, ct := range tp.byClient {
+		if clientID != "" && id != clientID {
+			continue
+		}
+		queue := make([]*poolTxn, 0, len(ct.pending))
+		for _, pt := range ct.pending {
+			queue = append(queue, pt)
+		}
+		sort.Slice(queue, func(i, j int) bool {
+			return queue[i].txn.Nonce < queue[j].txn.Nonce
+		})
+		queues = append(queues, queue)
+	}
+
+	heap.Init(&queues)
+	for len(queues) > 0 {
+		queue := queues[0]
+		list = append(list, queue[0])
+		if len(queue) > 1 {
+			queues[0] = queue[1:]
+			heap.Fix(&queues, 0)
+			continue
+		}
+		heap.Pop(&queues)
+	}
+	return list
+}
+
+// TxnPoolEntry is a pending transaction of the transaction pool
+type TxnPoolEntry struct {
+	Hash         string           `json:"hash"`
+	ClientID     string           `json:"client_id"`
+	Nonce        int64            `json:"nonce"`
+	Fee          currency.Coin    `json:"fee"`
+	Cost         int              `json:"cost"`
+	FeePerCost   float64          `json:"fee_per_cost"`
+	CreationDate common.Timestamp `json:"creation_date"`
+}
+
+// TxnPoolSnapshot is the state of the transaction pool
+type TxnPoolSnapshot struct {
+	Config txnPoolConfig   `json:"config"`
+	Stats  txnPoolStats    `json:"stats"`
+	Txns   []*TxnPoolEntry `json:"txns"`
+}
+
+// snapshot returns the stats and the first pending transactions of the pool,
+// of all the clients or of the given one, in the order of their priority
+func (tp *txnPool) snapshot(clientID datastore.Key, limit int) *TxnPoolSnapshot {
+	tp.mutex.RLock()
+	defer tp.mutex.RUnlock()
+
+	list := tp.sorted(clientID)
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	s := &TxnPoolSnapshot{
+		Config: tp.conf,
+		Stats:  tp.stats,
+		Txns:   make([]*TxnPoolEntry, 0, len(list)),
+	}
+	s.Stats.Size = len(tp.byHash)
+	s.Stats.Clients = len(tp.byClient)
+	for _, pt := range list {
+		s.Txns = append(s.Txns, &TxnPoolEntry{
+			Hash:         pt.txn.Hash,
+			ClientID:     pt.txn.ClientID,
+			Nonce:        pt.txn.Nonce,
+			Fee:          pt.txn.Fee,
+			Cost:         pt.cost,
+			FeePerCost:   pt.feePerCost(),
+			CreationDate: pt.txn.CreationDate,
+		})
+	}
+	return s
+}
+
+// txnQueues is a max heap of the nonce ordered transactions of the clients
+// by the priority of their first transactions
+type txnQueues [][]*poolTxn
+
+func (q txnQueues) Len() int           { return len(q) }
+func (q txnQueues) Less(i, j int) bool { return lowerPriority(q[j][0], q[i][0]) }
+func (q txnQueues) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *txnQueues) Push(x interface{}) {
+	*q = append(*q, x.([]*poolTxn))
+}
+
+func (q *txnQueues) Pop() interface{} {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[:n-1]
+	return x
+}
+
+// lastTxns is a min heap of the pending transactions of the clients by the
+// priority of their last transactions, the ones evicted first
+type lastTxns []*clientTxns
+
+func (q lastTxns) Len() int           { return len(q) }
+func (q lastTxns) Less(i, j int) bool { return lowerPriority(q[i].last, q[j].last) }
+
+func (q lastTxns) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *lastTxns) Push(x interface{}) {
+	ct := x.(*clientTxns)
+	ct.index = len(*q)
+	*q = append(*q, ct)
+}
+
+func (q *lastTxns) Pop() interface{} {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[:n-1]
+	return x
+}
@@ -0,0 +1,202 @@
+package miner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"0chain.net/chaincore/currency"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+)
+
+func newPoolTxn(hash, clientID string, nonce int64, fee currency.Coin) *transaction.Transaction {
+	return &transaction.Transaction{
+		HashIDField:  datastore.HashIDField{Hash: hash},
+		ClientID:     clientID,
+		Nonce:        nonce,
+		Fee:          fee,
+		CreationDate: common.Timestamp(100),
+	}
+}
+
+func poolHashes(list []*poolTxn) []string {
+	hashes := make([]string, 0, len(list))
+	for _, pt := range list {
+		hashes = append(hashes, pt.txn.Hash)
+	}
+	return hashes
+}
+
+func TestTxnPool_candidates(t *testing.T) {
+	tp := newTxnPool(txnPoolConfig{})
+
+	for _, tt := range []struct {
+		txn  *transaction.Transaction
+		cost int
+	}{
+		{newPoolTxn("a1", "a", 1, 10), 10},  // 1 per cost
+		{newPoolTxn("a2", "a", 2, 100), 10}, // 10 per cost
+		{newPoolTxn("b1", "b", 1, 30), 10},  // 3 per cost
+		{newPoolTxn("c1", "c", 1, 40), 100}, // 0.4 per cost
+		{newPoolTxn("b2", "b", 2, 20), 10},  // 2 per cost
+	} {
+		_, err := tp.add(tt.txn, tt.cost)
+		require.NoError(t, err)
+	}
+
+	// a2 has the highest fee per cost but goes after a1 of the same client
+	require.Equal(t, []string{"b1", "b2", "a1", "a2", "c1"}, poolHashes(tp.candidates()))
+}
+
+func TestTxnPool_replaceByFee(t *testing.T) {
+	tp := newTxnPool(txnPoolConfig{ReplaceFeeBump: 10})
+
+	_, err := tp.add(newPoolTxn("a1", "a", 1, 100), 1)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		txn     *transaction.Transaction
+		dropped []string
+		err     error
+		pending string
+	}{
+		{
+			name:    "same_txn",
+			txn:     newPoolTxn("a1", "a", 1, 100),
+			pending: "a1",
+		},
+		{
+			name:    "lower_fee",
+			txn:     newPoolTxn("a1-low", "a", 1, 90),
+			err:     ErrTxnPoolUnderpriced,
+			pending: "a1",
+		},
+		{
+			name:    "bump_too_small",
+			txn:     newPoolTxn("a1-small", "a", 1, 109),
+			err:     ErrTxnPoolUnderpriced,
+			pending: "a1",
+		},
+		{
+			name:    "replaced",
+			txn:     newPoolTxn("a1-high", "a", 1, 110),
+			dropped: []string{"a1"},
+			pending: "a1-high",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dropped, err := tp.add(tt.txn, 1)
+			require.Equal(t, tt.err, err)
+			var hashes []string
+			for _, txn := range dropped {
+				hashes = append(hashes, txn.Hash)
+			}
+			require.Equal(t, tt.dropped, hashes)
+			require.Equal(t, []string{tt.pending}, poolHashes(tp.candidates()))
+		})
+	}
+	require.EqualValues(t, 1, tp.stats.Replaced)
+	require.EqualValues(t, 2, tp.stats.Rejected)
+}
+
+func TestTxnPool_limits(t *testing.T) {
+	t.Run("per_client", func(t *testing.T) {
+		tp := newTxnPool(txnPoolConfig{MaxPerClient: 2})
+		for i, hash := range []string{"a2", "a3"} {
+			_, err := tp.add(newPoolTxn(hash, "a", int64(i+2), 10), 1)
+			require.NoError(t, err)
+		}
+
+		_, err := tp.add(newPoolTxn("a4", "a", 4, 10), 1)
+		require.Equal(t, ErrTxnPoolClientLimit, err)
+
+		// a lower nonce evicts the highest one
+		dropped, err := tp.add(newPoolTxn("a1", "a", 1, 10), 1)
+		require.NoError(t, err)
+		require.Len(t, dropped, 1)
+		require.Equal(t, "a3", dropped[0].Hash)
+		require.Equal(t, []string{"a1", "a2"}, poolHashes(tp.candidates()))
+	})
+
+	t.Run("max_size", func(t *testing.T) {
+		tp := newTxnPool(txnPoolConfig{MaxSize: 3})
+		for _, txn := range []*transaction.Transaction{
+			newPoolTxn("a1", "a", 1, 50),
+			newPoolTxn("a2", "a", 2, 1),
+			newPoolTxn("b1", "b", 1, 5),
+		} {
+			_, err := tp.add(txn, 1)
+			require.NoError(t, err)
+		}
+
+		// the last transactions of the clients are evicted first, a2 has
+		// the lowest priority
+		dropped, err := tp.add(newPoolTxn("c1", "c", 1, 10), 1)
+		require.NoError(t, err)
+		require.Len(t, dropped, 1)
+		require.Equal(t, "a2", dropped[0].Hash)
+
+		_, err = tp.add(newPoolTxn("d1", "d", 1, 1), 1)
+		require.Equal(t, ErrTxnPoolFull, err)
+		require.Equal(t, []string{"a1", "c1", "b1"}, poolHashes(tp.candidates()))
+	})
+}
+
+func TestTxnPool_lowest(t *testing.T) {
+	tp := newTxnPool(txnPoolConfig{MaxSize: 3, ReplaceFeeBump: 10})
+	for _, txn := range []*transaction.Transaction{
+		newPoolTxn("a1", "a", 1, 50),
+		newPoolTxn("a2", "a", 2, 1),
+		newPoolTxn("b1", "b", 1, 5),
+	} {
+		_, err := tp.add(txn, 1)
+		require.NoError(t, err)
+	}
+
+	// a1 is the last transaction of the client once a2 is removed
+	tp.remove([]datastore.Entity{newPoolTxn("a2", "", 0, 0)})
+	_, err := tp.add(newPoolTxn("c1", "c", 1, 3), 1)
+	require.NoError(t, err)
+	dropped, err := tp.add(newPoolTxn("d1", "d", 1, 20), 1)
+	require.NoError(t, err)
+	require.Len(t, dropped, 1)
+	require.Equal(t, "c1", dropped[0].Hash)
+
+	// the replacement of b1 has the highest priority
+	_, err = tp.add(newPoolTxn("b1x", "b", 1, 100), 1)
+	require.NoError(t, err)
+	dropped, err = tp.add(newPoolTxn("e1", "e", 1, 30), 1)
+	require.NoError(t, err)
+	require.Len(t, dropped, 1)
+	require.Equal(t, "d1", dropped[0].Hash)
+	require.Equal(t, []string{"b1x", "a1", "e1"}, poolHashes(tp.candidates()))
+}
+
+func TestTxnPool_removeAndPrune(t *testing.T) {
+	tp := newTxnPool(txnPoolConfig{})
+	old := newPoolTxn("old", "b", 1, 1)
+	old.CreationDate = common.Timestamp(100 - transaction.TXN_TIME_TOLERANCE - 1)
+	expired := newPoolTxn("expired", "c", 1, 1)
+	expired.ValidUntil = &transaction.ValidityBound{Round: 9}
+	for _, txn := range []*transaction.Transaction{
+		newPoolTxn("a1", "a", 1, 1),
+		newPoolTxn("a2", "a", 2, 1),
+		old,
+		expired,
+	} {
+		_, err := tp.add(txn, 1)
+		require.NoError(t, err)
+	}
+
+	tp.remove([]datastore.Entity{newPoolTxn("a1", "", 0, 0)})
+	tp.prune(10, common.Timestamp(100))
+
+	require.Equal(t, []string{"a2"}, poolHashes(tp.candidates()))
+	s := tp.snapshot("a", 0)
+	require.Equal(t, 1, s.Stats.Size)
+	require.EqualValues(t, 3, s.Stats.Removed)
+}
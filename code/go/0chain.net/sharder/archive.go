@@ -0,0 +1,255 @@
+package sharder
+
+import (
+	"context"
+	"io"
+
+	"go.uber.org/zap"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/round"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/ememorystore"
+	. "0chain.net/core/logging"
+	"0chain.net/sharder/archive"
+	"0chain.net/sharder/blockstore"
+)
+
+// ArchiveStats - number of rounds and magic blocks exported or imported.
+type ArchiveStats struct {
+	Rounds      int64 `json:"rounds"`
+	MagicBlocks int64 `json:"magic_blocks"`
+	FirstRound  int64 `json:"first_round"`
+	LastRound   int64 `json:"last_round"`
+}
+
+// ExportArchive - write finalized rounds, block summaries, blocks and magic
+// block maps of the [from, to] rounds range into the archive.
+func (sc *Chain) ExportArchive(ctx context.Context, w io.Writer, from, to int64) (*ArchiveStats, error) {
+	if from <= 0 || to < from {
+		return nil, common.NewErrorf("export_archive", "invalid rounds range [%d, %d]", from, to)
+	}
+	aw, err := archive.NewWriter(w, archive.Header{
+		ChainID:   sc.GetKey(),
+		FromRound: from,
+		ToRound:   to,
+		CreatedAt: common.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ArchiveStats{FirstRound: from}
+	for rn := from; rn <= to; rn++ {
+		select {
+		case <-ctx.Done():
+			return stats, ctx.Err()
+		default:
+		}
+
+		r, err := sc.GetRoundFromStore(ctx, rn)
+		if err != nil {
+			return stats, common.NewErrorf("export_archive", "round %d: %v", rn, err)
+		}
+		bs, err := sc.readBlockSummary(ctx, r.BlockHash)
+		if err != nil {
+			return stats, common.NewErrorf("export_archive", "block summary of round %d: %v", rn, err)
+		}
+		b, err := blockstore.GetStore().ReadWithBlockSummary(bs)
+		if err != nil {
+			return stats, common.NewErrorf("export_archive", "block of round %d: %v", rn, err)
+		}
+
+		if err := aw.Write(archive.RecordRound, r); err != nil {
+			return stats, err
+		}
+		if err := aw.Write(archive.RecordBlockSummary, bs); err != nil {
+			return stats, err
+		}
+		if err := aw.Write(archive.RecordBlock, b); err != nil {
+			return stats, err
+		}
+		if b.MagicBlock != nil {
+			if err := aw.Write(archive.RecordMagicBlockMap, bs.GetMagicBlockMap()); err != nil {
+				return stats, err
+			}
+			stats.MagicBlocks++
+		}
+		stats.Rounds++
+		stats.LastRound = rn
+	}
+
+	if err := aw.Close(); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+func (sc *Chain) readBlockSummary(ctx context.Context, hash string) (*block.BlockSummary, error) {
+	bctx := ememorystore.WithEntityConnection(ctx, datastore.GetEntityMetadata("block_summary"))
+	defer ememorystore.Close(bctx)
+	return sc.GetBlockSummary(bctx, hash)
+}
+
+// archivedRound - records of a round read from an archive.
+type archivedRound struct {
+	round   *round.Round
+	summary *block.BlockSummary
+	block   *block.Block
+	mbMap   *block.MagicBlockMap
+}
+
+// ImportArchive - read the archive, verify the blocks hashes and the hash
+// chain linkage and store the rounds, block summaries, blocks and magic
+// block maps. Rounds are stored after the records of the next round are
+// read, so nothing is stored from a broken tail of an archive.
+func (sc *Chain) ImportArchive(ctx context.Context, r io.Reader) (*ArchiveStats, error) {
+	ar, err := archive.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer ar.Close()
+
+	h := ar.Header()
+	if h.ChainID != sc.GetKey() {
+		return nil, common.NewErrorf("import_archive", "chain id mismatch: %s, expected %s",
+			h.ChainID, sc.GetKey())
+	}
+
+	var (
+		stats    = &ArchiveStats{}
+		prevHash string
+		cur      *archivedRound
+	)
+	// link the first block to the local chain if it's known
+	if h.FromRound > 1 {
+		if hash, err := sc.GetBlockHash(ctx, h.FromRound-1); err == nil {
+			prevHash = hash
+		}
+	}
+
+	flush := func() error {
+		if cur == nil {
+			return nil
+		}
+		if err := sc.verifyArchivedRound(cur, prevHash); err != nil {
+			return err
+		}
+		if err := sc.storeArchivedRound(ctx, cur); err != nil {
+			return err
+		}
+		if stats.Rounds == 0 {
+			stats.FirstRound = cur.round.Number
+		}
+		stats.Rounds++
+		stats.LastRound = cur.round.Number
+		if cur.mbMap != nil {
+			stats.MagicBlocks++
+		}
+		prevHash = cur.block.Hash
+		cur = nil
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return stats, ctx.Err()
+		default:
+		}
+
+		rt, payload, err := ar.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, err
+		}
+
+		switch rt {
+		case archive.RecordRound:
+			if err := flush(); err != nil {
+				return stats, err
+			}
+			rnd := datastore.GetEntityMetadata("round").Instance().(*round.Round)
+			if err := datastore.FromMsgpack(payload, rnd); err != nil {
+				return stats, err
+			}
+			if stats.LastRound > 0 && rnd.Number != stats.LastRound+1 {
+				return stats, common.NewErrorf("import_archive", "missing round %d", stats.LastRound+1)
+			}
+			cur = &archivedRound{round: rnd}
+		case archive.RecordBlockSummary, archive.RecordBlock, archive.RecordMagicBlockMap:
+			if cur == nil {
+				return stats, common.NewErrorf("import_archive", "unexpected %v record", rt)
+			}
+			if err := cur.decode(rt, payload); err != nil {
+				return stats, err
+			}
+		default:
+			Logger.Warn("import archive - skipping unknown record", zap.Stringer("type", rt))
+		}
+	}
+
+	if err := flush(); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+func (ar *archivedRound) decode(rt archive.RecordType, payload []byte) error {
+	switch rt {
+	case archive.RecordBlockSummary:
+		ar.summary = datastore.GetEntityMetadata("block_summary").Instance().(*block.BlockSummary)
+		return datastore.FromMsgpack(payload, ar.summary)
+	case archive.RecordBlock:
+		ar.block = datastore.GetEntityMetadata("block").Instance().(*block.Block)
+		return datastore.FromMsgpack(payload, ar.block)
+	case archive.RecordMagicBlockMap:
+		ar.mbMap = datastore.GetEntityMetadata("magic_block_map").Instance().(*block.MagicBlockMap)
+		return datastore.FromMsgpack(payload, ar.mbMap)
+	}
+	return nil
+}
+
+func (sc *Chain) verifyArchivedRound(ar *archivedRound, prevHash string) error {
+	rn := ar.round.Number
+	if ar.summary == nil || ar.block == nil {
+		return common.NewErrorf("import_archive", "incomplete round %d", rn)
+	}
+	b := ar.block
+	if b.Round != rn || ar.summary.Round != rn {
+		return common.NewErrorf("import_archive", "round %d: round mismatch", rn)
+	}
+	if hash := b.ComputeHash(); hash != b.Hash {
+		return common.NewErrorf("import_archive", "round %d: invalid block hash %s, computed %s",
+			rn, b.Hash, hash)
+	}
+	if ar.round.BlockHash != b.Hash || ar.summary.Hash != b.Hash {
+		return common.NewErrorf("import_archive", "round %d: block hash mismatch", rn)
+	}
+	if prevHash != "" && b.PrevHash != prevHash {
+		return common.NewErrorf("import_archive", "round %d: broken hash chain, prev hash %s, expected %s",
+			rn, b.PrevHash, prevHash)
+	}
+	if (ar.mbMap != nil) != (b.MagicBlock != nil) {
+		return common.NewErrorf("import_archive", "round %d: magic block map mismatch", rn)
+	}
+	return nil
+}
+
+func (sc *Chain) storeArchivedRound(ctx context.Context, ar *archivedRound) error {
+	if err := blockstore.GetStore().Write(ar.block); err != nil {
+		return err
+	}
+	if err := sc.StoreBlockSummary(ctx, ar.summary); err != nil {
+		return err
+	}
+	if ar.mbMap != nil {
+		if err := sc.StoreMagicBlockMapFromBlock(ar.mbMap); err != nil {
+			return err
+		}
+	}
+	return sc.StoreRound(ar.round)
+}
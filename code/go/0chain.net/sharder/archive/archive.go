@@ -0,0 +1,262 @@
+// Package archive implements the portable archive format of finalized chain
+// history exported from a sharder.
+//
+// An archive starts with a magic string, the format version and a JSON
+// encoded header. It is followed by a zlib compressed stream of records,
+// every record is its type, payload length and msgpack encoded payload.
+// The stream is closed by the end record keeping the number of records and
+// the SHA3-256 checksum of the header and all the records.
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"golang.org/x/crypto/sha3"
+
+	"0chain.net/core/common"
+)
+
+// Version - current version of the archive format.
+const Version uint16 = 1
+
+// maxRecordSize - upper limit of a single record payload.
+const maxRecordSize = 1 << 30
+
+var magic = []byte("0CHNARCV")
+
+// RecordType - type of an archive record.
+type RecordType byte
+
+// Archive record types.
+const (
+	RecordRound         RecordType = 1
+	RecordBlockSummary  RecordType = 2
+	RecordBlock         RecordType = 3
+	RecordMagicBlockMap RecordType = 4
+
+	recordEnd RecordType = 0xff
+)
+
+func (rt RecordType) String() string {
+	switch rt {
+	case RecordRound:
+		return "round"
+	case RecordBlockSummary:
+		return "block_summary"
+	case RecordBlock:
+		return "block"
+	case RecordMagicBlockMap:
+		return "magic_block_map"
+	case recordEnd:
+		return "end"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(rt))
+	}
+}
+
+var (
+	// ErrInvalidArchive - the stream is not an archive.
+	ErrInvalidArchive = errors.New("invalid archive")
+	// ErrUnsupportedVersion - the archive is written by newer format version.
+	ErrUnsupportedVersion = errors.New("unsupported archive version")
+	// ErrTruncated - the archive has no end record.
+	ErrTruncated = errors.New("archive is truncated")
+	// ErrChecksumMismatch - the archive content doesn't match the checksum.
+	ErrChecksumMismatch = errors.New("archive checksum mismatch")
+)
+
+// Header - the archive header.
+type Header struct {
+	Version   uint16           `json:"version"`
+	ChainID   string           `json:"chain_id"`
+	FromRound int64            `json:"from_round"`
+	ToRound   int64            `json:"to_round"`
+	CreatedAt common.Timestamp `json:"created_at"`
+}
+
+// Writer - writes an archive.
+type Writer struct {
+	zw    *zlib.Writer
+	sum   hash.Hash
+	out   io.Writer
+	count uint64
+}
+
+// NewWriter - write the archive header and return writer of the records.
+func NewWriter(w io.Writer, h Header) (*Writer, error) {
+	h.Version = Version
+	hb, err := json.Marshal(&h)
+	if err != nil {
+		return nil, err
+	}
+	var head bytes.Buffer
+	head.Write(magic)
+	_ = binary.Write(&head, binary.LittleEndian, h.Version)
+	_ = binary.Write(&head, binary.LittleEndian, uint32(len(hb)))
+	head.Write(hb)
+	if _, err := w.Write(head.Bytes()); err != nil {
+		return nil, err
+	}
+
+	aw := &Writer{sum: sha3.New256()}
+	aw.sum.Write(head.Bytes())
+	aw.zw, err = zlib.NewWriterLevel(w, zlib.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	aw.out = io.MultiWriter(aw.zw, aw.sum)
+	return aw, nil
+}
+
+func (aw *Writer) writeRecord(rt RecordType, payload []byte) error {
+	var head [5]byte
+	head[0] = byte(rt)
+	binary.LittleEndian.PutUint32(head[1:], uint32(len(payload)))
+	if _, err := aw.out.Write(head[:]); err != nil {
+		return err
+	}
+	_, err := aw.out.Write(payload)
+	return err
+}
+
+// Write - append msgpack encoded entity as a record of given type.
+func (aw *Writer) Write(rt RecordType, entity interface{}) error {
+	if rt == recordEnd {
+		return fmt.Errorf("archive: reserved record type %v", rt)
+	}
+	if err := aw.writeRecord(rt, common.ToMsgpack(entity).Bytes()); err != nil {
+		return err
+	}
+	aw.count++
+	return nil
+}
+
+// Close - write the end record and flush the archive. It doesn't close the
+// underlying writer.
+func (aw *Writer) Close() error {
+	var end [5 + 8]byte
+	end[0] = byte(recordEnd)
+	binary.LittleEndian.PutUint32(end[1:5], 8+32)
+	binary.LittleEndian.PutUint64(end[5:], aw.count)
+	if _, err := aw.out.Write(end[:]); err != nil {
+		return err
+	}
+	// the checksum itself is not a part of the checksum
+	if _, err := aw.zw.Write(aw.sum.Sum(nil)); err != nil {
+		return err
+	}
+	return aw.zw.Close()
+}
+
+// Reader - reads an archive.
+type Reader struct {
+	header Header
+	zr     io.ReadCloser
+	in     io.Reader
+	sum    hash.Hash
+	count  uint64
+	done   bool
+}
+
+// NewReader - read the archive header and return reader of the records.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	var head [14]byte
+	if _, err := io.ReadFull(br, head[:]); err != nil {
+		return nil, ErrInvalidArchive
+	}
+	if !bytes.Equal(head[:8], magic) {
+		return nil, ErrInvalidArchive
+	}
+	version := binary.LittleEndian.Uint16(head[8:10])
+	if version > Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+	hb := make([]byte, binary.LittleEndian.Uint32(head[10:14]))
+	if _, err := io.ReadFull(br, hb); err != nil {
+		return nil, ErrTruncated
+	}
+	ar := &Reader{sum: sha3.New256()}
+	if err := json.Unmarshal(hb, &ar.header); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidArchive, err)
+	}
+	ar.sum.Write(head[:])
+	ar.sum.Write(hb)
+
+	zr, err := zlib.NewReader(br)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	ar.zr = zr
+	ar.in = io.TeeReader(zr, ar.sum)
+	return ar, nil
+}
+
+// Header - returns the archive header.
+func (ar *Reader) Header() Header {
+	return ar.header
+}
+
+// Next - returns the next record of the archive. It returns io.EOF after
+// the end record when the checksum of the archive is verified.
+func (ar *Reader) Next() (RecordType, []byte, error) {
+	if ar.done {
+		return 0, nil, io.EOF
+	}
+	var head [5]byte
+	if _, err := io.ReadFull(ar.in, head[:]); err != nil {
+		return 0, nil, ErrTruncated
+	}
+	rt := RecordType(head[0])
+	size := binary.LittleEndian.Uint32(head[1:])
+	if size > maxRecordSize {
+		return 0, nil, fmt.Errorf("%w: record size %d", ErrInvalidArchive, size)
+	}
+	if rt == recordEnd {
+		return 0, nil, ar.verify(size)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(ar.in, payload); err != nil {
+		return 0, nil, ErrTruncated
+	}
+	ar.count++
+	return rt, payload, nil
+}
+
+func (ar *Reader) verify(size uint32) error {
+	if size != 8+32 {
+		return fmt.Errorf("%w: end record size %d", ErrInvalidArchive, size)
+	}
+	var count [8]byte
+	if _, err := io.ReadFull(ar.in, count[:]); err != nil {
+		return ErrTruncated
+	}
+	want := ar.sum.Sum(nil)
+	var got [32]byte
+	if _, err := io.ReadFull(ar.zr, got[:]); err != nil {
+		return ErrTruncated
+	}
+	if binary.LittleEndian.Uint64(count[:]) != ar.count {
+		return fmt.Errorf("%w: %d records expected, %d read", ErrChecksumMismatch,
+			binary.LittleEndian.Uint64(count[:]), ar.count)
+	}
+	if !bytes.Equal(want, got[:]) {
+		return ErrChecksumMismatch
+	}
+	ar.done = true
+	return io.EOF
+}
+
+// Close - release the reader resources. It doesn't close the underlying
+// reader.
+func (ar *Reader) Close() error {
+	return ar.zr.Close()
+}
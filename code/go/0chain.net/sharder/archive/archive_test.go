@@ -0,0 +1,114 @@
+package archive
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"0chain.net/core/common"
+)
+
+type testRecord struct {
+	Round int64  `json:"round"`
+	Hash  string `json:"hash"`
+}
+
+func writeTestArchive(t *testing.T, records []testRecord) []byte {
+	var buf bytes.Buffer
+	aw, err := NewWriter(&buf, Header{ChainID: "chain", FromRound: 1, ToRound: int64(len(records))})
+	require.NoError(t, err)
+	for i := range records {
+		require.NoError(t, aw.Write(RecordBlock, &records[i]))
+	}
+	require.NoError(t, aw.Close())
+	return buf.Bytes()
+}
+
+func TestArchive_WriteRead(t *testing.T) {
+	records := []testRecord{{Round: 1, Hash: "a"}, {Round: 2, Hash: "b"}, {Round: 3, Hash: "c"}}
+	data := writeTestArchive(t, records)
+
+	ar, err := NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	defer ar.Close()
+
+	h := ar.Header()
+	assert.Equal(t, Version, h.Version)
+	assert.Equal(t, "chain", h.ChainID)
+	assert.EqualValues(t, 1, h.FromRound)
+	assert.EqualValues(t, 3, h.ToRound)
+
+	var got []testRecord
+	for {
+		rt, payload, err := ar.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		assert.Equal(t, RecordBlock, rt)
+		var r testRecord
+		require.NoError(t, common.FromMsgpack(payload, &r))
+		got = append(got, r)
+	}
+	assert.Equal(t, records, got)
+
+	_, _, err = ar.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestArchive_Empty(t *testing.T) {
+	ar, err := NewReader(bytes.NewReader(writeTestArchive(t, nil)))
+	require.NoError(t, err)
+	_, _, err = ar.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestArchive_Errors(t *testing.T) {
+	data := writeTestArchive(t, []testRecord{{Round: 1, Hash: "a"}, {Round: 2, Hash: "b"}})
+
+	readAll := func(data []byte) error {
+		ar, err := NewReader(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		for {
+			if _, _, err := ar.Next(); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+
+	t.Run("invalid magic", func(t *testing.T) {
+		corrupted := append([]byte{}, data...)
+		corrupted[0] = 'X'
+		assert.True(t, errors.Is(readAll(corrupted), ErrInvalidArchive))
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		corrupted := append([]byte{}, data...)
+		corrupted[8] = byte(Version + 1)
+		assert.True(t, errors.Is(readAll(corrupted), ErrUnsupportedVersion))
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		assert.Error(t, readAll(data[:len(data)-10]))
+	})
+
+	t.Run("header modified", func(t *testing.T) {
+		corrupted := bytes.Replace(data, []byte(`"chain"`), []byte(`"CHAIN"`), 1)
+		assert.True(t, errors.Is(readAll(corrupted), ErrChecksumMismatch))
+	})
+
+	t.Run("reserved record type", func(t *testing.T) {
+		aw, err := NewWriter(io.Discard, Header{})
+		require.NoError(t, err)
+		assert.Error(t, aw.Write(recordEnd, &testRecord{}))
+	})
+}
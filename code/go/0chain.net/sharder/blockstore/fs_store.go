@@ -200,24 +200,21 @@ func (fbs *FSBlockStore) read(hash string, round int64) (*block.Block, error) {
 	if len(hash) != 64 {
 		return nil, encryption.ErrInvalidHash
 	}
-	fileName := fbs.getFileName(hash, round)
-	f, err := os.Open(fileName)
+	b, err := fbs.readLocal(hash, round)
+	if err == nil || !os.IsNotExist(err) || !viper.GetBool("minio.enabled") {
+		return b, err
+	}
+	if err := fbs.DownloadFromCloud(hash, round); err != nil {
+		return nil, err
+	}
+	return fbs.readLocal(hash, round)
+}
+
+// readLocal - read the block from the local file system only
+func (fbs *FSBlockStore) readLocal(hash string, round int64) (*block.Block, error) {
+	f, err := os.Open(fbs.getFileName(hash, round))
 	if err != nil {
-		if os.IsNotExist(err) {
-			if viper.GetBool("minio.enabled") {
-				err = fbs.DownloadFromCloud(hash, round)
-				if err != nil {
-					return nil, err
-				}
-			}
-			f, err = os.Open(fileName)
-			if err != nil {
-				return nil, err
-
-			}
-		} else {
-			return nil, err
-		}
+		return nil, err
 	}
 	defer f.Close()
 	r, err := zlib.NewReader(f)
@@ -8,6 +8,7 @@ import (
 //MultiBlockStore - a block store backed by multiple other block stores - useful to experiment different block stores
 type MultiBlockStore struct {
 	BlockStores []BlockStore
+	tiering     *tiering
 }
 
 var (
@@ -29,11 +30,20 @@ func (mbs *MultiBlockStore) Write(b *block.Block) error {
 			return err
 		}
 	}
+	if mbs.tiering != nil {
+		mbs.tiering.written(b)
+	}
 	return nil
 }
 
 //Read - implement interface
 func (mbs *MultiBlockStore) Read(hash string, round int64) (*block.Block, error) {
+	if mbs.tiering != nil {
+		if len(hash) != 64 {
+			return nil, common.NewError("multi_store_read", "invalid block hash length given")
+		}
+		return mbs.tiering.read(hash, round)
+	}
 	var b *block.Block
 	var err error
 	for _, bs := range mbs.BlockStores {
@@ -47,6 +57,9 @@ func (mbs *MultiBlockStore) Read(hash string, round int64) (*block.Block, error)
 
 //ReadWithBlockSummary - implement interface
 func (mbs *MultiBlockStore) ReadWithBlockSummary(bs *block.BlockSummary) (*block.Block, error) {
+	if mbs.tiering != nil {
+		return mbs.tiering.read(bs.Hash, bs.Round)
+	}
 	var b *block.Block
 	var err error
 	for _, bstore := range mbs.BlockStores {
@@ -81,13 +94,22 @@ func (mbs *MultiBlockStore) DeleteBlock(b *block.Block) error {
 }
 
 func (mbs *MultiBlockStore) UploadToCloud(hash string, round int64) error {
+	if mbs.tiering != nil {
+		return mbs.tiering.moveToCold(hash, round)
+	}
 	return common.NewError("interface_not_implemented", "MultiBlockStore cannote provide this interface")
 }
 
 func (mbs *MultiBlockStore) DownloadFromCloud(hash string, round int64) error {
+	if mbs.tiering != nil {
+		return mbs.tiering.hot.DownloadFromCloud(hash, round)
+	}
 	return common.NewError("interface_not_implemented", "MultiBlockStore cannote provide this interface")
 }
 
 func (mbs *MultiBlockStore) CloudObjectExists(hash string) bool {
+	if mbs.tiering != nil {
+		return mbs.tiering.hot.CloudObjectExists(hash)
+	}
 	return false
 }
@@ -259,7 +259,7 @@ func TestNewMultiBlockStore(t *testing.T) {
 				},
 			},
 			want: &MultiBlockStore{
-				[]BlockStore{
+				BlockStores: []BlockStore{
 					bs,
 					bs,
 				},
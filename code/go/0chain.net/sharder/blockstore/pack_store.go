@@ -0,0 +1,688 @@
+package blockstore
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	. "0chain.net/core/logging"
+)
+
+const (
+	packFilePrefix = "pack-"
+	packFileExt    = ".dat"
+	packIndexFile  = "index.log"
+
+	// DefaultMaxPackSize is the size after which the active pack file is
+	// rotated.
+	DefaultMaxPackSize int64 = 1 << 30
+	// DefaultCompactionThreshold is the ratio of dead bytes in a pack file
+	// above which the pack is rewritten by the compaction.
+	DefaultCompactionThreshold = 0.5
+
+	// record header: payload length (uint32) + payload crc32 (uint32)
+	packRecordHeaderSize = 8
+)
+
+const (
+	indexOpPut       byte = 1
+	indexOpTombstone byte = 2
+)
+
+// ErrPackRecordCorrupted is returned when a pack record doesn't match its
+// checksum.
+var ErrPackRecordCorrupted = errors.New("pack record corrupted")
+
+type (
+	// packLocation - position of a block record in a pack file.
+	packLocation struct {
+		pack   uint32
+		offset int64
+		size   uint32 // record size, including header
+	}
+
+	// packFileStats - total and live bytes of a pack file.
+	packFileStats struct {
+		size int64
+		live int64
+	}
+
+	// PackBlockStore - a block store that appends msgpack encoded blocks
+	// to large rotating pack files instead of keeping one file per block.
+	// The location of every block is kept in an append-only index log
+	// keyed by hash and round, deletes are recorded as tombstones and the
+	// space is reclaimed by the background compaction.
+	PackBlockStore struct {
+		RootDirectory         string
+		MaxPackSize           int64
+		CompactionThreshold   float64
+		blockMetadataProvider datastore.EntityMetadata
+
+		mutex      sync.RWMutex
+		index      map[string]map[int64]packLocation
+		packs      map[uint32]*packFileStats
+		readers    map[uint32]*os.File
+		indexLog   *os.File
+		active     *os.File
+		activeID   uint32
+		activeSize int64
+
+		compactMutex sync.Mutex
+	}
+)
+
+var (
+	// Make sure PackBlockStore implements BlockStore.
+	_ BlockStore = (*PackBlockStore)(nil)
+)
+
+// NewPackBlockStore - open (or create) a pack block store in the given
+// directory.
+func NewPackBlockStore(rootDir string, maxPackSize int64, compactionThreshold float64) (*PackBlockStore, error) {
+	if maxPackSize <= 0 {
+		maxPackSize = DefaultMaxPackSize
+	}
+	if compactionThreshold <= 0 || compactionThreshold > 1 {
+		compactionThreshold = DefaultCompactionThreshold
+	}
+	if err := os.MkdirAll(rootDir, 0755); err != nil {
+		return nil, err
+	}
+	pbs := &PackBlockStore{
+		RootDirectory:         rootDir,
+		MaxPackSize:           maxPackSize,
+		CompactionThreshold:   compactionThreshold,
+		blockMetadataProvider: datastore.GetEntityMetadata("block"),
+		index:                 make(map[string]map[int64]packLocation),
+		packs:                 make(map[uint32]*packFileStats),
+		readers:               make(map[uint32]*os.File),
+	}
+	if err := pbs.open(); err != nil {
+		pbs.Close()
+		return nil, err
+	}
+	return pbs, nil
+}
+
+func (pbs *PackBlockStore) packFileName(id uint32) string {
+	return filepath.Join(pbs.RootDirectory, fmt.Sprintf("%s%08d%s", packFilePrefix, id, packFileExt))
+}
+
+func (pbs *PackBlockStore) indexFileName() string {
+	return filepath.Join(pbs.RootDirectory, packIndexFile)
+}
+
+// open - load existing pack files and replay the index log. A new pack file
+// is always started for writing so that a partially written tail of the
+// previous active pack is never appended to. The empty packs left by the
+// previous runs are removed, the last one is reused as the active pack.
+func (pbs *PackBlockStore) open() error {
+	var maxID uint32
+	entries, err := os.ReadDir(pbs.RootDirectory)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, packFilePrefix) || !strings.HasSuffix(name, packFileExt) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, packFilePrefix), packFileExt), 10, 32)
+		if err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(pbs.packFileName(uint32(id)))
+		if err != nil {
+			return err
+		}
+		pbs.readers[uint32(id)] = f
+		pbs.packs[uint32(id)] = &packFileStats{size: info.Size()}
+		if uint32(id) > maxID {
+			maxID = uint32(id)
+		}
+	}
+
+	if err := pbs.replayIndex(); err != nil {
+		return err
+	}
+
+	pbs.indexLog, err = os.OpenFile(pbs.indexFileName(), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	next := maxID + 1
+	for id, ps := range pbs.packs {
+		if ps.size > 0 {
+			continue
+		}
+		if f, ok := pbs.readers[id]; ok {
+			f.Close()
+			delete(pbs.readers, id)
+		}
+		delete(pbs.packs, id)
+		if id == maxID {
+			next = id
+			continue
+		}
+		if err := os.Remove(pbs.packFileName(id)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return pbs.rotate(next)
+}
+
+func (pbs *PackBlockStore) replayIndex() error {
+	f, err := os.OpenFile(pbs.indexFileName(), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var (
+		r     = bufio.NewReaderSize(f, 64*1024)
+		valid int64
+	)
+	for {
+		op, hash, round, loc, n, err := readIndexEntry(r)
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			if err == io.ErrUnexpectedEOF {
+				Logger.Warn("pack store - truncating partially written index entry",
+					zap.Int64("offset", valid))
+				if err := f.Truncate(valid); err != nil {
+					return err
+				}
+				break
+			}
+			return err
+		}
+		valid += n
+		switch op {
+		case indexOpPut:
+			ps, ok := pbs.packs[loc.pack]
+			if !ok || loc.offset+int64(loc.size) > ps.size {
+				// the pack file is gone (removed by the compaction) or
+				// the record has never reached the disk
+				continue
+			}
+			pbs.setLocation(hash, round, loc)
+		case indexOpTombstone:
+			pbs.removeLocation(hash, round)
+		default:
+			return fmt.Errorf("pack store - unknown index operation %d", op)
+		}
+	}
+	return nil
+}
+
+func writeIndexEntry(w io.Writer, op byte, hash string, round int64, loc packLocation) error {
+	if len(hash) > 255 {
+		return encryption.ErrInvalidHash
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, 26+len(hash)))
+	buf.WriteByte(op)
+	_ = binary.Write(buf, binary.LittleEndian, round)
+	_ = binary.Write(buf, binary.LittleEndian, loc.pack)
+	_ = binary.Write(buf, binary.LittleEndian, loc.offset)
+	_ = binary.Write(buf, binary.LittleEndian, loc.size)
+	buf.WriteByte(byte(len(hash)))
+	buf.WriteString(hash)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func readIndexEntry(r io.Reader) (op byte, hash string, round int64, loc packLocation, n int64, err error) {
+	var head [26]byte
+	if _, err = io.ReadFull(r, head[:1]); err != nil {
+		return
+	}
+	if _, err = io.ReadFull(r, head[1:]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return
+	}
+	op = head[0]
+	round = int64(binary.LittleEndian.Uint64(head[1:9]))
+	loc.pack = binary.LittleEndian.Uint32(head[9:13])
+	loc.offset = int64(binary.LittleEndian.Uint64(head[13:21]))
+	loc.size = binary.LittleEndian.Uint32(head[21:25])
+	hb := make([]byte, head[25])
+	if _, err = io.ReadFull(r, hb); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return
+	}
+	return op, string(hb), round, loc, int64(len(head) + len(hb)), nil
+}
+
+// setLocation - must be called with the mutex locked.
+func (pbs *PackBlockStore) setLocation(hash string, round int64, loc packLocation) {
+	rounds, ok := pbs.index[hash]
+	if !ok {
+		rounds = make(map[int64]packLocation, 1)
+		pbs.index[hash] = rounds
+	}
+	if old, ok := rounds[round]; ok {
+		pbs.markDead(old)
+	}
+	rounds[round] = loc
+	if ps, ok := pbs.packs[loc.pack]; ok {
+		ps.live += int64(loc.size)
+	}
+}
+
+// removeLocation - must be called with the mutex locked.
+func (pbs *PackBlockStore) removeLocation(hash string, round int64) bool {
+	rounds, ok := pbs.index[hash]
+	if !ok {
+		return false
+	}
+	loc, ok := rounds[round]
+	if !ok {
+		return false
+	}
+	pbs.markDead(loc)
+	delete(rounds, round)
+	if len(rounds) == 0 {
+		delete(pbs.index, hash)
+	}
+	return true
+}
+
+func (pbs *PackBlockStore) markDead(loc packLocation) {
+	if ps, ok := pbs.packs[loc.pack]; ok {
+		ps.live -= int64(loc.size)
+	}
+}
+
+// rotate - start a new active pack file; must be called with the mutex
+// locked.
+func (pbs *PackBlockStore) rotate(id uint32) error {
+	if pbs.active != nil {
+		if err := pbs.active.Sync(); err != nil {
+			return err
+		}
+		if err := pbs.active.Close(); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(pbs.packFileName(id), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	r, err := os.Open(pbs.packFileName(id))
+	if err != nil {
+		f.Close()
+		return err
+	}
+	pbs.active = f
+	pbs.activeID = id
+	pbs.activeSize = 0
+	pbs.readers[id] = r
+	pbs.packs[id] = &packFileStats{}
+	return nil
+}
+
+// appendRecord - append an encoded record to the active pack and log its
+// location; must be called with the mutex locked.
+func (pbs *PackBlockStore) appendRecord(hash string, round int64, payload []byte) error {
+	if pbs.activeSize > 0 && pbs.activeSize+int64(len(payload)+packRecordHeaderSize) > pbs.MaxPackSize {
+		if err := pbs.rotate(pbs.activeID + 1); err != nil {
+			return err
+		}
+	}
+
+	var head [packRecordHeaderSize]byte
+	binary.LittleEndian.PutUint32(head[:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(head[4:], crc32.ChecksumIEEE(payload))
+	if _, err := pbs.active.Write(append(head[:], payload...)); err != nil {
+		return err
+	}
+
+	loc := packLocation{
+		pack:   pbs.activeID,
+		offset: pbs.activeSize,
+		size:   uint32(len(payload) + packRecordHeaderSize),
+	}
+	pbs.activeSize += int64(loc.size)
+	if err := writeIndexEntry(pbs.indexLog, indexOpPut, hash, round, loc); err != nil {
+		return err
+	}
+	pbs.packs[loc.pack].size += int64(loc.size)
+	pbs.setLocation(hash, round, loc)
+	return nil
+}
+
+// readRecord - read the record at the given location; the read lock is held
+// for the whole read so that the compaction can't close the pack file under it.
+func (pbs *PackBlockStore) readRecord(loc packLocation) ([]byte, error) {
+	pbs.mutex.RLock()
+	defer pbs.mutex.RUnlock()
+	f, ok := pbs.readers[loc.pack]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	buf := make([]byte, loc.size)
+	if _, err := f.ReadAt(buf, loc.offset); err != nil {
+		return nil, err
+	}
+	size := binary.LittleEndian.Uint32(buf[:4])
+	if int(size) != len(buf)-packRecordHeaderSize {
+		return nil, ErrPackRecordCorrupted
+	}
+	payload := buf[packRecordHeaderSize:]
+	if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(buf[4:8]) {
+		return nil, ErrPackRecordCorrupted
+	}
+	return payload, nil
+}
+
+func (pbs *PackBlockStore) lookup(hash string, round int64) (packLocation, bool) {
+	pbs.mutex.RLock()
+	defer pbs.mutex.RUnlock()
+	loc, ok := pbs.index[hash][round]
+	return loc, ok
+}
+
+func (pbs *PackBlockStore) write(hash string, round int64, b *block.Block) error {
+	payload := datastore.ToMsgpack(b).Bytes()
+	pbs.mutex.Lock()
+	defer pbs.mutex.Unlock()
+	return pbs.appendRecord(hash, round, payload)
+}
+
+// Write - append the block to the active pack file
+func (pbs *PackBlockStore) Write(b *block.Block) error {
+	if err := pbs.write(b.Hash, b.Round, b); err != nil {
+		return err
+	}
+	if b.MagicBlock != nil && b.Round == b.MagicBlock.StartingRound {
+		Logger.Debug("save magic block",
+			zap.Int64("round", b.Round),
+			zap.String("mb hash", b.MagicBlock.Hash),
+		)
+		return pbs.write(b.MagicBlock.Hash, b.MagicBlock.StartingRound, b)
+	}
+	return nil
+}
+
+// Read - read the block with the given hash and round
+func (pbs *PackBlockStore) Read(hash string, round int64) (*block.Block, error) {
+	if len(hash) != 64 {
+		return nil, common.NewError("pack_store_read", "invalid block hash length given")
+	}
+	return pbs.read(hash, round)
+}
+
+// ReadWithBlockSummary - read the block given the block summary
+func (pbs *PackBlockStore) ReadWithBlockSummary(bs *block.BlockSummary) (*block.Block, error) {
+	return pbs.read(bs.Hash, bs.Round)
+}
+
+func (pbs *PackBlockStore) read(hash string, round int64) (*block.Block, error) {
+	if len(hash) != 64 {
+		return nil, encryption.ErrInvalidHash
+	}
+	loc, ok := pbs.lookup(hash, round)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	payload, err := pbs.readRecord(loc)
+	if err != nil {
+		return nil, err
+	}
+	b := pbs.blockMetadataProvider.Instance().(*block.Block)
+	if err := datastore.FromMsgpack(payload, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Delete - write tombstones for all the blocks stored with the given hash
+func (pbs *PackBlockStore) Delete(hash string) error {
+	if len(hash) != 64 {
+		return encryption.ErrInvalidHash
+	}
+	pbs.mutex.Lock()
+	defer pbs.mutex.Unlock()
+	rounds, ok := pbs.index[hash]
+	if !ok {
+		return os.ErrNotExist
+	}
+	for round := range rounds {
+		if err := pbs.tombstone(hash, round); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteBlock - write a tombstone for the given block
+func (pbs *PackBlockStore) DeleteBlock(b *block.Block) error {
+	pbs.mutex.Lock()
+	defer pbs.mutex.Unlock()
+	if _, ok := pbs.index[b.Hash][b.Round]; !ok {
+		return os.ErrNotExist
+	}
+	return pbs.tombstone(b.Hash, b.Round)
+}
+
+// tombstone - must be called with the mutex locked.
+func (pbs *PackBlockStore) tombstone(hash string, round int64) error {
+	if err := writeIndexEntry(pbs.indexLog, indexOpTombstone, hash, round, packLocation{}); err != nil {
+		return err
+	}
+	pbs.removeLocation(hash, round)
+	return nil
+}
+
+// Compact - rewrite the live records of the pack files that have more dead
+// bytes than the compaction threshold into the active pack, remove the old
+// and the empty pack files and rewrite the index log.
+func (pbs *PackBlockStore) Compact(ctx context.Context) error {
+	pbs.compactMutex.Lock()
+	defer pbs.compactMutex.Unlock()
+
+	var candidates []uint32
+	pbs.mutex.RLock()
+	for id, ps := range pbs.packs {
+		if id == pbs.activeID {
+			continue
+		}
+		if ps.size == 0 || float64(ps.size-ps.live)/float64(ps.size) >= pbs.CompactionThreshold {
+			candidates = append(candidates, id)
+		}
+	}
+	pbs.mutex.RUnlock()
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i] < candidates[j] })
+
+	for _, id := range candidates {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err := pbs.compactPack(id); err != nil {
+			return err
+		}
+	}
+	return pbs.rewriteIndex()
+}
+
+type packRecordKey struct {
+	hash  string
+	round int64
+	loc   packLocation
+}
+
+func (pbs *PackBlockStore) compactPack(id uint32) error {
+	var live []packRecordKey
+	pbs.mutex.RLock()
+	for hash, rounds := range pbs.index {
+		for round, loc := range rounds {
+			if loc.pack == id {
+				live = append(live, packRecordKey{hash: hash, round: round, loc: loc})
+			}
+		}
+	}
+	pbs.mutex.RUnlock()
+
+	for _, rk := range live {
+		payload, err := pbs.readRecord(rk.loc)
+		if err != nil {
+			Logger.Error("pack store compaction - can't read record",
+				zap.String("hash", rk.hash), zap.Int64("round", rk.round), zap.Error(err))
+			continue
+		}
+		pbs.mutex.Lock()
+		if cur, ok := pbs.index[rk.hash][rk.round]; ok && cur == rk.loc {
+			err = pbs.appendRecord(rk.hash, rk.round, payload)
+		}
+		pbs.mutex.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+
+	pbs.mutex.Lock()
+	defer pbs.mutex.Unlock()
+	if err := pbs.active.Sync(); err != nil {
+		return err
+	}
+	if err := pbs.indexLog.Sync(); err != nil {
+		return err
+	}
+	if f, ok := pbs.readers[id]; ok {
+		f.Close()
+		delete(pbs.readers, id)
+	}
+	delete(pbs.packs, id)
+	if err := os.Remove(pbs.packFileName(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	Logger.Info("pack store compaction - pack removed", zap.Uint32("pack", id),
+		zap.Int("moved_records", len(live)))
+	return nil
+}
+
+// rewriteIndex - replace the index log with the live entries only.
+func (pbs *PackBlockStore) rewriteIndex() error {
+	pbs.mutex.Lock()
+	defer pbs.mutex.Unlock()
+
+	tmpName := pbs.indexFileName() + ".tmp"
+	f, err := os.OpenFile(tmpName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriterSize(f, 64*1024)
+	for hash, rounds := range pbs.index {
+		for round, loc := range rounds {
+			if err := writeIndexEntry(w, indexOpPut, hash, round, loc); err != nil {
+				f.Close()
+				return err
+			}
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpName, pbs.indexFileName()); err != nil {
+		return err
+	}
+	if err := pbs.indexLog.Close(); err != nil {
+		return err
+	}
+	pbs.indexLog, err = os.OpenFile(pbs.indexFileName(), os.O_WRONLY|os.O_APPEND, 0644)
+	return err
+}
+
+// CompactionWorker - periodically compact the pack files.
+func (pbs *PackBlockStore) CompactionWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := pbs.Compact(ctx); err != nil {
+				Logger.Error("pack store compaction failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Close - flush and close all the files of the store.
+func (pbs *PackBlockStore) Close() error {
+	pbs.mutex.Lock()
+	defer pbs.mutex.Unlock()
+	var err error
+	if pbs.active != nil {
+		if err = pbs.active.Sync(); err == nil {
+			err = pbs.active.Close()
+		}
+		pbs.active = nil
+	}
+	if pbs.indexLog != nil {
+		if cerr := pbs.indexLog.Close(); err == nil {
+			err = cerr
+		}
+		pbs.indexLog = nil
+	}
+	for id, f := range pbs.readers {
+		f.Close()
+		delete(pbs.readers, id)
+	}
+	return err
+}
+
+func (pbs *PackBlockStore) UploadToCloud(hash string, round int64) error {
+	return common.NewError("interface_not_implemented", "PackBlockStore cannote provide this interface")
+}
+
+func (pbs *PackBlockStore) DownloadFromCloud(hash string, round int64) error {
+	return common.NewError("interface_not_implemented", "PackBlockStore cannote provide this interface")
+}
+
+func (pbs *PackBlockStore) CloudObjectExists(hash string) bool {
+	return false
+}
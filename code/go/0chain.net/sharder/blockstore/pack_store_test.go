@@ -0,0 +1,219 @@
+package blockstore
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+)
+
+func makeTestPackBlockStore(t *testing.T, maxPackSize int64) (*PackBlockStore, func()) {
+	tmpDir, err := os.MkdirTemp("", "pack_store")
+	require.NoError(t, err)
+
+	pbs, err := NewPackBlockStore(tmpDir, maxPackSize, 0.5)
+	require.NoError(t, err)
+
+	cleanUp := func() {
+		_ = pbs.Close()
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}
+
+	return pbs, cleanUp
+}
+
+func makeTestPackBlock(round int64) *block.Block {
+	return &block.Block{
+		HashIDField: datastore.HashIDField{
+			Hash: encryption.Hash("block " + strconv.FormatInt(round, 10)),
+		},
+		UnverifiedBlockBody: block.UnverifiedBlockBody{
+			Round: round,
+		},
+	}
+}
+
+func TestPackBlockStore_WriteRead(t *testing.T) {
+	t.Parallel()
+
+	pbs, cleanUp := makeTestPackBlockStore(t, 0)
+	defer cleanUp()
+
+	b := makeTestPackBlock(1)
+	require.NoError(t, pbs.Write(b))
+
+	got, err := pbs.Read(b.Hash, b.Round)
+	require.NoError(t, err)
+	assert.Equal(t, b.Hash, got.Hash)
+	assert.Equal(t, b.Round, got.Round)
+
+	got, err = pbs.ReadWithBlockSummary(&block.BlockSummary{
+		Hash:  b.Hash,
+		Round: b.Round,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, b.Hash, got.Hash)
+
+	_, err = pbs.Read(b.Hash, b.Round+1)
+	assert.Error(t, err)
+
+	_, err = pbs.Read(b.Hash[:62], b.Round)
+	assert.Error(t, err)
+}
+
+func TestPackBlockStore_Delete(t *testing.T) {
+	t.Parallel()
+
+	pbs, cleanUp := makeTestPackBlockStore(t, 0)
+	defer cleanUp()
+
+	b1, b2 := makeTestPackBlock(1), makeTestPackBlock(2)
+	require.NoError(t, pbs.Write(b1))
+	require.NoError(t, pbs.Write(b2))
+
+	require.NoError(t, pbs.Delete(b1.Hash))
+	_, err := pbs.Read(b1.Hash, b1.Round)
+	assert.Error(t, err)
+	assert.Error(t, pbs.Delete(b1.Hash))
+
+	require.NoError(t, pbs.DeleteBlock(b2))
+	_, err = pbs.Read(b2.Hash, b2.Round)
+	assert.Error(t, err)
+	assert.Error(t, pbs.DeleteBlock(b2))
+}
+
+func TestPackBlockStore_Reopen(t *testing.T) {
+	t.Parallel()
+
+	pbs, cleanUp := makeTestPackBlockStore(t, 0)
+	defer cleanUp()
+
+	b1, b2 := makeTestPackBlock(1), makeTestPackBlock(2)
+	require.NoError(t, pbs.Write(b1))
+	require.NoError(t, pbs.Write(b2))
+	require.NoError(t, pbs.DeleteBlock(b1))
+	require.NoError(t, pbs.Close())
+
+	reopened, err := NewPackBlockStore(pbs.RootDirectory, 0, 0.5)
+	require.NoError(t, err)
+	defer reopened.Close()
+
+	_, err = reopened.Read(b1.Hash, b1.Round)
+	assert.Error(t, err)
+
+	got, err := reopened.Read(b2.Hash, b2.Round)
+	require.NoError(t, err)
+	assert.Equal(t, b2.Hash, got.Hash)
+}
+
+func TestPackBlockStore_ReopenEmptyPacks(t *testing.T) {
+	t.Parallel()
+
+	pbs, cleanUp := makeTestPackBlockStore(t, 0)
+	defer cleanUp()
+
+	b := makeTestPackBlock(1)
+	require.NoError(t, pbs.Write(b))
+
+	// the restarts without writes don't leave empty packs behind
+	dir := pbs.RootDirectory
+	for i := 0; i < 3; i++ {
+		require.NoError(t, pbs.Close())
+		var err error
+		pbs, err = NewPackBlockStore(dir, 0, 0.5)
+		require.NoError(t, err)
+	}
+	defer pbs.Close()
+	assert.Len(t, pbs.packs, 2)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3) // two packs and the index log
+
+	got, err := pbs.Read(b.Hash, b.Round)
+	require.NoError(t, err)
+	assert.Equal(t, b.Hash, got.Hash)
+}
+
+func TestPackBlockStore_Compact(t *testing.T) {
+	t.Parallel()
+
+	// small packs to force the rotation on every block
+	pbs, cleanUp := makeTestPackBlockStore(t, 1)
+	defer cleanUp()
+
+	var blocks []*block.Block
+	for i := int64(1); i <= 4; i++ {
+		b := makeTestPackBlock(i)
+		require.NoError(t, pbs.Write(b))
+		blocks = append(blocks, b)
+	}
+	require.Len(t, pbs.packs, 4)
+
+	require.NoError(t, pbs.DeleteBlock(blocks[0]))
+	require.NoError(t, pbs.DeleteBlock(blocks[1]))
+	require.NoError(t, pbs.Compact(context.Background()))
+
+	// the packs of the deleted blocks are removed, the active one is kept
+	assert.Len(t, pbs.packs, 2)
+	for _, b := range blocks[2:] {
+		got, err := pbs.Read(b.Hash, b.Round)
+		require.NoError(t, err)
+		assert.Equal(t, b.Hash, got.Hash)
+	}
+
+	require.NoError(t, pbs.Close())
+	reopened, err := NewPackBlockStore(pbs.RootDirectory, 1, 0.5)
+	require.NoError(t, err)
+	defer reopened.Close()
+
+	for _, b := range blocks[2:] {
+		got, err := reopened.Read(b.Hash, b.Round)
+		require.NoError(t, err)
+		assert.Equal(t, b.Hash, got.Hash)
+	}
+	_, err = reopened.Read(blocks[0].Hash, blocks[0].Round)
+	assert.Error(t, err)
+}
+
+func TestPackBlockStore_CompactConcurrentReads(t *testing.T) {
+	t.Parallel()
+
+	pbs, cleanUp := makeTestPackBlockStore(t, 1)
+	defer cleanUp()
+
+	var blocks []*block.Block
+	for i := int64(1); i <= 20; i++ {
+		b := makeTestPackBlock(i)
+		require.NoError(t, pbs.Write(b))
+		blocks = append(blocks, b)
+	}
+	for _, b := range blocks[:10] {
+		require.NoError(t, pbs.DeleteBlock(b))
+	}
+
+	// the live blocks stay readable while their packs are compacted
+	var wg sync.WaitGroup
+	for _, b := range blocks[10:] {
+		wg.Add(1)
+		go func(b *block.Block) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				got, err := pbs.Read(b.Hash, b.Round)
+				if assert.NoError(t, err) {
+					assert.Equal(t, b.Hash, got.Hash)
+				}
+			}
+		}(b)
+	}
+	require.NoError(t, pbs.Compact(context.Background()))
+	wg.Wait()
+}
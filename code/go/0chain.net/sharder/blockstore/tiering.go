@@ -0,0 +1,409 @@
+package blockstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/minio/minio-go"
+	"go.uber.org/zap"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/common"
+	. "0chain.net/core/logging"
+)
+
+const (
+	// HotTier - blocks stored on the local file system.
+	HotTier = "hot"
+	// ColdTier - blocks moved to the cloud (minio) storage.
+	ColdTier = "cold"
+	// CacheTier - cold blocks rehydrated into the local cache.
+	CacheTier = "cache"
+
+	// coldFrontierFile - the file of the hot tier directory keeping the last
+	// round migrated to the cold tier.
+	coldFrontierFile = "cold_frontier"
+)
+
+type (
+	// TieringPolicy - placement policy of the tiered MultiBlockStore.
+	TieringPolicy struct {
+		// ColdAfterRounds - finalized blocks older than this number of rounds
+		// are migrated from the hot to the cold tier.
+		ColdAfterRounds int64 `json:"cold_after_rounds"`
+		// CacheSize - max number of cold blocks kept in the local cache.
+		CacheSize int `json:"cache_size"`
+		// MigrationBatchSize - max number of blocks migrated in one run.
+		MigrationBatchSize int `json:"migration_batch_size"`
+	}
+
+	// TierStats - occupancy and migration statistics of a tier. Cold tier
+	// occupancy counts the blocks moved since the sharder start only.
+	TierStats struct {
+		Tier        string    `json:"tier"`
+		Blocks      int64     `json:"blocks"`
+		Bytes       int64     `json:"bytes"`
+		Reads       int64     `json:"reads"`
+		Moved       int64     `json:"moved"`
+		Failures    int64     `json:"failures"`
+		LastRound   int64     `json:"last_round"`
+		LastMovedAt time.Time `json:"last_moved_at"`
+	}
+
+	// TieringStats - statistics of all the tiers of a tiered MultiBlockStore.
+	TieringStats struct {
+		Policy TieringPolicy `json:"policy"`
+		Tiers  []TierStats   `json:"tiers"`
+	}
+
+	// BlockHashResolver - returns hash of the finalized block of given round.
+	BlockHashResolver func(ctx context.Context, round int64) (string, error)
+
+	tiering struct {
+		policy TieringPolicy
+		hot    *FSBlockStore
+		cache  *FSBlockStore
+		cached *lru.Cache
+
+		mutex             sync.Mutex
+		lastMigratedRound int64
+		stats             map[string]*TierStats
+	}
+
+	cacheKey struct {
+		hash  string
+		round int64
+	}
+)
+
+// NewTieredBlockStore - create a multi block store that keeps recent blocks
+// in the hot file system store and migrates old ones to the cold (minio)
+// tier of the store. Cold blocks are rehydrated on reads into the bounded
+// cache stored under the cacheDir.
+func NewTieredBlockStore(hot *FSBlockStore, cacheDir string, policy TieringPolicy) (*MultiBlockStore, error) {
+	if hot.Minio == nil {
+		return nil, common.NewError("tiered_block_store", "cold tier requires minio client")
+	}
+	if policy.ColdAfterRounds <= 0 {
+		return nil, common.NewError("tiered_block_store", "invalid cold_after_rounds: "+
+			strconv.FormatInt(policy.ColdAfterRounds, 10))
+	}
+	if policy.CacheSize <= 0 {
+		policy.CacheSize = 1
+	}
+	if policy.MigrationBatchSize <= 0 {
+		policy.MigrationBatchSize = 1000
+	}
+	t := &tiering{
+		policy: policy,
+		hot:    hot,
+		cache:  NewFSBlockStore(cacheDir, hot.Minio),
+		stats: map[string]*TierStats{
+			HotTier:   {Tier: HotTier},
+			ColdTier:  {Tier: ColdTier},
+			CacheTier: {Tier: CacheTier},
+		},
+	}
+	var err error
+	t.cached, err = lru.NewWithEvict(policy.CacheSize, t.onCacheEvicted)
+	if err != nil {
+		return nil, err
+	}
+	// the cache doesn't survive restarts
+	if err := os.RemoveAll(cacheDir); err != nil {
+		return nil, err
+	}
+	go t.scanHot()
+	return &MultiBlockStore{BlockStores: []BlockStore{hot}, tiering: t}, nil
+}
+
+func (t *tiering) onCacheEvicted(key, value interface{}) {
+	ck := key.(cacheKey)
+	if err := os.Remove(t.cache.getFileName(ck.hash, ck.round)); err != nil && !os.IsNotExist(err) {
+		Logger.Error("tiering - can't remove evicted block", zap.String("hash", ck.hash),
+			zap.Int64("round", ck.round), zap.Error(err))
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	cs := t.stats[CacheTier]
+	cs.Blocks--
+	cs.Bytes -= value.(int64)
+}
+
+func (t *tiering) written(b *block.Block) {
+	var size int64
+	if fi, err := os.Stat(t.hot.getFileName(b.Hash, b.Round)); err == nil {
+		size = fi.Size()
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	hs := t.stats[HotTier]
+	hs.Blocks++
+	hs.Bytes += size
+	hs.LastRound = b.Round
+}
+
+// scanHot - count blocks stored in the hot tier before the start.
+func (t *tiering) scanHot() {
+	var blocks, size int64
+	err := filepath.Walk(t.hot.RootDirectory, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !fi.IsDir() && strings.HasSuffix(path, fileExt) {
+			blocks++
+			size += fi.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		Logger.Error("tiering - can't scan hot tier", zap.Error(err))
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	hs := t.stats[HotTier]
+	hs.Blocks += blocks
+	hs.Bytes += size
+}
+
+// read - read the block from the hot tier, then from the cache and
+// finally rehydrate it from the cold tier into the cache.
+func (t *tiering) read(hash string, round int64) (*block.Block, error) {
+	b, err := t.hot.readLocal(hash, round)
+	if err == nil {
+		t.count(HotTier)
+		return b, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	ck := cacheKey{hash: hash, round: round}
+	if _, ok := t.cached.Get(ck); ok {
+		if b, err = t.cache.readLocal(hash, round); err == nil {
+			t.count(CacheTier)
+			return b, nil
+		}
+		t.cached.Remove(ck)
+	}
+
+	if err := t.cache.DownloadFromCloud(hash, round); err != nil {
+		return nil, err
+	}
+	t.count(ColdTier)
+	var size int64
+	if fi, err := os.Stat(t.cache.getFileName(hash, round)); err == nil {
+		size = fi.Size()
+	}
+	t.mutex.Lock()
+	cs := t.stats[CacheTier]
+	cs.Blocks++
+	cs.Bytes += size
+	cs.Moved++
+	cs.LastRound = round
+	cs.LastMovedAt = time.Now()
+	t.mutex.Unlock()
+	t.cached.Add(ck, size)
+
+	return t.cache.readLocal(hash, round)
+}
+
+func (t *tiering) count(tier string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.stats[tier].Reads++
+}
+
+// migrate - move the finalized blocks older than the policy allows from the
+// hot to the cold tier, at most one migration batch per call. The migration
+// stops at the first round it can't get the block hash of, the round is
+// retried on the next call.
+func (t *tiering) migrate(ctx context.Context, lfbRound int64, hashOf BlockHashResolver) (int, error) {
+	to := lfbRound - t.policy.ColdAfterRounds
+	if to <= 0 {
+		return 0, nil
+	}
+
+	t.mutex.Lock()
+	from := t.lastMigratedRound
+	t.mutex.Unlock()
+	if from == 0 {
+		var ok bool
+		if from, ok = t.loadColdFrontier(); !ok {
+			from = t.findColdFrontier(ctx, to, hashOf)
+		}
+	}
+	if to-from > int64(t.policy.MigrationBatchSize) {
+		to = from + int64(t.policy.MigrationBatchSize)
+	}
+
+	var (
+		moved int
+		start = from
+	)
+	defer func() {
+		t.mutex.Lock()
+		t.lastMigratedRound = from
+		t.mutex.Unlock()
+		if from != start {
+			t.saveColdFrontier(from)
+		}
+	}()
+	for r := from + 1; r <= to; r++ {
+		select {
+		case <-ctx.Done():
+			return moved, ctx.Err()
+		default:
+		}
+		hash, err := hashOf(ctx, r)
+		if err != nil {
+			t.failed(ColdTier)
+			return moved, common.NewError("tiering",
+				"can't get block hash of round "+strconv.FormatInt(r, 10)+": "+err.Error())
+		}
+		if err := t.moveToCold(hash, r); err != nil {
+			if !os.IsNotExist(err) {
+				t.failed(ColdTier)
+				return moved, err
+			}
+			// not in the hot tier, nothing to move
+			Logger.Debug("tiering - block is not in hot tier", zap.Int64("round", r),
+				zap.String("hash", hash))
+		} else {
+			moved++
+		}
+		from = r
+	}
+	return moved, nil
+}
+
+// findColdFrontier - binary search the rounds up to the given one for the
+// newest block already stored in the cold tier, the blocks are migrated in
+// the rounds order. Used when the frontier hasn't been saved locally yet.
+func (t *tiering) findColdFrontier(ctx context.Context, round int64, hashOf BlockHashResolver) int64 {
+	var lo, hi = int64(0), round
+	for lo < hi {
+		if ctx.Err() != nil {
+			return lo
+		}
+		mid := lo + (hi-lo+1)/2
+		if hash, err := hashOf(ctx, mid); err == nil && t.hot.CloudObjectExists(hash) {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo
+}
+
+func (t *tiering) coldFrontierFileName() string {
+	return filepath.Join(t.hot.RootDirectory, coldFrontierFile)
+}
+
+// loadColdFrontier - returns the last migrated round saved locally.
+func (t *tiering) loadColdFrontier() (int64, bool) {
+	data, err := os.ReadFile(t.coldFrontierFileName())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			Logger.Error("tiering - can't read cold frontier", zap.Error(err))
+		}
+		return 0, false
+	}
+	round, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		Logger.Error("tiering - invalid cold frontier", zap.Error(err))
+		return 0, false
+	}
+	return round, true
+}
+
+// saveColdFrontier - save the last migrated round locally, so that the
+// restarted sharder doesn't search the cold tier for it.
+func (t *tiering) saveColdFrontier(round int64) {
+	tmpName := t.coldFrontierFileName() + ".tmp"
+	err := os.MkdirAll(t.hot.RootDirectory, 0755)
+	if err == nil {
+		err = os.WriteFile(tmpName, []byte(strconv.FormatInt(round, 10)), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmpName, t.coldFrontierFileName())
+	}
+	if err != nil {
+		Logger.Error("tiering - can't save cold frontier", zap.Int64("round", round),
+			zap.Error(err))
+	}
+}
+
+func (t *tiering) moveToCold(hash string, round int64) error {
+	fileName := t.hot.getFileName(hash, round)
+	fi, err := os.Stat(fileName)
+	if err != nil {
+		return err
+	}
+	if _, err := t.hot.Minio.FPutObject(t.hot.Minio.BucketName(), hash, fileName, minio.PutObjectOptions{}); err != nil {
+		return err
+	}
+	if err := os.Remove(fileName); err != nil {
+		return err
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	hs, cs := t.stats[HotTier], t.stats[ColdTier]
+	hs.Blocks--
+	hs.Bytes -= fi.Size()
+	cs.Blocks++
+	cs.Bytes += fi.Size()
+	cs.Moved++
+	cs.LastRound = round
+	cs.LastMovedAt = time.Now()
+	return nil
+}
+
+func (t *tiering) failed(tier string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.stats[tier].Failures++
+}
+
+func (t *tiering) getStats() *TieringStats {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	ts := &TieringStats{Policy: t.policy}
+	for _, tier := range []string{HotTier, ColdTier, CacheTier} {
+		ts.Tiers = append(ts.Tiers, *t.stats[tier])
+	}
+	return ts
+}
+
+// IsTiered - returns true if the store moves old blocks to the cold tier.
+func (mbs *MultiBlockStore) IsTiered() bool {
+	return mbs.tiering != nil
+}
+
+// Migrate - move the blocks that are older than the tiering policy allows
+// to the cold tier; lfbRound is the latest finalized round.
+func (mbs *MultiBlockStore) Migrate(ctx context.Context, lfbRound int64, hashOf BlockHashResolver) (int, error) {
+	if mbs.tiering == nil {
+		return 0, common.NewError("interface_not_implemented", "MultiBlockStore is not tiered")
+	}
+	return mbs.tiering.migrate(ctx, lfbRound, hashOf)
+}
+
+// GetTieringStats - returns per tier statistics, nil if the store is not
+// tiered.
+func (mbs *MultiBlockStore) GetTieringStats() *TieringStats {
+	if mbs.tiering == nil {
+		return nil
+	}
+	return mbs.tiering.getStats()
+}
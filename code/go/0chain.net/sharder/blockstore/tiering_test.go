@@ -0,0 +1,261 @@
+package blockstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/minio/minio-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"0chain.net/chaincore/block"
+)
+
+// localMinio - a minio stand-in keeping objects in a local directory.
+type localMinio struct {
+	dir string
+}
+
+var _ MinioClient = (*localMinio)(nil)
+
+func copyFile(dst, src string) (int64, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return 0, err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return 0, err
+	}
+	defer out.Close()
+	return io.Copy(out, in)
+}
+
+func (lm *localMinio) FPutObject(bucketName, hash, filePath string, _ minio.PutObjectOptions) (int64, error) {
+	return copyFile(filepath.Join(lm.dir, bucketName, hash), filePath)
+}
+
+func (lm *localMinio) FGetObject(bucketName, objectName, filePath string, _ minio.GetObjectOptions) error {
+	_, err := copyFile(filePath, filepath.Join(lm.dir, bucketName, objectName))
+	return err
+}
+
+func (lm *localMinio) StatObject(bucketName, hash string, _ minio.StatObjectOptions) (minio.ObjectInfo, error) {
+	fi, err := os.Stat(filepath.Join(lm.dir, bucketName, hash))
+	if err != nil {
+		return minio.ObjectInfo{}, err
+	}
+	return minio.ObjectInfo{Key: hash, Size: fi.Size()}, nil
+}
+
+func (lm *localMinio) BucketName() string {
+	return "blocks"
+}
+
+func (lm *localMinio) DeleteLocal() bool {
+	return true
+}
+
+func (lm *localMinio) MakeBucket(bucketName, _ string) error {
+	return os.MkdirAll(filepath.Join(lm.dir, bucketName), 0755)
+}
+
+func (lm *localMinio) BucketExists(bucketName string) (bool, error) {
+	_, err := os.Stat(filepath.Join(lm.dir, bucketName))
+	return err == nil, nil
+}
+
+func makeTestTieredBlockStore(t *testing.T, policy TieringPolicy) (*MultiBlockStore, []*block.Block, func()) {
+	tmpDir, err := os.MkdirTemp("", "tiered_store")
+	require.NoError(t, err)
+
+	hot := NewFSBlockStore(filepath.Join(tmpDir, "blocks"), &localMinio{dir: filepath.Join(tmpDir, "minio")})
+	mbs, err := NewTieredBlockStore(hot, filepath.Join(tmpDir, "cache"), policy)
+	require.NoError(t, err)
+
+	var blocks []*block.Block
+	for i := int64(1); i <= 10; i++ {
+		b := makeTestPackBlock(i)
+		require.NoError(t, mbs.Write(b))
+		blocks = append(blocks, b)
+	}
+
+	cleanUp := func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}
+	return mbs, blocks, cleanUp
+}
+
+func hashResolver(blocks []*block.Block) BlockHashResolver {
+	return func(_ context.Context, round int64) (string, error) {
+		if round < 1 || round > int64(len(blocks)) {
+			return "", errors.New("unknown round " + strconv.FormatInt(round, 10))
+		}
+		return blocks[round-1].Hash, nil
+	}
+}
+
+func getTierStats(t *testing.T, mbs *MultiBlockStore, tier string) TierStats {
+	for _, ts := range mbs.GetTieringStats().Tiers {
+		if ts.Tier == tier {
+			return ts
+		}
+	}
+	t.Fatalf("no stats for tier %s", tier)
+	return TierStats{}
+}
+
+func TestTieredBlockStore_Migrate(t *testing.T) {
+	t.Parallel()
+
+	mbs, blocks, cleanUp := makeTestTieredBlockStore(t, TieringPolicy{
+		ColdAfterRounds:    4,
+		CacheSize:          2,
+		MigrationBatchSize: 3,
+	})
+	defer cleanUp()
+	require.True(t, mbs.IsTiered())
+
+	// rounds 1-3 by the batch size limit
+	moved, err := mbs.Migrate(context.Background(), 10, hashResolver(blocks))
+	require.NoError(t, err)
+	assert.Equal(t, 3, moved)
+
+	// rounds 4-6
+	moved, err = mbs.Migrate(context.Background(), 10, hashResolver(blocks))
+	require.NoError(t, err)
+	assert.Equal(t, 3, moved)
+
+	// nothing left older than 4 rounds
+	moved, err = mbs.Migrate(context.Background(), 10, hashResolver(blocks))
+	require.NoError(t, err)
+	assert.Equal(t, 0, moved)
+
+	hot := mbs.tiering.hot
+	for _, b := range blocks[:6] {
+		assert.False(t, checkFile(hot.getFileName(b.Hash, b.Round)))
+		assert.True(t, mbs.CloudObjectExists(b.Hash))
+	}
+	for _, b := range blocks[6:] {
+		assert.True(t, checkFile(hot.getFileName(b.Hash, b.Round)))
+		assert.False(t, mbs.CloudObjectExists(b.Hash))
+	}
+
+	cs := getTierStats(t, mbs, ColdTier)
+	assert.EqualValues(t, 6, cs.Moved)
+	assert.EqualValues(t, 6, cs.Blocks)
+	assert.EqualValues(t, 6, cs.LastRound)
+}
+
+func TestTieredBlockStore_ReadRehydrates(t *testing.T) {
+	t.Parallel()
+
+	mbs, blocks, cleanUp := makeTestTieredBlockStore(t, TieringPolicy{
+		ColdAfterRounds: 5,
+		CacheSize:       2,
+	})
+	defer cleanUp()
+
+	_, err := mbs.Migrate(context.Background(), 10, hashResolver(blocks))
+	require.NoError(t, err)
+
+	// hot block
+	got, err := mbs.Read(blocks[9].Hash, blocks[9].Round)
+	require.NoError(t, err)
+	assert.Equal(t, blocks[9].Hash, got.Hash)
+
+	// cold blocks are rehydrated into the cache
+	for _, b := range blocks[:3] {
+		got, err := mbs.Read(b.Hash, b.Round)
+		require.NoError(t, err)
+		assert.Equal(t, b.Hash, got.Hash)
+	}
+
+	// cached block is read without the cold tier
+	got, err = mbs.ReadWithBlockSummary(&block.BlockSummary{Hash: blocks[2].Hash, Round: blocks[2].Round})
+	require.NoError(t, err)
+	assert.Equal(t, blocks[2].Hash, got.Hash)
+
+	cache := mbs.tiering.cache
+	assert.False(t, checkFile(cache.getFileName(blocks[0].Hash, blocks[0].Round)), "evicted")
+	assert.True(t, checkFile(cache.getFileName(blocks[1].Hash, blocks[1].Round)))
+	assert.True(t, checkFile(cache.getFileName(blocks[2].Hash, blocks[2].Round)))
+
+	assert.EqualValues(t, 1, getTierStats(t, mbs, HotTier).Reads)
+	assert.EqualValues(t, 3, getTierStats(t, mbs, ColdTier).Reads)
+	cs := getTierStats(t, mbs, CacheTier)
+	assert.EqualValues(t, 1, cs.Reads)
+	assert.EqualValues(t, 2, cs.Blocks)
+}
+
+func TestTieredBlockStore_MigrateStopsOnMissingHash(t *testing.T) {
+	t.Parallel()
+
+	mbs, blocks, cleanUp := makeTestTieredBlockStore(t, TieringPolicy{
+		ColdAfterRounds: 4,
+		CacheSize:       2,
+	})
+	defer cleanUp()
+
+	// the hash of round 3 isn't known yet
+	resolve := hashResolver(blocks)
+	moved, err := mbs.Migrate(context.Background(), 10,
+		func(ctx context.Context, round int64) (string, error) {
+			if round == 3 {
+				return "", errors.New("not found")
+			}
+			return resolve(ctx, round)
+		})
+	require.Error(t, err)
+	assert.Equal(t, 2, moved)
+	assert.False(t, mbs.CloudObjectExists(blocks[2].Hash))
+
+	// the round is retried on the next run
+	moved, err = mbs.Migrate(context.Background(), 10, resolve)
+	require.NoError(t, err)
+	assert.Equal(t, 4, moved)
+	for _, b := range blocks[:6] {
+		assert.True(t, mbs.CloudObjectExists(b.Hash))
+	}
+}
+
+func TestTieredBlockStore_ColdFrontier(t *testing.T) {
+	t.Parallel()
+
+	policy := TieringPolicy{ColdAfterRounds: 4, CacheSize: 2}
+	mbs, blocks, cleanUp := makeTestTieredBlockStore(t, policy)
+	defer cleanUp()
+
+	moved, err := mbs.Migrate(context.Background(), 8, hashResolver(blocks))
+	require.NoError(t, err)
+	require.Equal(t, 4, moved)
+
+	// the frontier is binary searched in the cold tier
+	var calls int
+	resolve := hashResolver(blocks)
+	counting := func(ctx context.Context, round int64) (string, error) {
+		calls++
+		return resolve(ctx, round)
+	}
+	assert.EqualValues(t, 4, mbs.tiering.findColdFrontier(context.Background(), 10, counting))
+	assert.LessOrEqual(t, calls, 4)
+
+	// the restarted store resumes from the saved frontier
+	hot := mbs.tiering.hot
+	restarted, err := NewTieredBlockStore(hot, filepath.Join(filepath.Dir(hot.RootDirectory), "cache"), policy)
+	require.NoError(t, err)
+	calls = 0
+	moved, err = restarted.Migrate(context.Background(), 10, counting)
+	require.NoError(t, err)
+	assert.Equal(t, 2, moved)
+	assert.Equal(t, 2, calls)
+}
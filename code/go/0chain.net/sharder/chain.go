@@ -44,10 +44,22 @@ func SetupSharderChain(c *chain.Chain) {
 	c.SetViewChanger(sharderChain)
 	c.SetAfterFetcher(sharderChain)
 	c.SetMagicBlockSaver(sharderChain)
+	c.SetRoundSummaryGetter(sharderChain)
 	sharderChain.BlockSyncStats = &SyncStats{}
 	sharderChain.TieringStats = &MinioStats{}
 	sharderChain.processingBlocks = cache.NewLRUCache(1000)
 	c.RoundF = SharderRoundFactory{}
+	if StreamEnabled() {
+		sharderChain.stream = newStreamHub()
+		c.OnBlockFinalized = sharderChain.stream.publish
+		if edb := c.GetEventDb(); edb != nil {
+			edb.StorePayloads()
+		}
+	}
+	// set before the handlers reading it are registered
+	if config := ReadScrubberConfig(); config.Enabled {
+		sharderChain.Scrubber = NewBlockScrubber(sharderChain, config)
+	}
 }
 
 /*GetSharderChain - get the sharder's chain */
@@ -71,9 +83,12 @@ type Chain struct {
 	SharderStats   Stats
 	BlockSyncStats *SyncStats
 	TieringStats   *MinioStats
+	Scrubber       *BlockScrubber
 
 	processingBlocks *cache.LRU
 	pbMutex          sync.RWMutex
+
+	stream *streamHub
 }
 
 // PushToBlockProcessor pushs the block to processor,
@@ -165,6 +180,23 @@ func (sc *Chain) GetBlockHash(ctx context.Context, roundNumber int64) (string, e
 	return r.BlockHash, nil
 }
 
+// GetRoundBlockSummary - get the summary of the finalized block of a round,
+// read from the stored block if there is no block summary stored.
+func (sc *Chain) GetRoundBlockSummary(ctx context.Context, roundNumber int64) (*block.BlockSummary, error) {
+	hash, err := sc.GetBlockHash(ctx, roundNumber)
+	if err != nil {
+		return nil, err
+	}
+	if bs, err := sc.readBlockSummary(ctx, hash); err == nil {
+		return bs, nil
+	}
+	b, err := sc.GetBlockFromStore(hash, roundNumber)
+	if err != nil {
+		return nil, err
+	}
+	return b.GetSummary(), nil
+}
+
 // GetSharderRound - get the sharder's version of the round.
 func (sc *Chain) GetSharderRound(roundNumber int64) *round.Round {
 	r := sc.GetRound(roundNumber)
@@ -0,0 +1,354 @@
+package sharder
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	. "0chain.net/core/logging"
+	"0chain.net/core/util"
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"0chain.net/smartcontract/storagesc"
+)
+
+// maxReplayMismatches - the number of mismatches reported by the events
+// replay verification
+const maxReplayMismatches = 100
+
+// replayVerifyPageSize - the number of rows verified at once
+const replayVerifyPageSize = 1000
+
+// EventReplayStats - number of blocks and events replayed into the events
+// database.
+type EventReplayStats struct {
+	FirstRound   int64 `json:"first_round"`
+	LastRound    int64 `json:"last_round"`
+	Blocks       int64 `json:"blocks"`
+	Events       int64 `json:"events"`
+	FailedEvents int64 `json:"failed_events"`
+	// the state of the last replayed block
+	lastBlock *block.Block
+}
+
+// EventReplayVerification - the result of the verification of the replayed
+// events database against the state.
+type EventReplayVerification struct {
+	Round         int64    `json:"round"`
+	Users         int64    `json:"users"`
+	Blobbers      int64    `json:"blobbers"`
+	Allocations   int64    `json:"allocations"`
+	DelegatePools int64    `json:"delegate_pools"`
+	Mismatches    []string `json:"mismatches"`
+}
+
+// ReplayEvents - re-execute the finalized blocks of the [from, to] rounds
+// over the stored state and write the events they emit into the events
+// database. The state of the block preceding the first round must be
+// stored. The replayed states are written to the replay node db only, the
+// state db is only read. The replay is checkpointed after every block and
+// resumes after the checkpoint of the database, if any, with the same replay
+// node db. The replay stops at the first block whose events fail, the
+// checkpoint is not advanced past it.
+func (sc *Chain) ReplayEvents(ctx context.Context, edb *event.EventDb, rndb util.NodeDB,
+	from, to int64) (*EventReplayStats, error) {
+
+	if from <= 0 || to < from {
+		return nil, common.NewErrorf("replay_events", "invalid rounds range [%d, %d]", from, to)
+	}
+
+	cp, err := edb.GetReplayCheckpoint()
+	if err != nil {
+		return nil, common.NewErrorf("replay_events", "can't get checkpoint: %v", err)
+	}
+	if cp != nil {
+		if cp.Round < from-1 {
+			return nil, common.NewErrorf("replay_events",
+				"events are replayed up to round %d, can't start from round %d", cp.Round, from)
+		}
+		from = cp.Round + 1
+	} else {
+		empty, err := edb.IsEmpty()
+		if err != nil {
+			return nil, common.NewErrorf("replay_events", "can't check events database: %v", err)
+		}
+		if !empty {
+			return nil, common.NewError("replay_events",
+				"events database is not empty and has no replay checkpoint, use a fresh one")
+		}
+	}
+
+	pb, err := sc.getReplayBlock(ctx, from-1)
+	if err != nil {
+		return nil, err
+	}
+	stats := &EventReplayStats{FirstRound: from, LastRound: from - 1, lastBlock: pb}
+	if cp != nil && cp.BlockHash != pb.Hash {
+		return nil, common.NewErrorf("replay_events",
+			"checkpoint block %s doesn't match the stored block %s of round %d",
+			cp.BlockHash, pb.Hash, pb.Round)
+	}
+	// the replayed states over the state db
+	ndb := util.NewLevelNodeDB(rndb, sc.GetStateDB(), false)
+	if err := pb.InitStateDB(ndb); err != nil {
+		return nil, common.NewErrorf("replay_events",
+			"state of round %d is not stored (%v), replay from a round kept by the"+
+				" state archive mode or restored from a state snapshot", pb.Round, err)
+	}
+	if err := sc.setupReplayMagicBlock(pb); err != nil {
+		return nil, err
+	}
+
+	for rn := from; rn <= to; rn++ {
+		select {
+		case <-ctx.Done():
+			return stats, ctx.Err()
+		default:
+		}
+
+		b, err := sc.getReplayBlock(ctx, rn)
+		if err != nil {
+			return stats, err
+		}
+		if b.PrevHash != pb.Hash {
+			return stats, common.NewErrorf("replay_events",
+				"block %s of round %d doesn't follow block %s", b.Hash, rn, pb.Hash)
+		}
+		b.PrevBlock = pb
+		if err := b.ComputeState(ctx, sc); err != nil {
+			return stats, common.NewErrorf("replay_events", "compute state of round %d: %v", rn, err)
+		}
+		b.PrevBlock = nil
+
+		_, blockEvent := block.CreateBlockEvent(b)
+		events := append([]event.Event{blockEvent}, b.Events...)
+		failed := edb.ProcessEvents(ctx, events)
+		stats.FailedEvents += int64(failed)
+		if failed > 0 {
+			return stats, common.NewErrorf("replay_events",
+				"%d of %d events of round %d failed, the replay is checkpointed at round %d",
+				failed, len(events), rn, pb.Round)
+		}
+		if err := edb.SetReplayCheckpoint(b.Round, b.Hash); err != nil {
+			return stats, common.NewErrorf("replay_events", "can't checkpoint round %d: %v", rn, err)
+		}
+
+		stats.LastRound = rn
+		stats.Blocks++
+		stats.Events += int64(len(events))
+		stats.lastBlock = b
+		b.Events = nil
+
+		if b.MagicBlock != nil {
+			sc.SetMagicBlock(b.MagicBlock)
+			sc.SetLatestFinalizedMagicBlock(b)
+		}
+
+		// the state of the round is already stored when it's kept by the
+		// state archive mode
+		if !sc.HasClientStateStored(b.ClientStateHash) {
+			if err := saveReplayState(ctx, b, rndb); err != nil {
+				return stats, err
+			}
+		}
+		b.CreateState(ndb, b.ClientStateHash)
+		pb = b
+
+		if stats.Blocks%1000 == 0 {
+			Logger.Info("replay events", zap.Int64("round", rn),
+				zap.Int64("events", stats.Events),
+				zap.Int64("failed_events", stats.FailedEvents))
+		}
+	}
+	return stats, nil
+}
+
+// saveReplayState - write the state changes of the replayed block to the
+// replay node db. The dead nodes of the past replayed states are dropped from
+// it, so that it doesn't grow with the replayed rounds, and nothing is
+// recorded for the pruning of the state db.
+func saveReplayState(ctx context.Context, b *block.Block, rndb util.NodeDB) error {
+	if err := b.ClientState.SaveChanges(ctx, rndb, false); err != nil {
+		return common.NewErrorf("replay_events", "save state of round %d: %v", b.Round, err)
+	}
+	deletes := b.ClientState.GetDeletes()
+	keys := make([]util.Key, 0, len(deletes))
+	for _, n := range deletes {
+		keys = append(keys, n.GetHashBytes())
+	}
+	if err := rndb.MultiDeleteNode(keys); err != nil {
+		return common.NewErrorf("replay_events", "drop dead nodes of round %d: %v", b.Round, err)
+	}
+	return nil
+}
+
+// VerifyEventReplay - check the users, the blobbers with their rewards and
+// delegate pools, and the allocations of the events database against the
+// state of the last replayed block.
+func (sc *Chain) VerifyEventReplay(ctx context.Context, edb *event.EventDb, stats *EventReplayStats) (
+	*EventReplayVerification, error) {
+
+	b := stats.lastBlock
+	if b == nil || b.ClientState == nil {
+		return nil, common.NewError("verify_event_replay", "no replayed state")
+	}
+	ev := &EventReplayVerification{Round: b.Round}
+	mismatch := func(format string, args ...interface{}) {
+		if len(ev.Mismatches) < maxReplayMismatches {
+			ev.Mismatches = append(ev.Mismatches, fmt.Sprintf(format, args...))
+		}
+	}
+
+	for offset := 0; ; offset += replayVerifyPageSize {
+		users, err := edb.GetUsers(common2.Pagination{Offset: offset, Limit: replayVerifyPageSize})
+		if err != nil {
+			return ev, common.NewErrorf("verify_event_replay", "can't get users: %v", err)
+		}
+		for _, u := range users {
+			if err := ctx.Err(); err != nil {
+				return ev, err
+			}
+			ev.Users++
+			s := &state.State{}
+			if err := b.ClientState.GetNodeValue(util.Path(u.UserID), s); err != nil {
+				mismatch("user %s: %v", u.UserID, err)
+				continue
+			}
+			if s.Balance != u.Balance || s.Nonce != u.Nonce {
+				mismatch("user %s: balance %d, nonce %d, state balance %d, nonce %d",
+					u.UserID, u.Balance, u.Nonce, s.Balance, s.Nonce)
+			}
+		}
+		if len(users) < replayVerifyPageSize {
+			break
+		}
+	}
+
+	balances := sc.NewStateContext(b, b.ClientState, &transaction.Transaction{}, nil)
+	ids, err := edb.GetAllBlobberId()
+	if err != nil {
+		return ev, common.NewErrorf("verify_event_replay", "can't get blobbers: %v", err)
+	}
+	for len(ids) > 0 {
+		n := replayVerifyPageSize
+		if n > len(ids) {
+			n = len(ids)
+		}
+		blobbers, err := edb.GetBlobbersFromIDs(ids[:n])
+		if err != nil {
+			return ev, common.NewErrorf("verify_event_replay", "can't get blobbers: %v", err)
+		}
+		ids = ids[n:]
+		for _, eb := range blobbers {
+			ev.Blobbers++
+			sn := &storagesc.StorageNode{ID: eb.BlobberID}
+			key := encryption.Hash(sn.GetKey(storagesc.ADDRESS))
+			if err := b.ClientState.GetNodeValue(util.Path(key), sn); err != nil {
+				mismatch("blobber %s: %v", eb.BlobberID, err)
+				continue
+			}
+			if sn.Capacity != eb.Capacity || sn.BaseURL != eb.BaseURL {
+				mismatch("blobber %s: capacity %d, url %s, state capacity %d, url %s",
+					eb.BlobberID, eb.Capacity, eb.BaseURL, sn.Capacity, sn.BaseURL)
+			}
+
+			sp, err := storagesc.BlobberStakePool(eb.BlobberID, balances)
+			if err != nil {
+				mismatch("blobber %s stake pool: %v", eb.BlobberID, err)
+				continue
+			}
+			if sp.Reward != eb.Reward {
+				mismatch("blobber %s: reward %d, state reward %d", eb.BlobberID, eb.Reward, sp.Reward)
+			}
+			dps, err := edb.GetDelegatePools(eb.BlobberID, int(spenum.Blobber))
+			if err != nil {
+				return ev, common.NewErrorf("verify_event_replay", "can't get delegate pools: %v", err)
+			}
+			if len(dps) != len(sp.Pools) {
+				mismatch("blobber %s: %d delegate pools, state delegate pools %d",
+					eb.BlobberID, len(dps), len(sp.Pools))
+			}
+			for _, dp := range dps {
+				ev.DelegatePools++
+				pool, ok := sp.Pools[dp.PoolID]
+				if !ok {
+					mismatch("delegate pool %s of blobber %s: not found", dp.PoolID, eb.BlobberID)
+					continue
+				}
+				if pool.Balance != dp.Balance || pool.Reward != dp.Reward {
+					mismatch("delegate pool %s of blobber %s: balance %d, reward %d, state balance %d, reward %d",
+						dp.PoolID, eb.BlobberID, dp.Balance, dp.Reward, pool.Balance, pool.Reward)
+				}
+			}
+		}
+	}
+
+	for offset := 0; ; offset += replayVerifyPageSize {
+		allocs, err := edb.GetAllocations(common2.Pagination{Offset: offset, Limit: replayVerifyPageSize})
+		if err != nil {
+			return ev, common.NewErrorf("verify_event_replay", "can't get allocations: %v", err)
+		}
+		for _, ea := range allocs {
+			if err := ctx.Err(); err != nil {
+				return ev, err
+			}
+			ev.Allocations++
+			sa := &storagesc.StorageAllocation{ID: ea.AllocationID}
+			key := encryption.Hash(sa.GetKey(storagesc.ADDRESS))
+			if err := b.ClientState.GetNodeValue(util.Path(key), sa); err != nil {
+				mismatch("allocation %s: %v", ea.AllocationID, err)
+				continue
+			}
+			if sa.Size != ea.Size || int64(sa.Expiration) != ea.Expiration ||
+				sa.Finalized != ea.Finalized || sa.Canceled != ea.Cancelled {
+				mismatch("allocation %s: size %d, expiration %d, finalized %t, cancelled %t,"+
+					" state size %d, expiration %d, finalized %t, cancelled %t",
+					ea.AllocationID, ea.Size, ea.Expiration, ea.Finalized, ea.Cancelled,
+					sa.Size, sa.Expiration, sa.Finalized, sa.Canceled)
+			}
+		}
+		if len(allocs) < replayVerifyPageSize {
+			break
+		}
+	}
+	return ev, nil
+}
+
+// getReplayBlock - get the stored finalized block of given round.
+func (sc *Chain) getReplayBlock(ctx context.Context, round int64) (*block.Block, error) {
+	hash, err := sc.GetBlockHash(ctx, round)
+	if err != nil {
+		return nil, common.NewErrorf("replay_events", "round %d: %v", round, err)
+	}
+	b, err := sc.GetBlockFromStore(hash, round)
+	if err != nil {
+		return nil, common.NewErrorf("replay_events", "block of round %d: %v", round, err)
+	}
+	return b, nil
+}
+
+// setupReplayMagicBlock - set the magic block the replay starts with, the one
+// of the block preceding the first replayed round.
+func (sc *Chain) setupReplayMagicBlock(b *block.Block) error {
+	mb := b
+	if b.MagicBlock == nil {
+		var err error
+		mb, err = sc.GetBlockFromStore(b.LatestFinalizedMagicBlockHash, b.LatestFinalizedMagicBlockRound)
+		if err != nil {
+			return common.NewErrorf("replay_events", "magic block of round %d: %v", b.Round, err)
+		}
+		if mb.MagicBlock == nil {
+			return common.NewErrorf("replay_events", "block %s has no magic block", mb.Hash)
+		}
+	}
+	sc.SetMagicBlock(mb.MagicBlock)
+	sc.SetLatestFinalizedMagicBlock(mb)
+	return nil
+}
@@ -15,6 +15,9 @@ import (
 	"0chain.net/chaincore/node"
 	"0chain.net/core/build"
 	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/core/util"
+	"0chain.net/sharder/blockstore"
 )
 
 const (
@@ -31,9 +34,16 @@ func handlersMap() map[string]func(http.ResponseWriter, *http.Request) {
 		"/_chain_stats":                    ChainStatsWriter,
 		"/_healthcheck":                    HealthCheckWriter,
 		"/v1/sharder/get/stats":            common.ToJSONResponse(SharderStatsHandler),
+		"/v1/sharder/get/tiering_stats":    common.ToJSONResponse(TieringStatsHandler),
+		"/v1/sharder/get/scrubber_stats":   common.ToJSONResponse(ScrubberStatsHandler),
 
 		"/v1/state/nodes":        common.ToJSONResponse(chain.StateNodesHandler),
+		"/v1/state/proof":        common.ToJSONResponse(StateProofHandler),
 		"/v1/block/state_change": common.ToJSONResponse(BlockStateChangeHandler),
+
+		"/v1/stream/blocks":       StreamBlocksHandler,
+		"/v1/stream/transactions": StreamTransactionsHandler,
+		"/v1/stream/events":       StreamEventsHandler,
 	}
 
 	handlers := make(map[string]func(http.ResponseWriter, *http.Request))
@@ -48,6 +58,49 @@ func BlockStateChangeHandler(ctx context.Context, r *http.Request) (interface{},
 	return c.BlockStateChangeHandler(ctx, r)
 }
 
+// StateProofHandler - a handler to respond with the proof of a state value at
+// given round, the latest finalized one by default. The value is selected by
+// its state key, by the client_id or by the sc_address and key of the smart
+// contract state.
+func StateProofHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	var path util.Path
+	switch {
+	case r.FormValue("client_id") != "":
+		path = util.Path(r.FormValue("client_id"))
+	case r.FormValue("sc_address") != "":
+		path = util.Path(encryption.Hash(r.FormValue("sc_address") + r.FormValue("key")))
+	case r.FormValue("key") != "":
+		path = util.Path(r.FormValue("key"))
+	default:
+		return nil, common.InvalidRequest("key, client_id or sc_address is required")
+	}
+
+	sc := GetSharderChain()
+	b := sc.GetLatestFinalizedBlock()
+	if b == nil {
+		return nil, common.NewError("state_proof", "no finalized block")
+	}
+	if roundData := r.FormValue("round"); roundData != "" {
+		roundNumber, err := strconv.ParseInt(roundData, 10, 64)
+		if err != nil || roundNumber <= 0 {
+			return nil, common.InvalidRequest("invalid round number")
+		}
+		if roundNumber > b.Round {
+			return nil, common.InvalidRequest("Block not available")
+		}
+		if roundNumber != b.Round {
+			hash, err := sc.GetBlockHash(ctx, roundNumber)
+			if err != nil {
+				return nil, err
+			}
+			if b, err = sc.GetBlockFromStore(hash, roundNumber); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return sc.GetStateProof(b, path)
+}
+
 type ChainInfo struct {
 	LatestFinalizedBlock *block.BlockSummary `json:"latest_finalized_block"`
 }
@@ -260,3 +313,22 @@ func SharderStatsHandler(ctx context.Context, r *http.Request) (interface{}, err
 		MeanScanBlockStatsTime: cc.BlockSyncTimer.Mean() / 1000000.0,
 	}, nil
 }
+
+// TieringStatsHandler - returns per tier statistics of the tiered block store.
+func TieringStatsHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	mbs, ok := blockstore.GetStore().(*blockstore.MultiBlockStore)
+	if !ok || !mbs.IsTiered() {
+		return nil, common.NewError("tiering_stats", "block store is not tiered")
+	}
+	return mbs.GetTieringStats(), nil
+}
+
+// ScrubberStatsHandler - returns progress and findings of the block store
+// scrubber.
+func ScrubberStatsHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	sc := GetSharderChain()
+	if sc.Scrubber == nil {
+		return nil, common.NewError("scrubber_stats", "block scrubber is not enabled")
+	}
+	return sc.Scrubber.GetStats(), nil
+}
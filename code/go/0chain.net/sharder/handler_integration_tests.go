@@ -62,6 +62,9 @@ func TransactionConfirmationHandler(ctx context.Context, r *http.Request) (
 		sc                = GetSharderChain()
 		confirmation, err = sc.GetTransactionConfirmation(ctx, hash)
 	)
+	if err != nil {
+		err = sc.txnNotFoundError(r, err)
+	}
 
 	if confirmation != nil && state.VerifyTransaction != nil {
 		confirmation.Hash = revertString(confirmation.Hash)
@@ -32,6 +32,9 @@ func TransactionConfirmationHandler(ctx context.Context, r *http.Request) (inter
 	defer persistencestore.Close(ctx)
 	sc := GetSharderChain()
 	confirmation, err := sc.GetTransactionConfirmation(ctx, hash)
+	if err != nil {
+		err = sc.txnNotFoundError(r, err)
+	}
 
 	if content == "confirmation" {
 		return confirmation, err
@@ -28,6 +28,12 @@ func HealthCheckWriter(w http.ResponseWriter, r *http.Request) {
 	sc.WriteMinioStats(w)
 	fmt.Fprintf(w, "</td></tr>")
 
+	if sc.Scrubber != nil {
+		fmt.Fprintf(w, "<tr><td valign='top' colspan='2'><h2>Block Scrubber</h2>")
+		sc.WriteScrubberStats(w)
+		fmt.Fprintf(w, "</td></tr>")
+	}
+
 	fmt.Fprintf(w, "<tr><td valign='top'><h2>Deep Scan Configuration</h2>")
 	sc.WriteHealthCheckConfiguration(w, DeepScan)
 	fmt.Fprintf(w, "</td><td valign='top'><h2>Proximity Scan Configuration</h2>")
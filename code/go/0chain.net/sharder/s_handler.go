@@ -105,6 +105,13 @@ func (lrw *wrappedResponseWriter) Write(buff []byte) (int, error) {
 	return retVal, err
 }
 
+// Flush implements http.Flusher for the streaming handlers
+func (lrw *wrappedResponseWriter) Flush() {
+	if f, ok := lrw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 func elapsedHandler(handler func(http.ResponseWriter, *http.Request)) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		lrw := newWrappedResponseWriter(w)
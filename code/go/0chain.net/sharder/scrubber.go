@@ -0,0 +1,320 @@
+package sharder
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/node"
+	"0chain.net/chaincore/round"
+	"0chain.net/core/logging"
+	"0chain.net/core/viper"
+)
+
+// maxScrubFindings - number of the latest findings kept for diagnostics.
+const maxScrubFindings = 100
+
+// ScrubProblem - kind of a block store integrity problem.
+type ScrubProblem string
+
+// Block store integrity problems found by the scrubber.
+const (
+	ScrubBlockMissing        ScrubProblem = "block_missing"
+	ScrubBlockUnreadable     ScrubProblem = "block_unreadable"
+	ScrubSummaryMismatch     ScrubProblem = "summary_mismatch"
+	ScrubHashMismatch        ScrubProblem = "hash_mismatch"
+	ScrubMerkleRootMismatch  ScrubProblem = "merkle_root_mismatch"
+	ScrubReceiptRootMismatch ScrubProblem = "receipt_merkle_root_mismatch"
+	ScrubTxnCountMismatch    ScrubProblem = "txn_count_mismatch"
+)
+
+// ScrubberConfig - the block store scrubber tunables.
+type ScrubberConfig struct {
+	Enabled bool `json:"enabled"`
+	// Interval between scrub cycles.
+	Interval time.Duration `json:"interval"`
+	// Window - number of rounds below the LFB scrubbed every cycle,
+	// 0 to scrub the whole chain.
+	Window int64 `json:"window"`
+	// BatchSize - number of rounds scrubbed before pausing.
+	BatchSize int64 `json:"batch_size"`
+	// BatchPause - pause between batches to limit the disk load.
+	BatchPause time.Duration `json:"batch_pause"`
+}
+
+// ReadScrubberConfig - read the scrubber configuration.
+func ReadScrubberConfig() ScrubberConfig {
+	viper.SetDefault("server_chain.block.scrubber.interval", time.Hour)
+	viper.SetDefault("server_chain.block.scrubber.batch_size", 100)
+	viper.SetDefault("server_chain.block.scrubber.batch_pause", time.Second)
+	return ScrubberConfig{
+		Enabled:    viper.GetBool("server_chain.block.scrubber.enabled"),
+		Interval:   viper.GetDuration("server_chain.block.scrubber.interval"),
+		Window:     viper.GetInt64("server_chain.block.scrubber.window"),
+		BatchSize:  viper.GetInt64("server_chain.block.scrubber.batch_size"),
+		BatchPause: viper.GetDuration("server_chain.block.scrubber.batch_pause"),
+	}
+}
+
+// ScrubFinding - an integrity problem of a stored block.
+type ScrubFinding struct {
+	Round    int64        `json:"round"`
+	Hash     string       `json:"hash"`
+	Problem  ScrubProblem `json:"problem"`
+	Detail   string       `json:"detail,omitempty"`
+	Repaired bool         `json:"repaired"`
+	FoundAt  time.Time    `json:"found_at"`
+}
+
+// ScrubCounters - the scrubber counters.
+type ScrubCounters struct {
+	Scanned        int64 `json:"scanned"`
+	Healthy        int64 `json:"healthy"`
+	Skipped        int64 `json:"skipped"`
+	Missing        int64 `json:"missing"`
+	Corrupted      int64 `json:"corrupted"`
+	Repaired       int64 `json:"repaired"`
+	RepairFailures int64 `json:"repair_failures"`
+}
+
+func (c *ScrubCounters) add(o *ScrubCounters) {
+	c.Scanned += o.Scanned
+	c.Healthy += o.Healthy
+	c.Skipped += o.Skipped
+	c.Missing += o.Missing
+	c.Corrupted += o.Corrupted
+	c.Repaired += o.Repaired
+	c.RepairFailures += o.RepairFailures
+}
+
+// ScrubberStats - progress and findings of the scrubber.
+type ScrubberStats struct {
+	Config       ScrubberConfig `json:"config"`
+	Cycle        int64          `json:"cycle"`
+	Running      bool           `json:"running"`
+	FromRound    int64          `json:"from_round"`
+	ToRound      int64          `json:"to_round"`
+	CurrentRound int64          `json:"current_round"`
+	StartedAt    time.Time      `json:"started_at"`
+	FinishedAt   time.Time      `json:"finished_at"`
+	Current      ScrubCounters  `json:"current"`
+	Previous     ScrubCounters  `json:"previous"`
+	Total        ScrubCounters  `json:"total"`
+	Findings     []ScrubFinding `json:"findings"`
+}
+
+// BlockScrubber - walks the stored blocks, verifies them against the stored
+// block summaries and re-fetches bad or missing blocks from other sharders.
+type BlockScrubber struct {
+	sc     *Chain
+	config ScrubberConfig
+
+	mutex sync.RWMutex
+	stats ScrubberStats
+}
+
+// NewBlockScrubber - create a block scrubber of the sharder chain.
+func NewBlockScrubber(sc *Chain, config ScrubberConfig) *BlockScrubber {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	return &BlockScrubber{
+		sc:     sc,
+		config: config,
+		stats:  ScrubberStats{Config: config},
+	}
+}
+
+// GetStats - returns a copy of the scrubber progress and findings.
+func (bsc *BlockScrubber) GetStats() ScrubberStats {
+	bsc.mutex.RLock()
+	defer bsc.mutex.RUnlock()
+	stats := bsc.stats
+	stats.Findings = make([]ScrubFinding, len(bsc.stats.Findings))
+	copy(stats.Findings, bsc.stats.Findings)
+	return stats
+}
+
+// Worker - scrub the configured rounds window every interval.
+func (bsc *BlockScrubber) Worker(ctx context.Context) {
+	ticker := time.NewTicker(bsc.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			lfb := bsc.sc.GetLatestFinalizedBlock()
+			if lfb == nil {
+				continue
+			}
+			to := lfb.Round
+			from := int64(1)
+			if bsc.config.Window > 0 && to-bsc.config.Window > from {
+				from = to - bsc.config.Window
+			}
+			bsc.Scrub(ctx, from, to)
+		}
+	}
+}
+
+// Scrub - verify stored blocks of the [from, to] rounds range and repair
+// the bad or missing ones.
+func (bsc *BlockScrubber) Scrub(ctx context.Context, from, to int64) {
+	bsc.mutex.Lock()
+	bsc.stats.Cycle++
+	bsc.stats.Running = true
+	bsc.stats.FromRound, bsc.stats.ToRound = from, to
+	bsc.stats.StartedAt = time.Now()
+	bsc.stats.Current = ScrubCounters{}
+	cycle := bsc.stats.Cycle
+	bsc.mutex.Unlock()
+
+	logging.Logger.Info("block scrubber - start", zap.Int64("cycle", cycle),
+		zap.Int64("from", from), zap.Int64("to", to))
+
+	for rn := from; rn <= to; rn++ {
+		if (rn-from)%bsc.config.BatchSize == 0 && rn != from && bsc.config.BatchPause > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(bsc.config.BatchPause):
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		bsc.scrubRound(ctx, rn)
+	}
+
+	bsc.mutex.Lock()
+	bsc.stats.Running = false
+	bsc.stats.FinishedAt = time.Now()
+	bsc.stats.Previous = bsc.stats.Current
+	bsc.stats.Total.add(&bsc.stats.Current)
+	current := bsc.stats.Current
+	bsc.mutex.Unlock()
+
+	logging.Logger.Info("block scrubber - done", zap.Int64("cycle", cycle),
+		zap.Any("counters", current))
+}
+
+func (bsc *BlockScrubber) scrubRound(ctx context.Context, rn int64) {
+	sc := bsc.sc
+	counters := ScrubCounters{Scanned: 1}
+	defer func() {
+		bsc.mutex.Lock()
+		bsc.stats.CurrentRound = rn
+		bsc.stats.Current.add(&counters)
+		bsc.mutex.Unlock()
+	}()
+
+	r, ok := sc.hasRoundSummary(ctx, rn)
+	if !ok {
+		// missing round summaries are repaired by the health check
+		counters.Skipped++
+		return
+	}
+	bs, ok := sc.hasBlockSummary(ctx, r.BlockHash)
+	if !ok {
+		counters.Skipped++
+		return
+	}
+
+	var (
+		problem ScrubProblem
+		detail  string
+	)
+	b, err := sc.GetBlockFromStoreBySummary(bs)
+	switch {
+	case err != nil && os.IsNotExist(err):
+		// blocks not sharded by this sharder are not kept
+		self := node.GetSelfNode(ctx)
+		if !sc.IsBlockSharderFromHash(rn, bs.Hash, self.Underlying()) {
+			counters.Skipped++
+			return
+		}
+		problem = ScrubBlockMissing
+		counters.Missing++
+	case err != nil:
+		problem, detail = ScrubBlockUnreadable, err.Error()
+		counters.Corrupted++
+	default:
+		if problem = verifyStoredBlock(b, bs); problem == "" {
+			counters.Healthy++
+			return
+		}
+		counters.Corrupted++
+	}
+
+	repaired := bsc.repair(ctx, r, bs)
+	if repaired {
+		counters.Repaired++
+	} else {
+		counters.RepairFailures++
+	}
+
+	logging.Logger.Warn("block scrubber - bad block",
+		zap.Int64("round", rn),
+		zap.String("hash", bs.Hash),
+		zap.String("problem", string(problem)),
+		zap.String("detail", detail),
+		zap.Bool("repaired", repaired))
+	bsc.addFinding(ScrubFinding{
+		Round:    rn,
+		Hash:     bs.Hash,
+		Problem:  problem,
+		Detail:   detail,
+		Repaired: repaired,
+		FoundAt:  time.Now(),
+	})
+}
+
+// repair - fetch the block from other sharders and store it if it matches
+// the stored block summary.
+func (bsc *BlockScrubber) repair(ctx context.Context, r *round.Round, bs *block.BlockSummary) bool {
+	b := bsc.sc.requestBlock(ctx, r)
+	if b == nil {
+		return false
+	}
+	if problem := verifyStoredBlock(b, bs); problem != "" {
+		logging.Logger.Error("block scrubber - fetched block doesn't match the summary",
+			zap.Int64("round", r.Number),
+			zap.String("problem", string(problem)))
+		return false
+	}
+	return bsc.sc.storeBlock(b) == nil
+}
+
+func (bsc *BlockScrubber) addFinding(f ScrubFinding) {
+	bsc.mutex.Lock()
+	defer bsc.mutex.Unlock()
+	bsc.stats.Findings = append(bsc.stats.Findings, f)
+	if len(bsc.stats.Findings) > maxScrubFindings {
+		bsc.stats.Findings = bsc.stats.Findings[len(bsc.stats.Findings)-maxScrubFindings:]
+	}
+}
+
+// verifyStoredBlock - check the block against its stored summary, returns
+// empty problem for a healthy block.
+func verifyStoredBlock(b *block.Block, bs *block.BlockSummary) ScrubProblem {
+	if b.Hash != bs.Hash || b.Round != bs.Round {
+		return ScrubSummaryMismatch
+	}
+	if len(b.Txns) != bs.NumTxns {
+		return ScrubTxnCountMismatch
+	}
+	if b.GetMerkleTree().GetRoot() != bs.MerkleTreeRoot {
+		return ScrubMerkleRootMismatch
+	}
+	if b.GetReceiptsMerkleTree().GetRoot() != bs.ReceiptMerkleTreeRoot {
+		return ScrubReceiptRootMismatch
+	}
+	if b.ComputeHash() != b.Hash {
+		return ScrubHashMismatch
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+package sharder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/encryption"
+)
+
+func makeTestScrubBlock() *block.Block {
+	b := block.NewBlock("", 10)
+	b.MinerID = encryption.Hash("miner")
+	b.PrevHash = encryption.Hash("prev block")
+	b.HashBlock()
+	return b
+}
+
+func TestVerifyStoredBlock(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(b *block.Block, bs *block.BlockSummary)
+		want   ScrubProblem
+	}{
+		{
+			name:   "healthy",
+			modify: func(b *block.Block, bs *block.BlockSummary) {},
+			want:   "",
+		},
+		{
+			name: "other_block",
+			modify: func(b *block.Block, bs *block.BlockSummary) {
+				bs.Hash = encryption.Hash("other block")
+			},
+			want: ScrubSummaryMismatch,
+		},
+		{
+			name: "other_round",
+			modify: func(b *block.Block, bs *block.BlockSummary) {
+				bs.Round++
+			},
+			want: ScrubSummaryMismatch,
+		},
+		{
+			name: "txn_count",
+			modify: func(b *block.Block, bs *block.BlockSummary) {
+				bs.NumTxns = 1
+			},
+			want: ScrubTxnCountMismatch,
+		},
+		{
+			name: "merkle_root",
+			modify: func(b *block.Block, bs *block.BlockSummary) {
+				bs.MerkleTreeRoot = encryption.Hash("merkle root")
+			},
+			want: ScrubMerkleRootMismatch,
+		},
+		{
+			name: "receipt_merkle_root",
+			modify: func(b *block.Block, bs *block.BlockSummary) {
+				bs.ReceiptMerkleTreeRoot = encryption.Hash("receipt merkle root")
+			},
+			want: ScrubReceiptRootMismatch,
+		},
+		{
+			name: "modified_block",
+			modify: func(b *block.Block, bs *block.BlockSummary) {
+				b.MinerID = encryption.Hash("other miner")
+			},
+			want: ScrubHashMismatch,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			b := makeTestScrubBlock()
+			bs := b.GetSummary()
+			tt.modify(b, bs)
+			assert.Equal(t, tt.want, verifyStoredBlock(b, bs))
+		})
+	}
+}
+
+func TestBlockScrubber_Findings(t *testing.T) {
+	t.Parallel()
+
+	bsc := NewBlockScrubber(&Chain{}, ScrubberConfig{})
+	assert.EqualValues(t, 100, bsc.config.BatchSize)
+
+	for i := int64(1); i <= maxScrubFindings+10; i++ {
+		bsc.addFinding(ScrubFinding{Round: i, Problem: ScrubBlockMissing})
+	}
+	stats := bsc.GetStats()
+	assert.Len(t, stats.Findings, maxScrubFindings)
+	assert.EqualValues(t, 11, stats.Findings[0].Round)
+	assert.EqualValues(t, maxScrubFindings+10, stats.Findings[maxScrubFindings-1].Round)
+
+	// the stats are a copy
+	stats.Findings[0].Round = 0
+	assert.EqualValues(t, 11, bsc.GetStats().Findings[0].Round)
+}
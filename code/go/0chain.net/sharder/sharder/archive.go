@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"0chain.net/chaincore/chain"
+	"0chain.net/chaincore/config"
+	"0chain.net/chaincore/node"
+	"0chain.net/core/common"
+	"0chain.net/core/logging"
+	. "0chain.net/core/logging"
+	"0chain.net/core/viper"
+	"0chain.net/sharder"
+	"0chain.net/sharder/blockstore"
+)
+
+const archiveUsage = `usage: sharder archive export --from R1 --to R2 --file FILE [options]
+       sharder archive import --file FILE [options]
+
+The sharder must be stopped while the archive is exported or imported.`
+
+// archiveMain - the 'sharder archive' command exporting finalized rounds
+// into an archive file or importing them from it.
+func archiveMain(args []string) {
+	if len(args) == 0 || (args[0] != "export" && args[0] != "import") {
+		fmt.Fprintln(os.Stderr, archiveUsage)
+		os.Exit(2)
+	}
+
+	cmd := args[0]
+	fs := flag.NewFlagSet("archive "+cmd, flag.ExitOnError)
+	deploymentMode := fs.Int("deployment_mode", 2, "deployment_mode")
+	minioFile := fs.String("minio_file", "", "minio_file")
+	file := fs.String("file", "", "archive file")
+	from := fs.Int64("from", 0, "first round to export")
+	to := fs.Int64("to", 0, "last round to export")
+	workdir := fs.String("work_dir", "", "work_dir")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, archiveUsage)
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args[1:])
+
+	if *file == "" || (cmd == "export" && (*from <= 0 || *to < *from)) {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	sc := setupOfflineChain(byte(*deploymentMode), *workdir, *minioFile)
+	ctx := common.GetRootContext()
+
+	var (
+		stats *sharder.ArchiveStats
+		err   error
+	)
+	switch cmd {
+	case "export":
+		var f *os.File
+		if f, err = os.Create(*file); err != nil {
+			break
+		}
+		stats, err = sc.ExportArchive(ctx, f, *from, *to)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(*file)
+		}
+	case "import":
+		var f *os.File
+		if f, err = os.Open(*file); err != nil {
+			break
+		}
+		stats, err = sc.ImportArchive(ctx, f)
+		f.Close()
+	}
+
+	if stats != nil {
+		Logger.Info("archive "+cmd, zap.String("file", *file),
+			zap.Int64("rounds", stats.Rounds),
+			zap.Int64("magic_blocks", stats.MagicBlocks),
+			zap.Int64("first_round", stats.FirstRound),
+			zap.Int64("last_round", stats.LastRound))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "archive %s failed: %v\n", cmd, err)
+		os.Exit(1)
+	}
+}
+
+// setupOfflineChain - set up the configuration, the stores and the sharder
+// chain needed by the offline commands, without starting the node.
+func setupOfflineChain(deploymentMode byte, workdir, minioFile string) *sharder.Chain {
+	config.Configuration().DeploymentMode = deploymentMode
+	config.SetupDefaultConfig()
+	config.SetupConfig(workdir)
+	config.SetupSmartContractConfig(workdir)
+
+	if config.Development() {
+		logging.InitLogging("development", workdir)
+	} else {
+		logging.InitLogging("production", workdir)
+	}
+
+	var mConf blockstore.MinioConfiguration
+	if viper.GetBool("minio.enabled") {
+		reader, err := os.Open(minioFile)
+		if err != nil {
+			panic(err)
+		}
+		mConf, err = processMinioConfig(reader)
+		if err != nil {
+			panic(err)
+		}
+		reader.Close()
+	}
+
+	config.Configuration().ChainID = viper.GetString("server_chain.id")
+	config.SetServerChainID(config.Configuration().ChainID)
+	common.SetupRootContext(node.GetNodeContext())
+	initEntities(workdir)
+
+	serverChain := chain.NewChainFromConfig()
+	sharder.SetupSharderChain(serverChain)
+	chain.SetServerChain(serverChain)
+	setupBlockStorageProvider(mConf, workdir)
+	return sharder.GetSharderChain()
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+
+	"0chain.net/core/common"
+	. "0chain.net/core/logging"
+	"0chain.net/core/util"
+	"0chain.net/sharder"
+	"0chain.net/smartcontract/dbs/event"
+)
+
+const eventsUsage = `usage: sharder events replay --from R1 --to R2 [--verify] [options]
+
+Re-execute the stored finalized blocks of the rounds over the state of the
+round R1-1 and write the events they emit into the configured events
+database, which must be a fresh one. The replay is checkpointed after every
+block, stops at the first block whose events fail and resumes from the
+checkpoint when run again. The replayed states are kept in the data/replay
+directory of the work directory, apart from the state db, until the replay
+is verified. The sharder must be stopped while the events are replayed.`
+
+// eventsMain - the 'sharder events' command rebuilding the events database
+// by replaying the stored blocks.
+func eventsMain(args []string) {
+	if len(args) == 0 || args[0] != "replay" {
+		fmt.Fprintln(os.Stderr, eventsUsage)
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("events replay", flag.ExitOnError)
+	deploymentMode := fs.Int("deployment_mode", 2, "deployment_mode")
+	minioFile := fs.String("minio_file", "", "minio_file")
+	from := fs.Int64("from", 0, "first round to replay")
+	to := fs.Int64("to", 0, "last round to replay")
+	verify := fs.Bool("verify", true, "verify the events database against the state of the last round")
+	workdir := fs.String("work_dir", "", "work_dir")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, eventsUsage)
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args[1:])
+
+	if *from <= 0 || *to < *from {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	sc := setupOfflineChain(byte(*deploymentMode), *workdir, *minioFile)
+	ctx := common.GetRootContext()
+
+	if err := sc.SetupEventDatabase(); err != nil {
+		fmt.Fprintf(os.Stderr, "events replay failed: %v\n", err)
+		os.Exit(1)
+	}
+	edb := sc.GetEventDb()
+	if edb == nil {
+		fmt.Fprintln(os.Stderr, "events replay failed: events database is disabled")
+		os.Exit(1)
+	}
+	if sharder.StreamEnabled() {
+		edb.StorePayloads()
+	}
+
+	// the replayed states are kept to resume the replay, until it's done
+	replayDir := filepath.Join(*workdir, "data", "replay", "state")
+	rndb, err := util.NewKVNodeDB(replayDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "events replay failed: replay state db: %v\n", err)
+		os.Exit(1)
+	}
+	err = replayEvents(ctx, sc, edb, rndb, *from, *to, *verify)
+	rndb.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	if err := os.RemoveAll(replayDir); err != nil {
+		Logger.Error("events replay - remove replay state db", zap.Error(err))
+	}
+}
+
+// replayEvents - replay the events of the rounds and verify the events
+// database against the state of the last round.
+func replayEvents(ctx context.Context, sc *sharder.Chain, edb *event.EventDb, rndb util.NodeDB,
+	from, to int64, verify bool) error {
+
+	stats, err := sc.ReplayEvents(ctx, edb, rndb, from, to)
+	if stats != nil {
+		Logger.Info("events replay",
+			zap.Int64("first_round", stats.FirstRound),
+			zap.Int64("last_round", stats.LastRound),
+			zap.Int64("blocks", stats.Blocks),
+			zap.Int64("events", stats.Events),
+			zap.Int64("failed_events", stats.FailedEvents))
+	}
+	if err != nil {
+		return fmt.Errorf("events replay failed: %v", err)
+	}
+	if !verify {
+		return nil
+	}
+
+	ev, err := sc.VerifyEventReplay(ctx, edb, stats)
+	if err != nil {
+		return fmt.Errorf("events replay verification failed: %v", err)
+	}
+	Logger.Info("events replay verification", zap.Int64("round", ev.Round),
+		zap.Int64("users", ev.Users),
+		zap.Int64("blobbers", ev.Blobbers),
+		zap.Int64("allocations", ev.Allocations),
+		zap.Int64("delegate_pools", ev.DelegatePools),
+		zap.Int("mismatches", len(ev.Mismatches)))
+	if len(ev.Mismatches) > 0 {
+		for _, m := range ev.Mismatches {
+			fmt.Fprintln(os.Stderr, m)
+		}
+		return fmt.Errorf("events replay verification failed at round %d", ev.Round)
+	}
+	return nil
+}
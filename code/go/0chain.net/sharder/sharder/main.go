@@ -86,11 +86,29 @@ func processMinioConfig(reader io.Reader) (blockstore.MinioConfiguration, error)
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "archive":
+			archiveMain(os.Args[2:])
+			return
+		case "snapshot":
+			snapshotMain(os.Args[2:])
+			return
+		case "state":
+			chain.StateMain("sharder", os.Args[2:])
+			return
+		case "events":
+			eventsMain(os.Args[2:])
+			return
+		}
+	}
+
 	deploymentMode := flag.Int("deployment_mode", 2, "deployment_mode")
 	keysFile := flag.String("keys_file", "", "keys_file")
 	magicBlockFile := flag.String("magic_block_file", "", "magic_block_file")
 	minioFile := flag.String("minio_file", "", "minio_file")
 	initialStatesFile := flag.String("initial_states", "", "initial_states")
+	stateSnapshot := flag.String("state_snapshot", "", "state snapshot directory to bootstrap the state from")
 	flag.String("nodes_file", "", "nodes_file (deprecated)")
 	workdir := ""
 	flag.StringVar(&workdir, "work_dir", "", "work_dir")
@@ -285,10 +303,27 @@ func main() {
 	initN2NHandlers(sc)
 	initWorkers(ctx)
 
-	// start sharding from the LFB stored
-	if err = sc.LoadLatestBlocksFromStore(common.GetRootContext()); err != nil {
-		Logger.Error("load latest blocks from store: " + err.Error())
-		return
+	var snapshotBlock *block.Block
+	if *stateSnapshot != "" {
+		if snapshotBlock, err = sc.BootstrapStateFromSnapshot(ctx, *stateSnapshot); err != nil {
+			Logger.Panic("bootstrap state from snapshot", zap.Error(err))
+		}
+		// store the snapshot block the sharder syncs from
+		if err = blockstore.GetStore().Write(snapshotBlock); err != nil {
+			Logger.Panic("store snapshot block", zap.Error(err))
+		}
+		if err = sc.StoreBlockSummaryFromBlock(snapshotBlock); err != nil {
+			Logger.Panic("store snapshot block summary", zap.Error(err))
+		}
+	}
+
+	// start sharding from the LFB stored, the snapshot block is the LFB
+	// already if bootstrapped from a snapshot
+	if snapshotBlock == nil {
+		if err = sc.LoadLatestBlocksFromStore(common.GetRootContext()); err != nil {
+			Logger.Error("load latest blocks from store: " + err.Error())
+			return
+		}
 	}
 
 	sharder.SetupWorkers(ctx)
@@ -521,6 +556,28 @@ func setupBlockStorageProvider(mConf blockstore.MinioConfiguration, workdir stri
 			),
 		}
 		blockstore.SetupStore(blockstore.NewMultiBlockStore(bs))
+	case "blockstore.TieredBlockStore":
+		if mClient == nil {
+			panic("tiered block store requires minio to be enabled")
+		}
+		tbs, err := blockstore.NewTieredBlockStore(fsbs, filepath.Join(workdir, "data/blockcache"),
+			blockstore.TieringPolicy{
+				ColdAfterRounds:    viper.GetInt64("server_chain.block.storage.tiering.cold_after_rounds"),
+				CacheSize:          viper.GetInt("server_chain.block.storage.tiering.cache_size"),
+				MigrationBatchSize: viper.GetInt("server_chain.block.storage.tiering.migration_batch_size"),
+			})
+		if err != nil {
+			panic(fmt.Sprintf("can not create tiered block store - %v", err))
+		}
+		blockstore.SetupStore(tbs)
+	case "blockstore.PackBlockStore":
+		pbs, err := blockstore.NewPackBlockStore(filepath.Join(workdir, "data/blockpacks"),
+			viper.GetInt64("server_chain.block.storage.pack.max_pack_size"),
+			viper.GetFloat64("server_chain.block.storage.pack.compaction_threshold"))
+		if err != nil {
+			panic(fmt.Sprintf("can not open pack block store - %v", err))
+		}
+		blockstore.SetupStore(pbs)
 	default:
 		panic(fmt.Sprintf("uknown block store provider - %v", blockStorageProvider))
 	}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"0chain.net/core/common"
+	. "0chain.net/core/logging"
+	"0chain.net/core/util"
+)
+
+const snapshotUsage = `usage: sharder snapshot export --dir DIR [--round R] [options]
+
+Export the client state of the finalized round, the latest stored one by
+default. The sharder must be stopped while the snapshot is exported.`
+
+// snapshotMain - the 'sharder snapshot' command exporting the client state
+// snapshot used to bootstrap new nodes with the --state_snapshot flag.
+func snapshotMain(args []string) {
+	if len(args) == 0 || args[0] != "export" {
+		fmt.Fprintln(os.Stderr, snapshotUsage)
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("snapshot export", flag.ExitOnError)
+	deploymentMode := fs.Int("deployment_mode", 2, "deployment_mode")
+	minioFile := fs.String("minio_file", "", "minio_file")
+	dir := fs.String("dir", "", "snapshot directory")
+	roundNum := fs.Int64("round", 0, "finalized round to export, the latest stored by default")
+	chunkSize := fs.Int64("chunk_size", util.DefaultSnapshotChunkSize, "uncompressed size of a snapshot chunk")
+	workdir := fs.String("work_dir", "", "work_dir")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, snapshotUsage)
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args[1:])
+
+	if *dir == "" || *roundNum < 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	sc := setupOfflineChain(byte(*deploymentMode), *workdir, *minioFile)
+	ctx := common.GetRootContext()
+
+	if *roundNum == 0 {
+		if err := sc.LoadLatestBlocksFromStore(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "snapshot export failed: %v\n", err)
+			os.Exit(1)
+		}
+		lfb := sc.GetLatestFinalizedBlock()
+		if lfb == nil {
+			fmt.Fprintln(os.Stderr, "snapshot export failed: no finalized block stored")
+			os.Exit(1)
+		}
+		*roundNum = lfb.Round
+	}
+
+	hash, err := sc.GetBlockHash(ctx, *roundNum)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot export failed: round %d: %v\n", *roundNum, err)
+		os.Exit(1)
+	}
+	b, err := sc.GetBlockFromStore(hash, *roundNum)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot export failed: block of round %d: %v\n", *roundNum, err)
+		os.Exit(1)
+	}
+
+	sm, err := sc.ExportStateSnapshot(ctx, b, *dir, *chunkSize)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot export failed: %v\n", err)
+		os.Exit(1)
+	}
+	Logger.Info("snapshot export", zap.String("dir", *dir),
+		zap.Int64("round", sm.Round),
+		zap.String("block", sm.BlockHash),
+		zap.String("root", sm.Root),
+		zap.Int64("nodes", sm.Nodes),
+		zap.Int("chunks", len(sm.Chunks)))
+}
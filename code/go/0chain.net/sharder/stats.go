@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"0chain.net/chaincore/diagnostics"
+	"0chain.net/sharder/blockstore"
 	"github.com/rcrowley/go-metrics"
 )
 
@@ -233,4 +234,66 @@ func (sc *Chain) WriteMinioStats(w http.ResponseWriter) {
 	fmt.Fprintf(w, "<tr><td>Last Round processed</td><td>%d</td></tr>", sc.TieringStats.LastRoundUploaded)
 	fmt.Fprintf(w, "<tr><td>Last Upload time</td class='string'><td>%v</td></tr>", sc.TieringStats.LastUploadTime.Format(HealthCheckDateTimeFormat))
 	fmt.Fprintf(w, "</table>")
+
+	mbs, ok := blockstore.GetStore().(*blockstore.MultiBlockStore)
+	if !ok || !mbs.IsTiered() {
+		return
+	}
+	ts := mbs.GetTieringStats()
+	fmt.Fprintf(w, "<table width='100%%'>")
+	fmt.Fprintf(w, "<tr><th class='sheader' colspan='8'>Block Store Tiers (cold after %d rounds, cache size %d)</th></tr>",
+		ts.Policy.ColdAfterRounds, ts.Policy.CacheSize)
+	fmt.Fprintf(w, "<tr><th>Tier</th><th>Blocks</th><th>Bytes</th><th>Reads</th><th>Moved</th>"+
+		"<th>Failures</th><th>Last Round Moved</th><th>Last Moved At</th></tr>")
+	for _, tier := range ts.Tiers {
+		fmt.Fprintf(w, "<tr><td>%s</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td>"+
+			"<td class='string'>%v</td></tr>", tier.Tier, tier.Blocks, tier.Bytes, tier.Reads, tier.Moved,
+			tier.Failures, tier.LastRound, tier.LastMovedAt.Format(HealthCheckDateTimeFormat))
+	}
+	fmt.Fprintf(w, "</table>")
+}
+
+// WriteScrubberStats - writes progress and the latest findings of the block
+// store scrubber.
+func (sc *Chain) WriteScrubberStats(w http.ResponseWriter) {
+	ss := sc.Scrubber.GetStats()
+	fmt.Fprintf(w, "<table width='100%%'>")
+	fmt.Fprintf(w, "<tr><th class='sheader' colspan='3'>Cycle %d (rounds %d - %d, running: %v)</th></tr>",
+		ss.Cycle, ss.FromRound, ss.ToRound, ss.Running)
+	fmt.Fprintf(w, "<tr><td>Current Round</td><td colspan='2'>%d</td></tr>", ss.CurrentRound)
+	fmt.Fprintf(w, "<tr><td>Started At</td><td class='string' colspan='2'>%v</td></tr>",
+		ss.StartedAt.Format(HealthCheckDateTimeFormat))
+	fmt.Fprintf(w, "<tr><td>Finished At</td><td class='string' colspan='2'>%v</td></tr>",
+		ss.FinishedAt.Format(HealthCheckDateTimeFormat))
+	fmt.Fprintf(w, "<tr><th></th><th>Current</th><th>Total</th></tr>")
+	rows := []struct {
+		name           string
+		current, total int64
+	}{
+		{"Scanned", ss.Current.Scanned, ss.Total.Scanned},
+		{"Healthy", ss.Current.Healthy, ss.Total.Healthy},
+		{"Skipped", ss.Current.Skipped, ss.Total.Skipped},
+		{"Missing", ss.Current.Missing, ss.Total.Missing},
+		{"Corrupted", ss.Current.Corrupted, ss.Total.Corrupted},
+		{"Repaired", ss.Current.Repaired, ss.Total.Repaired},
+		{"Repair Failures", ss.Current.RepairFailures, ss.Total.RepairFailures},
+	}
+	for _, row := range rows {
+		fmt.Fprintf(w, "<tr><td>%s</td><td>%d</td><td>%d</td></tr>", row.name, row.current, row.total)
+	}
+	fmt.Fprintf(w, "</table>")
+
+	if len(ss.Findings) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "<table width='100%%'>")
+	fmt.Fprintf(w, "<tr><th class='sheader' colspan='5'>Latest Findings</th></tr>")
+	fmt.Fprintf(w, "<tr><th>Round</th><th>Hash</th><th>Problem</th><th>Repaired</th><th>Found At</th></tr>")
+	for i := len(ss.Findings) - 1; i >= 0; i-- {
+		f := ss.Findings[i]
+		fmt.Fprintf(w, "<tr><td>%d</td><td class='string'>%s</td><td class='string'>%s</td><td>%v</td>"+
+			"<td class='string'>%v</td></tr>", f.Round, f.Hash, f.Problem, f.Repaired,
+			f.FoundAt.Format(HealthCheckDateTimeFormat))
+	}
+	fmt.Fprintf(w, "</table>")
 }
@@ -0,0 +1,470 @@
+package sharder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/currency"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/ememorystore"
+	"0chain.net/core/logging"
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const (
+	// streamRecentRounds is the number of the latest finalized rounds kept in
+	// memory for the stream subscribers, older rounds are replayed from the
+	// block summaries and the events database
+	streamRecentRounds = 100
+	// streamMaxReplayRounds is the max number of rounds behind the latest
+	// finalized one a subscriber can resume from
+	streamMaxReplayRounds = 10000
+	// streamMaxDuration ends a stream before the write timeout of the server,
+	// the clients reconnect from the last round they got
+	streamMaxDuration = 25 * time.Second
+	// streamKeepAlive is the interval of the comments sent on idle streams
+	streamKeepAlive = 10 * time.Second
+	// streamRetry is the reconnection delay advised to the clients, ms
+	streamRetry = 1000
+)
+
+// StreamTransaction is a transaction of a finalized block sent to the
+// transactions subscribers
+type StreamTransaction struct {
+	Hash            string        `json:"hash"`
+	Round           int64         `json:"round"`
+	BlockHash       string        `json:"block_hash"`
+	ClientID        string        `json:"client_id"`
+	ToClientID      string        `json:"to_client_id,omitempty"`
+	Value           currency.Coin `json:"transaction_value"`
+	Fee             currency.Coin `json:"transaction_fee"`
+	TransactionType int           `json:"transaction_type"`
+	Status          int           `json:"transaction_status"`
+	Output          string        `json:"transaction_output,omitempty"`
+}
+
+// streamRound is a finalized round sent to the stream subscribers
+type streamRound struct {
+	block  *block.BlockSummary
+	txns   []*StreamTransaction
+	events []event.Event
+}
+
+func newStreamRound(b *block.Block, events []event.Event) *streamRound {
+	sr := &streamRound{
+		block:  b.GetSummary(),
+		txns:   make([]*StreamTransaction, 0, len(b.Txns)),
+		events: make([]event.Event, 0, len(events)),
+	}
+	// the events data is encoded now, the events database may change it
+	// while the subscribers send it
+	for _, e := range events {
+		if e.Data != nil {
+			data, err := json.Marshal(e.Data)
+			if err != nil {
+				logging.Logger.Error("stream - encoding the event data",
+					zap.Int64("round", b.Round),
+					zap.Int("tag", e.Tag),
+					zap.Error(err))
+				continue
+			}
+			e.Data = json.RawMessage(data)
+		}
+		sr.events = append(sr.events, e)
+	}
+	for _, txn := range b.Txns {
+		sr.txns = append(sr.txns, &StreamTransaction{
+			Hash:            txn.Hash,
+			Round:           b.Round,
+			BlockHash:       b.Hash,
+			ClientID:        txn.ClientID,
+			ToClientID:      txn.ToClientID,
+			Value:           txn.Value,
+			Fee:             txn.Fee,
+			TransactionType: txn.TransactionType,
+			Status:          txn.Status,
+			Output:          txn.TransactionOutput,
+		})
+	}
+	return sr
+}
+
+// streamHub keeps the latest finalized rounds and wakes up the stream
+// subscribers on each new one
+type streamHub struct {
+	mutex  sync.RWMutex
+	rounds map[int64]*streamRound
+	latest int64
+	notify chan struct{}
+}
+
+func newStreamHub() *streamHub {
+	return &streamHub{
+		rounds: make(map[int64]*streamRound, streamRecentRounds),
+		notify: make(chan struct{}),
+	}
+}
+
+// publish adds the finalized block with its events, it's the OnBlockFinalized
+// handler of the chain
+func (sh *streamHub) publish(b *block.Block, events []event.Event) {
+	sr := newStreamRound(b, events)
+
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+	sh.rounds[b.Round] = sr
+	if b.Round > sh.latest {
+		sh.latest = b.Round
+	}
+	for r := range sh.rounds {
+		if r <= sh.latest-streamRecentRounds {
+			delete(sh.rounds, r)
+		}
+	}
+	close(sh.notify)
+	sh.notify = make(chan struct{})
+}
+
+// state returns the latest published round and a channel closed on the next
+// publication
+func (sh *streamHub) state() (int64, <-chan struct{}) {
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+	return sh.latest, sh.notify
+}
+
+func (sh *streamHub) get(round int64) *streamRound {
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+	return sh.rounds[round]
+}
+
+type streamMessage struct {
+	event string
+	data  interface{}
+}
+
+// streamSubscription selects the messages of the finalized rounds sent to a
+// subscriber
+type streamSubscription struct {
+	filter func(sr *streamRound) []streamMessage
+	// txns and events are loaded for the replayed rounds if needed
+	txns   bool
+	events bool
+	tags   []int
+	// marker sends a round message carrying the cursor after the messages of
+	// each round, otherwise the messages carry it
+	marker bool
+}
+
+func blockStreamSubscription() *streamSubscription {
+	return &streamSubscription{
+		filter: func(sr *streamRound) []streamMessage {
+			return []streamMessage{{event: "block", data: sr.block}}
+		},
+	}
+}
+
+func txnStreamSubscription(hashes, clientIDs []string) *streamSubscription {
+	var (
+		hs = make(map[string]struct{}, len(hashes))
+		cs = make(map[string]struct{}, len(clientIDs))
+	)
+	for _, h := range hashes {
+		hs[h] = struct{}{}
+	}
+	for _, c := range clientIDs {
+		cs[c] = struct{}{}
+	}
+	return &streamSubscription{
+		filter: func(sr *streamRound) []streamMessage {
+			var msgs []streamMessage
+			for _, txn := range sr.txns {
+				_, byHash := hs[txn.Hash]
+				_, byClient := cs[txn.ClientID]
+				_, byToClient := cs[txn.ToClientID]
+				if byHash || byClient || byToClient {
+					msgs = append(msgs, streamMessage{event: "transaction", data: txn})
+				}
+			}
+			return msgs
+		},
+		txns:   true,
+		marker: true,
+	}
+}
+
+func eventStreamSubscription(tags []int) *streamSubscription {
+	ts := make(map[int]struct{}, len(tags))
+	for _, t := range tags {
+		ts[t] = struct{}{}
+	}
+	return &streamSubscription{
+		filter: func(sr *streamRound) []streamMessage {
+			var msgs []streamMessage
+			for _, e := range sr.events {
+				if _, ok := ts[e.Tag]; ok || len(ts) == 0 {
+					msgs = append(msgs, streamMessage{event: "event", data: e})
+				}
+			}
+			return msgs
+		},
+		events: true,
+		tags:   tags,
+		marker: true,
+	}
+}
+
+// writeStreamMessage writes a server-sent event, the id is omitted if zero
+func writeStreamMessage(w io.Writer, id int64, name string, data interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if id > 0 {
+		fmt.Fprintf(&buf, "id: %d\n", id)
+	}
+	fmt.Fprintf(&buf, "event: %s\ndata: %s\n\n", name, body)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func writeStreamRound(w io.Writer, round int64, sr *streamRound, sub *streamSubscription) error {
+	var id int64
+	if !sub.marker {
+		id = round
+	}
+	for _, msg := range sub.filter(sr) {
+		if err := writeStreamMessage(w, id, msg.event, msg.data); err != nil {
+			return err
+		}
+	}
+	if !sub.marker {
+		return nil
+	}
+	return writeStreamMessage(w, round, "round", map[string]interface{}{
+		"round":      round,
+		"block_hash": sr.block.Hash,
+	})
+}
+
+// streamCursor returns the first round to send: the one after the
+// Last-Event-ID of a reconnecting client, the from_round parameter or the
+// next finalized round by default
+func streamCursor(r *http.Request, latest int64) (int64, error) {
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		round, err := strconv.ParseInt(id, 10, 64)
+		if err != nil || round < 0 {
+			return 0, common.InvalidRequest("invalid Last-Event-ID")
+		}
+		return round + 1, nil
+	}
+	if v := r.FormValue("from_round"); v != "" {
+		round, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || round <= 0 {
+			return 0, common.InvalidRequest("invalid from_round")
+		}
+		return round, nil
+	}
+	return latest + 1, nil
+}
+
+// getStreamRound returns the finalized round from the recent ones or replays
+// it from the stores
+func (sc *Chain) getStreamRound(ctx context.Context, round int64, sub *streamSubscription) (
+	*streamRound, error) {
+
+	if sr := sc.stream.get(round); sr != nil {
+		return sr, nil
+	}
+
+	hash, err := sc.GetBlockHash(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	bctx := ememorystore.WithEntityConnection(ctx, datastore.GetEntityMetadata("block_summary"))
+	defer ememorystore.Close(bctx)
+	bs, err := sc.GetBlockSummary(bctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	sr := &streamRound{block: bs}
+	if !sub.txns && !sub.events {
+		return sr, nil
+	}
+
+	edb := sc.GetEventDb()
+	if edb == nil {
+		return nil, common.NewError("stream", "no events database to replay the round")
+	}
+	if sub.txns {
+		// a negative limit is no limit
+		txns, err := edb.GetTransactionByBlockHash(hash, common2.Pagination{Limit: -1})
+		if err != nil {
+			return nil, err
+		}
+		for _, txn := range txns {
+			sr.txns = append(sr.txns, &StreamTransaction{
+				Hash:            txn.Hash,
+				Round:           round,
+				BlockHash:       hash,
+				ClientID:        txn.ClientId,
+				ToClientID:      txn.ToClientId,
+				Value:           txn.Value,
+				Fee:             txn.Fee,
+				TransactionType: txn.TransactionType,
+				Status:          txn.Status,
+				Output:          txn.TransactionOutput,
+			})
+		}
+	}
+	if sub.events {
+		if sr.events, err = edb.GetRoundEvents(ctx, round, sub.tags); err != nil {
+			return nil, err
+		}
+	}
+	return sr, nil
+}
+
+// serveStream sends the finalized rounds to the subscriber as server-sent
+// events, replaying the missed ones first. The stream ends before the write
+// timeout of the server, the clients resume from the id of the last round.
+func (sc *Chain) serveStream(w http.ResponseWriter, r *http.Request, sub *streamSubscription) {
+	flusher, ok := w.(http.Flusher)
+	if !ok || sc.stream == nil {
+		common.Respond(w, r, nil, common.NewError("stream", "streaming is not supported"))
+		return
+	}
+
+	latest, notify := sc.stream.state()
+	if latest == 0 {
+		if lfb := sc.GetLatestFinalizedBlock(); lfb != nil {
+			latest = lfb.Round
+		}
+	}
+	cursor, err := streamCursor(r, latest)
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+	if cursor < latest-streamMaxReplayRounds {
+		common.Respond(w, r, nil, common.InvalidRequest(
+			fmt.Sprintf("can't replay more than %d rounds", streamMaxReplayRounds)))
+		return
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", streamRetry); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	ctx, cancel := context.WithTimeout(r.Context(), streamMaxDuration)
+	defer cancel()
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		for ; cursor <= latest; cursor++ {
+			if ctx.Err() != nil {
+				return
+			}
+			sr, err := sc.getStreamRound(ctx, cursor, sub)
+			if err != nil {
+				logging.Logger.Error("stream - get round failed",
+					zap.Int64("round", cursor), zap.Error(err))
+				_ = writeStreamMessage(w, 0, "error", err.Error())
+				return
+			}
+			if err := writeStreamRound(w, cursor, sr, sub); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-notify:
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+		var l int64
+		if l, notify = sc.stream.state(); l > latest {
+			latest = l
+		}
+	}
+}
+
+func splitStreamParam(r *http.Request, name string) []string {
+	var values []string
+	for _, v := range strings.Split(r.FormValue(name), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// StreamEnabled reports whether the sharder streams the finalized rounds, the
+// events database then stores the data of the events to replay them.
+func StreamEnabled() bool {
+	return viper.GetBool("server_chain.stream.enabled")
+}
+
+// StreamBlocksHandler - streams the summaries of the finalized blocks as
+// server-sent events from the from_round parameter or the Last-Event-ID
+func StreamBlocksHandler(w http.ResponseWriter, r *http.Request) {
+	GetSharderChain().serveStream(w, r, blockStreamSubscription())
+}
+
+// StreamTransactionsHandler - streams the finalized transactions of the
+// comma separated hash or client_id parameters as server-sent events, a
+// client matches the sent and the received transactions
+func StreamTransactionsHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		hashes    = splitStreamParam(r, "hash")
+		clientIDs = splitStreamParam(r, "client_id")
+	)
+	if len(hashes) == 0 && len(clientIDs) == 0 {
+		common.Respond(w, r, nil, common.InvalidRequest("hash or client_id is required"))
+		return
+	}
+	GetSharderChain().serveStream(w, r, txnStreamSubscription(hashes, clientIDs))
+}
+
+// StreamEventsHandler - streams the events of the finalized blocks, all of
+// them or the ones of the comma separated tag parameter, as server-sent events
+func StreamEventsHandler(w http.ResponseWriter, r *http.Request) {
+	var tags []int
+	for _, v := range splitStreamParam(r, "tag") {
+		tag, err := strconv.Atoi(v)
+		if err != nil || tag <= int(event.TagNone) || tag >= int(event.NumberOfTags) {
+			common.Respond(w, r, nil, common.InvalidRequest("invalid tag: "+v))
+			return
+		}
+		tags = append(tags, tag)
+	}
+	GetSharderChain().serveStream(w, r, eventStreamSubscription(tags))
+}
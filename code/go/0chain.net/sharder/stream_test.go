@@ -0,0 +1,141 @@
+package sharder
+
+import (
+	"bytes"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/stretchr/testify/require"
+)
+
+func newStreamTestBlock(round int64, txns ...*transaction.Transaction) *block.Block {
+	b := block.NewBlock("", round)
+	b.Hash = fmt.Sprintf("hash%d", round)
+	b.Txns = txns
+	return b
+}
+
+func TestStreamHub(t *testing.T) {
+	sh := newStreamHub()
+	latest, notify := sh.state()
+	require.Zero(t, latest)
+
+	sh.publish(newStreamTestBlock(1), nil)
+	select {
+	case <-notify:
+	default:
+		t.Fatal("subscribers are not notified")
+	}
+
+	for r := int64(2); r <= streamRecentRounds+10; r++ {
+		sh.publish(newStreamTestBlock(r), nil)
+	}
+	latest, _ = sh.state()
+	require.EqualValues(t, streamRecentRounds+10, latest)
+	require.Len(t, sh.rounds, streamRecentRounds)
+	require.Nil(t, sh.get(10))
+	require.NotNil(t, sh.get(11))
+	require.EqualValues(t, 11, sh.get(11).block.Round)
+}
+
+func TestStreamSubscriptions(t *testing.T) {
+	b := newStreamTestBlock(5,
+		&transaction.Transaction{HashIDField: datastore.HashIDField{Hash: "t1"}, ClientID: "alice", ToClientID: "bob"},
+		&transaction.Transaction{HashIDField: datastore.HashIDField{Hash: "t2"}, ClientID: "carol"},
+	)
+	sr := newStreamRound(b, []event.Event{
+		{BlockNumber: 5, Tag: int(event.TagAddTransaction)},
+		{BlockNumber: 5, Tag: int(event.TagAddBlock), Data: map[string]interface{}{"hash": "b5"}},
+	})
+
+	tt := []struct {
+		name  string
+		sub   *streamSubscription
+		count int
+		want  []string
+	}{
+		{
+			name:  "blocks",
+			sub:   blockStreamSubscription(),
+			count: 1,
+			want:  []string{"id: 5\nevent: block\n"},
+		},
+		{
+			name:  "transactions by receiver",
+			sub:   txnStreamSubscription(nil, []string{"bob"}),
+			count: 1,
+			want:  []string{"event: transaction\n", `"hash":"t1"`, "id: 5\nevent: round\n"},
+		},
+		{
+			name:  "transactions by hash",
+			sub:   txnStreamSubscription([]string{"t1", "t2"}, nil),
+			count: 2,
+		},
+		{
+			name:  "no matching transactions",
+			sub:   txnStreamSubscription(nil, []string{"dave"}),
+			count: 0,
+			want:  []string{"id: 5\nevent: round\n"},
+		},
+		{
+			name:  "all events",
+			sub:   eventStreamSubscription(nil),
+			count: 2,
+		},
+		{
+			name:  "events by tag",
+			sub:   eventStreamSubscription([]int{int(event.TagAddBlock)}),
+			count: 1,
+			want:  []string{"event: event\n", `"data":{"hash":"b5"}`},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Len(t, tc.sub.filter(sr), tc.count)
+
+			var buf bytes.Buffer
+			require.NoError(t, writeStreamRound(&buf, 5, sr, tc.sub))
+			for _, want := range tc.want {
+				require.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestStreamCursor(t *testing.T) {
+	tt := []struct {
+		name        string
+		url         string
+		lastEventID string
+		want        int64
+		wantErr     bool
+	}{
+		{name: "next round", url: "/v1/stream/blocks", want: 11},
+		{name: "from round", url: "/v1/stream/blocks?from_round=3", want: 3},
+		{name: "reconnect", url: "/v1/stream/blocks?from_round=3", lastEventID: "7", want: 8},
+		{name: "invalid from round", url: "/v1/stream/blocks?from_round=x", wantErr: true},
+		{name: "invalid last event id", url: "/v1/stream/blocks", lastEventID: "x", wantErr: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tc.url, nil)
+			if tc.lastEventID != "" {
+				r.Header.Set("Last-Event-ID", tc.lastEventID)
+			}
+			cursor, err := streamCursor(r, 10)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, cursor)
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net/http"
+	"strconv"
 	"time"
 
 	"0chain.net/chaincore/block"
@@ -35,6 +37,42 @@ func (sc *Chain) GetTransactionSummary(ctx context.Context, hash string) (*trans
 	return txnSummary, nil
 }
 
+// txnNotFoundError returns transaction.ErrTxnExpired for a transaction not
+// found in the chain once the latest finalized block is past the
+// valid_until_round or the valid_until bounds of the request, such a
+// transaction can't be included in a block anymore
+func (sc *Chain) txnNotFoundError(r *http.Request, err error) error {
+	if cerr, ok := err.(*common.Error); !ok || cerr.Code != datastore.EntityNotFound {
+		return err
+	}
+
+	var vb transaction.ValidityBound
+	if v := r.FormValue("valid_until_round"); v != "" {
+		round, perr := strconv.ParseInt(v, 10, 64)
+		if perr != nil || round < 0 {
+			return common.InvalidRequest("invalid valid_until_round")
+		}
+		vb.Round = round
+	}
+	if v := r.FormValue("valid_until"); v != "" {
+		ts, perr := strconv.ParseInt(v, 10, 64)
+		if perr != nil || ts < 0 {
+			return common.InvalidRequest("invalid valid_until")
+		}
+		vb.Timestamp = common.Timestamp(ts)
+	}
+
+	lfb := sc.GetLatestFinalizedBlock()
+	if lfb == nil {
+		return err
+	}
+	txn := &transaction.Transaction{ValidUntil: &vb}
+	if txn.IsExpired(lfb.Round, lfb.CreationDate) {
+		return transaction.ErrTxnExpired
+	}
+	return err
+}
+
 /*GetTransactionConfirmation - given a transaction return the confirmation of it's presence in the block chain */
 func (sc *Chain) GetTransactionConfirmation(ctx context.Context, hash string) (*transaction.Confirmation, error) {
 	var ts *transaction.TransactionSummary
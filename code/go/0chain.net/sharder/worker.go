@@ -42,11 +42,22 @@ func SetupWorkers(ctx context.Context) {
 	go sc.UpdateMagicBlockWorker(ctx)
 	go sc.RegisterSharderKeepWorker(ctx)
 	// Move old blocks to cloud
-	if viper.GetBool("minio.enabled") {
+	if mbs, ok := blockstore.GetStore().(*blockstore.MultiBlockStore); ok && mbs.IsTiered() {
+		go sc.TieringWorker(ctx, mbs)
+	} else if viper.GetBool("minio.enabled") {
 		go sc.MinioWorker(ctx)
 	}
 
 	go sc.SharderHealthCheck(ctx)
+
+	if sc.Scrubber != nil {
+		go sc.Scrubber.Worker(ctx)
+	}
+
+	if pbs, ok := blockstore.GetStore().(*blockstore.PackBlockStore); ok {
+		viper.SetDefault("server_chain.block.storage.pack.compaction_interval", 10*time.Minute)
+		go pbs.CompactionWorker(ctx, viper.GetDuration("server_chain.block.storage.pack.compaction_interval"))
+	}
 }
 
 /*BlockWorker - stores the blocks */
@@ -413,6 +424,34 @@ func (sc *Chain) MinioWorker(ctx context.Context) {
 	}
 }
 
+// TieringWorker - periodically moves the old finalized blocks to the cold
+// tier of the tiered block store.
+func (sc *Chain) TieringWorker(ctx context.Context, mbs *blockstore.MultiBlockStore) {
+	ticker := time.NewTicker(time.Duration(viper.GetInt64("minio.worker_frequency")) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			lfb := sc.GetLatestFinalizedBlock()
+			if lfb == nil {
+				continue
+			}
+			moved, err := mbs.Migrate(ctx, lfb.Round, sc.GetBlockHash)
+			if err != nil {
+				logging.Logger.Error("tiering - migration failed", zap.Error(err),
+					zap.Int("moved", moved))
+				continue
+			}
+			if moved > 0 {
+				logging.Logger.Info("tiering - blocks moved to cold tier", zap.Int("moved", moved),
+					zap.Int64("lfb_round", lfb.Round))
+			}
+		}
+	}
+}
+
 func (sc *Chain) moveBlockToCloud(ctx context.Context, round int64, hash string, fs blockstore.BlockStore, swg *sizedwaitgroup.SizedWaitGroup) {
 	err := fs.UploadToCloud(hash, round)
 	if err != nil {
@@ -29,8 +29,11 @@ const (
 	Vesting
 	VestingRest
 	MultiSig
+	MultiSigRest
 	ZCNSCBridge
 	ZCNSCBridgeRest
+	Governance
+	GovernanceRest
 	Control
 	NumberOdfBenchmarkSources
 )
@@ -46,8 +49,11 @@ var (
 		"vesting",
 		"vesting_rest",
 		"multi_sig",
+		"multi_sig_rest",
 		"zcnscbridge",
 		"zcnscbridge_rest",
+		"governance",
+		"governance_rest",
 		"control",
 	}
 
@@ -61,8 +67,11 @@ var (
 		SourceNames[Vesting]:         Vesting,
 		SourceNames[VestingRest]:     VestingRest,
 		SourceNames[MultiSig]:        MultiSig,
+		SourceNames[MultiSigRest]:    MultiSigRest,
 		SourceNames[ZCNSCBridge]:     ZCNSCBridge,
 		SourceNames[ZCNSCBridgeRest]: ZCNSCBridgeRest,
+		SourceNames[Governance]:      Governance,
+		SourceNames[GovernanceRest]:  GovernanceRest,
 		SourceNames[Control]:         Control,
 	}
 )
@@ -107,6 +116,7 @@ const (
 	FaucetSc      = "faucetsc."
 	VestingSc     = "vestingsc."
 	ZcnSc         = "zcnsc."
+	GovernanceSc  = "governancesc."
 	DbsEvents     = "dbs.Events."
 
 	BlockReward = "block_reward."
@@ -190,6 +200,17 @@ const (
 	StorageBlockRewardZetaK           = SmartContract + StorageSc + BlockReward + "zeta.k"
 	StorageBlockRewardZetaMu          = SmartContract + StorageSc + BlockReward + "zeta.mu"
 
+	StorageAutoRenewEnabled     = SmartContract + StorageSc + "auto_renew.enabled"
+	StorageAutoRenewRenewWindow = SmartContract + StorageSc + "auto_renew.renew_window"
+	StorageAutoRenewMinPeriod   = SmartContract + StorageSc + "auto_renew.min_period"
+	StorageAutoRenewMaxRenewals = SmartContract + StorageSc + "auto_renew.max_renewals"
+
+	StorageReputationInitialScore      = SmartContract + StorageSc + "reputation.initial_score"
+	StorageReputationChallengeWeight   = SmartContract + StorageSc + "reputation.challenge_weight"
+	StorageReputationHealthCheckWeight = SmartContract + StorageSc + "reputation.health_check_weight"
+	StorageReputationHalfLife          = SmartContract + StorageSc + "reputation.half_life"
+	StorageReputationSelectionWeight   = SmartContract + StorageSc + "reputation.selection_weight"
+
 	VestingPoolOwner            = SmartContract + VestingSc + "owner_id"
 	VestingMinLock              = SmartContract + VestingSc + "min_lock"
 	VestingMaxDestinations      = SmartContract + VestingSc + "max_destinations"
@@ -199,6 +220,8 @@ const (
 
 	FaucetOwner = SmartContract + FaucetSc + "owner_id"
 
+	GovernanceOwner = SmartContract + GovernanceSc + "owner_id"
+
 	ZcnOwner              = SmartContract + ZcnSc + "owner_id"
 	ZcnMinMintAmount      = SmartContract + ZcnSc + "min_mint"
 	ZcnMinBurnAmount      = SmartContract + ZcnSc + "min_burn"
@@ -20,6 +20,7 @@ import (
 	bk "0chain.net/smartcontract/benchmark"
 	"0chain.net/smartcontract/benchmark/main/cmd/log"
 	"0chain.net/smartcontract/faucetsc"
+	"0chain.net/smartcontract/governancesc"
 	"0chain.net/smartcontract/minersc"
 	"0chain.net/smartcontract/multisigsc"
 	"0chain.net/smartcontract/storagesc"
@@ -42,8 +43,11 @@ var benchmarkSources = map[bk.Source]func(data bk.BenchData, sigScheme bk.Signat
 	bk.Vesting:         vestingsc.BenchmarkTests,
 	bk.VestingRest:     vestingsc.BenchmarkRestTests,
 	bk.MultiSig:        multisigsc.BenchmarkTests,
+	bk.MultiSigRest:    multisigsc.BenchmarkRestTests,
 	bk.ZCNSCBridge:     zcnsc.BenchmarkTests,
 	bk.ZCNSCBridgeRest: zcnsc.BenchmarkRestTests,
+	bk.Governance:      governancesc.BenchmarkTests,
+	bk.GovernanceRest:  governancesc.BenchmarkRestTests,
 	bk.Control:         control.BenchmarkTests,
 }
 
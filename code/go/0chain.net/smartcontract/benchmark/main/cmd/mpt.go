@@ -21,6 +21,7 @@ import (
 	"0chain.net/core/datastore"
 	"0chain.net/smartcontract/benchmark/main/cmd/control"
 	ebk "0chain.net/smartcontract/dbs/benchmark"
+	"0chain.net/smartcontract/governancesc"
 	"0chain.net/smartcontract/multisigsc"
 	"0chain.net/smartcontract/vestingsc"
 
@@ -366,6 +367,14 @@ func setUpMpt(
 		log.Println("added free storage assigners\t", time.Since(timer))
 	}()
 	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := time.Now()
+		storagesc.AddMockUnbondingQueues(clients, balances)
+		minersc.AddMockUnbondingQueues(clients, balances)
+		log.Println("added unbonding queues\t", time.Since(timer))
+	}()
+	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		timer := time.Now()
@@ -384,6 +393,7 @@ func setUpMpt(
 		defer wg.Done()
 		timer := time.Now()
 		faucetsc.AddMockUserNodes(clients, balances)
+		faucetsc.AddMockAllowlist(clients, balances)
 		log.Println("added faucet user nodes\t", time.Since(timer))
 	}()
 
@@ -392,6 +402,7 @@ func setUpMpt(
 		defer wg.Done()
 		timer := time.Now()
 		multisigsc.AddMockWallets(clients, publicKeys, balances)
+		multisigsc.AddMockProposals(clients, common.Now(), balances)
 		log.Println("added client wallets\t", time.Since(timer))
 	}()
 	wg.Add(1)
@@ -409,6 +420,14 @@ func setUpMpt(
 		vestingsc.AddMockConfig(balances)
 		log.Println("added vesting pools\t", time.Since(timer))
 	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := time.Now()
+		governancesc.AddMockConfig(balances)
+		governancesc.AddMockProposals(clients, eventDb, balances)
+		log.Println("added governance proposals\t", time.Since(timer))
+	}()
 
 	wg.Add(1)
 	go func() {
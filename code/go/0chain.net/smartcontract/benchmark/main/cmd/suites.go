@@ -6,7 +6,9 @@ import (
 	"0chain.net/core/common"
 	"0chain.net/core/viper"
 	"0chain.net/smartcontract/faucetsc"
+	"0chain.net/smartcontract/governancesc"
 	"0chain.net/smartcontract/minersc"
+	"0chain.net/smartcontract/multisigsc"
 	"0chain.net/smartcontract/rest"
 	"0chain.net/smartcontract/storagesc"
 	"0chain.net/smartcontract/vestingsc"
@@ -69,7 +71,9 @@ func runSuites(
 		},
 	}
 	faucetsc.SetupRestHandler(restSetup)
+	governancesc.SetupRestHandler(restSetup)
 	minersc.SetupRestHandler(restSetup)
+	multisigsc.SetupRestHandler(restSetup)
 	storagesc.SetupRestHandler(restSetup)
 	vestingsc.SetupRestHandler(restSetup)
 	zcnsc.SetupRestHandler(restSetup)
@@ -5,6 +5,12 @@ import (
 	"gorm.io/gorm"
 )
 
+// the supported event database drivers
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
 type Store interface {
 	Get() *gorm.DB
 	Open(config config.DbAccess) error
@@ -94,6 +94,16 @@ func (edb EventDb) GetClientsAllocation(clientID string, limit common.Pagination
 	return allocs, nil
 }
 
+// GetAllocations - get a page of all the allocations, in the order they were
+// added.
+func (edb EventDb) GetAllocations(limit common.Pagination) ([]Allocation, error) {
+	var allocs []Allocation
+	return allocs, edb.Store.Get().Model(&Allocation{}).Offset(limit.Offset).Limit(limit.Limit).Order(clause.OrderByColumn{
+		Column: clause.Column{Name: "id"},
+		Desc:   limit.IsDescending,
+	}).Find(&allocs).Error
+}
+
 func (edb EventDb) GetActiveAllocationsCount() (int64, error) {
 	var count int64
 	result := edb.Store.Get().Model(&Allocation{}).Where("finalized = ? AND cancelled = ?", false, false).Count(&count)
@@ -0,0 +1,71 @@
+package event
+
+import (
+	"errors"
+
+	"0chain.net/chaincore/currency"
+	"gorm.io/gorm"
+)
+
+// AllocationRenewal - the auto renewal subscription of an allocation.
+type AllocationRenewal struct {
+	gorm.Model
+	AllocationID   string        `json:"allocation_id" gorm:"uniqueIndex"`
+	Owner          string        `json:"owner"`
+	Active         bool          `json:"active"`
+	Period         int64         `json:"period"`
+	WritePoolTopUp currency.Coin `json:"write_pool_top_up"`
+	Balance        currency.Coin `json:"balance"`
+	NextRenewal    int64         `json:"next_renewal"`
+	LastRenewal    int64         `json:"last_renewal"`
+	Renewals       int64         `json:"renewals"`
+	LastError      string        `json:"last_error"`
+}
+
+// GetAllocationRenewal - get the auto renewal of the allocation, nil if the
+// allocation is not auto renewed.
+func (edb *EventDb) GetAllocationRenewal(allocationID string) (*AllocationRenewal, error) {
+	var ar AllocationRenewal
+	err := edb.Store.Get().Model(&AllocationRenewal{}).
+		Where("allocation_id = ?", allocationID).
+		Take(&ar).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+func (edb *EventDb) addOrOverwriteAllocationRenewal(ar AllocationRenewal) error {
+	var id uint
+	err := edb.Store.Get().Model(&AllocationRenewal{}).Select("id").
+		Where("allocation_id = ?", ar.AllocationID).
+		Take(&id).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return edb.Store.Get().Create(&ar).Error
+	case err != nil:
+		return err
+	}
+
+	return edb.Store.Get().Model(&AllocationRenewal{}).
+		Where("allocation_id = ?", ar.AllocationID).
+		Updates(map[string]interface{}{
+			"owner":             ar.Owner,
+			"active":            ar.Active,
+			"period":            ar.Period,
+			"write_pool_top_up": ar.WritePoolTopUp,
+			"balance":           ar.Balance,
+			"next_renewal":      ar.NextRenewal,
+			"last_renewal":      ar.LastRenewal,
+			"renewals":          ar.Renewals,
+			"last_error":        ar.LastError,
+		}).Error
+}
+
+func (edb *EventDb) deleteAllocationRenewal(allocationID string) error {
+	return edb.Store.Get().Where("allocation_id = ?", allocationID).
+		Delete(&AllocationRenewal{}).Error
+}
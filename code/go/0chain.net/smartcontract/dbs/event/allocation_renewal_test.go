@@ -0,0 +1,45 @@
+package event
+
+import (
+	"testing"
+
+	"0chain.net/smartcontract/dbs/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDb_AllocationRenewal(t *testing.T) {
+	edb := newSqliteEventDb(t, sqlite.InMemory)
+
+	ar, err := edb.GetAllocationRenewal("alloc")
+	require.NoError(t, err)
+	assert.Nil(t, ar)
+
+	require.NoError(t, edb.addStat(Event{
+		Type: int(TypeStats),
+		Tag:  int(TagAddOrOverwriteAllocationRenewal),
+		Data: AllocationRenewal{AllocationID: "alloc", Owner: "owner", Active: true, Period: 600, Balance: 10},
+	}))
+	require.NoError(t, edb.addStat(Event{
+		Type: int(TypeStats),
+		Tag:  int(TagAddOrOverwriteAllocationRenewal),
+		Data: AllocationRenewal{AllocationID: "alloc", Owner: "owner", Period: 600, Renewals: 1, LastError: "failed"},
+	}))
+
+	ar, err = edb.GetAllocationRenewal("alloc")
+	require.NoError(t, err)
+	require.NotNil(t, ar)
+	assert.False(t, ar.Active)
+	assert.Zero(t, ar.Balance)
+	assert.EqualValues(t, 1, ar.Renewals)
+	assert.Equal(t, "failed", ar.LastError)
+
+	require.NoError(t, edb.addStat(Event{
+		Type: int(TypeStats),
+		Tag:  int(TagDeleteAllocationRenewal),
+		Data: "alloc",
+	}))
+	ar, err = edb.GetAllocationRenewal("alloc")
+	require.NoError(t, err)
+	assert.Nil(t, ar)
+}
@@ -61,6 +61,10 @@ type Blobber struct {
 	ChallengesPassed    uint64  `json:"challenges_passed"`
 	ChallengesCompleted uint64  `json:"challenges_completed"`
 	RankMetric          float64 `json:"rank_metric" gorm:"index"` // currently ChallengesPassed / ChallengesCompleted
+	// Reputation is the decaying challenges based score of the blobber at
+	// the ReputationUpdated time
+	Reputation        float64 `json:"reputation" gorm:"index"`
+	ReputationUpdated int64   `json:"reputation_updated"`
 
 	WriteMarkers []WriteMarker `gorm:"foreignKey:BlobberID;references:BlobberID"`
 	ReadMarkers  []ReadMarker  `gorm:"foreignKey:BlobberID;references:BlobberID"`
@@ -118,17 +122,49 @@ func (edb *EventDb) updateBlobberChallenges(challenge dbs.ChallengeResult) error
 	return edb.updateBlobber(*update)
 }
 
-func (edb *EventDb) GetBlobberRank(blobberId string) (int64, error) {
+// ReputationDecay is the decay of the blobbers reputation to the initial
+// score, the blobbers are ranked on their reputation decayed to the Now time.
+type ReputationDecay struct {
+	InitialScore float64
+	HalfLife     time.Duration
+	Now          int64
+}
+
+func (rd ReputationDecay) value(b *Blobber) float64 {
+	if rd.HalfLife <= 0 || rd.Now <= b.ReputationUpdated {
+		return b.Reputation
+	}
+	decay := math.Pow(0.5, float64(rd.Now-b.ReputationUpdated)/rd.HalfLife.Seconds())
+	return rd.InitialScore + (b.Reputation-rd.InitialScore)*decay
+}
+
+// GetBlobberRank returns the rank of the blobber by the decayed reputation,
+// then by the challenges passed.
+func (edb *EventDb) GetBlobberRank(blobberId string, decay ReputationDecay) (int64, error) {
 	blobber, err := edb.GetBlobber(blobberId)
 	if err != nil {
 		return 0, err
 	}
-	var rank int64
-	result := edb.Store.Get().
+	var blobbers []Blobber
+	err = edb.Store.Get().
 		Model(&Blobber{}).
-		Where("rank_metric > ?", blobber.RankMetric).
-		Count(&rank)
-	return rank + 1, result.Error
+		Select("blobber_id", "reputation", "reputation_updated", "rank_metric").
+		Find(&blobbers).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		rank       int64 = 1
+		reputation       = decay.value(blobber)
+	)
+	for i := range blobbers {
+		r := decay.value(&blobbers[i])
+		if r > reputation || (r == reputation && blobbers[i].RankMetric > blobber.RankMetric) {
+			rank++
+		}
+	}
+	return rank, nil
 }
 
 func (edb *EventDb) BlobberTotalCapacity() (int64, error) {
@@ -314,6 +350,8 @@ func (edb *EventDb) overwriteBlobber(blobber Blobber) error {
 			"website_url":          blobber.WebsiteUrl,
 			"logo_url":             blobber.LogoUrl,
 			"description":          blobber.Description,
+			"reputation":           blobber.Reputation,
+			"reputation_updated":   blobber.ReputationUpdated,
 		}).Error
 }
 
@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"0chain.net/smartcontract/dbs"
+	"0chain.net/smartcontract/dbs/sqlite"
 )
 
 func init() {
@@ -376,3 +377,46 @@ func setUpBlobbers(t *testing.T, eventDb *EventDb) {
 		}
 	}
 }
+
+func TestGetBlobberRank(t *testing.T) {
+	edb := newSqliteEventDb(t, sqlite.InMemory)
+
+	for _, b := range []Blobber{
+		{BlobberID: "a", BaseURL: "a", Reputation: 0.5, RankMetric: 1},
+		{BlobberID: "b", BaseURL: "b", Reputation: 0.9},
+		{BlobberID: "c", BaseURL: "c", Reputation: 0.5},
+		{BlobberID: "d", BaseURL: "d", Reputation: 0.99},
+	} {
+		require.NoError(t, edb.addOrOverwriteBlobber(b))
+	}
+	require.NoError(t, edb.updateBlobber(dbs.DbUpdates{
+		Id:      "c",
+		Updates: map[string]interface{}{"reputation": 0.95, "reputation_updated": 3600},
+	}))
+
+	tests := []struct {
+		name  string
+		decay ReputationDecay
+		want  map[string]int64
+	}{
+		{
+			name: "no decay",
+			want: map[string]int64{"d": 1, "c": 2, "b": 3, "a": 4},
+		},
+		{
+			// d decays by two half lives to 0.5+0.49/4, c by one to 0.5+0.45/2
+			name:  "decayed",
+			decay: ReputationDecay{InitialScore: 0.5, HalfLife: time.Hour, Now: 7200},
+			want:  map[string]int64{"c": 1, "d": 2, "b": 3, "a": 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for id, want := range tt.want {
+				rank, err := edb.GetBlobberRank(id, tt.decay)
+				require.NoError(t, err)
+				require.Equal(t, want, rank, id)
+			}
+		})
+	}
+}
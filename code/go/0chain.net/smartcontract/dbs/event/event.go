@@ -1,9 +1,12 @@
 package event
 
 import (
+	"encoding/json"
 	"errors"
 
+	"0chain.net/core/logging"
 	"0chain.net/smartcontract/common"
+	"go.uber.org/zap"
 	"golang.org/x/net/context"
 	"gorm.io/gorm/clause"
 
@@ -18,6 +21,9 @@ type Event struct {
 	Tag         int         `json:"tag" gorm:"index:idx_event"`
 	Index       string      `json:"index" gorm:"index:idx_event"`
 	Data        interface{} `json:"data" gorm:"-"`
+	// Payload is the JSON encoded data, stored to replay the event with
+	// its data if the events database stores the payloads
+	Payload string `json:"-"`
 }
 
 func (edb *EventDb) FindEvents(ctx context.Context, search Event, p common.Pagination) ([]Event, error) {
@@ -64,8 +70,45 @@ func (edb *EventDb) GetEvents(ctx context.Context, block int64) ([]Event, error)
 	return events, result.Error
 }
 
+// GetRoundEvents returns the events of the block of the round in the order
+// they were added, restricted to the given tags if any. The events carry
+// their data only if it was stored, see StorePayloads.
+func (edb *EventDb) GetRoundEvents(ctx context.Context, round int64, tags []int) ([]Event, error) {
+	if edb.Store == nil {
+		return nil, errors.New("event database is nil")
+	}
+	db := edb.Store.Get().WithContext(ctx).Where("block_number = ?", round)
+	if len(tags) > 0 {
+		db = db.Where("tag IN ?", tags)
+	}
+	var events []Event
+	if err := db.Order("id").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	for i := range events {
+		if events[i].Payload != "" {
+			events[i].Data = json.RawMessage(events[i].Payload)
+		}
+	}
+	return events, nil
+}
+
 func (edb *EventDb) addEvents(ctx context.Context, events []Event) {
 	if edb.Store != nil && len(events) > 0 {
+		for i := range events {
+			if !edb.payloads || events[i].Data == nil {
+				continue
+			}
+			payload, err := json.Marshal(events[i].Data)
+			if err != nil {
+				logging.Logger.Error("events - encoding the event data",
+					zap.Int("tag", events[i].Tag),
+					zap.String("index", events[i].Index),
+					zap.Error(err))
+				continue
+			}
+			events[i].Payload = string(payload)
+		}
 		edb.Store.Get().WithContext(ctx).Create(&events)
 	}
 }
@@ -146,5 +189,45 @@ func (edb *EventDb) Drop() error {
 		return err
 	}
 
+	err = edb.Store.Get().Migrator().DropTable(&ReplayCheckpoint{})
+	if err != nil {
+		return err
+	}
+
+	err = edb.Store.Get().Migrator().DropTable(&AllocationRenewal{})
+	if err != nil {
+		return err
+	}
+
+	err = edb.Store.Get().Migrator().DropTable(&Provider{})
+	if err != nil {
+		return err
+	}
+
+	err = edb.Store.Get().Migrator().DropTable(&Slash{})
+	if err != nil {
+		return err
+	}
+
+	err = edb.Store.Get().Migrator().DropTable(&Unbonding{})
+	if err != nil {
+		return err
+	}
+
+	err = edb.Store.Get().Migrator().DropTable(&GovernanceProposal{})
+	if err != nil {
+		return err
+	}
+
+	err = edb.Store.Get().Migrator().DropTable(&GovernanceVote{})
+	if err != nil {
+		return err
+	}
+
+	err = edb.Store.Get().Migrator().DropTable(&TransactionTransfer{})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -1,6 +1,8 @@
 package event
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -9,6 +11,7 @@ import (
 
 	"0chain.net/chaincore/config"
 	"0chain.net/core/logging"
+	"0chain.net/smartcontract/dbs/sqlite"
 )
 
 func init() {
@@ -38,3 +41,58 @@ func TestSetupDatabase(t *testing.T) {
 	err = eventDb.AutoMigrate()
 	require.NoError(t, err)
 }
+
+func TestEventDb_GetRoundEvents(t *testing.T) {
+	edb := newSqliteEventDb(t, sqlite.InMemory)
+	edb.StorePayloads()
+	ctx := context.Background()
+
+	edb.addEvents(ctx, []Event{
+		{BlockNumber: 1, TxHash: "t1", Type: int(TypeStats), Tag: int(TagAddTransaction), Index: "t1"},
+		{BlockNumber: 2, TxHash: "t2", Type: int(TypeStats), Tag: int(TagAddTransaction), Index: "t2"},
+		{BlockNumber: 2, TxHash: "t2", Type: int(TypeStats), Tag: int(TagAddBlock), Index: "b2",
+			Data: map[string]interface{}{"hash": "b2"}},
+		{BlockNumber: 2, TxHash: "t3", Type: int(TypeStats), Tag: int(TagAddTransaction), Index: "t3"},
+	})
+
+	tests := []struct {
+		name  string
+		round int64
+		tags  []int
+		want  []string
+	}{
+		{name: "all", round: 2, want: []string{"t2", "b2", "t3"}},
+		{name: "by_tag", round: 2, tags: []int{int(TagAddTransaction)}, want: []string{"t2", "t3"}},
+		{name: "other_round", round: 1, tags: []int{int(TagAddTransaction), int(TagAddBlock)}, want: []string{"t1"}},
+		{name: "none", round: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := edb.GetRoundEvents(ctx, tt.round, tt.tags)
+			require.NoError(t, err)
+			var got []string
+			for _, e := range events {
+				got = append(got, e.Index)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	events, err := edb.GetRoundEvents(ctx, 2, []int{int(TagAddBlock)})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	data, err := json.Marshal(events[0])
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"data":{"hash":"b2"}`)
+	// the data isn't stored by default
+	edb = newSqliteEventDb(t, sqlite.InMemory)
+	edb.addEvents(ctx, []Event{
+		{BlockNumber: 2, TxHash: "t2", Type: int(TypeStats), Tag: int(TagAddBlock), Index: "b2",
+			Data: map[string]interface{}{"hash": "b2"}},
+	})
+	events, err = edb.GetRoundEvents(ctx, 2, nil)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Empty(t, events[0].Payload)
+	require.Nil(t, events[0].Data)
+}
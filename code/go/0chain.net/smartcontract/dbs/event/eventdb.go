@@ -1,18 +1,20 @@
 package event
 
 import (
+	"fmt"
 	"time"
 
 	"0chain.net/chaincore/config"
 	"0chain.net/core/common"
 	"0chain.net/smartcontract/dbs"
 	"0chain.net/smartcontract/dbs/postgresql"
+	"0chain.net/smartcontract/dbs/sqlite"
 )
 
 const DefaultQueryTimeout = 5 * time.Second
 
 func NewEventDb(config config.DbAccess) (*EventDb, error) {
-	db, err := postgresql.GetPostgresSqlDb(config)
+	db, err := openStore(config)
 	if err != nil {
 		return nil, err
 	}
@@ -28,9 +30,30 @@ func NewEventDb(config config.DbAccess) (*EventDb, error) {
 	return eventDb, nil
 }
 
+// openStore - open the database of the configured driver, PostgreSQL by
+// default
+func openStore(config config.DbAccess) (dbs.Store, error) {
+	switch config.Driver {
+	case "", dbs.DriverPostgres:
+		return postgresql.GetPostgresSqlDb(config)
+	case dbs.DriverSQLite:
+		return sqlite.GetSqliteDb(config)
+	default:
+		return nil, fmt.Errorf("unknown events db driver: %q", config.Driver)
+	}
+}
+
 type EventDb struct {
 	dbs.Store
 	eventsChannel chan events
+	payloads      bool
+}
+
+// StorePayloads makes the events database store the data of the events, to
+// replay the events with their data on the sharders streaming the rounds.
+// It's set before any event is added.
+func (edb *EventDb) StorePayloads() {
+	edb.payloads = true
 }
 
 type events []Event
@@ -55,6 +78,14 @@ func (edb *EventDb) AutoMigrate() error {
 		&Reward{},
 		&Authorizer{},
 		&Challenge{},
+		&ReplayCheckpoint{},
+		&AllocationRenewal{},
+		&Provider{},
+		&Slash{},
+		&Unbonding{},
+		&GovernanceProposal{},
+		&GovernanceVote{},
+		&TransactionTransfer{},
 	); err != nil {
 		return err
 	}
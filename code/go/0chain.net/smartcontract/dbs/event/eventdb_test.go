@@ -0,0 +1,134 @@
+package event
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"0chain.net/chaincore/config"
+	"0chain.net/chaincore/currency"
+	"0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs"
+	"0chain.net/smartcontract/dbs/sqlite"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSqliteEventDb(t *testing.T, name string) *EventDb {
+	edb, err := NewEventDb(config.DbAccess{
+		Enabled:      true,
+		Driver:       dbs.DriverSQLite,
+		Name:         name,
+		MaxIdleConns: 2,
+		MaxOpenConns: 4,
+	})
+	require.NoError(t, err)
+	t.Cleanup(edb.Close)
+	return edb
+}
+
+func TestNewEventDb_Driver(t *testing.T) {
+	_, err := NewEventDb(config.DbAccess{Enabled: true, Driver: "mysql"})
+	assert.Error(t, err)
+
+	for _, name := range []string{sqlite.InMemory, filepath.Join(t.TempDir(), "events.db")} {
+		edb := newSqliteEventDb(t, name)
+		// migrating again is a no-op
+		require.NoError(t, edb.AutoMigrate())
+	}
+}
+
+func TestEventDb_SQLiteEvents(t *testing.T) {
+	edb := newSqliteEventDb(t, sqlite.InMemory)
+
+	const (
+		blobberID = "blobber"
+		clientID  = "client"
+		allocID   = "allocation"
+		txnHash   = "txn"
+	)
+	stats := []Event{
+		{Tag: int(TagAddOrOverwriteUser), Data: User{UserID: clientID, Balance: 10, Nonce: 1}},
+		{Tag: int(TagAddOrOverwriteUser), Data: User{UserID: clientID, Balance: 20, Nonce: 2}},
+		{Tag: int(TagAddBlock), Data: Block{Hash: "block", Round: 7}},
+		{Tag: int(TagAddTransaction), Data: Transaction{Hash: txnHash, BlockHash: "block", ClientId: clientID}},
+		{Tag: int(TagAddOrOverwriteBlobber), Data: Blobber{BlobberID: blobberID, BaseURL: "http://blobber",
+			Capacity: 100, WritePrice: 4}},
+		{Tag: int(TagUpdateBlobber), Data: dbs.DbUpdates{Id: blobberID,
+			Updates: map[string]interface{}{"capacity": int64(200)}}},
+		{Tag: int(TagAddAllocation), Data: Allocation{AllocationID: allocID, Owner: clientID,
+			DataShards: 2, ParityShards: 1}},
+		{Tag: int(TagAddWriteMarker), TxHash: txnHash, BlockNumber: 7, Data: WriteMarker{ClientID: clientID,
+			BlobberID: blobberID, AllocationID: allocID, Size: 30, Name: "file", ContentHash: "content"}},
+		{Tag: int(TagAddOrOverwriteDelegatePool), Data: DelegatePool{PoolID: "pool",
+			ProviderType: int(spenum.Blobber), ProviderID: blobberID, DelegateID: clientID, Balance: 5}},
+		{Tag: int(TagStakePoolReward), Data: dbs.StakePoolReward{
+			StakePoolId:     dbs.StakePoolId{ProviderId: blobberID, ProviderType: int(spenum.Blobber)},
+			Reward:          3,
+			DelegateRewards: map[string]int64{"pool": 2}}},
+		{Tag: int(TagAddReward), Data: Reward{Amount: 3, BlockNumber: 7, ClientID: clientID, ProviderID: blobberID}},
+		{Tag: int(TagAddOrOverwriteMiner), Data: Miner{MinerID: "miner", TotalStaked: 50, Active: true}},
+	}
+	for _, e := range stats {
+		e.Type = int(TypeStats)
+		require.NoError(t, edb.addStat(e), "tag %d", e.Tag)
+	}
+	edb.addEvents(context.TODO(), stats)
+
+	user, err := edb.GetUser(clientID)
+	require.NoError(t, err)
+	assert.Equal(t, currency.Coin(20), user.Balance)
+	assert.EqualValues(t, 2, user.Nonce)
+
+	block, err := edb.GetBlockByRound(7)
+	require.NoError(t, err)
+	assert.Equal(t, "block", block.Hash)
+
+	txns, err := edb.GetTransactionByBlockHash("block", common.Pagination{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, txns, 1)
+
+	blobber, err := edb.GetBlobber(blobberID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 200, blobber.Capacity)
+	assert.EqualValues(t, 30, blobber.Used)
+	assert.Equal(t, currency.Coin(3), blobber.Reward)
+
+	capacity, err := edb.BlobberTotalCapacity()
+	require.NoError(t, err)
+	assert.EqualValues(t, 200, capacity)
+
+	written, err := edb.GetAllocationWrittenSizeInBlocks(0, 10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 30, written)
+
+	dps, err := edb.GetDelegatePools(blobberID, int(spenum.Blobber))
+	require.NoError(t, err)
+	require.Len(t, dps, 1)
+	assert.Equal(t, currency.Coin(2), dps[0].Reward)
+
+	claimed, err := edb.GetRewardClaimedTotal(RewardQuery{ClientID: clientID})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, claimed)
+
+	staked, err := edb.GetMinersTotalStake()
+	require.NoError(t, err)
+	assert.EqualValues(t, 50, staked)
+
+	for query, want := range map[string]string{
+		"7":       "BlockRound",
+		clientID:  "UserId",
+		txnHash:   "TransactionHash",
+		"content": "ContentHash",
+		"unknown": "Not Found",
+	} {
+		got, err := edb.GetGenericSearchType(query)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, query)
+	}
+
+	events, err := edb.GetEvents(context.TODO(), 0)
+	require.NoError(t, err)
+	assert.Len(t, events, len(stats))
+}
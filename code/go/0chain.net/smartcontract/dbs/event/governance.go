@@ -0,0 +1,142 @@
+package event
+
+import (
+	"errors"
+
+	"0chain.net/chaincore/currency"
+	"0chain.net/smartcontract/common"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// the statuses of the governance proposals
+const (
+	ProposalVoting   = "voting"
+	ProposalRejected = "rejected"
+	ProposalApplied  = "applied"
+	ProposalFailed   = "failed"
+)
+
+// GovernanceProposal - a proposal of the governance smart contract to change
+// the settings of a smart contract.
+type GovernanceProposal struct {
+	gorm.Model
+	ProposalID     string        `json:"proposal_id" gorm:"uniqueIndex"`
+	Proposer       string        `json:"proposer" gorm:"index"`
+	Description    string        `json:"description"`
+	ToClientID     string        `json:"to_client_id" gorm:"index"`
+	FunctionName   string        `json:"function_name"`
+	Changes        string        `json:"changes"` // JSON of the settings changes
+	StartRound     int64         `json:"start_round"`
+	EndRound       int64         `json:"end_round"`
+	VotesFor       currency.Coin `json:"votes_for"`
+	VotesAgainst   currency.Coin `json:"votes_against"`
+	Voters         int           `json:"voters"`
+	Status         string        `json:"status" gorm:"index"`
+	Error          string        `json:"error"`
+	FinalizedRound int64         `json:"finalized_round"`
+}
+
+// GovernanceVote - the vote of a staker for a governance proposal weighted by
+// the stake delegated by the staker.
+type GovernanceVote struct {
+	gorm.Model
+	ProposalID string        `json:"proposal_id" gorm:"uniqueIndex:idx_governance_vote"`
+	VoterID    string        `json:"voter_id" gorm:"uniqueIndex:idx_governance_vote;index"`
+	Support    bool          `json:"support"`
+	Weight     currency.Coin `json:"weight"`
+	Round      int64         `json:"round"`
+}
+
+// GovernanceProposalQuery - the filter of the governance proposals list, the
+// empty fields match all the proposals.
+type GovernanceProposalQuery struct {
+	Proposer   string
+	ToClientID string
+	Status     string
+}
+
+// GetGovernanceProposals - get the governance proposals matching the filter.
+func (edb *EventDb) GetGovernanceProposals(filter GovernanceProposalQuery,
+	limit common.Pagination) ([]GovernanceProposal, error) {
+
+	query := edb.Store.Get().Model(&GovernanceProposal{})
+	if filter.Proposer != "" {
+		query = query.Where("proposer = ?", filter.Proposer)
+	}
+	if filter.ToClientID != "" {
+		query = query.Where("to_client_id = ?", filter.ToClientID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	var proposals []GovernanceProposal
+	return proposals, query.Offset(limit.Offset).Limit(limit.Limit).Order(clause.OrderByColumn{
+		Column: clause.Column{Name: "id"},
+		Desc:   limit.IsDescending,
+	}).Find(&proposals).Error
+}
+
+// GetGovernanceVotes - get the votes of a governance proposal, or the votes
+// of a voter when the proposal ID is empty.
+func (edb *EventDb) GetGovernanceVotes(proposalID, voterID string,
+	limit common.Pagination) ([]GovernanceVote, error) {
+
+	query := edb.Store.Get().Model(&GovernanceVote{})
+	if proposalID != "" {
+		query = query.Where("proposal_id = ?", proposalID)
+	}
+	if voterID != "" {
+		query = query.Where("voter_id = ?", voterID)
+	}
+	var votes []GovernanceVote
+	return votes, query.Offset(limit.Offset).Limit(limit.Limit).Order(clause.OrderByColumn{
+		Column: clause.Column{Name: "id"},
+		Desc:   limit.IsDescending,
+	}).Find(&votes).Error
+}
+
+func (edb *EventDb) addOrOverwriteGovernanceProposal(p GovernanceProposal) error {
+	var id uint
+	err := edb.Store.Get().Model(&GovernanceProposal{}).Select("id").
+		Where("proposal_id = ?", p.ProposalID).
+		Take(&id).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return edb.Store.Get().Create(&p).Error
+	case err != nil:
+		return err
+	}
+
+	return edb.Store.Get().Model(&GovernanceProposal{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"votes_for":       p.VotesFor,
+			"votes_against":   p.VotesAgainst,
+			"voters":          p.Voters,
+			"status":          p.Status,
+			"error":           p.Error,
+			"finalized_round": p.FinalizedRound,
+		}).Error
+}
+
+func (edb *EventDb) addOrOverwriteGovernanceVote(v GovernanceVote) error {
+	var id uint
+	err := edb.Store.Get().Model(&GovernanceVote{}).Select("id").
+		Where("proposal_id = ? AND voter_id = ?", v.ProposalID, v.VoterID).
+		Take(&id).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return edb.Store.Get().Create(&v).Error
+	case err != nil:
+		return err
+	}
+
+	return edb.Store.Get().Model(&GovernanceVote{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"support": v.Support,
+			"weight":  v.Weight,
+			"round":   v.Round,
+		}).Error
+}
@@ -0,0 +1,79 @@
+package event
+
+import (
+	"testing"
+
+	"0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDb_GovernanceProposals(t *testing.T) {
+	edb := newSqliteEventDb(t, sqlite.InMemory)
+
+	var add = func(tag EventTag, data interface{}) {
+		require.NoError(t, edb.addStat(Event{
+			Type: int(TypeStats),
+			Tag:  int(tag),
+			Data: data,
+		}))
+	}
+	add(TagAddOrOverwriteGovernanceProposal, GovernanceProposal{ProposalID: "p1", Proposer: "c1",
+		ToClientID: "storage", FunctionName: "update_settings", Changes: `{"max_file_size":"100"}`,
+		StartRound: 10, EndRound: 20, Status: ProposalVoting})
+	add(TagAddOrOverwriteGovernanceProposal, GovernanceProposal{ProposalID: "p2", Proposer: "c2",
+		ToClientID: "miner", FunctionName: "update_globals", StartRound: 12, EndRound: 22,
+		Status: ProposalVoting})
+	add(TagAddOrOverwriteGovernanceVote, GovernanceVote{ProposalID: "p1", VoterID: "c1", Support: true, Weight: 10, Round: 11})
+	add(TagAddOrOverwriteGovernanceVote, GovernanceVote{ProposalID: "p1", VoterID: "c2", Support: true, Weight: 5, Round: 12})
+	add(TagAddOrOverwriteGovernanceVote, GovernanceVote{ProposalID: "p2", VoterID: "c1", Support: true, Weight: 10, Round: 13})
+	// c2 changes its vote
+	add(TagAddOrOverwriteGovernanceVote, GovernanceVote{ProposalID: "p1", VoterID: "c2", Support: false, Weight: 7, Round: 14})
+	add(TagAddOrOverwriteGovernanceProposal, GovernanceProposal{ProposalID: "p1", Proposer: "c1",
+		ToClientID: "storage", VotesFor: 10, VotesAgainst: 7, Voters: 2, Status: ProposalApplied,
+		FinalizedRound: 20})
+
+	limit := common.Pagination{Limit: 20}
+	tests := []struct {
+		name   string
+		filter GovernanceProposalQuery
+		want   []string
+	}{
+		{name: "all", want: []string{"p1", "p2"}},
+		{name: "by_proposer", filter: GovernanceProposalQuery{Proposer: "c2"}, want: []string{"p2"}},
+		{name: "by_sc", filter: GovernanceProposalQuery{ToClientID: "storage"}, want: []string{"p1"}},
+		{name: "voting", filter: GovernanceProposalQuery{Status: ProposalVoting}, want: []string{"p2"}},
+		{name: "applied", filter: GovernanceProposalQuery{Status: ProposalApplied}, want: []string{"p1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proposals, err := edb.GetGovernanceProposals(tt.filter, limit)
+			require.NoError(t, err)
+			var got []string
+			for _, p := range proposals {
+				got = append(got, p.ProposalID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	proposals, err := edb.GetGovernanceProposals(GovernanceProposalQuery{Proposer: "c1"}, limit)
+	require.NoError(t, err)
+	require.Len(t, proposals, 1)
+	assert.EqualValues(t, 10, proposals[0].VotesFor)
+	assert.EqualValues(t, 7, proposals[0].VotesAgainst)
+	assert.Equal(t, `{"max_file_size":"100"}`, proposals[0].Changes)
+	assert.EqualValues(t, 20, proposals[0].FinalizedRound)
+
+	votes, err := edb.GetGovernanceVotes("p1", "", limit)
+	require.NoError(t, err)
+	require.Len(t, votes, 2)
+	assert.Equal(t, "c2", votes[1].VoterID)
+	assert.False(t, votes[1].Support)
+	assert.EqualValues(t, 7, votes[1].Weight)
+
+	votes, err = edb.GetGovernanceVotes("", "c1", limit)
+	require.NoError(t, err)
+	assert.Len(t, votes, 2)
+}
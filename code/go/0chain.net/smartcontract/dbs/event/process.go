@@ -58,6 +58,16 @@ const (
 	TagAddChallenge
 	TagUpdateChallenge
 	TagUpdateBlobberChallenge
+	TagAddOrOverwriteAllocationRenewal
+	TagDeleteAllocationRenewal
+	TagAddOrOverwriteProvider
+	TagAddSlash
+	TagAddUnbonding
+	TagClaimUnbonding
+	TagAddOrOverwriteGovernanceProposal
+	TagAddOrOverwriteGovernanceVote
+	TagAddTransactionTransfers
+	TagUpdateUnbonding
 	NumberOfTags
 )
 
@@ -70,29 +80,37 @@ func (edb *EventDb) AddEvents(ctx context.Context, events []Event) {
 func (edb *EventDb) addEventsWorker(ctx context.Context) {
 	for {
 		events := <-edb.eventsChannel
-		edb.addEvents(ctx, events)
-		for _, event := range events {
-			var err error = nil
-			switch EventType(event.Type) {
-			case TypeStats:
-				err = edb.addStat(event)
-			case TypeError:
-				err = edb.addError(Error{
-					TransactionID: event.TxHash,
-					Error:         fmt.Sprintf("%v", event.Data),
-				})
+		edb.ProcessEvents(ctx, events)
+	}
+}
+
+// ProcessEvents - store the events and update the tables derived from them
+// synchronously, returns the number of events that could not be processed.
+func (edb *EventDb) ProcessEvents(ctx context.Context, events []Event) (failed int) {
+	edb.addEvents(ctx, events)
+	for _, event := range events {
+		var err error = nil
+		switch EventType(event.Type) {
+		case TypeStats:
+			err = edb.addStat(event)
+		case TypeError:
+			err = edb.addError(Error{
+				TransactionID: event.TxHash,
+				Error:         fmt.Sprintf("%v", event.Data),
+			})
 
-			default:
-			}
-			if err != nil {
-				logging.Logger.Error(
-					"event could not be processed",
-					zap.Any("event", event),
-					zap.Error(err),
-				)
-			}
+		default:
+		}
+		if err != nil {
+			failed++
+			logging.Logger.Error(
+				"event could not be processed",
+				zap.Any("event", event),
+				zap.Error(err),
+			)
 		}
 	}
+	return failed
 }
 
 func (edb *EventDb) addStat(event Event) error {
@@ -301,6 +319,66 @@ func (edb *EventDb) addStat(event Event) error {
 			return ErrInvalidEventData
 		}
 		return edb.updateBlobberChallenges(*challenge)
+	case TagAddOrOverwriteAllocationRenewal:
+		ar, ok := fromEvent[AllocationRenewal](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addOrOverwriteAllocationRenewal(*ar)
+	case TagDeleteAllocationRenewal:
+		allocationID, ok := fromEvent[string](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.deleteAllocationRenewal(*allocationID)
+	case TagAddOrOverwriteProvider:
+		p, ok := fromEvent[Provider](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addOrOverwriteProvider(*p)
+	case TagAddSlash:
+		s, ok := fromEvent[Slash](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addSlash(*s)
+	case TagAddUnbonding:
+		u, ok := fromEvent[Unbonding](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addUnbonding(*u)
+	case TagClaimUnbonding:
+		c, ok := fromEvent[UnbondingClaim](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.claimUnbonding(*c)
+	case TagUpdateUnbonding:
+		u, ok := fromEvent[Unbonding](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.updateUnbonding(*u)
+	case TagAddOrOverwriteGovernanceProposal:
+		p, ok := fromEvent[GovernanceProposal](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addOrOverwriteGovernanceProposal(*p)
+	case TagAddOrOverwriteGovernanceVote:
+		v, ok := fromEvent[GovernanceVote](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addOrOverwriteGovernanceVote(*v)
+	case TagAddTransactionTransfers:
+		transfers, ok := fromEvent[[]TransactionTransfer](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addTransactionTransfers(*transfers)
 	default:
 		return fmt.Errorf("unrecognised event %v", event)
 	}
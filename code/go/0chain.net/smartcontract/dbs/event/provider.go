@@ -0,0 +1,87 @@
+package event
+
+import (
+	"errors"
+
+	"0chain.net/chaincore/currency"
+	"0chain.net/smartcontract/common"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Provider - the representation of a provider common to all the provider
+// types: miners, sharders, blobbers, validators and authorizers.
+type Provider struct {
+	gorm.Model
+	ProviderID     string        `json:"provider_id" gorm:"uniqueIndex:idx_provider"`
+	ProviderType   int           `json:"provider_type" gorm:"uniqueIndex:idx_provider;index"`
+	DelegateWallet string        `json:"delegate_wallet"`
+	MinStake       currency.Coin `json:"min_stake"`
+	MaxStake       currency.Coin `json:"max_stake"`
+	NumDelegates   int           `json:"num_delegates"`
+	ServiceCharge  float64       `json:"service_charge"`
+	TotalStake     currency.Coin `json:"total_stake"`
+	// Status is the lifecycle status of the provider: active, shut_down
+	// or killed
+	Status string `json:"status" gorm:"index"`
+}
+
+// ProviderQuery - the filter of the providers list, the empty fields match
+// all the providers.
+type ProviderQuery struct {
+	ProviderTypes []int
+	Status        string
+}
+
+// GetProvider - get the provider of given type and id.
+func (edb *EventDb) GetProvider(providerType int, providerID string) (*Provider, error) {
+	var p Provider
+	err := edb.Store.Get().Model(&Provider{}).
+		Where("provider_type = ? AND provider_id = ?", providerType, providerID).
+		Take(&p).Error
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetProviders - get the providers matching the filter.
+func (edb *EventDb) GetProviders(filter ProviderQuery, limit common.Pagination) ([]Provider, error) {
+	query := edb.Store.Get().Model(&Provider{})
+	if len(filter.ProviderTypes) > 0 {
+		query = query.Where("provider_type IN ?", filter.ProviderTypes)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	var providers []Provider
+	return providers, query.Offset(limit.Offset).Limit(limit.Limit).Order(clause.OrderByColumn{
+		Column: clause.Column{Name: "id"},
+		Desc:   limit.IsDescending,
+	}).Find(&providers).Error
+}
+
+func (edb *EventDb) addOrOverwriteProvider(p Provider) error {
+	var id uint
+	err := edb.Store.Get().Model(&Provider{}).Select("id").
+		Where("provider_type = ? AND provider_id = ?", p.ProviderType, p.ProviderID).
+		Take(&id).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return edb.Store.Get().Create(&p).Error
+	case err != nil:
+		return err
+	}
+
+	return edb.Store.Get().Model(&Provider{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"delegate_wallet": p.DelegateWallet,
+			"min_stake":       p.MinStake,
+			"max_stake":       p.MaxStake,
+			"num_delegates":   p.NumDelegates,
+			"service_charge":  p.ServiceCharge,
+			"total_stake":     p.TotalStake,
+			"status":          p.Status,
+		}).Error
+}
@@ -0,0 +1,54 @@
+package event
+
+import (
+	"testing"
+
+	"0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDb_Providers(t *testing.T) {
+	edb := newSqliteEventDb(t, sqlite.InMemory)
+
+	emit := func(p Provider) {
+		require.NoError(t, edb.addStat(Event{
+			Type: int(TypeStats),
+			Tag:  int(TagAddOrOverwriteProvider),
+			Data: p,
+		}))
+	}
+	emit(Provider{ProviderID: "p1", ProviderType: 3, DelegateWallet: "w1", NumDelegates: 5, Status: "active"})
+	emit(Provider{ProviderID: "p1", ProviderType: 4, DelegateWallet: "w2", Status: "active"})
+	emit(Provider{ProviderID: "p2", ProviderType: 3, DelegateWallet: "w3", Status: "active"})
+	emit(Provider{ProviderID: "p1", ProviderType: 3, DelegateWallet: "w1", NumDelegates: 7, TotalStake: 10, Status: "shut_down"})
+
+	p, err := edb.GetProvider(3, "p1")
+	require.NoError(t, err)
+	assert.Equal(t, 7, p.NumDelegates)
+	assert.EqualValues(t, 10, p.TotalStake)
+	assert.Equal(t, "shut_down", p.Status)
+
+	_, err = edb.GetProvider(3, "p3")
+	require.Error(t, err)
+
+	limit := common.Pagination{Limit: 20}
+	tests := []struct {
+		name   string
+		filter ProviderQuery
+		want   int
+	}{
+		{name: "all", want: 3},
+		{name: "by_type", filter: ProviderQuery{ProviderTypes: []int{3}}, want: 2},
+		{name: "by_status", filter: ProviderQuery{Status: "active"}, want: 2},
+		{name: "by_type_and_status", filter: ProviderQuery{ProviderTypes: []int{3}, Status: "active"}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providers, err := edb.GetProviders(tt.filter, limit)
+			require.NoError(t, err)
+			assert.Len(t, providers, tt.want)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package event
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// replayCheckpointID - the replay checkpoint is a single row
+const replayCheckpointID = 1
+
+// ReplayCheckpoint - the last block whose events are replayed into the
+// events database, the replay resumes from the next round.
+type ReplayCheckpoint struct {
+	gorm.Model
+	Round     int64  `json:"round"`
+	BlockHash string `json:"block_hash"`
+}
+
+// GetReplayCheckpoint - get the replay checkpoint, nil if the events were
+// never replayed into the database.
+func (edb *EventDb) GetReplayCheckpoint() (*ReplayCheckpoint, error) {
+	var cp ReplayCheckpoint
+	err := edb.Store.Get().Model(&ReplayCheckpoint{}).First(&cp, replayCheckpointID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+// SetReplayCheckpoint - record the last block whose events are replayed.
+func (edb *EventDb) SetReplayCheckpoint(round int64, blockHash string) error {
+	cp := ReplayCheckpoint{Round: round, BlockHash: blockHash}
+	cp.ID = replayCheckpointID
+	return edb.Store.Get().Save(&cp).Error
+}
+
+// IsEmpty - true if no block or event is stored in the database.
+func (edb *EventDb) IsEmpty() (bool, error) {
+	for _, model := range []interface{}{&Block{}, &Event{}} {
+		var count int64
+		if err := edb.Store.Get().Model(model).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
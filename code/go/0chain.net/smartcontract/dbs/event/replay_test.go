@@ -0,0 +1,83 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	"0chain.net/chaincore/currency"
+	"0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDb_ReplayCheckpoint(t *testing.T) {
+	edb := newSqliteEventDb(t, sqlite.InMemory)
+
+	cp, err := edb.GetReplayCheckpoint()
+	require.NoError(t, err)
+	assert.Nil(t, cp)
+
+	for round := int64(1); round <= 3; round++ {
+		require.NoError(t, edb.SetReplayCheckpoint(round, "hash"))
+	}
+	cp, err = edb.GetReplayCheckpoint()
+	require.NoError(t, err)
+	require.NotNil(t, cp)
+	assert.EqualValues(t, 3, cp.Round)
+	assert.Equal(t, "hash", cp.BlockHash)
+
+	var count int64
+	require.NoError(t, edb.Store.Get().Model(&ReplayCheckpoint{}).Count(&count).Error)
+	assert.EqualValues(t, 1, count)
+}
+
+func TestEventDb_ProcessEvents(t *testing.T) {
+	edb := newSqliteEventDb(t, sqlite.InMemory)
+
+	empty, err := edb.IsEmpty()
+	require.NoError(t, err)
+	assert.True(t, empty)
+
+	failed := edb.ProcessEvents(context.TODO(), []Event{
+		{Type: int(TypeStats), Tag: int(TagAddBlock), Data: Block{Hash: "block", Round: 1}},
+		{Type: int(TypeStats), Tag: int(TagAddOrOverwriteUser), Data: User{UserID: "a", Balance: 10, Nonce: 1}},
+		{Type: int(TypeStats), Tag: int(TagAddOrOverwriteUser), Data: User{UserID: "b", Balance: 20, Nonce: 2}},
+		{Type: int(TypeStats), Tag: int(TagAddOrOverwriteUser), Data: "invalid"},
+		{Type: int(TypeError), TxHash: "txn", Data: "failed"},
+	})
+	assert.Equal(t, 1, failed)
+
+	empty, err = edb.IsEmpty()
+	require.NoError(t, err)
+	assert.False(t, empty)
+
+	users, err := edb.GetUsers(common.Pagination{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "a", users[0].UserID)
+	assert.Equal(t, currency.Coin(20), users[1].Balance)
+
+	txnErrors, err := edb.GetErrorByTransactionHash("txn", common.Pagination{Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, txnErrors, 1)
+}
+
+func TestEventDb_GetAllocations(t *testing.T) {
+	edb := newSqliteEventDb(t, sqlite.InMemory)
+
+	require.NoError(t, edb.addOrOverwriteUser(User{UserID: "owner"}))
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, edb.addAllocation(&Allocation{AllocationID: id, Owner: "owner", Size: 10}))
+	}
+
+	allocs, err := edb.GetAllocations(common.Pagination{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, allocs, 2)
+	assert.Equal(t, "a", allocs[0].AllocationID)
+
+	allocs, err = edb.GetAllocations(common.Pagination{Offset: 2, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, allocs, 1)
+	assert.Equal(t, "c", allocs[0].AllocationID)
+}
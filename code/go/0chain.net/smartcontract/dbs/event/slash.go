@@ -0,0 +1,52 @@
+package event
+
+import (
+	"0chain.net/chaincore/currency"
+	"0chain.net/smartcontract/common"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Slash - a penalty of a provider taken from its delegate pools.
+type Slash struct {
+	gorm.Model
+	ProviderID     string        `json:"provider_id" gorm:"index:idx_slash_provider"`
+	ProviderType   int           `json:"provider_type" gorm:"index:idx_slash_provider"`
+	OffenceID      string        `json:"offence_id"`
+	Reason         string        `json:"reason" gorm:"index"`
+	Reporter       string        `json:"reporter"`
+	Amount         currency.Coin `json:"amount"`
+	ReporterReward currency.Coin `json:"reporter_reward"`
+	Round          int64         `json:"round"`
+}
+
+// SlashQuery - the filter of the slashes list, the empty fields match all
+// the slashes.
+type SlashQuery struct {
+	ProviderID    string
+	ProviderTypes []int
+	Reason        string
+}
+
+// GetSlashes - get the slashes matching the filter.
+func (edb *EventDb) GetSlashes(filter SlashQuery, limit common.Pagination) ([]Slash, error) {
+	query := edb.Store.Get().Model(&Slash{})
+	if filter.ProviderID != "" {
+		query = query.Where("provider_id = ?", filter.ProviderID)
+	}
+	if len(filter.ProviderTypes) > 0 {
+		query = query.Where("provider_type IN ?", filter.ProviderTypes)
+	}
+	if filter.Reason != "" {
+		query = query.Where("reason = ?", filter.Reason)
+	}
+	var slashes []Slash
+	return slashes, query.Offset(limit.Offset).Limit(limit.Limit).Order(clause.OrderByColumn{
+		Column: clause.Column{Name: "id"},
+		Desc:   limit.IsDescending,
+	}).Find(&slashes).Error
+}
+
+func (edb *EventDb) addSlash(s Slash) error {
+	return edb.Store.Get().Create(&s).Error
+}
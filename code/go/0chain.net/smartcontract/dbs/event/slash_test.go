@@ -0,0 +1,50 @@
+package event
+
+import (
+	"testing"
+
+	"0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDb_Slashes(t *testing.T) {
+	edb := newSqliteEventDb(t, sqlite.InMemory)
+
+	for _, s := range []Slash{
+		{ProviderID: "m1", ProviderType: 1, OffenceID: "o1", Reason: "double_signing", Reporter: "r1", Amount: 10, ReporterReward: 1, Round: 5},
+		{ProviderID: "m1", ProviderType: 1, OffenceID: "o2", Reason: "invalid_dkg_share", Reporter: "r2", Amount: 20, Round: 7},
+		{ProviderID: "a1", ProviderType: 5, OffenceID: "o3", Reason: "conflicting_signatures", Reporter: "r1", Amount: 30, Round: 9},
+	} {
+		require.NoError(t, edb.addStat(Event{
+			Type: int(TypeStats),
+			Tag:  int(TagAddSlash),
+			Data: s,
+		}))
+	}
+
+	limit := common.Pagination{Limit: 20}
+	tests := []struct {
+		name   string
+		filter SlashQuery
+		want   []string
+	}{
+		{name: "all", want: []string{"o1", "o2", "o3"}},
+		{name: "by_provider", filter: SlashQuery{ProviderID: "m1"}, want: []string{"o1", "o2"}},
+		{name: "by_type", filter: SlashQuery{ProviderTypes: []int{5}}, want: []string{"o3"}},
+		{name: "by_reason", filter: SlashQuery{Reason: "double_signing"}, want: []string{"o1"}},
+		{name: "no_match", filter: SlashQuery{ProviderID: "m1", ProviderTypes: []int{5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slashes, err := edb.GetSlashes(tt.filter, limit)
+			require.NoError(t, err)
+			var got []string
+			for _, s := range slashes {
+				got = append(got, s.OffenceID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package event
+
+import (
+	"0chain.net/chaincore/currency"
+	"0chain.net/smartcontract/common"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TransactionTransfer - a transfer of a batch send transaction, a row per
+// recipient of the transaction.
+// swagger:model TransactionTransfer
+type TransactionTransfer struct {
+	gorm.Model
+	TransactionHash string        `json:"transaction_hash" gorm:"uniqueIndex:idx_ttransfer"`
+	Index           int           `json:"index" gorm:"uniqueIndex:idx_ttransfer"`
+	BlockNumber     int64         `json:"block_number"`
+	ClientId        string        `json:"client_id" gorm:"index:idx_ttransfer_client_id"`
+	ToClientId      string        `json:"to_client_id" gorm:"index:idx_ttransfer_to_client_id"`
+	Amount          currency.Coin `json:"amount"`
+}
+
+func (edb *EventDb) addTransactionTransfers(transfers []TransactionTransfer) error {
+	if len(transfers) == 0 {
+		return nil
+	}
+	return edb.Store.Get().Create(&transfers).Error
+}
+
+// GetTransactionTransfers - get the transfers of a batch send transaction, or
+// the transfers from or to a client when the transaction hash is empty.
+func (edb *EventDb) GetTransactionTransfers(hash, clientID, toClientID string,
+	limit common.Pagination) ([]TransactionTransfer, error) {
+
+	query := edb.Store.Get().Model(&TransactionTransfer{})
+	if hash != "" {
+		query = query.Where("transaction_hash = ?", hash)
+	}
+	if clientID != "" {
+		query = query.Where("client_id = ?", clientID)
+	}
+	if toClientID != "" {
+		query = query.Where("to_client_id = ?", toClientID)
+	}
+	var transfers []TransactionTransfer
+	return transfers, query.Offset(limit.Offset).Limit(limit.Limit).Order(clause.OrderByColumn{
+		Column: clause.Column{Name: "id"},
+		Desc:   limit.IsDescending,
+	}).Find(&transfers).Error
+}
@@ -0,0 +1,56 @@
+package event
+
+import (
+	"testing"
+
+	"0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDb_TransactionTransfers(t *testing.T) {
+	edb := newSqliteEventDb(t, sqlite.InMemory)
+
+	for _, transfers := range [][]TransactionTransfer{
+		{
+			{TransactionHash: "t1", Index: 0, BlockNumber: 1, ClientId: "c1", ToClientId: "c2", Amount: 10},
+			{TransactionHash: "t1", Index: 1, BlockNumber: 1, ClientId: "c1", ToClientId: "c3", Amount: 20},
+		},
+		{
+			{TransactionHash: "t2", Index: 0, BlockNumber: 2, ClientId: "c2", ToClientId: "c3", Amount: 5},
+		},
+	} {
+		require.NoError(t, edb.addStat(Event{
+			Type: int(TypeStats),
+			Tag:  int(TagAddTransactionTransfers),
+			Data: transfers,
+		}))
+	}
+
+	limit := common.Pagination{Limit: 20}
+	tests := []struct {
+		name       string
+		hash       string
+		clientID   string
+		toClientID string
+		want       []string
+	}{
+		{name: "all", want: []string{"c1-c2", "c1-c3", "c2-c3"}},
+		{name: "by_hash", hash: "t1", want: []string{"c1-c2", "c1-c3"}},
+		{name: "by_client", clientID: "c2", want: []string{"c2-c3"}},
+		{name: "by_to_client", toClientID: "c3", want: []string{"c1-c3", "c2-c3"}},
+		{name: "none", hash: "t2", toClientID: "c2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transfers, err := edb.GetTransactionTransfers(tt.hash, tt.clientID, tt.toClientID, limit)
+			require.NoError(t, err)
+			var got []string
+			for _, tr := range transfers {
+				got = append(got, tr.ClientId+"-"+tr.ToClientId)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
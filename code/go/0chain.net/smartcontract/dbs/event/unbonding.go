@@ -0,0 +1,94 @@
+package event
+
+import (
+	"0chain.net/chaincore/currency"
+	"0chain.net/smartcontract/common"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// the statuses of the unbonding entries
+const (
+	UnbondingPending = "pending"
+	UnbondingMatured = "matured"
+	UnbondingClaimed = "claimed"
+)
+
+// Unbonding - an amount unlocked from a delegate pool waiting for the end of
+// the cooldown to be claimed.
+type Unbonding struct {
+	gorm.Model
+	EntryID       string        `json:"entry_id" gorm:"uniqueIndex"`
+	ClientID      string        `json:"client_id" gorm:"index"`
+	ProviderID    string        `json:"provider_id" gorm:"index:idx_unbonding_provider"`
+	ProviderType  int           `json:"provider_type" gorm:"index:idx_unbonding_provider"`
+	PoolID        string        `json:"pool_id"`
+	Amount        currency.Coin `json:"amount"`
+	Round         int64         `json:"round"`
+	MaturityRound int64         `json:"maturity_round"`
+	ClaimedRound  int64         `json:"claimed_round"`
+}
+
+// UnbondingClaim - the unbonding entries of a client claimed in a round.
+type UnbondingClaim struct {
+	ClientID string   `json:"client_id"`
+	EntryIDs []string `json:"entry_ids"`
+	Round    int64    `json:"round"`
+}
+
+// UnbondingQuery - the filter of the unbonding entries list, the empty fields
+// match all the entries. The pending and matured statuses are relative to the
+// Round.
+type UnbondingQuery struct {
+	ClientID      string
+	ProviderID    string
+	ProviderTypes []int
+	Status        string
+	Round         int64
+}
+
+// GetUnbondings - get the unbonding entries matching the filter.
+func (edb *EventDb) GetUnbondings(filter UnbondingQuery, limit common.Pagination) ([]Unbonding, error) {
+	query := edb.Store.Get().Model(&Unbonding{})
+	if filter.ClientID != "" {
+		query = query.Where("client_id = ?", filter.ClientID)
+	}
+	if filter.ProviderID != "" {
+		query = query.Where("provider_id = ?", filter.ProviderID)
+	}
+	if len(filter.ProviderTypes) > 0 {
+		query = query.Where("provider_type IN ?", filter.ProviderTypes)
+	}
+	switch filter.Status {
+	case UnbondingPending:
+		query = query.Where("claimed_round = 0 AND maturity_round > ?", filter.Round)
+	case UnbondingMatured:
+		query = query.Where("claimed_round = 0 AND maturity_round <= ?", filter.Round)
+	case UnbondingClaimed:
+		query = query.Where("claimed_round > 0")
+	}
+	var unbondings []Unbonding
+	return unbondings, query.Offset(limit.Offset).Limit(limit.Limit).Order(clause.OrderByColumn{
+		Column: clause.Column{Name: "id"},
+		Desc:   limit.IsDescending,
+	}).Find(&unbondings).Error
+}
+
+func (edb *EventDb) addUnbonding(u Unbonding) error {
+	return edb.Store.Get().Create(&u).Error
+}
+
+func (edb *EventDb) updateUnbonding(u Unbonding) error {
+	return edb.Store.Get().Model(&Unbonding{}).
+		Where("entry_id = ?", u.EntryID).
+		Update("amount", u.Amount).Error
+}
+
+func (edb *EventDb) claimUnbonding(c UnbondingClaim) error {
+	if len(c.EntryIDs) == 0 {
+		return nil
+	}
+	return edb.Store.Get().Model(&Unbonding{}).
+		Where("client_id = ? AND entry_id IN ?", c.ClientID, c.EntryIDs).
+		Update("claimed_round", c.Round).Error
+}
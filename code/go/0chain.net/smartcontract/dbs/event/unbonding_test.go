@@ -0,0 +1,68 @@
+package event
+
+import (
+	"testing"
+
+	"0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDb_Unbondings(t *testing.T) {
+	edb := newSqliteEventDb(t, sqlite.InMemory)
+
+	for _, u := range []Unbonding{
+		{EntryID: "e1", ClientID: "c1", ProviderID: "m1", ProviderType: 1, PoolID: "p1", Amount: 10, Round: 5, MaturityRound: 10},
+		{EntryID: "e2", ClientID: "c1", ProviderID: "b1", ProviderType: 3, PoolID: "p2", Amount: 20, Round: 7, MaturityRound: 20},
+		{EntryID: "e3", ClientID: "c2", ProviderID: "m1", ProviderType: 1, PoolID: "p3", Amount: 30, Round: 9, MaturityRound: 14},
+	} {
+		require.NoError(t, edb.addStat(Event{
+			Type: int(TypeStats),
+			Tag:  int(TagAddUnbonding),
+			Data: u,
+		}))
+	}
+	require.NoError(t, edb.addStat(Event{
+		Type: int(TypeStats),
+		Tag:  int(TagClaimUnbonding),
+		Data: UnbondingClaim{ClientID: "c2", EntryIDs: []string{"e3"}, Round: 15},
+	}))
+
+	require.NoError(t, edb.addStat(Event{
+		Type: int(TypeStats),
+		Tag:  int(TagUpdateUnbonding),
+		Data: Unbonding{EntryID: "e2", Amount: 15},
+	}))
+
+	slashed, err := edb.GetUnbondings(UnbondingQuery{ClientID: "c1", ProviderID: "b1"}, common.Pagination{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, slashed, 1)
+	assert.EqualValues(t, 15, slashed[0].Amount)
+
+	limit := common.Pagination{Limit: 20}
+	tests := []struct {
+		name   string
+		filter UnbondingQuery
+		want   []string
+	}{
+		{name: "all", want: []string{"e1", "e2", "e3"}},
+		{name: "by_client", filter: UnbondingQuery{ClientID: "c1"}, want: []string{"e1", "e2"}},
+		{name: "by_provider", filter: UnbondingQuery{ProviderID: "m1"}, want: []string{"e1", "e3"}},
+		{name: "by_type", filter: UnbondingQuery{ProviderTypes: []int{3}}, want: []string{"e2"}},
+		{name: "pending", filter: UnbondingQuery{Status: UnbondingPending, Round: 15}, want: []string{"e2"}},
+		{name: "matured", filter: UnbondingQuery{Status: UnbondingMatured, Round: 15}, want: []string{"e1"}},
+		{name: "claimed", filter: UnbondingQuery{Status: UnbondingClaimed}, want: []string{"e3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unbondings, err := edb.GetUnbondings(tt.filter, limit)
+			require.NoError(t, err)
+			var got []string
+			for _, u := range unbondings {
+				got = append(got, u.EntryID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
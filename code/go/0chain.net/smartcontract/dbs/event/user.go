@@ -5,7 +5,9 @@ import (
 
 	"0chain.net/chaincore/currency"
 	"0chain.net/core/util"
+	"0chain.net/smartcontract/common"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type User struct {
@@ -30,6 +32,15 @@ func (edb *EventDb) GetUser(userID string) (*User, error) {
 	return &user, nil
 }
 
+// GetUsers - get the users in order of creation.
+func (edb *EventDb) GetUsers(limit common.Pagination) ([]User, error) {
+	var users []User
+	return users, edb.Store.Get().Model(&User{}).Offset(limit.Offset).Limit(limit.Limit).Order(clause.OrderByColumn{
+		Column: clause.Column{Name: "id"},
+		Desc:   limit.IsDescending,
+	}).Find(&users).Error
+}
+
 func (edb *EventDb) overwriteUser(u User) error {
 	return edb.Store.Get().Model(&User{}).
 		Where("user_id = ?", u.UserID).
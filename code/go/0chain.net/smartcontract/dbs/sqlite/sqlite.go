@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"0chain.net/chaincore/config"
+	"0chain.net/smartcontract/dbs"
+)
+
+// InMemory - the database name of an in memory database
+const InMemory = ":memory:"
+
+// GetSqliteDb - open the embedded SQLite database stored in the file of the
+// config name, or in memory for the InMemory name.
+func GetSqliteDb(config config.DbAccess) (dbs.Store, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+	db := &SqliteStore{}
+	err := db.Open(config)
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+type SqliteStore struct {
+	db *gorm.DB
+}
+
+func (store *SqliteStore) Open(config config.DbAccess) error {
+	if !config.Enabled {
+		return errors.New("db_open_error, db disabled")
+	}
+	if config.Name == "" {
+		return errors.New("db_open_error, missing sqlite db file name")
+	}
+
+	// writers wait for each other instead of failing with a busy database
+	dsn := config.Name + "?_pragma=busy_timeout(10000)&_pragma=foreign_keys(1)"
+	if config.Name != InMemory {
+		dsn += "&_pragma=journal_mode(WAL)"
+	}
+
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger:                 logger.Default.LogMode(logger.Silent),
+		SkipDefaultTransaction: true,
+		PrepareStmt:            true,
+	})
+	if err != nil {
+		return fmt.Errorf("db_open_error, Error opening the DB connection: %v", err)
+	}
+
+	sqldb, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("db_open_error, Error opening the DB connection: %v", err)
+	}
+	if config.Name == InMemory || config.MaxOpenConns <= 0 {
+		// every connection of an in memory database has its own database
+		sqldb.SetMaxOpenConns(1)
+	} else {
+		sqldb.SetMaxOpenConns(config.MaxOpenConns)
+	}
+	sqldb.SetMaxIdleConns(config.MaxIdleConns)
+	sqldb.SetConnMaxLifetime(config.ConnMaxLifetime)
+	if config.Name == InMemory {
+		// closing the last connection drops the database
+		sqldb.SetConnMaxLifetime(0)
+		sqldb.SetMaxIdleConns(1)
+	}
+
+	store.db = db
+	return nil
+}
+
+func (store *SqliteStore) AutoMigrate() error {
+	panic("should not be called")
+}
+
+func (store *SqliteStore) Close() {
+	if store.db != nil {
+		if sqldb, _ := store.db.DB(); sqldb != nil {
+			sqldb.Close()
+		}
+	}
+}
+
+func (store *SqliteStore) Get() *gorm.DB {
+	return store.db
+}
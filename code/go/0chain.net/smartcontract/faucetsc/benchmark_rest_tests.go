@@ -31,6 +31,20 @@ func BenchmarkRestTests(
 				FuncName: "faucet_config",
 				Endpoint: frh.getConfig,
 			},
+			{
+				FuncName: "allowance",
+				Params: map[string]string{
+					"client_id": data.Clients[0],
+				},
+				Endpoint: frh.getAllowance,
+			},
+			{
+				FuncName: "voucherSubject",
+				Params: map[string]string{
+					"subject": getMockVoucherSubject(0),
+				},
+				Endpoint: frh.getVoucherSubject,
+			},
 		},
 		ADDRESS,
 		frh,
@@ -1,6 +1,8 @@
 package faucetsc
 
 import (
+	"strconv"
+
 	cstate "0chain.net/chaincore/chain/state"
 	"0chain.net/chaincore/currency"
 	"0chain.net/chaincore/state"
@@ -39,3 +41,31 @@ func AddMockUserNodes(
 		_, _ = balances.InsertTrieNode(un.GetKey(ADDRESS), un)
 	}
 }
+
+const mockAllowlisted = 10
+
+// AddMockAllowlist adds allowlist entries and voucher subjects of the first
+// clients
+func AddMockAllowlist(
+	clients []string,
+	balances cstate.StateContextI,
+) {
+	for i := 0; i < len(clients) && i < mockAllowlisted; i++ {
+		entry := &AllowlistEntry{
+			ClientID:      clients[i],
+			PourAmount:    1e10,
+			MaxPourAmount: 2e10,
+			PeriodicLimit: 10e10,
+		}
+		_, _ = balances.InsertTrieNode(entry.GetKey(ADDRESS), entry)
+		sn := &SubjectNode{
+			Subject:  getMockVoucherSubject(i),
+			LastPour: 1,
+		}
+		_, _ = balances.InsertTrieNode(sn.GetKey(ADDRESS), sn)
+	}
+}
+
+func getMockVoucherSubject(i int) string {
+	return "mock_subject_" + strconv.Itoa(i)
+}
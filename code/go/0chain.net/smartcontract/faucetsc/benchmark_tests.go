@@ -2,6 +2,7 @@ package faucetsc
 
 import (
 	"0chain.net/core/common"
+	"encoding/json"
 	"testing"
 
 	"0chain.net/core/viper"
@@ -56,6 +57,10 @@ func (bt BenchTest) Run(balances cstate.TimedQueryStateContext, b *testing.B) er
 		_, err = fsc.pour(bt.Transaction(), bt.input, balances, gn)
 	case "refill":
 		_, err = fsc.refill(bt.Transaction(), balances, gn)
+	case "updateAllowlist":
+		_, err = fsc.updateAllowlist(bt.Transaction(), bt.input, balances, gn)
+	case "removeAllowlist":
+		_, err = fsc.removeAllowlist(bt.Transaction(), bt.input, balances, gn)
 	default:
 		b.Errorf("unknown endpoint" + bt.endpoint)
 	}
@@ -114,6 +119,39 @@ func BenchmarkTests(
 			},
 			input: nil,
 		},
+		{
+			name:     "faucet.update-allowlist",
+			endpoint: "updateAllowlist",
+			txn: &transaction.Transaction{
+				ClientID:     viper.GetString(bk.FaucetOwner),
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&allowlistRequest{
+					Entries: []*AllowlistEntry{{
+						ClientID:      data.Clients[1],
+						PourAmount:    2e10,
+						MaxPourAmount: 5e10,
+						PeriodicLimit: 10e10,
+					}},
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "faucet.remove-allowlist",
+			endpoint: "removeAllowlist",
+			txn: &transaction.Transaction{
+				ClientID:     viper.GetString(bk.FaucetOwner),
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&removeAllowlistRequest{
+					ClientIDs: []string{data.Clients[0]},
+				})
+				return bytes
+			}(),
+		},
 	}
 	var testsI []bk.BenchTestI
 	for _, test := range tests {
@@ -20,6 +20,9 @@ const (
 	IndividualReset
 	GlobalReset
 	OwnerId
+	Restricted
+	VoucherIssuer
+	VoucherCooldown
 	Cost
 )
 
@@ -32,6 +35,9 @@ var (
 		"individual_reset",
 		"global_rest",
 		"owner_id",
+		"restricted",
+		"voucher_issuer",
+		"voucher_cooldown",
 		"cost",
 	}
 
@@ -39,17 +45,28 @@ var (
 		"update-settings",
 		"pour",
 		"refill",
+		"update-allowlist",
+		"remove-allowlist",
 	}
 )
 
 type FaucetConfig struct {
-	PourAmount      currency.Coin  `json:"pour_amount"`
-	MaxPourAmount   currency.Coin  `json:"max_pour_amount"`
-	PeriodicLimit   currency.Coin  `json:"periodic_limit"`
-	GlobalLimit     currency.Coin  `json:"global_limit"`
-	IndividualReset time.Duration  `json:"individual_reset"`
-	GlobalReset     time.Duration  `json:"global_rest"`
-	OwnerId         string         `json:"owner_id"`
+	PourAmount      currency.Coin `json:"pour_amount"`
+	MaxPourAmount   currency.Coin `json:"max_pour_amount"`
+	PeriodicLimit   currency.Coin `json:"periodic_limit"`
+	GlobalLimit     currency.Coin `json:"global_limit"`
+	IndividualReset time.Duration `json:"individual_reset"`
+	GlobalReset     time.Duration `json:"global_rest"`
+	OwnerId         string        `json:"owner_id"`
+	// Restricted faucet pours only to the allowlisted clients and for the
+	// vouchers
+	Restricted bool `json:"restricted"`
+	// VoucherIssuer is the public key signing the vouchers, empty disables
+	// the vouchers
+	VoucherIssuer string `json:"voucher_issuer"`
+	// VoucherCooldown is the min time between the pours of the vouchers of
+	// the same subject
+	VoucherCooldown time.Duration  `json:"voucher_cooldown"`
 	Cost            map[string]int `json:"cost"`
 }
 
@@ -76,6 +93,9 @@ func getFaucetConfig() (conf *FaucetConfig, err error) {
 	conf.IndividualReset = config.SmartContractConfig.GetDuration("smart_contracts.faucetsc.individual_reset")
 	conf.GlobalReset = config.SmartContractConfig.GetDuration("smart_contracts.faucetsc.global_reset")
 	conf.OwnerId = config.SmartContractConfig.GetString("smart_contracts.faucetsc.owner_id")
+	conf.Restricted = config.SmartContractConfig.GetBool("smart_contracts.faucetsc.restricted")
+	conf.VoucherIssuer = config.SmartContractConfig.GetString("smart_contracts.faucetsc.voucher_issuer")
+	conf.VoucherCooldown = config.SmartContractConfig.GetDuration("smart_contracts.faucetsc.voucher_cooldown")
 	conf.Cost = config.SmartContractConfig.GetStringMapInt("smart_contracts.faucetsc.cost")
 	return
 }
@@ -9,9 +9,9 @@ import (
 // MarshalMsg implements msgp.Marshaler
 func (z *FaucetConfig) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 8
+	// map header, size 11
 	// string "PourAmount"
-	o = append(o, 0x88, 0xaa, 0x50, 0x6f, 0x75, 0x72, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = append(o, 0x8b, 0xaa, 0x50, 0x6f, 0x75, 0x72, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
 	o, err = z.PourAmount.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "PourAmount")
@@ -47,6 +47,15 @@ func (z *FaucetConfig) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "OwnerId"
 	o = append(o, 0xa7, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64)
 	o = msgp.AppendString(o, z.OwnerId)
+	// string "Restricted"
+	o = append(o, 0xaa, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.Restricted)
+	// string "VoucherIssuer"
+	o = append(o, 0xad, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72)
+	o = msgp.AppendString(o, z.VoucherIssuer)
+	// string "VoucherCooldown"
+	o = append(o, 0xaf, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e)
+	o = msgp.AppendDuration(o, z.VoucherCooldown)
 	// string "Cost"
 	o = append(o, 0xa4, 0x43, 0x6f, 0x73, 0x74)
 	o = msgp.AppendMapHeader(o, uint32(len(z.Cost)))
@@ -123,6 +132,24 @@ func (z *FaucetConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "OwnerId")
 				return
 			}
+		case "Restricted":
+			z.Restricted, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Restricted")
+				return
+			}
+		case "VoucherIssuer":
+			z.VoucherIssuer, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "VoucherIssuer")
+				return
+			}
+		case "VoucherCooldown":
+			z.VoucherCooldown, bts, err = msgp.ReadDurationBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "VoucherCooldown")
+				return
+			}
 		case "Cost":
 			var zb0002 uint32
 			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
@@ -167,7 +194,7 @@ func (z *FaucetConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *FaucetConfig) Msgsize() (s int) {
-	s = 1 + 11 + z.PourAmount.Msgsize() + 14 + z.MaxPourAmount.Msgsize() + 14 + z.PeriodicLimit.Msgsize() + 12 + z.GlobalLimit.Msgsize() + 16 + msgp.DurationSize + 12 + msgp.DurationSize + 8 + msgp.StringPrefixSize + len(z.OwnerId) + 5 + msgp.MapHeaderSize
+	s = 1 + 11 + z.PourAmount.Msgsize() + 14 + z.MaxPourAmount.Msgsize() + 14 + z.PeriodicLimit.Msgsize() + 12 + z.GlobalLimit.Msgsize() + 16 + msgp.DurationSize + 12 + msgp.DurationSize + 8 + msgp.StringPrefixSize + len(z.OwnerId) + 11 + msgp.BoolSize + 14 + msgp.StringPrefixSize + len(z.VoucherIssuer) + 16 + msgp.DurationSize + 5 + msgp.MapHeaderSize
 	if z.Cost != nil {
 		for za0001, za0002 := range z.Cost {
 			_ = za0002
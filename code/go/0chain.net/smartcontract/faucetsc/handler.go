@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/currency"
 
 	"0chain.net/core/common"
 	"0chain.net/core/util"
@@ -29,7 +30,7 @@ func NewFaucetscRestHandler(rh rest.RestHandlerI) *FaucetscRestHandler {
 }
 
 func SetupRestHandler(rh rest.RestHandlerI) {
-	rh.Register(GetEndpoints(rh))
+	rh.Register(GetEndpoints)
 }
 
 func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
@@ -40,6 +41,8 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(faucet+"/globalPeriodicLimit", frh.getGlobalPeriodicLimit),
 		rest.MakeEndpoint(faucet+"/pourAmount", frh.getPourAmount),
 		rest.MakeEndpoint(faucet+"/faucet-config", frh.getConfig),
+		rest.MakeEndpoint(faucet+"/allowance", frh.getAllowance),
+		rest.MakeEndpoint(faucet+"/voucherSubject", frh.getVoucherSubject),
 	}
 }
 
@@ -100,6 +103,9 @@ func (frh *FaucetscRestHandler) getConfig(w http.ResponseWriter, r *http.Request
 		Settings[IndividualReset]: fmt.Sprintf("%v", faucetConfig.IndividualReset),
 		Settings[GlobalReset]:     fmt.Sprintf("%v", faucetConfig.GlobalReset),
 		Settings[OwnerId]:         fmt.Sprintf("%v", faucetConfig.OwnerId),
+		Settings[Restricted]:      fmt.Sprintf("%v", faucetConfig.Restricted),
+		Settings[VoucherIssuer]:   faucetConfig.VoucherIssuer,
+		Settings[VoucherCooldown]: fmt.Sprintf("%v", faucetConfig.VoucherCooldown),
 	}
 
 	for _, key := range costFunctions {
@@ -183,6 +189,126 @@ func (frh *FaucetscRestHandler) getPersonalPeriodicLimit(w http.ResponseWriter,
 	common.Respond(w, r, resp, nil)
 }
 
+// swagger:model allowanceResponse
+type allowanceResponse struct {
+	ClientID      string        `json:"client_id"`
+	Allowlisted   bool          `json:"allowlisted"`
+	PourAmount    currency.Coin `json:"pour_amount"`
+	MaxPourAmount currency.Coin `json:"max_pour_amount"`
+	PeriodicLimit currency.Coin `json:"periodic_limit"`
+	Used          currency.Coin `json:"tokens_poured"`
+	Restart       string        `json:"time_left"`
+	Allowed       currency.Coin `json:"tokens_allowed"`
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d3/allowance allowance
+// remaining allowance of a client according to the faucet policy
+//
+// parameters:
+//    +name: client_id
+//     description: client ID
+//     required: true
+//     in: query
+//     type: string
+//
+// responses:
+//  200: allowanceResponse
+//  400:
+//  404:
+func (frh *FaucetscRestHandler) getAllowance(w http.ResponseWriter, r *http.Request) {
+	clientId := r.URL.Query().Get("client_id")
+	if clientId == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing client_id"))
+		return
+	}
+	sctx := frh.GetQueryStateContext()
+	gn, err := getGlobalNode(sctx)
+	if err != nil {
+		NoResourceOrErrInternal(w, r, err)
+		return
+	}
+	entry, err := getAllowlistEntry(clientId, gn.ID, sctx)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal(noClient, err.Error()))
+		return
+	}
+	un := &UserNode{ID: clientId}
+	if err := sctx.GetTrieNode(un.GetKey(gn.ID), un); err != nil && err != util.ErrValueNotPresent {
+		common.Respond(w, r, nil, common.NewErrInternal(noClient, err.Error()))
+		return
+	}
+	if time.Since(un.StartTime) >= gn.IndividualReset {
+		un.StartTime, un.Used = time.Now(), 0
+	}
+
+	limits := gn.limits(entry)
+	resp := allowanceResponse{
+		ClientID:      clientId,
+		Allowlisted:   entry != nil,
+		PourAmount:    limits.PourAmount,
+		MaxPourAmount: limits.MaxPourAmount,
+		PeriodicLimit: limits.PeriodicLimit,
+		Used:          un.Used,
+		Restart:       (gn.IndividualReset - time.Since(un.StartTime)).String(),
+	}
+	if (entry != nil || !gn.Restricted) && limits.PeriodicLimit > un.Used {
+		resp.Allowed = limits.PeriodicLimit - un.Used
+	}
+	var globalAllowed currency.Coin
+	if gn.GlobalLimit > gn.Used {
+		globalAllowed = gn.GlobalLimit - gn.Used
+	}
+	if resp.Allowed > globalAllowed {
+		resp.Allowed = globalAllowed
+	}
+	common.Respond(w, r, resp, nil)
+}
+
+// swagger:model voucherSubjectResponse
+type voucherSubjectResponse struct {
+	Subject      string           `json:"subject"`
+	LastPour     common.Timestamp `json:"last_pour"`
+	CooldownLeft common.Timestamp `json:"cooldown_left"`
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d3/voucherSubject voucherSubject
+// last voucher pour of a subject and the time left before its next one
+//
+// parameters:
+//    +name: subject
+//     description: voucher subject
+//     required: true
+//     in: query
+//     type: string
+//
+// responses:
+//  200: voucherSubjectResponse
+//  400:
+//  404:
+func (frh *FaucetscRestHandler) getVoucherSubject(w http.ResponseWriter, r *http.Request) {
+	subject := r.URL.Query().Get("subject")
+	if subject == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing subject"))
+		return
+	}
+	sctx := frh.GetQueryStateContext()
+	gn, err := getGlobalNode(sctx)
+	if err != nil {
+		NoResourceOrErrInternal(w, r, err)
+		return
+	}
+	sn, err := getSubjectNode(subject, gn.ID, sctx)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get voucher subject", err.Error()))
+		return
+	}
+	common.Respond(w, r, voucherSubjectResponse{
+		Subject:      sn.Subject,
+		LastPour:     sn.LastPour,
+		CooldownLeft: sn.cooldownLeft(common.Now(), &gn),
+	}, nil)
+}
+
 func getGlobalNode(sctx state.QueryStateContextI) (GlobalNode, error) {
 	gn := GlobalNode{ID: ADDRESS}
 	err := sctx.GetTrieNode(gn.GetKey(), &gn)
@@ -117,6 +117,26 @@ func (gn *GlobalNode) updateConfig(fields map[string]string) error {
 			}
 			gn.OwnerId = value
 
+		case Settings[Restricted]:
+			restricted, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("key %s, unable to convert %v to bool", key, value)
+			}
+			gn.Restricted = restricted
+
+		case Settings[VoucherIssuer]:
+			if _, err := hex.DecodeString(value); err != nil {
+				return fmt.Errorf("key %s, %v should be valid hex string", key, value)
+			}
+			gn.VoucherIssuer = value
+
+		case Settings[VoucherCooldown]:
+			vc, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("key %s, unable to convert %v to time.duration", key, value)
+			}
+			gn.VoucherCooldown = vc
+
 		default:
 			return gn.setCostValue(key, value)
 		}
@@ -164,6 +184,8 @@ func (gn *GlobalNode) validate() error {
 		return common.NewError("failed to validate global node", fmt.Sprintf("individual reset(%v) is too short", gn.IndividualReset))
 	case gn.GlobalReset < gn.IndividualReset:
 		return common.NewError("failed to validate global node", fmt.Sprintf("global reset(%v) is less than individual reset(%v)", gn.GlobalReset, gn.IndividualReset))
+	case gn.VoucherCooldown < 0:
+		return common.NewError("failed to validate global node", fmt.Sprintf("voucher cooldown(%v) is negative", gn.VoucherCooldown))
 	}
 
 	return nil
@@ -0,0 +1,222 @@
+package faucetsc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"0chain.net/chaincore/currency"
+
+	c_state "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -v
+
+//msgp:ignore Voucher pourRequest allowlistRequest removeAllowlistRequest pourLimits
+
+// AllowlistEntry is the policy of an allowlisted client, its zero limits
+// fall back to the limits of the faucet.
+type AllowlistEntry struct {
+	ClientID      string        `json:"client_id"`
+	PourAmount    currency.Coin `json:"pour_amount"`
+	MaxPourAmount currency.Coin `json:"max_pour_amount"`
+	PeriodicLimit currency.Coin `json:"periodic_limit"`
+}
+
+func (ae *AllowlistEntry) GetKey(globalKey string) datastore.Key {
+	return datastore.Key(globalKey + ":allowlist:" + ae.ClientID)
+}
+
+func (ae *AllowlistEntry) Encode() []byte {
+	buff, _ := json.Marshal(ae)
+	return buff
+}
+
+func (ae *AllowlistEntry) Decode(input []byte) error {
+	return json.Unmarshal(input, ae)
+}
+
+func (ae *AllowlistEntry) validate() error {
+	switch {
+	case ae.ClientID == "":
+		return errors.New("missing client id")
+	case ae.MaxPourAmount > 0 && ae.PourAmount > ae.MaxPourAmount:
+		return fmt.Errorf("max pour amount(%v) is less than pour amount(%v)",
+			ae.MaxPourAmount, ae.PourAmount)
+	case ae.PeriodicLimit > 0 && ae.MaxPourAmount > ae.PeriodicLimit:
+		return fmt.Errorf("periodic limit(%v) is less than max pour amount(%v)",
+			ae.PeriodicLimit, ae.MaxPourAmount)
+	}
+	return nil
+}
+
+// pourLimits are the limits of the pours of a client
+type pourLimits struct {
+	PourAmount    currency.Coin
+	MaxPourAmount currency.Coin
+	PeriodicLimit currency.Coin
+}
+
+// limits of the client, the custom limits of the allowlist entry, if any,
+// override the faucet ones
+func (gn *GlobalNode) limits(ae *AllowlistEntry) pourLimits {
+	l := pourLimits{
+		PourAmount:    gn.PourAmount,
+		MaxPourAmount: gn.MaxPourAmount,
+		PeriodicLimit: gn.PeriodicLimit,
+	}
+	if ae == nil {
+		return l
+	}
+	if ae.PourAmount > 0 {
+		l.PourAmount = ae.PourAmount
+	}
+	if ae.MaxPourAmount > 0 {
+		l.MaxPourAmount = ae.MaxPourAmount
+	}
+	if ae.PeriodicLimit > 0 {
+		l.PeriodicLimit = ae.PeriodicLimit
+	}
+	return l
+}
+
+// Voucher is a one-time pour granted by the voucher issuer to the client
+// once the issuer checked the identity of the client. The subject identifies
+// the proven identity, the pours of the vouchers of the same subject are
+// limited by the voucher cooldown.
+type Voucher struct {
+	ID        string           `json:"id"`
+	Subject   string           `json:"subject"`
+	ClientID  string           `json:"client_id"`
+	Amount    currency.Coin    `json:"amount"`
+	ExpiresAt common.Timestamp `json:"expires_at"`
+	Signature string           `json:"signature"`
+}
+
+func (v *Voucher) GetHashData() string {
+	return fmt.Sprintf("%v:%v:%v:%v:%v", v.ID, v.Subject, v.ClientID, v.Amount, v.ExpiresAt)
+}
+
+func (v *Voucher) verifySignature(issuerPublicKey string, balances c_state.StateContextI) bool {
+	signatureScheme := balances.GetSignatureScheme()
+	if err := signatureScheme.SetPublicKey(issuerPublicKey); err != nil {
+		return false
+	}
+	ok, err := signatureScheme.Verify(v.Signature, encryption.Hash(v.GetHashData()))
+	return err == nil && ok
+}
+
+func (v *Voucher) validate(t *transaction.Transaction, gn *GlobalNode) error {
+	switch {
+	case v.ID == "" || v.Subject == "":
+		return errors.New("missing voucher id or subject")
+	case v.ClientID != t.ClientID:
+		return errors.New("voucher is issued to another client")
+	case v.ExpiresAt <= t.CreationDate:
+		return errors.New("voucher expired")
+	case v.Amount > gn.MaxPourAmount:
+		return fmt.Errorf("voucher amount(%v) exceeds max pour amount(%v)",
+			v.Amount, gn.MaxPourAmount)
+	}
+	return nil
+}
+
+// RedeemedVoucher marks a poured voucher to prevent its replay.
+type RedeemedVoucher struct {
+	ID      string `json:"id"`
+	TxnHash string `json:"txn_hash"`
+}
+
+func (rv *RedeemedVoucher) GetKey(globalKey string) datastore.Key {
+	return datastore.Key(globalKey + ":voucher:" + encryption.Hash(rv.ID))
+}
+
+func (rv *RedeemedVoucher) Encode() []byte {
+	buff, _ := json.Marshal(rv)
+	return buff
+}
+
+func (rv *RedeemedVoucher) Decode(input []byte) error {
+	return json.Unmarshal(input, rv)
+}
+
+// SubjectNode is the last voucher pour of a subject.
+type SubjectNode struct {
+	Subject  string           `json:"subject"`
+	LastPour common.Timestamp `json:"last_pour"`
+}
+
+func (sn *SubjectNode) GetKey(globalKey string) datastore.Key {
+	return datastore.Key(globalKey + ":subject:" + encryption.Hash(sn.Subject))
+}
+
+func (sn *SubjectNode) Encode() []byte {
+	buff, _ := json.Marshal(sn)
+	return buff
+}
+
+func (sn *SubjectNode) Decode(input []byte) error {
+	return json.Unmarshal(input, sn)
+}
+
+// cooldownLeft returns the time left before the next voucher pour of the
+// subject
+func (sn *SubjectNode) cooldownLeft(now common.Timestamp, gn *GlobalNode) common.Timestamp {
+	if sn.LastPour == 0 {
+		return 0
+	}
+	if left := sn.LastPour + toSeconds(gn.VoucherCooldown) - now; left > 0 {
+		return left
+	}
+	return 0
+}
+
+// pourRequest is the optional input of a pour, a pour of a voucher
+type pourRequest struct {
+	Voucher *Voucher `json:"voucher,omitempty"`
+}
+
+// pourVoucher returns the voucher of the pour input, nil unless the input
+// decodes as a voucher request; any other input is a plain pour.
+func pourVoucher(input []byte) *Voucher {
+	var pr pourRequest
+	if err := json.Unmarshal(input, &pr); err != nil {
+		return nil
+	}
+	return pr.Voucher
+}
+
+type allowlistRequest struct {
+	Entries []*AllowlistEntry `json:"entries"`
+}
+
+type removeAllowlistRequest struct {
+	ClientIDs []string `json:"client_ids"`
+}
+
+func getAllowlistEntry(clientID, globalKey string, balances c_state.CommonStateContextI) (*AllowlistEntry, error) {
+	ae := &AllowlistEntry{ClientID: clientID}
+	switch err := balances.GetTrieNode(ae.GetKey(globalKey), ae); err {
+	case nil:
+		return ae, nil
+	case util.ErrValueNotPresent:
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
+
+func getSubjectNode(subject, globalKey string, balances c_state.CommonStateContextI) (*SubjectNode, error) {
+	sn := &SubjectNode{Subject: subject}
+	switch err := balances.GetTrieNode(sn.GetKey(globalKey), sn); err {
+	case nil, util.ErrValueNotPresent:
+		return sn, nil
+	default:
+		return nil, err
+	}
+}
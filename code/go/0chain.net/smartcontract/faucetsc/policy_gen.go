@@ -0,0 +1,224 @@
+package faucetsc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *AllowlistEntry) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "ClientID"
+	o = append(o, 0x84, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ClientID)
+	// string "PourAmount"
+	o = append(o, 0xaa, 0x50, 0x6f, 0x75, 0x72, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o, err = z.PourAmount.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "PourAmount")
+		return
+	}
+	// string "MaxPourAmount"
+	o = append(o, 0xad, 0x4d, 0x61, 0x78, 0x50, 0x6f, 0x75, 0x72, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o, err = z.MaxPourAmount.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MaxPourAmount")
+		return
+	}
+	// string "PeriodicLimit"
+	o = append(o, 0xad, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x69, 0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	o, err = z.PeriodicLimit.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "PeriodicLimit")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *AllowlistEntry) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ClientID":
+			z.ClientID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ClientID")
+				return
+			}
+		case "PourAmount":
+			bts, err = z.PourAmount.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PourAmount")
+				return
+			}
+		case "MaxPourAmount":
+			bts, err = z.MaxPourAmount.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxPourAmount")
+				return
+			}
+		case "PeriodicLimit":
+			bts, err = z.PeriodicLimit.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PeriodicLimit")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *AllowlistEntry) Msgsize() (s int) {
+	s = 1 + 9 + msgp.StringPrefixSize + len(z.ClientID) + 11 + z.PourAmount.Msgsize() + 14 + z.MaxPourAmount.Msgsize() + 14 + z.PeriodicLimit.Msgsize()
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z RedeemedVoucher) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "ID"
+	o = append(o, 0x82, 0xa2, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ID)
+	// string "TxnHash"
+	o = append(o, 0xa7, 0x54, 0x78, 0x6e, 0x48, 0x61, 0x73, 0x68)
+	o = msgp.AppendString(o, z.TxnHash)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *RedeemedVoucher) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ID":
+			z.ID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
+			}
+		case "TxnHash":
+			z.TxnHash, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TxnHash")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z RedeemedVoucher) Msgsize() (s int) {
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 8 + msgp.StringPrefixSize + len(z.TxnHash)
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SubjectNode) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Subject"
+	o = append(o, 0x82, 0xa7, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74)
+	o = msgp.AppendString(o, z.Subject)
+	// string "LastPour"
+	o = append(o, 0xa8, 0x4c, 0x61, 0x73, 0x74, 0x50, 0x6f, 0x75, 0x72)
+	o, err = z.LastPour.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "LastPour")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SubjectNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Subject":
+			z.Subject, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Subject")
+				return
+			}
+		case "LastPour":
+			bts, err = z.LastPour.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LastPour")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SubjectNode) Msgsize() (s int) {
+	s = 1 + 8 + msgp.StringPrefixSize + len(z.Subject) + 9 + z.LastPour.Msgsize()
+	return
+}
@@ -0,0 +1,97 @@
+package faucetsc
+
+import (
+	"testing"
+	"time"
+
+	"0chain.net/chaincore/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalNode_limits(t *testing.T) {
+	gn := &GlobalNode{FaucetConfig: &FaucetConfig{
+		PourAmount:    1,
+		MaxPourAmount: 10,
+		PeriodicLimit: 100,
+	}}
+
+	require.Equal(t, pourLimits{1, 10, 100}, gn.limits(nil))
+	require.Equal(t, pourLimits{5, 10, 1000}, gn.limits(&AllowlistEntry{
+		ClientID:      "client",
+		PourAmount:    5,
+		PeriodicLimit: 1000,
+	}))
+}
+
+func TestVoucher_validate(t *testing.T) {
+	var (
+		gn  = &GlobalNode{FaucetConfig: &FaucetConfig{MaxPourAmount: 10}}
+		txn = &transaction.Transaction{ClientID: "client", CreationDate: 100}
+	)
+	tests := []struct {
+		name    string
+		voucher Voucher
+		err     string
+	}{
+		{
+			name:    "ok",
+			voucher: Voucher{ID: "1", Subject: "s", ClientID: "client", Amount: 10, ExpiresAt: 101},
+		},
+		{
+			name:    "no_subject",
+			voucher: Voucher{ID: "1", ClientID: "client", ExpiresAt: 101},
+			err:     "missing voucher id or subject",
+		},
+		{
+			name:    "other_client",
+			voucher: Voucher{ID: "1", Subject: "s", ClientID: "other", ExpiresAt: 101},
+			err:     "voucher is issued to another client",
+		},
+		{
+			name:    "expired",
+			voucher: Voucher{ID: "1", Subject: "s", ClientID: "client", ExpiresAt: 100},
+			err:     "voucher expired",
+		},
+		{
+			name:    "too_much",
+			voucher: Voucher{ID: "1", Subject: "s", ClientID: "client", Amount: 11, ExpiresAt: 101},
+			err:     "voucher amount(11) exceeds max pour amount(10)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.voucher.validate(txn, gn)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestPourVoucher(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  *Voucher
+	}{
+		{name: "empty"},
+		{name: "free_text", input: "{Pay day}"},
+		{name: "other_json", input: `{"amount":1}`},
+		{name: "voucher", input: `{"voucher":{"id":"1","subject":"s"}}`, want: &Voucher{ID: "1", Subject: "s"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, pourVoucher([]byte(tt.input)))
+		})
+	}
+}
+
+func TestSubjectNode_cooldownLeft(t *testing.T) {
+	gn := &GlobalNode{FaucetConfig: &FaucetConfig{VoucherCooldown: time.Minute}}
+
+	require.EqualValues(t, 0, (&SubjectNode{}).cooldownLeft(100, gn))
+	require.EqualValues(t, 50, (&SubjectNode{LastPour: 90}).cooldownLeft(100, gn))
+	require.EqualValues(t, 0, (&SubjectNode{LastPour: 40}).cooldownLeft(100, gn))
+}
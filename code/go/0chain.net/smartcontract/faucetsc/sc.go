@@ -2,6 +2,7 @@ package faucetsc
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 	"net/url"
@@ -76,11 +77,13 @@ func (fc *FaucetSmartContract) setSC(sc *smartcontractinterface.SmartContract, _
 	fc.SmartContractExecutionStats["update-settings"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", fc.ID, "update-settings"), nil)
 	fc.SmartContractExecutionStats["pour"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", fc.ID, "pour"), nil)
 	fc.SmartContractExecutionStats["refill"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", fc.ID, "refill"), nil)
+	fc.SmartContractExecutionStats["update-allowlist"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", fc.ID, "update-allowlist"), nil)
+	fc.SmartContractExecutionStats["remove-allowlist"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", fc.ID, "remove-allowlist"), nil)
 	fc.SmartContractExecutionStats["tokens Poured"] = metrics.GetOrRegisterHistogram(fmt.Sprintf("sc:%v:func:%v", fc.ID, "tokens Poured"), nil, metrics.NewUniformSample(1024))
 	fc.SmartContractExecutionStats["token refills"] = metrics.GetOrRegisterHistogram(fmt.Sprintf("sc:%v:func:%v", fc.ID, "token refills"), nil, metrics.NewUniformSample(1024))
 }
 
-func (un *UserNode) validPourRequest(t *transaction.Transaction, balances c_state.StateContextI, gn *GlobalNode) (bool, error) {
+func (un *UserNode) validPourRequest(t *transaction.Transaction, balances c_state.StateContextI, gn *GlobalNode, limits pourLimits) (bool, error) {
 	smartContractBalance, err := balances.GetClientBalance(gn.ID)
 	if err == util.ErrValueNotPresent {
 		return false, common.NewError("invalid_request", "faucet has no tokens and needs to be refilled")
@@ -88,30 +91,30 @@ func (un *UserNode) validPourRequest(t *transaction.Transaction, balances c_stat
 	if err != nil {
 		return false, common.NewError("invalid_request", fmt.Sprintf("getting faucet balance resulted in an error: %v", err.Error()))
 	}
-	if gn.PourAmount > smartContractBalance {
+	if limits.PourAmount > smartContractBalance {
 		return false, common.NewError("invalid_request", fmt.Sprintf("amount asked to be poured (%v) exceeds contract's wallet ballance (%v)", t.Value, smartContractBalance))
 	}
 
-	totalAmount, err := currency.AddCoin(gn.PourAmount, un.Used)
+	totalAmount, err := currency.AddCoin(limits.PourAmount, un.Used)
 	if err != nil {
-		return false, common.NewError("invalid_request", fmt.Sprintf("amount asked to be poured (%v) plus previous amount (%v) is not a valid currency. error: %v", gn.PourAmount, un.Used, err))
+		return false, common.NewError("invalid_request", fmt.Sprintf("amount asked to be poured (%v) plus previous amount (%v) is not a valid currency. error: %v", limits.PourAmount, un.Used, err))
 	}
-	if totalAmount > gn.PeriodicLimit {
+	if totalAmount > limits.PeriodicLimit {
 		return false, common.NewError("invalid_request",
 			fmt.Sprintf("amount asked to be poured (%v) plus previous amounts (%v) exceeds allowed periodic limit (%v/%vhr)",
-				t.Value, un.Used, gn.PeriodicLimit, gn.IndividualReset.String()))
+				t.Value, un.Used, limits.PeriodicLimit, gn.IndividualReset.String()))
 	}
 
-	totalGAmount, err := currency.AddCoin(gn.PourAmount, gn.Used)
+	totalGAmount, err := currency.AddCoin(limits.PourAmount, gn.Used)
 	if err != nil {
-		return false, common.NewError("invalid_request", fmt.Sprintf("amount asked to be poured (%v) plus global used amount (%v) is not a valid currency. error: %v", gn.PourAmount, gn.Used, err))
+		return false, common.NewError("invalid_request", fmt.Sprintf("amount asked to be poured (%v) plus global used amount (%v) is not a valid currency. error: %v", limits.PourAmount, gn.Used, err))
 	}
 	if totalGAmount > gn.GlobalLimit {
 		return false, common.NewError("invalid_request",
 			fmt.Sprintf("amount asked to be poured (%v) plus global used amount (%v) exceeds allowed global limit (%v/%vhr)",
 				t.Value, gn.Used, gn.GlobalLimit, gn.GlobalReset.String()))
 	}
-	logging.Logger.Info("Valid sc request", zap.Any("contract_balance", smartContractBalance), zap.Any("txn.Value", t.Value), zap.Any("max_pour", gn.PourAmount), zap.Any("periodic_used+t.Value", currency.Coin(t.Value)+un.Used), zap.Any("periodic_limit", gn.PeriodicLimit), zap.Any("global_used+txn.Value", currency.Coin(t.Value)+gn.Used), zap.Any("global_limit", gn.GlobalLimit))
+	logging.Logger.Info("Valid sc request", zap.Any("contract_balance", smartContractBalance), zap.Any("txn.Value", t.Value), zap.Any("max_pour", limits.PourAmount), zap.Any("periodic_used+t.Value", currency.Coin(t.Value)+un.Used), zap.Any("periodic_limit", limits.PeriodicLimit), zap.Any("global_used+txn.Value", currency.Coin(t.Value)+gn.Used), zap.Any("global_limit", gn.GlobalLimit))
 	return true, nil
 }
 
@@ -121,7 +124,7 @@ func (fc *FaucetSmartContract) updateSettings(
 	balances c_state.StateContextI,
 	gn *GlobalNode,
 ) (string, error) {
-	if err := smartcontractinterface.AuthorizeWithOwner("update_settings", func() bool {
+	if err := smartcontractinterface.AuthorizeWithOwnerOrGovernance("update_settings", t.ClientID, func() bool {
 		return gn.FaucetConfig.OwnerId == t.ClientID
 	}); err != nil {
 		return "", err
@@ -151,12 +154,25 @@ func toSeconds(dur time.Duration) common.Timestamp {
 	return common.Timestamp(dur / time.Second)
 }
 
-func (fc *FaucetSmartContract) pour(t *transaction.Transaction, _ []byte, balances c_state.StateContextI, gn *GlobalNode) (string, error) {
+func (fc *FaucetSmartContract) pour(t *transaction.Transaction, inputData []byte, balances c_state.StateContextI, gn *GlobalNode) (string, error) {
+	if v := pourVoucher(inputData); v != nil {
+		return fc.pourVoucher(t, v, balances, gn)
+	}
+
+	entry, err := getAllowlistEntry(t.ClientID, gn.ID, balances)
+	if err != nil {
+		return "", common.NewErrorf("pour", "error getting allowlist entry: %v", err)
+	}
+	if entry == nil && gn.Restricted {
+		return "", common.NewError("pour", "faucet pours only to allowlisted clients and for vouchers")
+	}
+	limits := gn.limits(entry)
+
 	user := fc.getUserVariables(t, gn, balances)
-	ok, err := user.validPourRequest(t, balances, gn)
+	ok, err := user.validPourRequest(t, balances, gn, limits)
 	if ok {
-		var pourAmount = gn.PourAmount
-		if t.Value > 0 && t.Value < gn.MaxPourAmount {
+		var pourAmount = limits.PourAmount
+		if t.Value > 0 && t.Value < limits.MaxPourAmount {
 			pourAmount = t.Value
 		}
 		tokensPoured := fc.SmartContractExecutionStats["tokens Poured"].(metrics.Histogram)
@@ -199,6 +215,130 @@ func (fc *FaucetSmartContract) pour(t *transaction.Transaction, _ []byte, balanc
 	return "", err
 }
 
+// pourVoucher pours the amount of the voucher, the faucet pour amount for a
+// voucher without amount, once per voucher and per voucher cooldown of its
+// subject
+func (fc *FaucetSmartContract) pourVoucher(t *transaction.Transaction, v *Voucher, balances c_state.StateContextI, gn *GlobalNode) (string, error) {
+	if gn.VoucherIssuer == "" {
+		return "", common.NewError("pour", "vouchers are disabled")
+	}
+	if err := v.validate(t, gn); err != nil {
+		return "", common.NewError("pour", "invalid voucher: "+err.Error())
+	}
+	if !v.verifySignature(gn.VoucherIssuer, balances) {
+		return "", common.NewError("pour", "invalid voucher signature")
+	}
+
+	rv := &RedeemedVoucher{ID: v.ID}
+	switch err := balances.GetTrieNode(rv.GetKey(gn.ID), rv); err {
+	case nil:
+		return "", common.NewError("pour", "voucher already redeemed in transaction "+rv.TxnHash)
+	case util.ErrValueNotPresent:
+	default:
+		return "", common.NewErrorf("pour", "error getting redeemed voucher: %v", err)
+	}
+
+	subject, err := getSubjectNode(v.Subject, gn.ID, balances)
+	if err != nil {
+		return "", common.NewErrorf("pour", "error getting voucher subject: %v", err)
+	}
+	if left := subject.cooldownLeft(t.CreationDate, gn); left > 0 {
+		return "", common.NewErrorf("pour", "voucher subject cooldown, %vs left", left)
+	}
+
+	var pourAmount = v.Amount
+	if pourAmount == 0 {
+		pourAmount = gn.PourAmount
+	}
+	smartContractBalance, err := balances.GetClientBalance(gn.ID)
+	if err != nil && err != util.ErrValueNotPresent {
+		return "", common.NewError("invalid_request", fmt.Sprintf("getting faucet balance resulted in an error: %v", err.Error()))
+	}
+	if pourAmount > smartContractBalance {
+		return "", common.NewError("invalid_request", fmt.Sprintf("amount asked to be poured (%v) exceeds contract's wallet ballance (%v)", pourAmount, smartContractBalance))
+	}
+	gnUsed, err := currency.AddCoin(gn.Used, pourAmount)
+	if err != nil {
+		return "", common.NewError("pour", fmt.Sprintf("adding tokens to global used amount resulted in an error: %v", err.Error()))
+	}
+	if gnUsed > gn.GlobalLimit {
+		return "", common.NewError("invalid_request",
+			fmt.Sprintf("amount asked to be poured (%v) plus global used amount (%v) exceeds allowed global limit (%v/%vhr)",
+				pourAmount, gn.Used, gn.GlobalLimit, gn.GlobalReset.String()))
+	}
+
+	transfer := state.NewTransfer(t.ToClientID, t.ClientID, pourAmount)
+	if err := balances.AddTransfer(transfer); err != nil {
+		return "", common.NewErrorf("pour", "error adding transfer: %v", err)
+	}
+	gn.Used = gnUsed
+	if _, err = balances.InsertTrieNode(gn.GetKey(), gn); err != nil {
+		return "", common.NewErrorf("pour", "error inserting global node: %v", err)
+	}
+	rv.TxnHash = t.Hash
+	if _, err = balances.InsertTrieNode(rv.GetKey(gn.ID), rv); err != nil {
+		return "", common.NewErrorf("pour", "error inserting redeemed voucher: %v", err)
+	}
+	subject.LastPour = t.CreationDate
+	if _, err = balances.InsertTrieNode(subject.GetKey(gn.ID), subject); err != nil {
+		return "", common.NewErrorf("pour", "error inserting voucher subject: %v", err)
+	}
+	fc.SmartContractExecutionStats["tokens Poured"].(metrics.Histogram).Update(int64(transfer.Amount))
+	return string(transfer.Encode()), nil
+}
+
+// updateAllowlist adds the allowlist entries, or overwrites the existing
+// entries of the same clients
+func (fc *FaucetSmartContract) updateAllowlist(t *transaction.Transaction, inputData []byte, balances c_state.StateContextI, gn *GlobalNode) (string, error) {
+	if err := smartcontractinterface.AuthorizeWithOwner("update-allowlist", func() bool {
+		return gn.FaucetConfig.OwnerId == t.ClientID
+	}); err != nil {
+		return "", err
+	}
+
+	var req allowlistRequest
+	if err := json.Unmarshal(inputData, &req); err != nil {
+		return "", common.NewError("update-allowlist", "allowlist request not formatted correctly")
+	}
+	if len(req.Entries) == 0 {
+		return "", common.NewError("update-allowlist", "no allowlist entries")
+	}
+	for _, entry := range req.Entries {
+		if entry == nil {
+			return "", common.NewError("update-allowlist", "empty allowlist entry")
+		}
+		if err := entry.validate(); err != nil {
+			return "", common.NewErrorf("update-allowlist", "invalid entry of %s: %v", entry.ClientID, err)
+		}
+		if _, err := balances.InsertTrieNode(entry.GetKey(gn.ID), entry); err != nil {
+			return "", common.NewErrorf("update-allowlist", "saving allowlist entry: %v", err)
+		}
+	}
+	return fmt.Sprintf("%d allowlist entries updated", len(req.Entries)), nil
+}
+
+// removeAllowlist removes the clients from the allowlist
+func (fc *FaucetSmartContract) removeAllowlist(t *transaction.Transaction, inputData []byte, balances c_state.StateContextI, gn *GlobalNode) (string, error) {
+	if err := smartcontractinterface.AuthorizeWithOwner("remove-allowlist", func() bool {
+		return gn.FaucetConfig.OwnerId == t.ClientID
+	}); err != nil {
+		return "", err
+	}
+
+	var req removeAllowlistRequest
+	if err := json.Unmarshal(inputData, &req); err != nil {
+		return "", common.NewError("remove-allowlist", "remove allowlist request not formatted correctly")
+	}
+	for _, clientID := range req.ClientIDs {
+		entry := &AllowlistEntry{ClientID: clientID}
+		_, err := balances.DeleteTrieNode(entry.GetKey(gn.ID))
+		if err != nil && err != util.ErrValueNotPresent && err != util.ErrNodeNotFound {
+			return "", common.NewErrorf("remove-allowlist", "deleting allowlist entry of %s: %v", clientID, err)
+		}
+	}
+	return fmt.Sprintf("%d allowlist entries removed", len(req.ClientIDs)), nil
+}
+
 func (fc *FaucetSmartContract) refill(t *transaction.Transaction, balances c_state.StateContextI, gn *GlobalNode) (string, error) {
 	clientBalance, err := balances.GetClientBalance(t.ClientID)
 	if err != nil {
@@ -289,6 +429,10 @@ func (fc *FaucetSmartContract) Execute(t *transaction.Transaction, funcName stri
 		return fc.pour(t, inputData, balances, gn)
 	case "refill":
 		return fc.refill(t, balances, gn)
+	case "update-allowlist":
+		return fc.updateAllowlist(t, inputData, balances, gn)
+	case "remove-allowlist":
+		return fc.removeAllowlist(t, inputData, balances, gn)
 	default:
 		return "", common.NewErrorf("failed execution", "no faucet smart contract method with name %s", funcName)
 	}
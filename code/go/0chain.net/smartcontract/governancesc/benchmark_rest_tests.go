@@ -0,0 +1,45 @@
+package governancesc
+
+import (
+	"0chain.net/smartcontract/benchmark"
+	"0chain.net/smartcontract/rest"
+)
+
+func BenchmarkRestTests(
+	data benchmark.BenchData, _ benchmark.SignatureScheme,
+) benchmark.TestSuite {
+	rh := rest.NewRestHandler(&rest.TestQueryChainer{})
+	grh := NewGovernanceRestHandler(rh)
+	return benchmark.GetRestTests(
+		[]benchmark.TestParameters{
+			{
+				FuncName: "getProposals",
+				Params: map[string]string{
+					"status": "voting",
+				},
+				Endpoint: grh.getProposals,
+			},
+			{
+				FuncName: "getProposal",
+				Params: map[string]string{
+					"proposal_id": getMockProposalID(0),
+				},
+				Endpoint: grh.getProposal,
+			},
+			{
+				FuncName: "getProposalVotes",
+				Params: map[string]string{
+					"proposal_id": getMockProposalID(0),
+				},
+				Endpoint: grh.getProposalVotes,
+			},
+			{
+				FuncName: "governance-config",
+				Endpoint: grh.getConfig,
+			},
+		},
+		ADDRESS,
+		grh,
+		benchmark.GovernanceRest,
+	)
+}
@@ -0,0 +1,92 @@
+package governancesc
+
+import (
+	"log"
+	"math"
+	"strconv"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/smartcontract"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/minersc"
+)
+
+const mockProposals = 10
+
+func AddMockConfig(balances cstate.StateContextI) {
+	conf, err := getConfiguredConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err = balances.InsertTrieNode(scConfigKey(ADDRESS), conf); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func getMockProposalID(i int) string {
+	return "mock_governance_proposal_" + strconv.Itoa(i)
+}
+
+// AddMockProposals adds the governance proposals of the clients, the even
+// ones are open for votes, the odd ones are waiting to be finalized.
+func AddMockProposals(
+	clients []string,
+	eventDb *event.EventDb,
+	balances cstate.StateContextI,
+) {
+	var ap activeProposals
+	for i := 0; i < len(clients) && i < mockProposals; i++ {
+		p := &proposal{
+			ID:           getMockProposalID(i),
+			Proposer:     clients[i],
+			Description:  "mock proposal",
+			ToClientID:   minersc.ADDRESS,
+			FunctionName: "update_globals",
+			Changes: &smartcontract.StringMap{
+				Fields: map[string]string{"server_chain.smart_contract.setting_update_period": "200"},
+			},
+			EndRound: math.MaxInt64,
+			VotesFor: 1e10,
+			Voters:   1,
+			Status:   event.ProposalVoting,
+		}
+		if i%2 == 1 {
+			p.EndRound = 0
+		}
+		if _, err := balances.InsertTrieNode(proposalKey(p.ID), p); err != nil {
+			panic(err)
+		}
+		if _, err := balances.InsertTrieNode(voteKey(p.ID, clients[i]), &vote{
+			Support: true,
+			Weight:  p.VotesFor,
+		}); err != nil {
+			panic(err)
+		}
+		ap.IDs = append(ap.IDs, p.ID)
+
+		if eventDb == nil {
+			continue
+		}
+		_ = eventDb.Store.Get().Create(&event.GovernanceProposal{
+			ProposalID:   p.ID,
+			Proposer:     p.Proposer,
+			Description:  p.Description,
+			ToClientID:   p.ToClientID,
+			FunctionName: p.FunctionName,
+			Changes:      string(p.Changes.Encode()),
+			EndRound:     p.EndRound,
+			VotesFor:     p.VotesFor,
+			Voters:       p.Voters,
+			Status:       p.Status,
+		})
+		_ = eventDb.Store.Get().Create(&event.GovernanceVote{
+			ProposalID: p.ID,
+			VoterID:    clients[i],
+			Support:    true,
+			Weight:     p.VotesFor,
+		})
+	}
+	if _, err := balances.InsertTrieNode(activeProposalsKey(), &ap); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,138 @@
+package governancesc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	sci "0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	sc "0chain.net/smartcontract"
+	bk "0chain.net/smartcontract/benchmark"
+	"0chain.net/smartcontract/storagesc"
+)
+
+type BenchTest struct {
+	name     string
+	endpoint func(
+		*transaction.Transaction,
+		[]byte,
+		cstate.StateContextI,
+	) (string, error)
+	txn   *transaction.Transaction
+	input []byte
+}
+
+func (bt BenchTest) Name() string {
+	return bt.name
+}
+
+func (bt BenchTest) Transaction() *transaction.Transaction {
+	return &transaction.Transaction{
+		HashIDField: datastore.HashIDField{
+			Hash: bt.txn.Hash,
+		},
+		ClientID:     bt.txn.ClientID,
+		ToClientID:   bt.txn.ToClientID,
+		Value:        bt.txn.Value,
+		CreationDate: bt.txn.CreationDate,
+	}
+}
+
+func (bt BenchTest) Run(balances cstate.TimedQueryStateContext, _ *testing.B) error {
+	_, err := bt.endpoint(bt.Transaction(), bt.input, balances)
+	return err
+}
+
+func BenchmarkTests(
+	data bk.BenchData, _ bk.SignatureScheme,
+) bk.TestSuite {
+	creationTimeRaw := viper.GetInt64("MptCreationTime")
+	creationTime := common.Now()
+	if creationTimeRaw != 0 {
+		creationTime = common.Timestamp(creationTimeRaw)
+	}
+
+	var gsc = GovernanceSmartContract{
+		SmartContract: sci.NewSC(ADDRESS),
+	}
+	gsc.setSC(gsc.SmartContract, &smartcontract.BCContext{})
+	var tests = []BenchTest{
+		{
+			name:     "governance.submit_proposal",
+			endpoint: gsc.submitProposal,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: "my hash",
+				},
+				ClientID:     data.Clients[0],
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&proposalRequest{
+					Description:  "benchmark proposal",
+					ToClientID:   storagesc.ADDRESS,
+					FunctionName: "update_settings",
+					Changes: &sc.StringMap{
+						Fields: map[string]string{"max_mint": "1500000"},
+					},
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "governance.vote",
+			endpoint: gsc.vote,
+			txn: &transaction.Transaction{
+				ClientID:     data.Clients[0],
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&voteRequest{
+					ProposalID: getMockProposalID(0),
+					Support:    false,
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "governance.finalize_proposals",
+			endpoint: gsc.finalizeProposals,
+			txn: &transaction.Transaction{
+				ClientID:     data.Clients[0],
+				CreationDate: creationTime,
+			},
+			input: []byte{},
+		},
+		{
+			name:     "governance.update_settings",
+			endpoint: gsc.updateConfig,
+			txn: &transaction.Transaction{
+				ClientID:     viper.GetString(bk.GovernanceOwner),
+				CreationDate: creationTime,
+			},
+			input: (&sc.StringMap{
+				Fields: map[string]string{
+					Settings[VotingPeriod]:       "1000",
+					Settings[MinProposerStake]:   "1",
+					Settings[Quorum]:             "10",
+					Settings[PassRatio]:          "0.6",
+					Settings[MaxActiveProposals]: "10",
+				},
+			}).Encode(),
+		},
+	}
+	var testsI []bk.BenchTestI
+	for _, test := range tests {
+		testsI = append(testsI, test)
+	}
+	return bk.TestSuite{
+		Source:     bk.Governance,
+		Benchmarks: testsI,
+	}
+}
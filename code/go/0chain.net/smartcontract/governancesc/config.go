@@ -0,0 +1,326 @@
+package governancesc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	chainstate "0chain.net/chaincore/chain/state"
+	configpkg "0chain.net/chaincore/config"
+	"0chain.net/chaincore/currency"
+	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/util"
+	"0chain.net/smartcontract"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+type Setting int
+
+const (
+	OwnerId Setting = iota
+	VotingPeriod
+	MinProposerStake
+	Quorum
+	PassRatio
+	MaxActiveProposals
+	MaxChanges
+	MaxDescriptionLength
+	Cost
+)
+
+var (
+	Settings = []string{
+		"owner_id",
+		"voting_period",
+		"min_proposer_stake",
+		"quorum",
+		"pass_ratio",
+		"max_active_proposals",
+		"max_changes",
+		"max_description_length",
+		"cost",
+	}
+
+	costFunctions = []string{
+		"submit_proposal",
+		"vote",
+		"finalize_proposals",
+		"update_settings",
+	}
+)
+
+func scConfigKey(scKey string) datastore.Key {
+	return scKey + ":configurations"
+}
+
+// config represents SC configurations ('governancesc:' from sc.yaml)
+type config struct {
+	OwnerId string `json:"owner_id"`
+	// VotingPeriod is the number of rounds a proposal is open for votes
+	VotingPeriod int64 `json:"voting_period"`
+	// MinProposerStake is the stake a client must delegate to submit a
+	// proposal
+	MinProposerStake currency.Coin `json:"min_proposer_stake"`
+	// Quorum is the min stake of the votes of a passed proposal
+	Quorum currency.Coin `json:"quorum"`
+	// PassRatio is the part of the votes stake a passed proposal must exceed
+	PassRatio            float64        `json:"pass_ratio"`
+	MaxActiveProposals   int            `json:"max_active_proposals"`
+	MaxChanges           int            `json:"max_changes"`
+	MaxDescriptionLength int            `json:"max_description_length"`
+	Cost                 map[string]int `json:"cost"`
+}
+
+func (c *config) validate() (err error) {
+	switch {
+	case c.VotingPeriod < 1:
+		return errors.New("invalid voting_period (< 1)")
+	case c.PassRatio < 0 || c.PassRatio >= 1:
+		return errors.New("invalid pass_ratio, out of [0; 1)")
+	case c.MaxActiveProposals < 1:
+		return errors.New("invalid max_active_proposals (< 1)")
+	case c.MaxChanges < 1:
+		return errors.New("invalid max_changes (< 1)")
+	case c.MaxDescriptionLength < 1:
+		return errors.New("invalid max_description_length (< 1)")
+	case c.OwnerId == "":
+		return errors.New("owner_id is not set or empty")
+	}
+	return
+}
+
+func (c *config) Encode() (b []byte) {
+	var err error
+	if b, err = json.Marshal(c); err != nil {
+		panic(err) // must not happens
+	}
+	return
+}
+
+func (c *config) Decode(b []byte) error {
+	return json.Unmarshal(b, c)
+}
+
+func (c *config) update(changes *smartcontract.StringMap) error {
+	for key, value := range changes.Fields {
+		switch key {
+		case Settings[OwnerId]:
+			if _, err := hex.DecodeString(value); err != nil {
+				return fmt.Errorf("value %v cannot be converted to int with 16 base, "+
+					"failing to set config key %s", value, key)
+			}
+			c.OwnerId = value
+		case Settings[VotingPeriod]:
+			iValue, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("value %v cannot be converted to int64, "+
+					"failing to set config key %s", value, key)
+			}
+			c.VotingPeriod = iValue
+		case Settings[MinProposerStake], Settings[Quorum]:
+			fValue, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("value %v cannot be converted to currency.Coin, "+
+					"failing to set config key %s", value, key)
+			}
+			coin, err := currency.ParseZCN(fValue)
+			if err != nil {
+				return err
+			}
+			if key == Settings[Quorum] {
+				c.Quorum = coin
+			} else {
+				c.MinProposerStake = coin
+			}
+		case Settings[PassRatio]:
+			fValue, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("value %v cannot be converted to float64, "+
+					"failing to set config key %s", value, key)
+			}
+			c.PassRatio = fValue
+		case Settings[MaxActiveProposals], Settings[MaxChanges], Settings[MaxDescriptionLength]:
+			iValue, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("value %v cannot be converted to int, "+
+					"failing to set config key %s", value, key)
+			}
+			switch key {
+			case Settings[MaxActiveProposals]:
+				c.MaxActiveProposals = iValue
+			case Settings[MaxChanges]:
+				c.MaxChanges = iValue
+			default:
+				c.MaxDescriptionLength = iValue
+			}
+		default:
+			if err := c.setCostValue(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return c.validate()
+}
+
+func (c *config) setCostValue(key, value string) error {
+	if !strings.HasPrefix(key, Settings[Cost]) {
+		return fmt.Errorf("config setting %s not found", key)
+	}
+
+	costKey := strings.ToLower(strings.TrimPrefix(key, Settings[Cost]+"."))
+	for _, costFunction := range costFunctions {
+		if costKey != strings.ToLower(costFunction) {
+			continue
+		}
+		costValue, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("key %s, unable to convert %v to integer", key, value)
+		}
+
+		if costValue < 0 {
+			return fmt.Errorf("cost.%s contains invalid value %s", key, value)
+		}
+
+		if c.Cost == nil {
+			c.Cost = make(map[string]int)
+		}
+		c.Cost[costKey] = costValue
+
+		return nil
+	}
+
+	return fmt.Errorf("cost config setting %s not found", costKey)
+}
+
+func (c *config) getConfigMap() smartcontract.StringMap {
+	fields := map[string]string{
+		Settings[OwnerId]:              c.OwnerId,
+		Settings[VotingPeriod]:         fmt.Sprintf("%v", c.VotingPeriod),
+		Settings[MinProposerStake]:     fmt.Sprintf("%v", float64(c.MinProposerStake)/1e10),
+		Settings[Quorum]:               fmt.Sprintf("%v", float64(c.Quorum)/1e10),
+		Settings[PassRatio]:            fmt.Sprintf("%v", c.PassRatio),
+		Settings[MaxActiveProposals]:   fmt.Sprintf("%v", c.MaxActiveProposals),
+		Settings[MaxChanges]:           fmt.Sprintf("%v", c.MaxChanges),
+		Settings[MaxDescriptionLength]: fmt.Sprintf("%v", c.MaxDescriptionLength),
+	}
+
+	for _, key := range costFunctions {
+		fields[fmt.Sprintf("cost.%s", key)] = fmt.Sprintf("%0v", c.Cost[strings.ToLower(key)])
+	}
+
+	return smartcontract.StringMap{
+		Fields: fields,
+	}
+}
+
+func (gsc *GovernanceSmartContract) updateConfig(
+	txn *transaction.Transaction,
+	input []byte,
+	balances chainstate.StateContextI,
+) (resp string, err error) {
+	var conf *config
+	if conf, err = gsc.getConfig(balances); err != nil {
+		return "", common.NewError("update_settings",
+			"can't get config: "+err.Error())
+	}
+
+	if err := smartcontractinterface.AuthorizeWithOwnerOrGovernance("update_settings", txn.ClientID, func() bool {
+		return conf.OwnerId == txn.ClientID
+	}); err != nil {
+		return "", err
+	}
+
+	update := &smartcontract.StringMap{}
+	if err = update.Decode(input); err != nil {
+		return "", common.NewError("update_settings", err.Error())
+	}
+
+	if err := conf.update(update); err != nil {
+		return "", common.NewError("update_settings", err.Error())
+	}
+
+	_, err = balances.InsertTrieNode(scConfigKey(gsc.ID), conf)
+	if err != nil {
+		return "", common.NewError("update_settings", err.Error())
+	}
+
+	return "", nil
+}
+
+//
+// helpers
+//
+
+// configurations from sc.yaml
+func getConfiguredConfig() (conf *config, err error) {
+	const prefix = "smart_contracts.governancesc."
+
+	conf = new(config)
+
+	// short hand
+	var scconf = configpkg.SmartContractConfig
+	conf.OwnerId = scconf.GetString(prefix + "owner_id")
+	conf.VotingPeriod = scconf.GetInt64(prefix + "voting_period")
+	conf.MinProposerStake, err = currency.ParseZCN(scconf.GetFloat64(prefix + "min_proposer_stake"))
+	if err != nil {
+		return nil, err
+	}
+	conf.Quorum, err = currency.ParseZCN(scconf.GetFloat64(prefix + "quorum"))
+	if err != nil {
+		return nil, err
+	}
+	conf.PassRatio = scconf.GetFloat64(prefix + "pass_ratio")
+	conf.MaxActiveProposals = scconf.GetInt(prefix + "max_active_proposals")
+	conf.MaxChanges = scconf.GetInt(prefix + "max_changes")
+	conf.MaxDescriptionLength = scconf.GetInt(prefix + "max_description_length")
+	conf.Cost = scconf.GetStringMapInt(prefix + "cost")
+
+	err = conf.validate()
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func getConfigReadOnly(
+	balances chainstate.CommonStateContextI,
+) (conf *config, err error) {
+	conf = new(config)
+	err = balances.GetTrieNode(scConfigKey(ADDRESS), conf)
+	switch err {
+	case nil:
+		return conf, nil
+	case util.ErrValueNotPresent:
+		return getConfiguredConfig()
+	default:
+		return nil, err
+	}
+}
+
+func (gsc *GovernanceSmartContract) getConfig(
+	balances chainstate.StateContextI,
+) (conf *config, err error) {
+	conf = new(config)
+	err = balances.GetTrieNode(scConfigKey(gsc.ID), conf)
+	switch err {
+	case nil:
+		return conf, nil
+	case util.ErrValueNotPresent:
+		if conf, err = getConfiguredConfig(); err != nil {
+			return nil, err
+		}
+		if _, err = balances.InsertTrieNode(scConfigKey(gsc.ID), conf); err != nil {
+			return nil, err
+		}
+		return conf, nil
+	default:
+		return nil, err
+	}
+}
@@ -0,0 +1,89 @@
+package governancesc
+
+import (
+	"testing"
+
+	"0chain.net/smartcontract"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() *config {
+	return &config{
+		OwnerId:              "1746b06bb09f55ee01b33b5e2e055d6cc7a900cb57c0a3a5eaabb8a0e7745802",
+		VotingPeriod:         100,
+		MinProposerStake:     10e10,
+		Quorum:               100e10,
+		PassRatio:            0.5,
+		MaxActiveProposals:   5,
+		MaxChanges:           3,
+		MaxDescriptionLength: 20,
+		Cost:                 map[string]int{"vote": 100},
+	}
+}
+
+func Test_config_update(t *testing.T) {
+	var tests = []struct {
+		name    string
+		changes map[string]string
+		check   func(t *testing.T, c *config)
+		err     string
+	}{
+		{
+			name: "ok",
+			changes: map[string]string{
+				"voting_period":        "200",
+				"min_proposer_stake":   "1.5",
+				"quorum":               "1000",
+				"pass_ratio":           "0.75",
+				"max_changes":          "10",
+				"cost.submit_proposal": "50",
+			},
+			check: func(t *testing.T, c *config) {
+				assert.EqualValues(t, 200, c.VotingPeriod)
+				assert.EqualValues(t, 1.5e10, c.MinProposerStake)
+				assert.EqualValues(t, 1000e10, c.Quorum)
+				assert.Equal(t, 0.75, c.PassRatio)
+				assert.Equal(t, 10, c.MaxChanges)
+				assert.Equal(t, 50, c.Cost["submit_proposal"])
+			},
+		},
+		{
+			name:    "unknown_setting",
+			changes: map[string]string{"foo": "1"},
+			err:     "config setting foo not found",
+		},
+		{
+			name:    "unknown_cost",
+			changes: map[string]string{"cost.foo": "1"},
+			err:     "cost config setting foo not found",
+		},
+		{
+			name:    "bad_voting_period",
+			changes: map[string]string{"voting_period": "x"},
+			err:     "value x cannot be converted to int64, failing to set config key voting_period",
+		},
+		{
+			name:    "invalid_pass_ratio",
+			changes: map[string]string{"pass_ratio": "1"},
+			err:     "invalid pass_ratio, out of [0; 1)",
+		},
+		{
+			name:    "invalid_max_changes",
+			changes: map[string]string{"max_changes": "0"},
+			err:     "invalid max_changes (< 1)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testConfig()
+			err := c.update(&smartcontract.StringMap{Fields: tt.changes})
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, c)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package governancesc
+
+import (
+	"net/http"
+
+	"0chain.net/core/common"
+	"0chain.net/smartcontract"
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/rest"
+)
+
+type GovernanceRestHandler struct {
+	rest.RestHandlerI
+}
+
+func NewGovernanceRestHandler(rh rest.RestHandlerI) *GovernanceRestHandler {
+	return &GovernanceRestHandler{rh}
+}
+
+func SetupRestHandler(rh rest.RestHandlerI) {
+	rh.Register(GetEndpoints)
+}
+
+func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
+	grh := NewGovernanceRestHandler(rh)
+	governance := "/v1/screst/" + ADDRESS
+	return []rest.Endpoint{
+		rest.MakeEndpoint(governance+"/getProposals", grh.getProposals),
+		rest.MakeEndpoint(governance+"/getProposal", grh.getProposal),
+		rest.MakeEndpoint(governance+"/getProposalVotes", grh.getProposalVotes),
+		rest.MakeEndpoint(governance+"/governance-config", grh.getConfig),
+	}
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e1/getProposals getProposals
+// get the governance proposals, current and past
+//
+// parameters:
+//    +name: proposer
+//     description: client ID of the proposer
+//     in: query
+//     type: string
+//    +name: to_client_id
+//     description: address of the smart contract of the proposed settings
+//     in: query
+//     type: string
+//    +name: status
+//     description: voting, applied, rejected or failed
+//     in: query
+//     type: string
+//    +name: offset
+//     description: offset
+//     in: query
+//     type: string
+//    +name: limit
+//     description: limit
+//     in: query
+//     type: string
+//    +name: sort
+//     description: desc or asc
+//     in: query
+//     type: string
+//
+// responses:
+//  200: []GovernanceProposal
+//  400:
+//  500:
+func (grh *GovernanceRestHandler) getProposals(w http.ResponseWriter, r *http.Request) {
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+	filter := event.GovernanceProposalQuery{
+		Proposer:   r.URL.Query().Get("proposer"),
+		ToClientID: r.URL.Query().Get("to_client_id"),
+		Status:     r.URL.Query().Get("status"),
+	}
+	edb := grh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrNoResource("db not initialized"))
+		return
+	}
+	proposals, err := edb.GetGovernanceProposals(filter, limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get proposals", err.Error()))
+		return
+	}
+	common.Respond(w, r, proposals, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e1/getProposal getProposal
+// get a governance proposal and the tally of its votes
+//
+// parameters:
+//    +name: proposal_id
+//     description: proposal ID
+//     required: true
+//     in: query
+//     type: string
+//
+// responses:
+//  200: proposal
+//  400:
+//  404:
+func (grh *GovernanceRestHandler) getProposal(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("proposal_id")
+	if id == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing proposal_id"))
+		return
+	}
+	p, err := getProposal(id, grh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get proposal"))
+		return
+	}
+	common.Respond(w, r, p, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e1/getProposalVotes getProposalVotes
+// get the votes of a governance proposal or of a voter
+//
+// parameters:
+//    +name: proposal_id
+//     description: proposal ID
+//     in: query
+//     type: string
+//    +name: voter_id
+//     description: client ID of the voter
+//     in: query
+//     type: string
+//    +name: offset
+//     description: offset
+//     in: query
+//     type: string
+//    +name: limit
+//     description: limit
+//     in: query
+//     type: string
+//    +name: sort
+//     description: desc or asc
+//     in: query
+//     type: string
+//
+// responses:
+//  200: []GovernanceVote
+//  400:
+//  500:
+func (grh *GovernanceRestHandler) getProposalVotes(w http.ResponseWriter, r *http.Request) {
+	var (
+		proposalID = r.URL.Query().Get("proposal_id")
+		voterID    = r.URL.Query().Get("voter_id")
+	)
+	if proposalID == "" && voterID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing proposal_id or voter_id"))
+		return
+	}
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+	edb := grh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrNoResource("db not initialized"))
+		return
+	}
+	votes, err := edb.GetGovernanceVotes(proposalID, voterID, limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get votes", err.Error()))
+		return
+	}
+	common.Respond(w, r, votes, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e1/governance-config governance-config
+// get governance configuration settings
+//
+// responses:
+//  200: StringMap
+//  500:
+func (grh *GovernanceRestHandler) getConfig(w http.ResponseWriter, r *http.Request) {
+	conf, err := getConfigReadOnly(grh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get config", err.Error()))
+		return
+	}
+	common.Respond(w, r, conf.getConfigMap(), nil)
+}
@@ -0,0 +1,379 @@
+package governancesc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	chainstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/currency"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/datastore"
+	"0chain.net/core/util"
+	"0chain.net/smartcontract"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/faucetsc"
+	"0chain.net/smartcontract/minersc"
+	"0chain.net/smartcontract/storagesc"
+	"0chain.net/smartcontract/zcnsc"
+)
+
+//msgp:ignore proposalRequest voteRequest
+
+// settingsFunctions are the settings update functions of the smart contracts
+// a proposal can call
+var settingsFunctions = map[string][]string{
+	storagesc.ADDRESS: {"update_settings"},
+	minersc.ADDRESS:   {"update_globals", "update_settings"},
+	zcnsc.ADDRESS:     {zcnsc.UpdateGlobalConfigFunc},
+	faucetsc.ADDRESS:  {"update-settings"},
+	ADDRESS:           {"update_settings"},
+}
+
+func isSettingsFunction(toClientID, functionName string) bool {
+	for _, name := range settingsFunctions[toClientID] {
+		if name == functionName {
+			return true
+		}
+	}
+	return false
+}
+
+func proposalKey(id string) datastore.Key {
+	return ADDRESS + ":proposal:" + id
+}
+
+func voteKey(proposalID, voterID string) datastore.Key {
+	return ADDRESS + ":vote:" + proposalID + ":" + voterID
+}
+
+func votersKey(proposalID string) datastore.Key {
+	return ADDRESS + ":voters:" + proposalID
+}
+
+func activeProposalsKey() datastore.Key {
+	return ADDRESS + ":active_proposals"
+}
+
+// proposal to change the settings of a smart contract
+type proposal struct {
+	ID             string                   `json:"id"`
+	Proposer       string                   `json:"proposer"`
+	Description    string                   `json:"description"`
+	ToClientID     string                   `json:"to_client_id"`
+	FunctionName   string                   `json:"function_name"`
+	Changes        *smartcontract.StringMap `json:"changes"`
+	StartRound     int64                    `json:"start_round"`
+	EndRound       int64                    `json:"end_round"`
+	VotesFor       currency.Coin            `json:"votes_for"`
+	VotesAgainst   currency.Coin            `json:"votes_against"`
+	Voters         int                      `json:"voters"`
+	Status         string                   `json:"status"`
+	Error          string                   `json:"error,omitempty"`
+	FinalizedRound int64                    `json:"finalized_round,omitempty"`
+}
+
+func (p *proposal) Encode() []byte {
+	b, err := json.Marshal(p)
+	if err != nil {
+		panic(err) // must not happen
+	}
+	return b
+}
+
+func (p *proposal) Decode(b []byte) error {
+	return json.Unmarshal(b, p)
+}
+
+// passed returns true if the votes of the proposal reach the quorum and the
+// part of the votes for it exceeds the pass ratio
+func (p *proposal) passed(conf *config) bool {
+	total := p.VotesFor + p.VotesAgainst
+	if total == 0 || total < conf.Quorum {
+		return false
+	}
+	return float64(p.VotesFor) > conf.PassRatio*float64(total)
+}
+
+// addVote adds the weight of the vote to the tally of the proposal, the
+// previous vote of the same voter, if any, is replaced
+func (p *proposal) addVote(prev, v *vote) {
+	if prev != nil {
+		if prev.Support {
+			p.VotesFor -= prev.Weight
+		} else {
+			p.VotesAgainst -= prev.Weight
+		}
+	} else {
+		p.Voters++
+	}
+	if v.Support {
+		p.VotesFor += v.Weight
+	} else {
+		p.VotesAgainst += v.Weight
+	}
+}
+
+func (p *proposal) emit(balances chainstate.StateContextI) error {
+	changes, err := json.Marshal(p.Changes)
+	if err != nil {
+		return err
+	}
+	balances.EmitEvent(event.TypeStats, event.TagAddOrOverwriteGovernanceProposal, p.ID, event.GovernanceProposal{
+		ProposalID:     p.ID,
+		Proposer:       p.Proposer,
+		Description:    p.Description,
+		ToClientID:     p.ToClientID,
+		FunctionName:   p.FunctionName,
+		Changes:        string(changes),
+		StartRound:     p.StartRound,
+		EndRound:       p.EndRound,
+		VotesFor:       p.VotesFor,
+		VotesAgainst:   p.VotesAgainst,
+		Voters:         p.Voters,
+		Status:         p.Status,
+		Error:          p.Error,
+		FinalizedRound: p.FinalizedRound,
+	})
+	return nil
+}
+
+// tally counts the votes of the proposal weighted by the active stake of the
+// voters at the round of the tally, the stake moved from a voter to another
+// during the voting period counts once; the votes with the new weights are
+// emitted
+func (p *proposal) tally(balances chainstate.StateContextI) error {
+	pv, err := getVoters(p.ID, balances)
+	if err != nil {
+		return fmt.Errorf("can't get voters: %v", err)
+	}
+	p.VotesFor, p.VotesAgainst = 0, 0
+	for _, id := range pv.IDs {
+		v, err := getVote(p.ID, id, balances)
+		if err != nil {
+			return fmt.Errorf("can't get vote of %s: %v", id, err)
+		}
+		if v == nil {
+			continue
+		}
+		if v.Weight, err = delegatedStake(id, balances); err != nil {
+			return fmt.Errorf("can't get stake of %s: %v", id, err)
+		}
+		if v.Support {
+			p.VotesFor, err = currency.AddCoin(p.VotesFor, v.Weight)
+		} else {
+			p.VotesAgainst, err = currency.AddCoin(p.VotesAgainst, v.Weight)
+		}
+		if err != nil {
+			return err
+		}
+		v.emit(p.ID, id, balances)
+	}
+	return nil
+}
+
+// vote of a staker for a proposal weighted by the stake of the staker at the
+// round of the vote, the votes are weighted again by the tally of the
+// proposal
+type vote struct {
+	Support bool          `json:"support"`
+	Weight  currency.Coin `json:"weight"`
+	Round   int64         `json:"round"`
+}
+
+func (v *vote) Encode() []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err) // must not happen
+	}
+	return b
+}
+
+func (v *vote) Decode(b []byte) error {
+	return json.Unmarshal(b, v)
+}
+
+func (v *vote) emit(proposalID, voterID string, balances chainstate.StateContextI) {
+	balances.EmitEvent(event.TypeStats, event.TagAddOrOverwriteGovernanceVote, proposalID, event.GovernanceVote{
+		ProposalID: proposalID,
+		VoterID:    voterID,
+		Support:    v.Support,
+		Weight:     v.Weight,
+		Round:      v.Round,
+	})
+}
+
+// proposalVoters are the IDs of the voters of a proposal, in the order of
+// their first votes
+type proposalVoters struct {
+	IDs []string `json:"ids"`
+}
+
+func (pv *proposalVoters) Encode() []byte {
+	b, err := json.Marshal(pv)
+	if err != nil {
+		panic(err) // must not happen
+	}
+	return b
+}
+
+func (pv *proposalVoters) Decode(b []byte) error {
+	return json.Unmarshal(b, pv)
+}
+
+// activeProposals are the IDs of the proposals not finalized yet, in the
+// order of submission
+type activeProposals struct {
+	IDs []string `json:"ids"`
+}
+
+func (ap *activeProposals) Encode() []byte {
+	b, err := json.Marshal(ap)
+	if err != nil {
+		panic(err) // must not happen
+	}
+	return b
+}
+
+func (ap *activeProposals) Decode(b []byte) error {
+	return json.Unmarshal(b, ap)
+}
+
+func (ap *activeProposals) remove(id string) {
+	for i, pid := range ap.IDs {
+		if pid == id {
+			ap.IDs = append(ap.IDs[:i], ap.IDs[i+1:]...)
+			return
+		}
+	}
+}
+
+// proposalRequest is the input of the submit_proposal function
+type proposalRequest struct {
+	Description  string                   `json:"description"`
+	ToClientID   string                   `json:"to_client_id"`
+	FunctionName string                   `json:"function_name"`
+	Changes      *smartcontract.StringMap `json:"changes"`
+}
+
+func (pr *proposalRequest) decode(b []byte) error {
+	return json.Unmarshal(b, pr)
+}
+
+func (pr *proposalRequest) validate(conf *config) error {
+	switch {
+	case !isSettingsFunction(pr.ToClientID, pr.FunctionName):
+		return fmt.Errorf("function %q of smart contract %q can't be proposed",
+			pr.FunctionName, pr.ToClientID)
+	case pr.Changes == nil || len(pr.Changes.Fields) == 0:
+		return errors.New("no changes proposed")
+	case len(pr.Changes.Fields) > conf.MaxChanges:
+		return fmt.Errorf("too many changes, max %d", conf.MaxChanges)
+	case len(pr.Description) > conf.MaxDescriptionLength:
+		return fmt.Errorf("description is too long, max %d", conf.MaxDescriptionLength)
+	}
+	return nil
+}
+
+// voteRequest is the input of the vote function
+type voteRequest struct {
+	ProposalID string `json:"proposal_id"`
+	Support    bool   `json:"support"`
+}
+
+func (vr *voteRequest) decode(b []byte) error {
+	return json.Unmarshal(b, vr)
+}
+
+//
+// MPT helpers
+//
+
+func getProposal(id string, balances chainstate.CommonStateContextI) (*proposal, error) {
+	p := new(proposal)
+	if err := balances.GetTrieNode(proposalKey(id), p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func getVote(proposalID, voterID string, balances chainstate.CommonStateContextI) (*vote, error) {
+	v := new(vote)
+	err := balances.GetTrieNode(voteKey(proposalID, voterID), v)
+	switch err {
+	case nil:
+		return v, nil
+	case util.ErrValueNotPresent:
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
+
+func getVoters(proposalID string, balances chainstate.CommonStateContextI) (*proposalVoters, error) {
+	pv := new(proposalVoters)
+	err := balances.GetTrieNode(votersKey(proposalID), pv)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return pv, nil
+	default:
+		return nil, err
+	}
+}
+
+func getActiveProposals(balances chainstate.CommonStateContextI) (*activeProposals, error) {
+	ap := new(activeProposals)
+	err := balances.GetTrieNode(activeProposalsKey(), ap)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return ap, nil
+	default:
+		return nil, err
+	}
+}
+
+// delegatedStake is the weight of the votes of the client, the stake the
+// client delegated to the miners, sharders, blobbers, validators and
+// authorizers
+func delegatedStake(clientID string, balances chainstate.StateContextI) (currency.Coin, error) {
+	var total currency.Coin
+	for _, stake := range []func(string, chainstate.StateContextI) (currency.Coin, error){
+		minersc.DelegatedStake,
+		storagesc.DelegatedStake,
+		zcnsc.DelegatedStake,
+	} {
+		s, err := stake(clientID, balances)
+		if err != nil {
+			return 0, err
+		}
+		if total, err = currency.AddCoin(total, s); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// applyTxn returns the transaction calling the settings function of the
+// proposal on behalf of the governance smart contract
+func (p *proposal) applyTxn(t *transaction.Transaction) (*transaction.Transaction, []byte, error) {
+	input, err := json.Marshal(p.Changes)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"name":  p.FunctionName,
+		"input": json.RawMessage(input),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &transaction.Transaction{
+		HashIDField:     datastore.HashIDField{Hash: t.Hash},
+		ClientID:        ADDRESS,
+		ToClientID:      p.ToClientID,
+		ChainID:         t.ChainID,
+		TransactionData: string(data),
+		CreationDate:    t.CreationDate,
+		TransactionType: transaction.TxnTypeSmartContract,
+	}, input, nil
+}
@@ -0,0 +1,118 @@
+package governancesc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/chaincore/currency"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract"
+	"0chain.net/smartcontract/minersc"
+	"0chain.net/smartcontract/storagesc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_proposal_passed(t *testing.T) {
+	var conf = testConfig()
+	var tests = []struct {
+		name    string
+		for_    currency.Coin
+		against currency.Coin
+		want    bool
+	}{
+		{name: "no_votes"},
+		{name: "below_quorum", for_: 99e10, want: false},
+		{name: "quorum", for_: 100e10, want: true},
+		{name: "tie", for_: 100e10, against: 100e10, want: false},
+		{name: "majority", for_: 101e10, against: 100e10, want: true},
+		{name: "against", for_: 10e10, against: 200e10, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &proposal{VotesFor: tt.for_, VotesAgainst: tt.against}
+			assert.Equal(t, tt.want, p.passed(conf))
+		})
+	}
+}
+
+func Test_proposal_addVote(t *testing.T) {
+	var p proposal
+	first := &vote{Support: true, Weight: 10}
+	p.addVote(nil, first)
+	p.addVote(nil, &vote{Support: false, Weight: 5})
+	assert.EqualValues(t, 10, p.VotesFor)
+	assert.EqualValues(t, 5, p.VotesAgainst)
+	assert.Equal(t, 2, p.Voters)
+
+	// the first voter changes the mind and the stake
+	p.addVote(first, &vote{Support: false, Weight: 20})
+	assert.EqualValues(t, 0, p.VotesFor)
+	assert.EqualValues(t, 25, p.VotesAgainst)
+	assert.Equal(t, 2, p.Voters)
+}
+
+func Test_proposalRequest_validate(t *testing.T) {
+	var (
+		conf    = testConfig()
+		changes = &smartcontract.StringMap{Fields: map[string]string{"max_mint": "100"}}
+	)
+	var tests = []struct {
+		name string
+		pr   proposalRequest
+		err  string
+	}{
+		{name: "ok", pr: proposalRequest{ToClientID: storagesc.ADDRESS,
+			FunctionName: "update_settings", Changes: changes}},
+		{name: "not_settings", pr: proposalRequest{ToClientID: storagesc.ADDRESS,
+			FunctionName: "new_allocation_request", Changes: changes},
+			err: `function "new_allocation_request" of smart contract "` +
+				storagesc.ADDRESS + `" can't be proposed`},
+		{name: "no_changes", pr: proposalRequest{ToClientID: minersc.ADDRESS,
+			FunctionName: "update_globals"}, err: "no changes proposed"},
+		{name: "too_many_changes", pr: proposalRequest{ToClientID: ADDRESS,
+			FunctionName: "update_settings", Changes: &smartcontract.StringMap{
+				Fields: map[string]string{"a": "1", "b": "2", "c": "3", "d": "4"}}},
+			err: "too many changes, max 3"},
+		{name: "long_description", pr: proposalRequest{ToClientID: storagesc.ADDRESS,
+			FunctionName: "update_settings", Changes: changes,
+			Description: "a description longer than twenty"},
+			err: "description is too long, max 20"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.pr.validate(conf)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func Test_proposal_applyTxn(t *testing.T) {
+	p := &proposal{
+		ToClientID:   storagesc.ADDRESS,
+		FunctionName: "update_settings",
+		Changes:      &smartcontract.StringMap{Fields: map[string]string{"max_mint": "100"}},
+	}
+	dtxn, input, err := p.applyTxn(&transaction.Transaction{
+		HashIDField:  datastore.HashIDField{Hash: "hash"},
+		CreationDate: 10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ADDRESS, dtxn.ClientID)
+	assert.Equal(t, storagesc.ADDRESS, dtxn.ToClientID)
+	assert.Equal(t, "hash", dtxn.Hash)
+
+	var data struct {
+		Name  string                  `json:"name"`
+		Input smartcontract.StringMap `json:"input"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(dtxn.TransactionData), &data))
+	assert.Equal(t, "update_settings", data.Name)
+	assert.Equal(t, p.Changes.Fields, data.Input.Fields)
+	assert.JSONEq(t, string(input), `{"fields":{"max_mint":"100"}}`)
+}
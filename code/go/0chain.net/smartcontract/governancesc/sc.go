@@ -0,0 +1,343 @@
+package governancesc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+
+	"0chain.net/chaincore/smartcontract"
+
+	chainstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/logging"
+	"0chain.net/core/util"
+	"0chain.net/smartcontract/dbs/event"
+	metrics "github.com/rcrowley/go-metrics"
+	"go.uber.org/zap"
+)
+
+const (
+	ADDRESS = smartcontractinterface.GovernanceAddress
+	name    = "governance"
+)
+
+type GovernanceSmartContract struct {
+	*smartcontractinterface.SmartContract
+}
+
+func NewGovernanceSmartContract() smartcontractinterface.SmartContractInterface {
+	var gscCopy = &GovernanceSmartContract{
+		smartcontractinterface.NewSC(ADDRESS),
+	}
+	gscCopy.setSC(gscCopy.SmartContract, &smartcontract.BCContext{})
+	return gscCopy
+}
+
+func (gsc *GovernanceSmartContract) GetHandlerStats(ctx context.Context, params url.Values) (interface{}, error) {
+	return gsc.SmartContract.HandlerStats(ctx, params)
+}
+
+func (gsc *GovernanceSmartContract) GetExecutionStats() map[string]interface{} {
+	return gsc.SmartContractExecutionStats
+}
+
+func (gsc *GovernanceSmartContract) GetName() string {
+	return name
+}
+
+func (gsc *GovernanceSmartContract) GetAddress() string {
+	return ADDRESS
+}
+
+func (gsc *GovernanceSmartContract) GetCost(t *transaction.Transaction, funcName string, balances chainstate.StateContextI) (int, error) {
+	node, err := gsc.getConfig(balances)
+	if err != nil {
+		return math.MaxInt32, err
+	}
+	if node.Cost == nil {
+		return math.MaxInt32, errors.New("can't get cost")
+	}
+	cost, ok := node.Cost[funcName]
+	if !ok {
+		return math.MaxInt32, errors.New("no cost given for " + funcName)
+	}
+	return cost, nil
+}
+
+func (gsc *GovernanceSmartContract) setSC(sc *smartcontractinterface.SmartContract,
+	bcContext smartcontractinterface.BCContextI) {
+
+	gsc.SmartContract = sc
+
+	// proposals of settings changes and the votes of the stakers
+	gsc.SmartContractExecutionStats["submit_proposal"] = metrics.GetOrRegisterTimer(
+		fmt.Sprintf("sc:%v:func:%v", gsc.ID, "submit_proposal"), nil)
+	gsc.SmartContractExecutionStats["vote"] = metrics.GetOrRegisterTimer(
+		fmt.Sprintf("sc:%v:func:%v", gsc.ID, "vote"), nil)
+
+	// tally the votes of the proposals whose voting period is over, and
+	// apply the passed ones
+	gsc.SmartContractExecutionStats["finalize_proposals"] = metrics.GetOrRegisterTimer(
+		fmt.Sprintf("sc:%v:func:%v", gsc.ID, "finalize_proposals"), nil)
+
+	gsc.SmartContractExecutionStats["update_settings"] = metrics.GetOrRegisterTimer(
+		fmt.Sprintf("sc:%v:func:%v", gsc.ID, "update_settings"), nil)
+}
+
+func (gsc *GovernanceSmartContract) Execute(t *transaction.Transaction,
+	function string, input []byte, balances chainstate.StateContextI) (
+	resp string, err error) {
+
+	switch function {
+	case "submit_proposal":
+		resp, err = gsc.submitProposal(t, input, balances)
+	case "vote":
+		resp, err = gsc.vote(t, input, balances)
+	case "finalize_proposals":
+		resp, err = gsc.finalizeProposals(t, input, balances)
+	case "update_settings":
+		resp, err = gsc.updateConfig(t, input, balances)
+	default:
+		err = common.NewError("governance_sc_failed",
+			fmt.Sprintf("no function with %q name", function))
+	}
+	return
+}
+
+func (gsc *GovernanceSmartContract) submitProposal(
+	t *transaction.Transaction,
+	input []byte,
+	balances chainstate.StateContextI,
+) (string, error) {
+	conf, err := gsc.getConfig(balances)
+	if err != nil {
+		return "", common.NewError("submit_proposal_failed",
+			"can't get config: "+err.Error())
+	}
+
+	var pr proposalRequest
+	if err = pr.decode(input); err != nil {
+		return "", common.NewError("submit_proposal_failed",
+			"malformed request: "+err.Error())
+	}
+	if err = pr.validate(conf); err != nil {
+		return "", common.NewError("submit_proposal_failed",
+			"invalid request: "+err.Error())
+	}
+
+	stake, err := delegatedStake(t.ClientID, balances)
+	if err != nil {
+		return "", common.NewError("submit_proposal_failed",
+			"can't get stake of proposer: "+err.Error())
+	}
+	if stake < conf.MinProposerStake {
+		return "", common.NewErrorf("submit_proposal_failed",
+			"not enough stake to propose, %v < %v", stake, conf.MinProposerStake)
+	}
+
+	ap, err := getActiveProposals(balances)
+	if err != nil {
+		return "", common.NewError("submit_proposal_failed",
+			"can't get active proposals: "+err.Error())
+	}
+	if len(ap.IDs) >= conf.MaxActiveProposals {
+		return "", common.NewErrorf("submit_proposal_failed",
+			"too many active proposals, max %d", conf.MaxActiveProposals)
+	}
+
+	var round = balances.GetBlock().Round
+	p := &proposal{
+		ID:           t.Hash,
+		Proposer:     t.ClientID,
+		Description:  pr.Description,
+		ToClientID:   pr.ToClientID,
+		FunctionName: pr.FunctionName,
+		Changes:      pr.Changes,
+		StartRound:   round,
+		EndRound:     round + conf.VotingPeriod,
+		Status:       event.ProposalVoting,
+	}
+	if _, err = balances.InsertTrieNode(proposalKey(p.ID), p); err != nil {
+		return "", common.NewError("submit_proposal_failed",
+			"saving proposal: "+err.Error())
+	}
+	ap.IDs = append(ap.IDs, p.ID)
+	if _, err = balances.InsertTrieNode(activeProposalsKey(), ap); err != nil {
+		return "", common.NewError("submit_proposal_failed",
+			"saving active proposals: "+err.Error())
+	}
+	if err = p.emit(balances); err != nil {
+		return "", common.NewError("submit_proposal_failed",
+			"emitting proposal: "+err.Error())
+	}
+
+	return string(p.Encode()), nil
+}
+
+func (gsc *GovernanceSmartContract) vote(
+	t *transaction.Transaction,
+	input []byte,
+	balances chainstate.StateContextI,
+) (string, error) {
+	var vr voteRequest
+	if err := vr.decode(input); err != nil {
+		return "", common.NewError("vote_failed",
+			"malformed request: "+err.Error())
+	}
+
+	p, err := getProposal(vr.ProposalID, balances)
+	if err != nil {
+		return "", common.NewError("vote_failed",
+			"can't get proposal: "+err.Error())
+	}
+	var round = balances.GetBlock().Round
+	if p.Status != event.ProposalVoting || round > p.EndRound {
+		return "", common.NewError("vote_failed", "voting period is over")
+	}
+
+	weight, err := delegatedStake(t.ClientID, balances)
+	if err != nil {
+		return "", common.NewError("vote_failed",
+			"can't get stake of voter: "+err.Error())
+	}
+	if weight == 0 {
+		return "", common.NewError("vote_failed", "no stake to vote with")
+	}
+
+	prev, err := getVote(p.ID, t.ClientID, balances)
+	if err != nil {
+		return "", common.NewError("vote_failed",
+			"can't get previous vote: "+err.Error())
+	}
+	v := &vote{Support: vr.Support, Weight: weight, Round: round}
+	p.addVote(prev, v)
+
+	if prev == nil {
+		pv, err := getVoters(p.ID, balances)
+		if err != nil {
+			return "", common.NewError("vote_failed",
+				"can't get voters: "+err.Error())
+		}
+		pv.IDs = append(pv.IDs, t.ClientID)
+		if _, err = balances.InsertTrieNode(votersKey(p.ID), pv); err != nil {
+			return "", common.NewError("vote_failed", "saving voters: "+err.Error())
+		}
+	}
+	if _, err = balances.InsertTrieNode(voteKey(p.ID, t.ClientID), v); err != nil {
+		return "", common.NewError("vote_failed", "saving vote: "+err.Error())
+	}
+	if _, err = balances.InsertTrieNode(proposalKey(p.ID), p); err != nil {
+		return "", common.NewError("vote_failed", "saving proposal: "+err.Error())
+	}
+
+	v.emit(p.ID, t.ClientID, balances)
+	if err = p.emit(balances); err != nil {
+		return "", common.NewError("vote_failed",
+			"emitting proposal: "+err.Error())
+	}
+
+	return string(p.Encode()), nil
+}
+
+func (gsc *GovernanceSmartContract) finalizeProposals(
+	t *transaction.Transaction,
+	_ []byte,
+	balances chainstate.StateContextI,
+) (string, error) {
+	conf, err := gsc.getConfig(balances)
+	if err != nil {
+		return "", common.NewError("finalize_proposals_failed",
+			"can't get config: "+err.Error())
+	}
+	ap, err := getActiveProposals(balances)
+	if err != nil {
+		return "", common.NewError("finalize_proposals_failed",
+			"can't get active proposals: "+err.Error())
+	}
+
+	var (
+		round     = balances.GetBlock().Round
+		finalized = 0
+	)
+	for _, id := range append([]string(nil), ap.IDs...) {
+		p, err := getProposal(id, balances)
+		if err != nil && err != util.ErrValueNotPresent {
+			return "", common.NewError("finalize_proposals_failed",
+				"can't get proposal: "+err.Error())
+		}
+		if err == nil {
+			if round <= p.EndRound {
+				continue
+			}
+			if err = gsc.finalize(t, p, conf, balances); err != nil {
+				return "", common.NewError("finalize_proposals_failed",
+					"tallying proposal "+p.ID+": "+err.Error())
+			}
+			p.FinalizedRound = round
+			if _, err = balances.InsertTrieNode(proposalKey(p.ID), p); err != nil {
+				return "", common.NewError("finalize_proposals_failed",
+					"saving proposal: "+err.Error())
+			}
+			if err = p.emit(balances); err != nil {
+				return "", common.NewError("finalize_proposals_failed",
+					"emitting proposal: "+err.Error())
+			}
+		}
+		ap.remove(id)
+		finalized++
+	}
+
+	if finalized == 0 {
+		return "", nil
+	}
+	if _, err = balances.InsertTrieNode(activeProposalsKey(), ap); err != nil {
+		return "", common.NewError("finalize_proposals_failed",
+			"saving active proposals: "+err.Error())
+	}
+	return fmt.Sprintf("%d proposals finalized", finalized), nil
+}
+
+// finalize tallies the votes of the proposal and sets its status according
+// to them, a passed proposal is applied calling the settings function on
+// behalf of the governance smart contract. The settings function runs on a
+// copy of the state, a failed one changes nothing.
+func (gsc *GovernanceSmartContract) finalize(
+	t *transaction.Transaction,
+	p *proposal,
+	conf *config,
+	balances chainstate.StateContextI,
+) error {
+	if err := p.tally(balances); err != nil {
+		return err
+	}
+	if !p.passed(conf) {
+		p.Status = event.ProposalRejected
+		return nil
+	}
+
+	dtxn, input, err := p.applyTxn(t)
+	if err == nil {
+		err = chainstate.RunNested(balances, func(balances chainstate.StateContextI) error {
+			_, err := smartcontract.ExecuteSmartContract(dtxn,
+				&smartcontractinterface.SmartContractTransactionData{
+					FunctionName: p.FunctionName,
+					InputData:    input,
+				}, chainstate.NewDelegatedStateContext(balances, dtxn))
+			return err
+		})
+	}
+	if err != nil {
+		logging.Logger.Info("governance proposal failed",
+			zap.String("proposal", p.ID),
+			zap.Error(err))
+		p.Status = event.ProposalFailed
+		p.Error = err.Error()
+		return nil
+	}
+	p.Status = event.ProposalApplied
+	return nil
+}
@@ -2,7 +2,9 @@ package minersc
 
 import (
 	benchmark "0chain.net/smartcontract/benchmark"
+	"0chain.net/smartcontract/dbs/event"
 	"0chain.net/smartcontract/rest"
+	"0chain.net/smartcontract/stakepool"
 	"0chain.net/smartcontract/stakepool/spenum"
 )
 
@@ -123,6 +125,39 @@ func BenchmarkRestTests(
 				FuncName: "configs",
 				Endpoint: mrh.getConfigs,
 			},
+			{
+				FuncName: "provider",
+				Params: map[string]string{
+					"provider_type": spenum.Miner.String(),
+					"provider_id":   GetMockNodeId(0, spenum.Miner),
+				},
+				Endpoint: stakepool.GetProviderHandler(rh, spenum.Miner, spenum.Sharder),
+			},
+			{
+				FuncName: "providers",
+				Params: map[string]string{
+					"status": spenum.ProviderActive.String(),
+					"limit":  "20",
+				},
+				Endpoint: stakepool.GetProvidersHandler(rh, spenum.Miner, spenum.Sharder),
+			},
+			{
+				FuncName: "slashes",
+				Params: map[string]string{
+					"provider_id": GetMockNodeId(0, spenum.Miner),
+					"limit":       "20",
+				},
+				Endpoint: stakepool.GetSlashesHandler(rh, spenum.Miner, spenum.Sharder),
+			},
+			{
+				FuncName: "unbondings",
+				Params: map[string]string{
+					"client_id": data.Clients[0],
+					"status":    event.UnbondingPending,
+					"limit":     "20",
+				},
+				Endpoint: stakepool.GetUnbondingsHandler(rh, spenum.Miner, spenum.Sharder),
+			},
 		},
 		ADDRESS,
 		mrh,
@@ -250,3 +250,24 @@ func getMinerDelegatePoolId(miner, delegate int, nodeType spenum.Provider) strin
 func GetMockNodeId(index int, nodeType spenum.Provider) string {
 	return encryption.Hash("mock" + nodeType.String() + strconv.Itoa(index))
 }
+
+// AddMockUnbondingQueues adds a matured unbonding entry of a miner delegate
+// pool to every client.
+func AddMockUnbondingQueues(clients []string, balances cstate.StateContextI) {
+	for i, client := range clients {
+		uq := &stakepool.UnbondingQueue{
+			Entries: []*stakepool.UnbondingEntry{{
+				ID:           encryption.Hash("mock miner unbonding " + strconv.Itoa(i)),
+				ProviderID:   GetMockNodeId(0, spenum.Miner),
+				ProviderType: spenum.Miner,
+				PoolID:       getMinerDelegatePoolId(0, 0, spenum.Miner),
+				Amount:       1e10,
+			}},
+		}
+		if _, err := balances.InsertTrieNode(
+			stakepool.UnbondingQueueKey(ADDRESS, client), uq,
+		); err != nil {
+			panic(err)
+		}
+	}
+}
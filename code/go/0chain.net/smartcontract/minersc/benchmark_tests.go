@@ -21,6 +21,7 @@ import (
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/datastore"
 	"0chain.net/core/encryption"
+	"0chain.net/core/stateproof"
 	"0chain.net/core/viper"
 	bk "0chain.net/smartcontract/benchmark"
 )
@@ -83,7 +84,7 @@ func (bt BenchTest) Run(balances cstate.TimedQueryStateContext, b *testing.B) er
 }
 
 func BenchmarkTests(
-	data bk.BenchData, _ bk.SignatureScheme,
+	data bk.BenchData, sigScheme bk.SignatureScheme,
 ) bk.TestSuite {
 	creationTimeRaw := viper.GetInt64("MptCreationTime")
 	creationTime := common.Now()
@@ -398,6 +399,87 @@ func BenchmarkTests(
 				return bytes
 			}(),
 		},
+		{
+			name:     "miner.shutdown_provider",
+			endpoint: msc.shutdownProvider,
+			txn: &transaction.Transaction{
+				ClientID:     GetMockNodeId(0, spenum.Miner),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.ProviderRequest{
+				ProviderID:   GetMockNodeId(0, spenum.Miner),
+				ProviderType: spenum.Miner,
+			}).Encode(),
+		},
+		{
+			name:     "miner.kill_provider",
+			endpoint: msc.killProvider,
+			txn: &transaction.Transaction{
+				ClientID:     owner,
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.ProviderRequest{
+				ProviderID:   GetMockNodeId(1, spenum.Sharder),
+				ProviderType: spenum.Sharder,
+			}).Encode(),
+		},
+		{
+			name:     "miner.slash",
+			endpoint: msc.slash,
+			txn: &transaction.Transaction{
+				ClientID:     data.Clients[1],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				_ = sigScheme.SetPublicKey(data.PublicKeys[0])
+				sigScheme.SetPrivateKey(data.PrivateKeys[0])
+				se := SlashEvidence{
+					Reason:  DoubleSigning,
+					MinerID: GetMockNodeId(0, spenum.Miner),
+				}
+				for _, prev := range []string{"block 1", "block 2"} {
+					h := &stateproof.BlockHeader{
+						MinerID:  se.MinerID,
+						PrevHash: encryption.Hash(prev),
+						Round:    1,
+					}
+					h.Hash = h.ComputeHash()
+					sig, _ := sigScheme.Sign(h.Hash)
+					se.Blocks = append(se.Blocks, &SignedBlock{Header: h, Signature: sig})
+				}
+				return se.Encode()
+			}(),
+		},
+		{
+			name:     "miner.unbond",
+			endpoint: msc.unbond,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.UnbondRequest{
+				ProviderID:   GetMockNodeId(0, spenum.Miner),
+				ProviderType: spenum.Miner,
+				PoolID:       miner00,
+			}).Encode(),
+		},
+		{
+			name:     "miner.claim_unbonded",
+			endpoint: msc.claimUnbonded,
+			txn: &transaction.Transaction{
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: []byte{},
+		},
 	}
 	var testsI []bk.BenchTestI
 	for _, test := range tests {
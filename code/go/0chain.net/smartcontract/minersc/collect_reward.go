@@ -30,51 +30,23 @@ func (ssc *MinerSmartContract) collectReward(
 			"invalid provider type: %s", prr.ProviderType.String())
 	}
 
-	var err error
-	var usp *stakepool.UserStakePools
-	var providerID = prr.ProviderId
-	if len(prr.PoolId) > 0 {
-		usp, err = stakepool.GetUserStakePools(prr.ProviderType, txn.ClientID, balances)
+	getProvider := func(providerType spenum.Provider, id string,
+		balances cstate.StateContextI) (stakepool.Provider, error) {
+		provider, err := ssc.getProvider(providerType, id, balances)
 		if err != nil {
-			return "", common.NewErrorf("collect_reward_failed",
-				"can't get related user stake pools: %v", err)
+			return nil, err
 		}
-
-		if len(prr.ProviderId) == 0 {
-			providerID = usp.FindProvider(prr.PoolId)
+		if id != txn.ClientID && provider.Settings.DelegateWallet != txn.ClientID {
+			return nil, fmt.Errorf("user %v does not own stake pool %v", txn.ClientID, prr.PoolId)
 		}
+		return provider, nil
 	}
 
-	var provider *MinerNode
-	switch prr.ProviderType {
-	case spenum.Miner:
-		provider, err = getMinerNode(providerID, balances)
-	case spenum.Sharder:
-		provider, err = ssc.getSharderNode(providerID, balances)
-	default:
-		err = fmt.Errorf("unsupported provider type %s", prr.ProviderType.String())
-	}
+	_, minted, err := stakepool.CollectReward(txn.ClientID, &prr, getProvider, balances)
 	if err != nil {
 		return "", common.NewError("collect_reward_failed", err.Error())
 	}
 
-	if providerID != txn.ClientID && provider.Settings.DelegateWallet != txn.ClientID {
-		return "", common.NewErrorf("collect_reward_failed",
-			"user %v does not own stake pool %v", txn.ClientID, prr.PoolId)
-	}
-
-	minted, err := provider.StakePool.MintRewards(
-		txn.ClientID, prr.PoolId, providerID, prr.ProviderType, usp, balances)
-	if err != nil {
-		return "", common.NewErrorf("collect_reward_failed",
-			"error emptying account, %v", err)
-	}
-
-	if err := provider.save(balances); err != nil {
-		return "", common.NewErrorf("collect_reward_failed",
-			"error saving stake pool, %v", err)
-	}
-
 	gnMinted, err := currency.AddCoin(gn.Minted, minted)
 	if err != nil {
 		return "", common.NewErrorf("collect_reward_failed",
@@ -1,10 +1,14 @@
 package minersc
 
 import (
+	"encoding/json"
+
 	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/currency"
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
 	"0chain.net/core/util"
+	"0chain.net/smartcontract/stakepool"
 	"0chain.net/smartcontract/stakepool/spenum"
 )
 
@@ -69,6 +73,9 @@ func (msc *MinerSmartContract) addToDelegatePool(t *transaction.Transaction,
 	return
 }
 
+// deleteFromDelegatePool unlocks a whole delegate pool of a miner through
+// the unbonding queue of the delegate, the tokens are claimable after the
+// unbonding period.
 func (msc *MinerSmartContract) deleteFromDelegatePool(
 	t *transaction.Transaction, inputData []byte, gn *GlobalNode,
 	balances cstate.StateContextI) (resp string, err error) {
@@ -85,48 +92,41 @@ func (msc *MinerSmartContract) deleteFromDelegatePool(
 			"error getting miner node: %v", err)
 	}
 
-	pool, ok := mn.Pools[dp.PoolID]
-	if !ok {
-		return "", common.NewError("delegate_pool_del",
-			"pool does not exist for deletion")
+	entry, err := stakepool.Unbond(msc.ID, t.ClientID, mn, &stakepool.UnbondRequest{
+		ProviderID:   dp.MinerID,
+		ProviderType: spenum.Miner,
+		PoolID:       dp.PoolID,
+	}, gn.unbondingPeriod(spenum.Miner), balances)
+	if err != nil {
+		return "", common.NewError("delegate_pool_del", err.Error())
 	}
 
-	if pool.DelegateID != t.ClientID {
-		return "", common.NewErrorf("delegate_pool_del",
-			"you (%v) do not own the pool, it belongs to %v",
-			t.ClientID, pool.DelegateID)
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return "", common.NewError("delegate_pool_del", err.Error())
 	}
+	return string(b), nil
+}
 
-	switch pool.Status {
-	case spenum.Pending:
-		{
-			_, err := mn.UnlockClientStakePool(t.ClientID, spenum.Miner, dp.MinerID, dp.PoolID, balances)
-			if err != nil {
-				return "", common.NewErrorf("delegate_pool_del",
-					"stake_pool_unlock_failed: %v", err)
-			}
-			if err = mn.save(balances); err != nil {
-				return "", common.NewError("delegate_pool_del", err.Error())
-			}
-			return resp, nil
+// DelegatedStake returns the tokens the client delegates to the miners and
+// the sharders.
+func DelegatedStake(clientID string, balances cstate.StateContextI) (currency.Coin, error) {
+	getStakePool := func(id string) (*stakepool.StakePool, error) {
+		mn, err := getMinerNode(id, balances)
+		if err != nil {
+			return nil, err
 		}
-	case spenum.Active:
-		{
-			pool.Status = spenum.Deleting
-			if err = mn.save(balances); err != nil {
-				return "", common.NewErrorf("delegate_pool_del",
-					"saving miner node: %v", err)
-			}
-			return `{"action": "pool will be released next VC"}`, nil
+		return mn.StakePool, nil
+	}
+	var total currency.Coin
+	for _, p := range []spenum.Provider{spenum.Miner, spenum.Sharder} {
+		stake, err := stakepool.DelegatedStake(p, clientID, getStakePool, balances)
+		if err != nil {
+			return 0, err
+		}
+		if total, err = currency.AddCoin(total, stake); err != nil {
+			return 0, err
 		}
-	case spenum.Deleting:
-		return "", common.NewError("delegate_pool_del",
-			"pool already deleted")
-	case spenum.Deleted:
-		return "", common.NewError("delegate_pool_del",
-			"pool already deleted")
-	default:
-		return "", common.NewErrorf("delegate_pool_del",
-			"unrecognised stakepool status: %v", pool.Status.String())
 	}
+	return total, nil
 }
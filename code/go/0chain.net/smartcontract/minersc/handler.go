@@ -10,6 +10,7 @@ import (
 	"0chain.net/smartcontract/rest"
 
 	"0chain.net/chaincore/smartcontract"
+	"0chain.net/smartcontract/stakepool"
 	"0chain.net/smartcontract/stakepool/spenum"
 
 	"0chain.net/smartcontract/dbs/event"
@@ -30,7 +31,7 @@ func NewMinerRestHandler(rh rest.RestHandlerI) *MinerRestHandler {
 }
 
 func SetupRestHandler(rh rest.RestHandlerI) {
-	rh.Register(GetEndpoints(rh))
+	rh.Register(GetEndpoints)
 }
 
 func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
@@ -58,6 +59,10 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(miner+"/configs", mrh.getConfigs),
 		rest.MakeEndpoint(miner+"/get_miner_geolocations", mrh.getMinerGeolocations),
 		rest.MakeEndpoint(miner+"/get_sharder_geolocations", mrh.getSharderGeolocations),
+		rest.MakeEndpoint(miner+"/provider", stakepool.GetProviderHandler(rh, spenum.Miner, spenum.Sharder)),
+		rest.MakeEndpoint(miner+"/providers", stakepool.GetProvidersHandler(rh, spenum.Miner, spenum.Sharder)),
+		rest.MakeEndpoint(miner+"/slashes", stakepool.GetSlashesHandler(rh, spenum.Miner, spenum.Sharder)),
+		rest.MakeEndpoint(miner+"/unbondings", stakepool.GetUnbondingsHandler(rh, spenum.Miner, spenum.Sharder)),
 	}
 }
 
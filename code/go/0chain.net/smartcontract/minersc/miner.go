@@ -87,13 +87,13 @@ func (msc *MinerSmartContract) AddMiner(t *transaction.Transaction,
 			"PublicKey or the ID is empty. Cannot proceed")
 	}
 
-	err = validateNodeSettings(newMiner, gn, "add_miner")
-	if err != nil {
-		return "", err
-	}
-
 	newMiner.NodeType = NodeTypeMiner // set node type
 
+	if existing, err := getMinerNode(newMiner.ID, balances); err == nil &&
+		existing.ProviderStatus == spenum.ProviderKilled {
+		return "", common.NewError("add_miner", "miner is killed")
+	}
+
 	if err = quickFixDuplicateHosts(newMiner, allMiners.Nodes); err != nil {
 		return "", common.NewError("add_miner", err.Error())
 	}
@@ -122,7 +122,9 @@ func (msc *MinerSmartContract) AddMiner(t *transaction.Transaction,
 	}
 
 	if !doesMinerExist(newMiner.GetKey(), balances) {
-		if err = newMiner.save(balances); err != nil {
+		err = stakepool.RegisterProvider(newMiner, newMiner.Settings,
+			gn.settingsLimits(), balances)
+		if err != nil {
 			return "", common.NewError("add_miner", err.Error())
 		}
 
@@ -262,11 +264,6 @@ func (msc *MinerSmartContract) UpdateMinerSettings(t *transaction.Transaction,
 			"decoding request: %v", err)
 	}
 
-	err = validateNodeSettings(update, gn, "update_miner_settings")
-	if err != nil {
-		return "", err
-	}
-
 	var mn *MinerNode
 	mn, err = getMinerNode(update.ID, balances)
 	switch err {
@@ -291,13 +288,10 @@ func (msc *MinerSmartContract) UpdateMinerSettings(t *transaction.Transaction,
 		return "", common.NewError("update_miner_settings", "access denied")
 	}
 
-	mn.Settings.ServiceChargeRatio = update.Settings.ServiceChargeRatio
-	mn.Settings.MaxNumDelegates = update.Settings.MaxNumDelegates
-	mn.Settings.MinStake = update.Settings.MinStake
-	mn.Settings.MaxStake = update.Settings.MaxStake
-
-	if err = mn.save(balances); err != nil {
-		return "", common.NewErrorf("update_miner_settings", "saving: %v", err)
+	err = stakepool.UpdateProviderSettings(mn, update.Settings,
+		gn.settingsLimits(), balances)
+	if err != nil {
+		return "", common.NewError("update_miner_settings", err.Error())
 	}
 
 	if err = emitUpdateMiner(mn, balances, false); err != nil {
@@ -343,45 +337,3 @@ func getMinerNode(id string, state cstate.CommonStateContextI) (*MinerNode, erro
 	return mn, nil
 }
 
-func validateNodeSettings(node *MinerNode, gn *GlobalNode, opcode string) error {
-	if node.Settings.ServiceChargeRatio < 0 {
-		return common.NewErrorf(opcode,
-			"invalid negative service charge: %v", node.Settings.ServiceChargeRatio)
-	}
-
-	if node.Settings.ServiceChargeRatio > gn.MaxCharge {
-		return common.NewErrorf(opcode,
-			"max_charge is greater than allowed by SC: %v > %v",
-			node.Settings.ServiceChargeRatio, gn.MaxCharge)
-	}
-
-	if node.Settings.MaxNumDelegates <= 0 {
-		return common.NewErrorf(opcode,
-			"invalid non-positive number_of_delegates: %v", node.Settings.MaxNumDelegates)
-	}
-
-	if node.Settings.MaxNumDelegates > gn.MaxDelegates {
-		return common.NewErrorf(opcode,
-			"number_of_delegates greater than max_delegates of SC: %v > %v",
-			node.Settings.MaxNumDelegates, gn.MaxDelegates)
-	}
-
-	if node.Settings.MinStake < gn.MinStake {
-		return common.NewErrorf(opcode,
-			"min_stake is less than allowed by SC: %v > %v",
-			node.Settings.MinStake, gn.MinStake)
-	}
-
-	if node.Settings.MinStake > node.Settings.MaxStake {
-		return common.NewErrorf(opcode,
-			"invalid node request results in min_stake greater than max_stake: %v > %v", node.Settings.MinStake, node.Settings.MaxStake)
-	}
-
-	if node.Settings.MaxStake > gn.MaxStake {
-		return common.NewErrorf(opcode,
-			"max_stake is greater than allowed by SC: %v > %v",
-			node.Settings.MaxStake, gn.MaxStake)
-	}
-
-	return nil
-}
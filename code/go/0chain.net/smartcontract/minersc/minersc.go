@@ -39,6 +39,11 @@ func (msc *MinerSmartContract) InitSmartContractFunctions() {
 	msc.smartContractFunctions["delete_miner"] = msc.DeleteMiner
 	msc.smartContractFunctions["delete_sharder"] = msc.DeleteSharder
 	msc.smartContractFunctions["collect_reward"] = msc.collectReward
+	msc.smartContractFunctions["shutdown_provider"] = msc.shutdownProvider
+	msc.smartContractFunctions["kill_provider"] = msc.killProvider
+	msc.smartContractFunctions["slash"] = msc.slash
+	msc.smartContractFunctions["unbond"] = msc.unbond
+	msc.smartContractFunctions["claim_unbonded"] = msc.claimUnbonded
 
 	msc.smartContractFunctions["miner_health_check"] = msc.minerHealthCheck
 	msc.smartContractFunctions["sharder_health_check"] = msc.sharderHealthCheck
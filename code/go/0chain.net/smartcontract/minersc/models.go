@@ -25,6 +25,7 @@ import (
 	"0chain.net/core/encryption"
 	"0chain.net/core/logging"
 	"0chain.net/core/util"
+	"0chain.net/smartcontract/stakepool/spenum"
 
 	"github.com/go-playground/validator/v10"
 	"go.uber.org/zap"
@@ -250,6 +251,50 @@ type GlobalNode struct {
 	OwnerId              string         `json:"owner_id"`
 	CooldownPeriod       int64          `json:"cooldown_period"`
 	Cost                 map[string]int `json:"cost"`
+
+	// SlashRatio is the part of the stake of a miner slashed for a proven
+	// fault.
+	SlashRatio float64 `json:"slash_ratio"`
+	// SlashReporterRatio is the part of the slashed tokens paid to the
+	// reporter of the fault, the rest is burnt.
+	SlashReporterRatio float64 `json:"slash_reporter_ratio"`
+
+	// MinerUnbondingPeriod and SharderUnbondingPeriod are the rounds an
+	// amount unbonded from a delegate pool of a miner or of a sharder waits
+	// before it can be claimed.
+	MinerUnbondingPeriod   int64 `json:"miner_unbonding_period"`
+	SharderUnbondingPeriod int64 `json:"sharder_unbonding_period"`
+
+	// BatchSendMaxTransfers is the max number of transfers of a batch send
+	// transaction and BatchSendTransferCost is the cost of a transfer, the
+	// zero values are the defaults of the transaction package.
+	BatchSendMaxTransfers int `json:"batch_send_max_transfers"`
+	BatchSendTransferCost int `json:"batch_send_transfer_cost"`
+}
+
+// GetBatchSendConfig returns the max number of transfers of a batch send
+// transaction and the cost of a transfer set in the miner SC configurations.
+func GetBatchSendConfig(balances cstate.CommonStateContextI) (maxTransfers, transferCost int, err error) {
+	gn, err := getGlobalNode(balances)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxTransfers, transferCost = gn.BatchSendMaxTransfers, gn.BatchSendTransferCost
+	if maxTransfers == 0 {
+		maxTransfers = transaction.DefaultBatchSendMaxTransfers
+	}
+	if transferCost == 0 {
+		transferCost = transaction.DefaultBatchSendTransferCost
+	}
+	return maxTransfers, transferCost, nil
+}
+
+// unbondingPeriod returns the unbonding period of the provider type
+func (gn *GlobalNode) unbondingPeriod(providerType spenum.Provider) int64 {
+	if providerType == spenum.Sharder {
+		return gn.SharderUnbondingPeriod
+	}
+	return gn.MinerUnbondingPeriod
 }
 
 func (gn *GlobalNode) readConfig() (err error) {
@@ -286,6 +331,12 @@ func (gn *GlobalNode) readConfig() (err error) {
 	}
 	gn.OwnerId = config.SmartContractConfig.GetString(pfx + SettingName[OwnerId])
 	gn.CooldownPeriod = config.SmartContractConfig.GetInt64(pfx + SettingName[CooldownPeriod])
+	gn.SlashRatio = config.SmartContractConfig.GetFloat64(pfx + SettingName[SlashRatio])
+	gn.SlashReporterRatio = config.SmartContractConfig.GetFloat64(pfx + SettingName[SlashReporterRatio])
+	gn.MinerUnbondingPeriod = config.SmartContractConfig.GetInt64(pfx + SettingName[MinerUnbondingPeriod])
+	gn.SharderUnbondingPeriod = config.SmartContractConfig.GetInt64(pfx + SettingName[SharderUnbondingPeriod])
+	gn.BatchSendMaxTransfers = config.SmartContractConfig.GetInt(pfx + SettingName[BatchSendMaxTransfers])
+	gn.BatchSendTransferCost = config.SmartContractConfig.GetInt(pfx + SettingName[BatchSendTransferCost])
 	gn.Cost = config.SmartContractConfig.GetStringMapInt(pfx + SettingName[Cost])
 	return nil
 }
@@ -310,6 +361,30 @@ func (gn *GlobalNode) validate() error {
 	if gn.MaxDelegates <= 0 {
 		return fmt.Errorf("max_delegates is too small: %d", gn.MaxDelegates)
 	}
+
+	if gn.SlashRatio < 0 || gn.SlashRatio > 1 {
+		return fmt.Errorf("slash_ratio not in [0; 1]: %v", gn.SlashRatio)
+	}
+	if gn.SlashReporterRatio < 0 || gn.SlashReporterRatio > 1 {
+		return fmt.Errorf("slash_reporter_ratio not in [0; 1]: %v",
+			gn.SlashReporterRatio)
+	}
+	if gn.MinerUnbondingPeriod < 0 {
+		return fmt.Errorf("negative miner_unbonding_period: %v",
+			gn.MinerUnbondingPeriod)
+	}
+	if gn.SharderUnbondingPeriod < 0 {
+		return fmt.Errorf("negative sharder_unbonding_period: %v",
+			gn.SharderUnbondingPeriod)
+	}
+	if gn.BatchSendMaxTransfers < 0 {
+		return fmt.Errorf("negative batch_send_max_transfers: %v",
+			gn.BatchSendMaxTransfers)
+	}
+	if gn.BatchSendTransferCost < 0 {
+		return fmt.Errorf("negative batch_send_transfer_cost: %v",
+			gn.BatchSendTransferCost)
+	}
 	return nil
 }
 
@@ -379,6 +454,18 @@ func (gn *GlobalNode) Get(key Setting) (interface{}, error) {
 		return gn.OwnerId, nil
 	case CooldownPeriod:
 		return gn.CooldownPeriod, nil
+	case SlashRatio:
+		return gn.SlashRatio, nil
+	case SlashReporterRatio:
+		return gn.SlashReporterRatio, nil
+	case MinerUnbondingPeriod:
+		return gn.MinerUnbondingPeriod, nil
+	case SharderUnbondingPeriod:
+		return gn.SharderUnbondingPeriod, nil
+	case BatchSendMaxTransfers:
+		return gn.BatchSendMaxTransfers, nil
+	case BatchSendTransferCost:
+		return gn.BatchSendTransferCost, nil
 	case Cost:
 		return "", nil
 	case CostAddMiner:
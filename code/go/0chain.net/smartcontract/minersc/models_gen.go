@@ -308,9 +308,9 @@ func (z *DKGMinerNodes) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *GlobalNode) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 25
+	// map header, size 31
 	// string "ViewChange"
-	o = append(o, 0xde, 0x0, 0x19, 0xaa, 0x56, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65)
+	o = append(o, 0xde, 0x0, 0x1f, 0xaa, 0x56, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65)
 	o = msgp.AppendInt64(o, z.ViewChange)
 	// string "MaxN"
 	o = append(o, 0xa4, 0x4d, 0x61, 0x78, 0x4e)
@@ -422,6 +422,24 @@ func (z *GlobalNode) MarshalMsg(b []byte) (o []byte, err error) {
 		o = msgp.AppendString(o, k)
 		o = msgp.AppendInt(o, za0002)
 	}
+	// string "SlashRatio"
+	o = append(o, 0xaa, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x52, 0x61, 0x74, 0x69, 0x6f)
+	o = msgp.AppendFloat64(o, z.SlashRatio)
+	// string "SlashReporterRatio"
+	o = append(o, 0xb2, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6f)
+	o = msgp.AppendFloat64(o, z.SlashReporterRatio)
+	// string "MinerUnbondingPeriod"
+	o = append(o, 0xb4, 0x4d, 0x69, 0x6e, 0x65, 0x72, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = msgp.AppendInt64(o, z.MinerUnbondingPeriod)
+	// string "SharderUnbondingPeriod"
+	o = append(o, 0xb6, 0x53, 0x68, 0x61, 0x72, 0x64, 0x65, 0x72, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = msgp.AppendInt64(o, z.SharderUnbondingPeriod)
+	// string "BatchSendMaxTransfers"
+	o = append(o, 0xb5, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x61, 0x78, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73)
+	o = msgp.AppendInt(o, z.BatchSendMaxTransfers)
+	// string "BatchSendTransferCost"
+	o = append(o, 0xb5, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x43, 0x6f, 0x73, 0x74)
+	o = msgp.AppendInt(o, z.BatchSendTransferCost)
 	return
 }
 
@@ -628,6 +646,42 @@ func (z *GlobalNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 				z.Cost[za0001] = za0002
 			}
+		case "SlashRatio":
+			z.SlashRatio, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SlashRatio")
+				return
+			}
+		case "SlashReporterRatio":
+			z.SlashReporterRatio, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SlashReporterRatio")
+				return
+			}
+		case "MinerUnbondingPeriod":
+			z.MinerUnbondingPeriod, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MinerUnbondingPeriod")
+				return
+			}
+		case "SharderUnbondingPeriod":
+			z.SharderUnbondingPeriod, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SharderUnbondingPeriod")
+				return
+			}
+		case "BatchSendMaxTransfers":
+			z.BatchSendMaxTransfers, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BatchSendMaxTransfers")
+				return
+			}
+		case "BatchSendTransferCost":
+			z.BatchSendTransferCost, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BatchSendTransferCost")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -655,6 +709,7 @@ func (z *GlobalNode) Msgsize() (s int) {
 			s += msgp.StringPrefixSize + len(za0001) + msgp.IntSize
 		}
 	}
+	s += 11 + msgp.Float64Size + 19 + msgp.Float64Size + 21 + msgp.Int64Size + 23 + msgp.Int64Size + 22 + msgp.IntSize + 22 + msgp.IntSize
 	return
 }
 
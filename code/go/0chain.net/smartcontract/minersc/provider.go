@@ -0,0 +1,140 @@
+package minersc
+
+import (
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+// Id returns the id of the miner or sharder, it implements
+// stakepool.Provider.
+func (mn *MinerNode) Id() string {
+	return mn.ID
+}
+
+// Type returns the provider type of the node.
+func (mn *MinerNode) Type() spenum.Provider {
+	switch mn.NodeType {
+	case NodeTypeMiner:
+		return spenum.Miner
+	case NodeTypeSharder:
+		return spenum.Sharder
+	default:
+		return 0
+	}
+}
+
+// GetStakePool returns the stake pool of the node.
+func (mn *MinerNode) GetStakePool() *stakepool.StakePool {
+	return mn.StakePool
+}
+
+// SaveProvider saves the node with its stake pool.
+func (mn *MinerNode) SaveProvider(balances cstate.StateContextI) error {
+	return mn.save(balances)
+}
+
+// settingsLimits returns the bounds of the stake pool settings of the miners
+// and the sharders
+func (gn *GlobalNode) settingsLimits() *stakepool.SettingsLimits {
+	return &stakepool.SettingsLimits{
+		MinStake:         gn.MinStake,
+		MaxStake:         gn.MaxStake,
+		MaxNumDelegates:  gn.MaxDelegates,
+		MaxServiceCharge: gn.MaxCharge,
+	}
+}
+
+// getProvider loads a miner or a sharder
+func (msc *MinerSmartContract) getProvider(providerType spenum.Provider,
+	id string, balances cstate.StateContextI) (*MinerNode, error) {
+
+	switch providerType {
+	case spenum.Miner:
+		return getMinerNode(id, balances)
+	case spenum.Sharder:
+		return msc.getSharderNode(id, balances)
+	default:
+		return nil, fmt.Errorf("unsupported provider type %s", providerType.String())
+	}
+}
+
+// removeProvider removes a stopped miner or sharder like delete_miner and
+// delete_sharder do.
+func (msc *MinerSmartContract) removeProvider(gn *GlobalNode, mn *MinerNode,
+	balances cstate.StateContextI) error {
+
+	updated, err := msc.deleteNode(gn, mn, balances)
+	if err != nil {
+		return err
+	}
+	switch mn.NodeType {
+	case NodeTypeMiner:
+		err = msc.deleteMinerFromViewChange(updated, balances)
+	case NodeTypeSharder:
+		err = msc.deleteSharderFromViewChange(updated, balances)
+	}
+	if err != nil {
+		return err
+	}
+	return stakepool.EmitProvider(updated, balances)
+}
+
+// shutdownProvider stops a miner or a sharder by itself or by its delegate
+// wallet, the node is removed and its delegate pools are released.
+func (msc *MinerSmartContract) shutdownProvider(
+	txn *transaction.Transaction,
+	input []byte,
+	gn *GlobalNode,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req stakepool.ProviderRequest
+	if err := req.Decode(input); err != nil {
+		return "", common.NewErrorf("shutdown_provider_failed",
+			"decoding request: %v", err)
+	}
+	mn, err := msc.getProvider(req.ProviderType, req.ProviderID, balances)
+	if err != nil {
+		return "", common.NewError("shutdown_provider_failed", err.Error())
+	}
+	if err = stakepool.ShutDownProvider(txn.ClientID, mn, balances); err != nil {
+		return "", common.NewError("shutdown_provider_failed", err.Error())
+	}
+	if err = msc.removeProvider(gn, mn, balances); err != nil {
+		return "", common.NewError("shutdown_provider_failed", err.Error())
+	}
+	return "", nil
+}
+
+// killProvider stops a miner or a sharder by the miner SC owner
+func (msc *MinerSmartContract) killProvider(
+	txn *transaction.Transaction,
+	input []byte,
+	gn *GlobalNode,
+	balances cstate.StateContextI,
+) (string, error) {
+	if txn.ClientID != gn.OwnerId {
+		return "", common.NewError("kill_provider_failed",
+			"access denied, allowed for SC owner only")
+	}
+	var req stakepool.ProviderRequest
+	if err := req.Decode(input); err != nil {
+		return "", common.NewErrorf("kill_provider_failed",
+			"decoding request: %v", err)
+	}
+	mn, err := msc.getProvider(req.ProviderType, req.ProviderID, balances)
+	if err != nil {
+		return "", common.NewError("kill_provider_failed", err.Error())
+	}
+	if err = stakepool.KillProvider(mn, balances); err != nil {
+		return "", common.NewError("kill_provider_failed", err.Error())
+	}
+	if err = msc.removeProvider(gn, mn, balances); err != nil {
+		return "", common.NewError("kill_provider_failed", err.Error())
+	}
+	return "", nil
+}
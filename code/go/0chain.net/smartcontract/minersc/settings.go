@@ -41,6 +41,12 @@ const (
 	MaxMint
 	OwnerId
 	CooldownPeriod
+	SlashRatio
+	SlashReporterRatio
+	MinerUnbondingPeriod
+	SharderUnbondingPeriod
+	BatchSendMaxTransfers
+	BatchSendTransferCost
 	Cost
 	CostAddMiner
 	CostAddSharder
@@ -84,6 +90,12 @@ var (
 		"max_mint",
 		"owner_id",
 		"cooldown_period",
+		"slash_ratio",
+		"slash_reporter_ratio",
+		"miner_unbonding_period",
+		"sharder_unbonding_period",
+		"batch_send_max_transfers",
+		"batch_send_transfer_cost",
 		"cost",
 		"cost.add_miner",
 		"cost.add_sharder",
@@ -130,6 +142,12 @@ var (
 		"max_mint":                     {MaxMint, smartcontract.CurrencyCoin},
 		"owner_id":                     {OwnerId, smartcontract.Key},
 		"cooldown_period":              {CooldownPeriod, smartcontract.Int64},
+		"slash_ratio":                  {SlashRatio, smartcontract.Float64},
+		"slash_reporter_ratio":         {SlashReporterRatio, smartcontract.Float64},
+		"miner_unbonding_period":       {MinerUnbondingPeriod, smartcontract.Int64},
+		"sharder_unbonding_period":     {SharderUnbondingPeriod, smartcontract.Int64},
+		"batch_send_max_transfers":     {BatchSendMaxTransfers, smartcontract.Int},
+		"batch_send_transfer_cost":     {BatchSendTransferCost, smartcontract.Int},
 		"cost":                         {Cost, smartcontract.Cost},
 		"cost.add_miner":               {CostAddMiner, smartcontract.Cost},
 		"cost.add_sharder":             {CostAddSharder, smartcontract.Cost},
@@ -164,6 +182,10 @@ func (gn *GlobalNode) setInt(key string, change int) error {
 		gn.MinS = change
 	case MaxDelegates:
 		gn.MaxDelegates = change
+	case BatchSendMaxTransfers:
+		gn.BatchSendMaxTransfers = change
+	case BatchSendTransferCost:
+		gn.BatchSendTransferCost = change
 	default:
 		return fmt.Errorf("key: %v not implemented as int", key)
 	}
@@ -194,6 +216,10 @@ func (gn *GlobalNode) setInt64(key string, change int64) error {
 		gn.Epoch = change
 	case CooldownPeriod:
 		gn.CooldownPeriod = change
+	case MinerUnbondingPeriod:
+		gn.MinerUnbondingPeriod = change
+	case SharderUnbondingPeriod:
+		gn.SharderUnbondingPeriod = change
 	default:
 		return fmt.Errorf("key: %v not implemented as int64", key)
 	}
@@ -216,6 +242,10 @@ func (gn *GlobalNode) setFloat64(key string, change float64) error {
 		gn.MaxCharge = change
 	case RewardDeclineRate:
 		gn.RewardDeclineRate = change
+	case SlashRatio:
+		gn.SlashRatio = change
+	case SlashReporterRatio:
+		gn.SlashReporterRatio = change
 	default:
 		return fmt.Errorf("key: %v not implemented as float64", key)
 	}
@@ -320,7 +350,7 @@ func (msc *MinerSmartContract) updateSettings(
 	gn *GlobalNode,
 	balances cstate.StateContextI,
 ) (resp string, err error) {
-	if err := smartcontractinterface.AuthorizeWithOwner("update_settings", func() bool {
+	if err := smartcontractinterface.AuthorizeWithOwnerOrGovernance("update_settings", t.ClientID, func() bool {
 		get, _ := gn.Get(OwnerId)
 		return get == t.ClientID
 	}); err != nil {
@@ -7,6 +7,8 @@ import (
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
 	"0chain.net/core/util"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
 
 	"0chain.net/core/logging"
 	"go.uber.org/zap"
@@ -22,11 +24,6 @@ func (msc *MinerSmartContract) UpdateSharderSettings(t *transaction.Transaction,
 			"decoding request: %v", err)
 	}
 
-	err = validateNodeSettings(update, gn, "update_sharder_settings")
-	if err != nil {
-		return "", err
-	}
-
 	var sn *MinerNode
 	sn, err = msc.getSharderNode(update.ID, balances)
 	if err != nil {
@@ -44,13 +41,10 @@ func (msc *MinerSmartContract) UpdateSharderSettings(t *transaction.Transaction,
 		return "", common.NewError("update_sharder_settings", "access denied")
 	}
 
-	sn.Settings.ServiceChargeRatio = update.Settings.ServiceChargeRatio
-	sn.Settings.MaxNumDelegates = update.Settings.MaxNumDelegates
-	sn.Settings.MinStake = update.Settings.MinStake
-	sn.Settings.MaxStake = update.Settings.MaxStake
-
-	if err = sn.save(balances); err != nil {
-		return "", common.NewErrorf("update_sharder_settings", "saving: %v", err)
+	err = stakepool.UpdateProviderSettings(sn, update.Settings,
+		gn.settingsLimits(), balances)
+	if err != nil {
+		return "", common.NewError("update_sharder_settings", err.Error())
 	}
 
 	if err = emitUpdateSharder(sn, balances, false); err != nil {
@@ -113,11 +107,6 @@ func (msc *MinerSmartContract) AddSharder(
 			"PublicKey or the ID is empty. Cannot proceed")
 	}
 
-	err = validateNodeSettings(newSharder, gn, "add_sharder")
-	if err != nil {
-		return "", common.NewErrorf("add_sharder", "validate node setting failed: %v", zap.Error(err))
-	}
-
 	existing, err := msc.getSharderNode(newSharder.ID, balances)
 	if err != nil && err != util.ErrValueNotPresent {
 		return "", common.NewErrorf("add_sharder", "unexpected error: %v", err)
@@ -125,6 +114,9 @@ func (msc *MinerSmartContract) AddSharder(
 
 	// if found
 	if err == nil {
+		if existing.ProviderStatus == spenum.ProviderKilled {
+			return "", common.NewError("add_sharder", "sharder is killed")
+		}
 		// and found in all
 		if allSharders.FindNodeById(newSharder.ID) != nil {
 			logging.Logger.Info("add_sharder: found node by id")
@@ -143,7 +135,8 @@ func (msc *MinerSmartContract) AddSharder(
 	allSharders.Nodes = append(allSharders.Nodes, newSharder)
 
 	// save the added sharder
-	_, err = balances.InsertTrieNode(newSharder.GetKey(), newSharder)
+	err = stakepool.RegisterProvider(newSharder, newSharder.Settings,
+		gn.settingsLimits(), balances)
 	if err != nil {
 		return "", common.NewErrorf("add_sharder", "saving sharder: %v", err)
 	}
@@ -0,0 +1,197 @@
+package minersc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/core/stateproof"
+	"0chain.net/smartcontract/stakepool"
+)
+
+// the faults of the miners a slash evidence can prove
+const (
+	// DoubleSigning - the miner generated and signed two blocks of a round
+	// for the same round random seed.
+	DoubleSigning = "double_signing"
+	// ConflictingTickets - the miner signed verification tickets of two
+	// blocks of a generator of a round for the same round random seed.
+	ConflictingTickets = "conflicting_tickets"
+)
+
+// SignedBlock is a block header with the signature of the miner generated
+// the block.
+type SignedBlock struct {
+	Header    *stateproof.BlockHeader `json:"header"`
+	Signature string                  `json:"signature"`
+}
+
+// SlashEvidence proves a fault of a miner by two blocks of the same round
+// and round random seed: the double signing by two blocks generated by the
+// miner, the conflicting tickets by two blocks of the same generator
+// ticketed by the miner. A verifier tickets the blocks of all the generators
+// of a round but only one block of a generator for a seed. The invalid DKG
+// shares are not slashed, the revealed shares are validated on publishing
+// and a miner with an invalid share is left out of the view change.
+type SlashEvidence struct {
+	Reason  string         `json:"reason"`
+	MinerID string         `json:"miner_id"`
+	Blocks  []*SignedBlock `json:"blocks,omitempty"`
+}
+
+func (se *SlashEvidence) Decode(input []byte) error {
+	return json.Unmarshal(input, se)
+}
+
+func (se *SlashEvidence) Encode() []byte {
+	buff, _ := json.Marshal(se)
+	return buff
+}
+
+// conflictingBlocks checks the evidence blocks are two different blocks of
+// the same round and returns the round. The blocks must have the same round
+// random seed, the seed changes on the round timeout and a generator
+// proposes a new block for the new seed legitimately.
+func (se *SlashEvidence) conflictingBlocks() (int64, error) {
+	if len(se.Blocks) != 2 || se.Blocks[0] == nil || se.Blocks[1] == nil ||
+		se.Blocks[0].Header == nil || se.Blocks[1].Header == nil {
+		return 0, errors.New("two blocks expected")
+	}
+	a, b := se.Blocks[0].Header, se.Blocks[1].Header
+	for _, h := range []*stateproof.BlockHeader{a, b} {
+		if err := stateproof.VerifyBlockHeader(h, nil, 0); err != nil {
+			return 0, err
+		}
+	}
+	if a.Round != b.Round {
+		return 0, fmt.Errorf("blocks of different rounds: %d, %d", a.Round, b.Round)
+	}
+	if a.RoundRandomSeed != b.RoundRandomSeed {
+		return 0, fmt.Errorf("blocks of different round random seeds: %d, %d",
+			a.RoundRandomSeed, b.RoundRandomSeed)
+	}
+	if a.Hash == b.Hash {
+		return 0, errors.New("same block")
+	}
+	return a.Round, nil
+}
+
+func verifySignature(publicKey, signature, hash string,
+	balances cstate.StateContextI) error {
+
+	scheme := balances.GetSignatureScheme()
+	if err := scheme.SetPublicKey(publicKey); err != nil {
+		return fmt.Errorf("setting public key: %v", err)
+	}
+	ok, err := scheme.Verify(signature, hash)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// verifyDoubleSigning checks the two blocks are generated and signed by the
+// miner; it returns the offence id.
+func (se *SlashEvidence) verifyDoubleSigning(mn *MinerNode,
+	balances cstate.StateContextI) (string, error) {
+
+	round, err := se.conflictingBlocks()
+	if err != nil {
+		return "", err
+	}
+	for _, b := range se.Blocks {
+		if b.Header.MinerID != mn.ID {
+			return "", fmt.Errorf("block %s is not generated by the miner", b.Header.Hash)
+		}
+		if err := verifySignature(mn.PublicKey, b.Signature, b.Header.Hash, balances); err != nil {
+			return "", fmt.Errorf("block %s: %v", b.Header.Hash, err)
+		}
+	}
+	return encryption.Hash(DoubleSigning + ":" + mn.ID + ":" + strconv.FormatInt(round, 10)), nil
+}
+
+// verifyConflictingTickets checks the two blocks of the same generator have
+// verification tickets signed by the miner; it returns the offence id.
+func (se *SlashEvidence) verifyConflictingTickets(mn *MinerNode,
+	balances cstate.StateContextI) (string, error) {
+
+	round, err := se.conflictingBlocks()
+	if err != nil {
+		return "", err
+	}
+	if se.Blocks[0].Header.MinerID != se.Blocks[1].Header.MinerID {
+		return "", errors.New("blocks of different generators")
+	}
+	for _, b := range se.Blocks {
+		var ticket *stateproof.Ticket
+		for _, t := range b.Header.VerificationTickets {
+			if t != nil && t.VerifierID == mn.ID {
+				ticket = t
+				break
+			}
+		}
+		if ticket == nil {
+			return "", fmt.Errorf("block %s has no ticket of the miner", b.Header.Hash)
+		}
+		if err := verifySignature(mn.PublicKey, ticket.Signature, b.Header.Hash, balances); err != nil {
+			return "", fmt.Errorf("block %s ticket: %v", b.Header.Hash, err)
+		}
+	}
+	return encryption.Hash(ConflictingTickets + ":" + mn.ID + ":" + strconv.FormatInt(round, 10)), nil
+}
+
+// slash verifies an evidence of a fault of a miner and slashes the stake
+// of the miner, a part of the slashed tokens is paid to the reporter.
+func (msc *MinerSmartContract) slash(
+	txn *transaction.Transaction,
+	input []byte,
+	gn *GlobalNode,
+	balances cstate.StateContextI,
+) (string, error) {
+	var se SlashEvidence
+	if err := se.Decode(input); err != nil {
+		return "", common.NewErrorf("slash_failed", "decoding evidence: %v", err)
+	}
+	mn, err := getMinerNode(se.MinerID, balances)
+	if err != nil {
+		return "", common.NewErrorf("slash_failed", "getting miner %s: %v", se.MinerID, err)
+	}
+
+	var offenceID string
+	switch se.Reason {
+	case DoubleSigning:
+		offenceID, err = se.verifyDoubleSigning(mn, balances)
+	case ConflictingTickets:
+		offenceID, err = se.verifyConflictingTickets(mn, balances)
+	default:
+		err = fmt.Errorf("unknown fault %q", se.Reason)
+	}
+	if err != nil {
+		return "", common.NewErrorf("slash_failed", "invalid evidence: %v", err)
+	}
+
+	sr, err := stakepool.SlashProvider(msc.ID, mn, &stakepool.Offence{
+		ID:            offenceID,
+		Reason:        se.Reason,
+		Reporter:      txn.ClientID,
+		Ratio:         gn.SlashRatio,
+		ReporterRatio: gn.SlashReporterRatio,
+	}, balances)
+	if err != nil {
+		return "", common.NewError("slash_failed", err.Error())
+	}
+
+	resp, err := json.Marshal(sr)
+	if err != nil {
+		return "", common.NewError("slash_failed", err.Error())
+	}
+	return string(resp), nil
+}
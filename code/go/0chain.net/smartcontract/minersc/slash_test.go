@@ -0,0 +1,173 @@
+package minersc
+
+import (
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/encryption"
+	"0chain.net/core/stateproof"
+	"0chain.net/smartcontract/stakepool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlash(t *testing.T) {
+	const stake = 100e10
+
+	signedBlock := func(t *testing.T, generator, verifier *Client, round, rrs int64, prev string) *SignedBlock {
+		h := &stateproof.BlockHeader{
+			MinerID:         generator.id,
+			PrevHash:        encryption.Hash(prev),
+			Round:           round,
+			RoundRandomSeed: rrs,
+		}
+		h.Hash = h.ComputeHash()
+		sig, err := generator.scheme.Sign(h.Hash)
+		require.NoError(t, err)
+		ticket, err := verifier.scheme.Sign(h.Hash)
+		require.NoError(t, err)
+		h.VerificationTickets = []*stateproof.Ticket{{VerifierID: verifier.id, Signature: ticket}}
+		return &SignedBlock{Header: h, Signature: sig}
+	}
+
+	tests := []struct {
+		name     string
+		evidence func(offender, other *Client) *SlashEvidence
+		wantErr  string
+	}{
+		{
+			name: "double_signing",
+			evidence: func(offender, other *Client) *SlashEvidence {
+				return &SlashEvidence{Reason: DoubleSigning, MinerID: offender.id, Blocks: []*SignedBlock{
+					signedBlock(t, offender, other, 10, 0, "a"),
+					signedBlock(t, offender, other, 10, 0, "b"),
+				}}
+			},
+		},
+		{
+			name: "conflicting_tickets",
+			evidence: func(offender, other *Client) *SlashEvidence {
+				return &SlashEvidence{Reason: ConflictingTickets, MinerID: offender.id, Blocks: []*SignedBlock{
+					signedBlock(t, other, offender, 10, 0, "a"),
+					signedBlock(t, other, offender, 10, 0, "b"),
+				}}
+			},
+		},
+		{
+			name: "tickets_of_different_generators",
+			evidence: func(offender, other *Client) *SlashEvidence {
+				return &SlashEvidence{Reason: ConflictingTickets, MinerID: offender.id, Blocks: []*SignedBlock{
+					signedBlock(t, other, offender, 10, 0, "a"),
+					signedBlock(t, offender, offender, 10, 0, "b"),
+				}}
+			},
+			wantErr: "different generators",
+		},
+		{
+			name: "tickets_of_other_verifier",
+			evidence: func(offender, other *Client) *SlashEvidence {
+				return &SlashEvidence{Reason: ConflictingTickets, MinerID: offender.id, Blocks: []*SignedBlock{
+					signedBlock(t, offender, other, 10, 0, "a"),
+					signedBlock(t, offender, other, 10, 0, "b"),
+				}}
+			},
+			wantErr: "no ticket of the miner",
+		},
+
+		{
+			name: "same_block",
+			evidence: func(offender, other *Client) *SlashEvidence {
+				b := signedBlock(t, offender, other, 10, 0, "a")
+				return &SlashEvidence{Reason: DoubleSigning, MinerID: offender.id, Blocks: []*SignedBlock{b, b}}
+			},
+			wantErr: "same block",
+		},
+		{
+			name: "different_rounds",
+			evidence: func(offender, other *Client) *SlashEvidence {
+				return &SlashEvidence{Reason: DoubleSigning, MinerID: offender.id, Blocks: []*SignedBlock{
+					signedBlock(t, offender, other, 10, 0, "a"),
+					signedBlock(t, offender, other, 11, 0, "b"),
+				}}
+			},
+			wantErr: "different rounds",
+		},
+		{
+			name: "round_timeout",
+			evidence: func(offender, other *Client) *SlashEvidence {
+				return &SlashEvidence{Reason: DoubleSigning, MinerID: offender.id, Blocks: []*SignedBlock{
+					signedBlock(t, offender, other, 10, 1, "a"),
+					signedBlock(t, offender, other, 10, 2, "b"),
+				}}
+			},
+			wantErr: "different round random seeds",
+		},
+		{
+			name: "tampered_block",
+			evidence: func(offender, other *Client) *SlashEvidence {
+				b := signedBlock(t, offender, other, 10, 0, "b")
+				b.Header.RoundRandomSeed = 1
+				return &SlashEvidence{Reason: DoubleSigning, MinerID: offender.id, Blocks: []*SignedBlock{
+					signedBlock(t, offender, other, 10, 0, "a"), b,
+				}}
+			},
+			wantErr: "invalid block",
+		},
+		{
+			name: "other_generator",
+			evidence: func(offender, other *Client) *SlashEvidence {
+				return &SlashEvidence{Reason: DoubleSigning, MinerID: offender.id, Blocks: []*SignedBlock{
+					signedBlock(t, other, offender, 10, 0, "a"),
+					signedBlock(t, other, offender, 10, 0, "b"),
+				}}
+			},
+			wantErr: "not generated by the miner",
+		},
+		{
+			name: "unknown_fault",
+			evidence: func(offender, other *Client) *SlashEvidence {
+				return &SlashEvidence{Reason: "late", MinerID: offender.id}
+			},
+			wantErr: "unknown fault",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				balances = newTestBalances()
+				msc      = newTestMinerSC()
+				gn       = setConfig(t, balances)
+				offender = newClient(0, balances)
+				other    = newClient(0, balances)
+				reporter = newClient(0, balances)
+			)
+			gn.SlashRatio, gn.SlashReporterRatio = 0.5, 0.1
+			balances.block = &block.Block{}
+			balances.balances[ADDRESS] = stake
+
+			mn := NewMinerNode()
+			mn.ID, mn.PublicKey, mn.NodeType = offender.id, offender.pk, NodeTypeMiner
+			mn.Pools["pool"] = &stakepool.DelegatePool{Balance: stake}
+			require.NoError(t, mn.save(balances))
+
+			input := tt.evidence(offender, other).Encode()
+			tx := newTransaction(reporter.id, ADDRESS, 0, 0)
+			balances.txn = tx
+			_, err := msc.slash(tx, input, gn, balances)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			mn, err = getMinerNode(offender.id, balances)
+			require.NoError(t, err)
+			require.EqualValues(t, stake/2, mn.Pools["pool"].Balance)
+			require.EqualValues(t, stake/20, balances.balances[reporter.id])
+
+			_, err = msc.slash(tx, input, gn, balances)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "already slashed")
+		})
+	}
+}
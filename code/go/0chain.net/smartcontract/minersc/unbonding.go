@@ -0,0 +1,59 @@
+package minersc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+// unbond moves an amount of a delegate pool of a miner or a sharder to the
+// unbonding queue of the delegate, the amount is claimable after the
+// unbonding period of the node type.
+func (msc *MinerSmartContract) unbond(
+	txn *transaction.Transaction,
+	input []byte,
+	gn *GlobalNode,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req stakepool.UnbondRequest
+	if err := req.Decode(input); err != nil {
+		return "", common.NewErrorf("unbond_failed", "decoding request: %v", err)
+	}
+	mn, err := msc.getProvider(req.ProviderType, req.ProviderID, balances)
+	if err != nil {
+		return "", common.NewError("unbond_failed", err.Error())
+	}
+	entry, err := stakepool.Unbond(msc.ID, txn.ClientID, mn, &req,
+		gn.unbondingPeriod(req.ProviderType), balances)
+	if err != nil {
+		return "", common.NewError("unbond_failed", err.Error())
+	}
+	resp, err := json.Marshal(entry)
+	if err != nil {
+		return "", common.NewError("unbond_failed", err.Error())
+	}
+	return string(resp), nil
+}
+
+// claimUnbonded pays the matured unbonding entries of the client
+func (msc *MinerSmartContract) claimUnbonded(
+	txn *transaction.Transaction,
+	_ []byte,
+	_ *GlobalNode,
+	balances cstate.StateContextI,
+) (string, error) {
+	getProvider := func(providerType spenum.Provider, id string,
+		balances cstate.StateContextI) (stakepool.Provider, error) {
+		return msc.getProvider(providerType, id, balances)
+	}
+	claimed, err := stakepool.ClaimUnbonded(msc.ID, txn.ClientID, getProvider, balances)
+	if err != nil {
+		return "", common.NewError("claim_unbonded_failed", err.Error())
+	}
+	return fmt.Sprintf(`{"claimed":%d}`, claimed), nil
+}
@@ -0,0 +1,317 @@
+package multisigsc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	c_state "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/currency"
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/core/util"
+)
+
+//msgp:ignore ActionVote
+//msgp:shim json.RawMessage as:[]byte using:[]byte/json.RawMessage
+
+// Kinds of the actions a multi-sig wallet can vote for.
+const (
+	ActionCall      = "call"      // smart contract call made by the wallet
+	ActionSigners   = "signers"   // new signers and number of required votes
+	ActionThreshold = "threshold" // higher number of required votes
+)
+
+// Action proposed to a multi-sig wallet other than a plain transfer.
+type Action struct {
+	Kind string `json:"kind"`
+
+	// Smart contract call. The Value is the max amount of tokens the called
+	// smart contract can take from the wallet.
+	ToClientID string        `json:"to_client_id,omitempty"`
+	Value      currency.Coin `json:"value,omitempty"`
+	Data       *CallData     `json:"data,omitempty"`
+
+	// New shares of the wallet key.
+	SignerThresholdIDs []string `json:"signer_threshold_ids,omitempty"`
+	SignerPublicKeys   []string `json:"signer_public_keys,omitempty"`
+
+	// Signers and threshold.
+	NumRequired int `json:"num_required,omitempty"`
+}
+
+// CallData is the smart contract transaction data of a call action.
+type CallData struct {
+	FunctionName string          `json:"name"`
+	InputData    json.RawMessage `json:"input"`
+}
+
+func (a *Action) Encode() []byte {
+	buff, _ := json.Marshal(a)
+	return buff
+}
+
+// Hash of the action of the wallet proposal signed by the voters.
+func (a *Action) Hash(clientID, proposalID string) string {
+	return encryption.Hash(clientID + ":" + proposalID + ":" + string(a.Encode()))
+}
+
+func (a *Action) validate() error {
+	switch a.Kind {
+	case ActionCall:
+		if a.ToClientID == "" || a.ToClientID == Address {
+			return errors.New("invalid smart contract to call")
+		}
+		if a.Data == nil || a.Data.FunctionName == "" {
+			return errors.New("missing smart contract function to call")
+		}
+		if len(a.ToClientID) > MaxFieldSize || len(a.Data.FunctionName) > MaxFieldSize {
+			return errors.New("an input field exceeded allowable length")
+		}
+		if len(a.SignerThresholdIDs) > 0 || len(a.SignerPublicKeys) > 0 || a.NumRequired != 0 {
+			return errors.New("unexpected signers of call action")
+		}
+	case ActionSigners:
+		if a.ToClientID != "" || a.Value != 0 || a.Data != nil {
+			return errors.New("unexpected call of signers action")
+		}
+	case ActionThreshold:
+		if a.ToClientID != "" || a.Value != 0 || a.Data != nil ||
+			len(a.SignerThresholdIDs) > 0 || len(a.SignerPublicKeys) > 0 {
+			return errors.New("unexpected call or signers of threshold action")
+		}
+	default:
+		return fmt.Errorf("unknown action kind %q", a.Kind)
+	}
+	return nil
+}
+
+// apply the signers or threshold action to the wallet
+func (a *Action) apply(w Wallet) (Wallet, error) {
+	switch a.Kind {
+	case ActionSigners:
+		w.SignerThresholdIDs = a.SignerThresholdIDs
+		w.SignerPublicKeys = a.SignerPublicKeys
+		w.NumRequired = a.NumRequired
+	case ActionThreshold:
+		// the shares of the wallet key recover it with the threshold they
+		// were split with, a lower threshold needs new shares of a signers
+		// action
+		if a.NumRequired < w.NumRequired {
+			return w, fmt.Errorf("threshold %d is lower than %d, it needs new key shares",
+				a.NumRequired, w.NumRequired)
+		}
+		w.NumRequired = a.NumRequired
+	default:
+		return w, fmt.Errorf("action %q doesn't change the wallet", a.Kind)
+	}
+	if _, err := w.valid(w.ClientID); err != nil {
+		return w, err
+	}
+	return w, nil
+}
+
+// ActionVote is a vote of a signer for an action of a multi-sig wallet.
+type ActionVote struct {
+	ProposalID string `json:"proposal_id"`
+	ClientID   string `json:"client_id"` // of the multi-sig wallet
+	Action     Action `json:"action"`
+	Signature  string `json:"signature"` // share of the action hash
+}
+
+func (v ActionVote) notTooBig() bool {
+	return len(v.ProposalID) <= MaxFieldSize &&
+		len(v.ClientID) <= MaxFieldSize &&
+		len(v.Signature) <= MaxFieldSize
+}
+
+// vote used to find or create the proposal of the action, a proposal of an
+// action has no transfer recipient.
+func (v ActionVote) vote() Vote {
+	return Vote{
+		ProposalID: v.ProposalID,
+		Transfer:   state.Transfer{ClientID: v.ClientID},
+		Signature:  v.Signature,
+	}
+}
+
+func (w Wallet) isActionVoteAuthorized(signingClientID string, v ActionVote) bool {
+	publicKey := w.publicKeyForSigner(signingClientID)
+	if publicKey == "" {
+		// Not a registered signer for this wallet.
+		return false
+	}
+	return verifySignature(w.SignatureScheme, publicKey, v.Signature,
+		v.Action.Hash(v.ClientID, v.ProposalID))
+}
+
+func verifySignature(schemeName, publicKey, sig, hash string) bool {
+	scheme := encryption.GetSignatureScheme(schemeName)
+	if err := scheme.SetPublicKey(publicKey); err != nil {
+		return false
+	}
+	ok, err := scheme.Verify(sig, hash)
+	return err == nil && ok
+}
+
+// Marks an executed action of a wallet to prevent a replay of the votes once
+// its proposal is pruned.
+type executedAction struct {
+	TxnHash string `json:"txn_hash"`
+}
+
+func getExecutedActionKey(clientID, actionHash string) datastore.Key {
+	return datastore.Key(Address + clientID + encryption.Hash("executed:"+actionHash))
+}
+
+func (ms MultiSigSmartContract) voteAction(t *transaction.Transaction, now common.Timestamp, inputData []byte, balances c_state.StateContextI) (string, error) {
+	err := ms.pruneExpirationQueue(now, balances)
+	if err != nil {
+		if err != util.ErrValueNotPresent && err != util.ErrNodeNotFound {
+			return "", err
+		}
+	}
+
+	var v ActionVote
+	if err = json.Unmarshal(inputData, &v); err != nil {
+		return "", common.NewError("err_vote_action_formatting", err.Error())
+	}
+	if !v.notTooBig() {
+		return "", common.NewError("err_vote_too_big", "an input field exceeded allowable length")
+	}
+	if v.ClientID == "" || v.ProposalID == "" {
+		return "", common.NewError("err_vote_action_invalid", "missing wallet or proposal id")
+	}
+	if err = v.Action.validate(); err != nil {
+		return "", common.NewError("err_vote_action_invalid", err.Error())
+	}
+	if v.Signature == "" {
+		return "", common.NewError("err_vote_no_signature", " must sign vote")
+	}
+
+	w, err := ms.getWallet(v.ClientID, balances)
+	if err != nil && err != util.ErrValueNotPresent {
+		return "", err
+	}
+	if w.isEmpty() {
+		return "", common.NewError("err_vote_wallet_not_registered", " wallet not registered")
+	}
+	signerThresholdID := w.thresholdIdForSigner(t.ClientID)
+	if signerThresholdID == "" || !w.isActionVoteAuthorized(t.ClientID, v) {
+		return "", common.NewError("err_vote_auth", " authorization failure")
+	}
+	if v.Action.Kind != ActionCall {
+		if _, err = v.Action.apply(w); err != nil {
+			return "", common.NewError("err_vote_action_invalid", err.Error())
+		}
+	}
+
+	actionHash := v.Action.Hash(v.ClientID, v.ProposalID)
+	var ea executedAction
+	switch err = balances.GetTrieNode(getExecutedActionKey(v.ClientID, actionHash), &ea); err {
+	case nil:
+		return "success 0: action previously executed in transaction hash " + ea.TxnHash, nil
+	case util.ErrValueNotPresent:
+	default:
+		return "", err
+	}
+
+	p, err := ms.findOrCreateProposal(now, v.vote(), balances)
+	if err != nil {
+		return "", err
+	}
+	if !v.vote().isCompatibleWithProposal(p) ||
+		(p.Action != nil && p.Action.Hash(v.ClientID, v.ProposalID) != actionHash) {
+		return "", common.NewError("err_vote_not_compatible", " previous votes for same proposal differed")
+	}
+	if p.ExecutedInTxnHash != "" {
+		return "success 0: proposal previously executed in transaction hash " + p.ExecutedInTxnHash, nil
+	}
+	p.Action = &v.Action
+
+	remaining := w.NumRequired - len(p.SignerSignatures)
+	for _, id := range p.SignerThresholdIDs {
+		if id == signerThresholdID {
+			return fmt.Sprintf("success %d: already voted, still need %d other votes", remaining, remaining), nil
+		}
+	}
+
+	p.SignerThresholdIDs = append(p.SignerThresholdIDs, signerThresholdID)
+	p.SignerSignatures = append(p.SignerSignatures, v.Signature)
+	if err = ms.putProposal(&p, balances); err != nil {
+		return "", err
+	}
+
+	remaining--
+	if remaining > 0 {
+		return fmt.Sprintf("success %d: need %d more votes", remaining, remaining), nil
+	}
+
+	// The recovered threshold signature must be the signature of the wallet
+	// key on the action.
+	thresholdSignature, err := w.constructTransferSignature(p)
+	if err != nil {
+		return "", common.NewError("err_vote_recover", " in signature recovery: "+err.Error())
+	}
+	if !verifySignature(w.SignatureScheme, w.PublicKey, thresholdSignature, actionHash) {
+		return "", common.NewError("err_vote_recover", " invalid recovered signature")
+	}
+
+	resp, err := ms.executeAction(t, w, p.Action, balances)
+	if err != nil {
+		return "", common.NewError("err_vote_action_execution", err.Error())
+	}
+
+	p.ClientSignature = thresholdSignature
+	p.ExecutedInTxnHash = t.Hash
+	if err = ms.putProposal(&p, balances); err != nil {
+		return "", err
+	}
+	ea.TxnHash = t.Hash
+	if _, err = balances.InsertTrieNode(getExecutedActionKey(v.ClientID, actionHash), &ea); err != nil {
+		return "", err
+	}
+
+	return "success 0: " + p.Action.Kind + " action executed: " + resp, nil
+}
+
+// executeAction executes the action on behalf of the wallet
+func (ms MultiSigSmartContract) executeAction(t *transaction.Transaction, w Wallet, a *Action, balances c_state.StateContextI) (string, error) {
+	if a.Kind != ActionCall {
+		nw, err := a.apply(w)
+		if err != nil {
+			return "", err
+		}
+		if err = ms.putWallet(nw, balances); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d of %d signers required", nw.NumRequired, len(nw.SignerThresholdIDs)), nil
+	}
+
+	scData := &smartcontractinterface.SmartContractTransactionData{
+		FunctionName: a.Data.FunctionName,
+		InputData:    a.Data.InputData,
+	}
+	data, err := json.Marshal(scData)
+	if err != nil {
+		return "", err
+	}
+	dtxn := &transaction.Transaction{
+		HashIDField:     datastore.HashIDField{Hash: t.Hash},
+		ClientID:        w.ClientID,
+		PublicKey:       w.PublicKey,
+		ToClientID:      a.ToClientID,
+		ChainID:         t.ChainID,
+		TransactionData: string(data),
+		Value:           a.Value,
+		CreationDate:    t.CreationDate,
+		TransactionType: transaction.TxnTypeSmartContract,
+	}
+	return smartcontract.ExecuteSmartContract(dtxn, scData,
+		c_state.NewDelegatedStateContext(balances, dtxn))
+}
@@ -0,0 +1,336 @@
+package multisigsc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"encoding/json"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *Action) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 7
+	// string "Kind"
+	o = append(o, 0x87, 0xa4, 0x4b, 0x69, 0x6e, 0x64)
+	o = msgp.AppendString(o, z.Kind)
+	// string "ToClientID"
+	o = append(o, 0xaa, 0x54, 0x6f, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ToClientID)
+	// string "Value"
+	o = append(o, 0xa5, 0x56, 0x61, 0x6c, 0x75, 0x65)
+	o, err = z.Value.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Value")
+		return
+	}
+	// string "Data"
+	o = append(o, 0xa4, 0x44, 0x61, 0x74, 0x61)
+	if z.Data == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		// map header, size 2
+		// string "FunctionName"
+		o = append(o, 0x82, 0xac, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65)
+		o = msgp.AppendString(o, z.Data.FunctionName)
+		// string "InputData"
+		o = append(o, 0xa9, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x61, 0x74, 0x61)
+		o = msgp.AppendBytes(o, []byte(z.Data.InputData))
+	}
+	// string "SignerThresholdIDs"
+	o = append(o, 0xb2, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x49, 0x44, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.SignerThresholdIDs)))
+	for za0001 := range z.SignerThresholdIDs {
+		o = msgp.AppendString(o, z.SignerThresholdIDs[za0001])
+	}
+	// string "SignerPublicKeys"
+	o = append(o, 0xb0, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.SignerPublicKeys)))
+	for za0002 := range z.SignerPublicKeys {
+		o = msgp.AppendString(o, z.SignerPublicKeys[za0002])
+	}
+	// string "NumRequired"
+	o = append(o, 0xab, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	o = msgp.AppendInt(o, z.NumRequired)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *Action) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Kind":
+			z.Kind, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Kind")
+				return
+			}
+		case "ToClientID":
+			z.ToClientID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ToClientID")
+				return
+			}
+		case "Value":
+			bts, err = z.Value.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Value")
+				return
+			}
+		case "Data":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Data = nil
+			} else {
+				if z.Data == nil {
+					z.Data = new(CallData)
+				}
+				var zb0002 uint32
+				zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Data")
+					return
+				}
+				for zb0002 > 0 {
+					zb0002--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Data")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "FunctionName":
+						z.Data.FunctionName, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Data", "FunctionName")
+							return
+						}
+					case "InputData":
+						{
+							var zb0003 []byte
+							zb0003, bts, err = msgp.ReadBytesBytes(bts, []byte(z.Data.InputData))
+							if err != nil {
+								err = msgp.WrapError(err, "Data", "InputData")
+								return
+							}
+							z.Data.InputData = json.RawMessage(zb0003)
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Data")
+							return
+						}
+					}
+				}
+			}
+		case "SignerThresholdIDs":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignerThresholdIDs")
+				return
+			}
+			if cap(z.SignerThresholdIDs) >= int(zb0004) {
+				z.SignerThresholdIDs = (z.SignerThresholdIDs)[:zb0004]
+			} else {
+				z.SignerThresholdIDs = make([]string, zb0004)
+			}
+			for za0001 := range z.SignerThresholdIDs {
+				z.SignerThresholdIDs[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "SignerThresholdIDs", za0001)
+					return
+				}
+			}
+		case "SignerPublicKeys":
+			var zb0005 uint32
+			zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignerPublicKeys")
+				return
+			}
+			if cap(z.SignerPublicKeys) >= int(zb0005) {
+				z.SignerPublicKeys = (z.SignerPublicKeys)[:zb0005]
+			} else {
+				z.SignerPublicKeys = make([]string, zb0005)
+			}
+			for za0002 := range z.SignerPublicKeys {
+				z.SignerPublicKeys[za0002], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "SignerPublicKeys", za0002)
+					return
+				}
+			}
+		case "NumRequired":
+			z.NumRequired, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NumRequired")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Action) Msgsize() (s int) {
+	s = 1 + 5 + msgp.StringPrefixSize + len(z.Kind) + 11 + msgp.StringPrefixSize + len(z.ToClientID) + 6 + z.Value.Msgsize() + 5
+	if z.Data == nil {
+		s += msgp.NilSize
+	} else {
+		s += 1 + 13 + msgp.StringPrefixSize + len(z.Data.FunctionName) + 10 + msgp.BytesPrefixSize + len([]byte(z.Data.InputData))
+	}
+	s += 19 + msgp.ArrayHeaderSize
+	for za0001 := range z.SignerThresholdIDs {
+		s += msgp.StringPrefixSize + len(z.SignerThresholdIDs[za0001])
+	}
+	s += 17 + msgp.ArrayHeaderSize
+	for za0002 := range z.SignerPublicKeys {
+		s += msgp.StringPrefixSize + len(z.SignerPublicKeys[za0002])
+	}
+	s += 12 + msgp.IntSize
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *CallData) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "FunctionName"
+	o = append(o, 0x82, 0xac, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.FunctionName)
+	// string "InputData"
+	o = append(o, 0xa9, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x61, 0x74, 0x61)
+	o = msgp.AppendBytes(o, []byte(z.InputData))
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *CallData) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "FunctionName":
+			z.FunctionName, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FunctionName")
+				return
+			}
+		case "InputData":
+			{
+				var zb0002 []byte
+				zb0002, bts, err = msgp.ReadBytesBytes(bts, []byte(z.InputData))
+				if err != nil {
+					err = msgp.WrapError(err, "InputData")
+					return
+				}
+				z.InputData = json.RawMessage(zb0002)
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *CallData) Msgsize() (s int) {
+	s = 1 + 13 + msgp.StringPrefixSize + len(z.FunctionName) + 10 + msgp.BytesPrefixSize + len([]byte(z.InputData))
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z executedAction) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "TxnHash"
+	o = append(o, 0x81, 0xa7, 0x54, 0x78, 0x6e, 0x48, 0x61, 0x73, 0x68)
+	o = msgp.AppendString(o, z.TxnHash)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *executedAction) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "TxnHash":
+			z.TxnHash, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TxnHash")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z executedAction) Msgsize() (s int) {
+	s = 1 + 8 + msgp.StringPrefixSize + len(z.TxnHash)
+	return
+}
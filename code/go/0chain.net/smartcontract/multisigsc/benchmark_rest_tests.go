@@ -0,0 +1,42 @@
+package multisigsc
+
+import (
+	"0chain.net/smartcontract/benchmark"
+	"0chain.net/smartcontract/rest"
+)
+
+func BenchmarkRestTests(
+	data benchmark.BenchData, _ benchmark.SignatureScheme,
+) benchmark.TestSuite {
+	rh := rest.NewRestHandler(&rest.TestQueryChainer{})
+	mrh := NewMultiSigRestHandler(rh)
+	return benchmark.GetRestTests(
+		[]benchmark.TestParameters{
+			{
+				FuncName: "getWallet",
+				Params: map[string]string{
+					"client_id": data.Clients[0],
+				},
+				Endpoint: mrh.getWallet,
+			},
+			{
+				FuncName: "getProposal",
+				Params: map[string]string{
+					"client_id":   data.Clients[0],
+					"proposal_id": getMockProposalID(0),
+				},
+				Endpoint: mrh.getProposal,
+			},
+			{
+				FuncName: "getPendingProposals",
+				Params: map[string]string{
+					"client_id": data.Clients[0],
+				},
+				Endpoint: mrh.getPendingProposals,
+			},
+		},
+		Address,
+		mrh,
+		benchmark.MultiSigRest,
+	)
+}
@@ -1,7 +1,11 @@
 package multisigsc
 
 import (
+	"strconv"
+
 	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/core/common"
 	"0chain.net/core/viper"
 	"0chain.net/smartcontract/benchmark"
 )
@@ -25,3 +29,46 @@ func AddMockWallets(
 		}
 	}
 }
+
+func AddMockProposals(
+	clients []string,
+	now common.Timestamp,
+	balances cstate.StateContextI,
+) {
+	var q expirationQueue
+	for i := 0; i < len(clients)-1 && i < mockProposals; i++ {
+		p := proposal{
+			ProposalID:     getMockProposalID(i),
+			ExpirationDate: now + ExpirationTime,
+			Transfer:       state.Transfer{ClientID: clients[i]},
+			Action: &Action{
+				Kind:        ActionThreshold,
+				NumRequired: MaxSigners - 1,
+			},
+			SignerThresholdIDs: clients[:1],
+			SignerSignatures:   []string{"signature"},
+		}
+		if i > 0 {
+			p.Prev = proposalRef{ClientID: clients[i-1], ProposalID: getMockProposalID(i - 1)}
+		}
+		if i+1 < len(clients)-1 && i+1 < mockProposals {
+			p.Next = proposalRef{ClientID: clients[i+1], ProposalID: getMockProposalID(i + 1)}
+		}
+		if _, err := balances.InsertTrieNode(p.getKey(), &p); err != nil {
+			panic(err)
+		}
+		if i == 0 {
+			q.Head = p.ref()
+		}
+		q.Tail = p.ref()
+	}
+	if _, err := balances.InsertTrieNode(getExpirationQueueKey(), &q); err != nil {
+		panic(err)
+	}
+}
+
+const mockProposals = 10
+
+func getMockProposalID(i int) string {
+	return "mock_proposal_" + strconv.Itoa(i)
+}
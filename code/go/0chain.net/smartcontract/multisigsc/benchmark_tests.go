@@ -60,6 +60,13 @@ func (bt BenchTest) Run(balances cstate.TimedQueryStateContext, _ *testing.B) er
 			bt.input,
 			balances,
 		)
+	case VoteActionFuncName:
+		_, err = msc.voteAction(
+			bt.Transaction(),
+			balances.GetBlock().CreationDate,
+			bt.input,
+			balances,
+		)
 	default:
 		panic("unknown endpoint: " + bt.endpoint)
 	}
@@ -126,6 +133,32 @@ func BenchmarkTests(
 				return bytes
 			}(),
 		},
+		{
+			name:     "multi_sig." + VoteActionFuncName,
+			endpoint: VoteActionFuncName,
+			txn: &transaction.Transaction{
+				ClientID: data.Clients[0],
+				HashIDField: datastore.HashIDField{
+					Hash: "my hash",
+				},
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				vote := &ActionVote{
+					ProposalID: "benchmark_action",
+					ClientID:   data.Clients[0],
+					Action: Action{
+						Kind:        ActionThreshold,
+						NumRequired: MaxSigners - 1,
+					},
+				}
+				_ = sigScheme.SetPublicKey(data.PublicKeys[0])
+				sigScheme.SetPrivateKey(data.PrivateKeys[0])
+				vote.Signature, _ = sigScheme.Sign(vote.Action.Hash(vote.ClientID, vote.ProposalID))
+				bytes, _ := json.Marshal(vote)
+				return bytes
+			}(),
+		},
 	}
 	var testsI []bk.BenchTestI
 	for _, test := range tests {
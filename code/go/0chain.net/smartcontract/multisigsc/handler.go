@@ -0,0 +1,211 @@
+package multisigsc
+
+import (
+	"encoding/hex"
+	"net/http"
+
+	"0chain.net/chaincore/state"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract"
+	"0chain.net/smartcontract/rest"
+)
+
+type MultiSigRestHandler struct {
+	rest.RestHandlerI
+}
+
+func NewMultiSigRestHandler(rh rest.RestHandlerI) *MultiSigRestHandler {
+	return &MultiSigRestHandler{rh}
+}
+
+func SetupRestHandler(rh rest.RestHandlerI) {
+	rh.Register(GetEndpoints)
+}
+
+func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
+	mrh := NewMultiSigRestHandler(rh)
+	multisig := "/v1/screst/" + Address
+	return []rest.Endpoint{
+		rest.MakeEndpoint(multisig+"/getWallet", mrh.getWallet),
+		rest.MakeEndpoint(multisig+"/getProposal", mrh.getProposal),
+		rest.MakeEndpoint(multisig+"/getPendingProposals", mrh.getPendingProposals),
+	}
+}
+
+// proposalInfo is a proposal of a wallet with the client IDs of its voters
+//
+// swagger:model multisigProposal
+type proposalInfo struct {
+	ProposalID        string           `json:"proposal_id"`
+	ExpirationDate    common.Timestamp `json:"expiration_date"`
+	Transfer          *state.Transfer  `json:"transfer,omitempty"`
+	Action            *Action          `json:"action,omitempty"`
+	Votes             []string         `json:"votes"`
+	Remaining         int              `json:"remaining"`
+	ExecutedInTxnHash string           `json:"executed_in_txn_hash,omitempty"`
+}
+
+func newProposalInfo(w Wallet, p proposal) *proposalInfo {
+	pi := &proposalInfo{
+		ProposalID:        p.ProposalID,
+		ExpirationDate:    p.ExpirationDate,
+		Action:            p.Action,
+		Votes:             make([]string, 0, len(p.SignerThresholdIDs)),
+		ExecutedInTxnHash: p.ExecutedInTxnHash,
+	}
+	if p.Action == nil {
+		transfer := p.Transfer
+		pi.Transfer = &transfer
+	}
+	for _, id := range p.SignerThresholdIDs {
+		pi.Votes = append(pi.Votes, w.signerForThresholdID(id))
+	}
+	if p.ExecutedInTxnHash == "" && w.NumRequired > len(p.SignerSignatures) {
+		pi.Remaining = w.NumRequired - len(p.SignerSignatures)
+	}
+	return pi
+}
+
+// signerForThresholdID returns client ID of the signer, empty for a signer
+// removed from the wallet
+func (w Wallet) signerForThresholdID(signerThresholdID string) string {
+	b, err := hex.DecodeString(w.publicKeyForThresholdID(signerThresholdID))
+	if err != nil || len(b) == 0 {
+		return ""
+	}
+	return encryption.Hash(b)
+}
+
+// swagger:route GET /v1/screst/27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7/getWallet getWallet
+// get a multi-sig wallet
+//
+// parameters:
+//    +name: client_id
+//     description: multi-sig wallet client ID
+//     required: true
+//     in: query
+//     type: string
+//
+// responses:
+//  200: Wallet
+//  400:
+//  404:
+func (mrh *MultiSigRestHandler) getWallet(w http.ResponseWriter, r *http.Request) {
+	clientID := r.URL.Query().Get("client_id")
+	if clientID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing client_id"))
+		return
+	}
+	wallet, err := MultiSigSmartContract{}.getWallet(clientID, mrh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get wallet"))
+		return
+	}
+	common.Respond(w, r, wallet, nil)
+}
+
+// swagger:route GET /v1/screst/27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7/getProposal getProposal
+// get a proposal of a multi-sig wallet and its votes
+//
+// parameters:
+//    +name: client_id
+//     description: multi-sig wallet client ID
+//     required: true
+//     in: query
+//     type: string
+//    +name: proposal_id
+//     description: proposal ID
+//     required: true
+//     in: query
+//     type: string
+//
+// responses:
+//  200: multisigProposal
+//  400:
+//  404:
+func (mrh *MultiSigRestHandler) getProposal(w http.ResponseWriter, r *http.Request) {
+	var (
+		ref = proposalRef{
+			ClientID:   r.URL.Query().Get("client_id"),
+			ProposalID: r.URL.Query().Get("proposal_id"),
+		}
+		ms       = MultiSigSmartContract{}
+		balances = mrh.GetQueryStateContext()
+	)
+	if ref.ClientID == "" || ref.ProposalID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing client_id or proposal_id"))
+		return
+	}
+	wallet, err := ms.getWallet(ref.ClientID, balances)
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get wallet"))
+		return
+	}
+	p, err := ms.getProposal(ref, balances)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get proposal", err.Error()))
+		return
+	}
+	if p.isEmpty() {
+		common.Respond(w, r, nil, common.NewErrNoResource("can't get proposal", "no such proposal"))
+		return
+	}
+	common.Respond(w, r, newProposalInfo(wallet, p), nil)
+}
+
+// swagger:route GET /v1/screst/27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7/getPendingProposals getPendingProposals
+// get the proposals of a multi-sig wallet neither executed nor expired, and their votes
+//
+// parameters:
+//    +name: client_id
+//     description: multi-sig wallet client ID
+//     required: true
+//     in: query
+//     type: string
+//
+// responses:
+//  200: []multisigProposal
+//  400:
+//  404:
+func (mrh *MultiSigRestHandler) getPendingProposals(w http.ResponseWriter, r *http.Request) {
+	var (
+		clientID = r.URL.Query().Get("client_id")
+		ms       = MultiSigSmartContract{}
+		balances = mrh.GetQueryStateContext()
+		now      = balances.Now()
+	)
+	if clientID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing client_id"))
+		return
+	}
+	wallet, err := ms.getWallet(clientID, balances)
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get wallet"))
+		return
+	}
+	q, err := ms.getOrCreateExpirationQueue(balances)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get expiration queue", err.Error()))
+		return
+	}
+
+	// The queue of all the wallets is sorted by the expiration date, the
+	// expired proposals are at its head.
+	var pending = make([]*proposalInfo, 0)
+	for ref := q.Head; ref != (proposalRef{}); {
+		p, err := ms.getProposal(ref, balances)
+		if err != nil {
+			common.Respond(w, r, nil, common.NewErrInternal("can't get proposal", err.Error()))
+			return
+		}
+		if p.isEmpty() {
+			break
+		}
+		if ref.ClientID == clientID && p.ExecutedInTxnHash == "" && !p.isExpired(now) {
+			pending = append(pending, newProposalInfo(wallet, p))
+		}
+		ref = p.Next
+	}
+	common.Respond(w, r, pending, nil)
+}
@@ -317,6 +317,10 @@ type proposal struct {
 
 	Transfer state.Transfer `json:"transfer"`
 
+	// Action of the proposal other than a transfer, the transfer of such a
+	// proposal has the wallet client ID only.
+	Action *Action `json:"action,omitempty"`
+
 	// Pertinent data from votes.
 	SignerThresholdIDs []string `json:"signer_threshold_ids"`
 	SignerSignatures   []string `json:"signer_signatures"`
@@ -277,9 +277,9 @@ func (z *expirationQueue) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *proposal) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 9
+	// map header, size 10
 	// string "ProposalID"
-	o = append(o, 0x89, 0xaa, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x49, 0x44)
+	o = append(o, 0x8a, 0xaa, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ProposalID)
 	// string "ExpirationDate"
 	o = append(o, 0xae, 0x45, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65)
@@ -313,6 +313,17 @@ func (z *proposal) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "Transfer")
 		return
 	}
+	// string "Action"
+	o = append(o, 0xa6, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e)
+	if z.Action == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.Action.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Action")
+			return
+		}
+	}
 	// string "SignerThresholdIDs"
 	o = append(o, 0xb2, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x49, 0x44, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.SignerThresholdIDs)))
@@ -440,6 +451,23 @@ func (z *proposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "Transfer")
 				return
 			}
+		case "Action":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Action = nil
+			} else {
+				if z.Action == nil {
+					z.Action = new(Action)
+				}
+				bts, err = z.Action.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Action")
+					return
+				}
+			}
 		case "SignerThresholdIDs":
 			var zb0004 uint32
 			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
@@ -504,7 +532,13 @@ func (z *proposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *proposal) Msgsize() (s int) {
-	s = 1 + 11 + msgp.StringPrefixSize + len(z.ProposalID) + 15 + z.ExpirationDate.Msgsize() + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Next.ClientID) + 11 + msgp.StringPrefixSize + len(z.Next.ProposalID) + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Prev.ClientID) + 11 + msgp.StringPrefixSize + len(z.Prev.ProposalID) + 9 + z.Transfer.Msgsize() + 19 + msgp.ArrayHeaderSize
+	s = 1 + 11 + msgp.StringPrefixSize + len(z.ProposalID) + 15 + z.ExpirationDate.Msgsize() + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Next.ClientID) + 11 + msgp.StringPrefixSize + len(z.Next.ProposalID) + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Prev.ClientID) + 11 + msgp.StringPrefixSize + len(z.Prev.ProposalID) + 9 + z.Transfer.Msgsize() + 7
+	if z.Action == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.Action.Msgsize()
+	}
+	s += 19 + msgp.ArrayHeaderSize
 	for za0001 := range z.SignerThresholdIDs {
 		s += msgp.StringPrefixSize + len(z.SignerThresholdIDs[za0001])
 	}
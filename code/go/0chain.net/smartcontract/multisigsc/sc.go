@@ -20,11 +20,12 @@ import (
 )
 
 const (
-	name             = "multisig"
-	Address          = "27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7"
-	RegisterFuncName = "register"
-	VoteFuncName     = "vote"
-	LogTimingInfo    = false
+	name               = "multisig"
+	Address            = "27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7"
+	RegisterFuncName   = "register"
+	VoteFuncName       = "vote"
+	VoteActionFuncName = "vote_action"
+	LogTimingInfo      = false
 )
 
 type MultiSigSmartContract struct {
@@ -74,6 +75,8 @@ func (ms *MultiSigSmartContract) Execute(t *transaction.Transaction, funcName st
 		return ms.register(t.ClientID, inputData, balances)
 	case VoteFuncName:
 		return ms.vote(t.Hash, t.ClientID, balances.GetBlock().CreationDate, inputData, balances)
+	case VoteActionFuncName:
+		return ms.voteAction(t, balances.GetBlock().CreationDate, inputData, balances)
 	default:
 		return "err_execute_function_not_found: no multi sig smart contract function with that name: " + funcName, nil
 	}
@@ -451,7 +454,7 @@ func (ms MultiSigSmartContract) walletExists(clientID string, balances c_state.S
 	}
 }
 
-func (ms MultiSigSmartContract) getWallet(clientID string, balances c_state.StateContextI) (Wallet, error) {
+func (ms MultiSigSmartContract) getWallet(clientID string, balances c_state.CommonStateContextI) (Wallet, error) {
 
 	w := Wallet{}
 	err := balances.GetTrieNode(getWalletKey(clientID), &w)
@@ -474,7 +477,7 @@ func (ms MultiSigSmartContract) putWallet(w Wallet, balances c_state.StateContex
 	return err
 }
 
-func (ms MultiSigSmartContract) getProposal(ref proposalRef, balances c_state.StateContextI) (proposal, error) {
+func (ms MultiSigSmartContract) getProposal(ref proposalRef, balances c_state.CommonStateContextI) (proposal, error) {
 	p := proposal{}
 	err := balances.GetTrieNode(getProposalKey(ref.ClientID, ref.ProposalID), &p)
 	switch err {
@@ -495,7 +498,7 @@ func (ms MultiSigSmartContract) putProposal(p *proposal, balances c_state.StateC
 	return err
 }
 
-func (ms MultiSigSmartContract) getOrCreateExpirationQueue(balances c_state.StateContextI) (expirationQueue, error) {
+func (ms MultiSigSmartContract) getOrCreateExpirationQueue(balances c_state.CommonStateContextI) (expirationQueue, error) {
 	q := expirationQueue{}
 	err := balances.GetTrieNode(getExpirationQueueKey(), &q)
 	switch err {
@@ -1,14 +1,26 @@
 package rest
 
 import (
+	"bytes"
+	"context"
 	"net/http"
+	"strconv"
 
 	"0chain.net/chaincore/chain/state"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs/event"
 )
 
+// RoundParam - the query parameter selecting the round of the state an
+// endpoint is evaluated against, the latest finalized round by default
+const RoundParam = "round"
+
 type Endpoint struct {
 	URI     string
 	Handler func(w http.ResponseWriter, r *http.Request)
+	// OwnRoundParam - the endpoint uses the round query parameter itself,
+	// it's always evaluated against the latest state
+	OwnRoundParam bool
 }
 
 func MakeEndpoint(uri string, f func(w http.ResponseWriter, r *http.Request)) Endpoint {
@@ -18,6 +30,16 @@ func MakeEndpoint(uri string, f func(w http.ResponseWriter, r *http.Request)) En
 	}
 }
 
+// MakeOwnRoundParamEndpoint - make an endpoint which has its own meaning of
+// the round query parameter.
+func MakeOwnRoundParamEndpoint(uri string, f func(w http.ResponseWriter, r *http.Request)) Endpoint {
+	return Endpoint{
+		URI:           uri,
+		Handler:       f,
+		OwnRoundParam: true,
+	}
+}
+
 // swagger:model Int64Map
 type Int64Map map[string]int64
 
@@ -29,9 +51,20 @@ type QueryChainer interface {
 	SetQueryStateContext(state.TimedQueryStateContextI)
 }
 
+// RoundQueryChainer - a QueryChainer which can also evaluate the queries
+// against the state of past rounds.
+type RoundQueryChainer interface {
+	QueryChainer
+	GetQueryStateContextAtRound(ctx context.Context, round int64) (state.TimedQueryStateContextI, error)
+}
+
+// EndpointsGetter - makes the endpoints of a smart contract using given
+// rest handler.
+type EndpointsGetter func(RestHandlerI) []Endpoint
+
 type RestHandlerI interface {
 	QueryChainer
-	Register([]Endpoint)
+	Register(EndpointsGetter)
 }
 
 type TestQueryChainer struct {
@@ -54,12 +87,121 @@ func NewRestHandler(c QueryChainer) RestHandlerI {
 	return &RestHandler{QueryChainer: c}
 }
 
-func (rh *RestHandler) Register(endpoints []Endpoint) {
-	for _, e := range endpoints {
-		http.HandleFunc(e.URI, WithCORS(e.Handler))
+// Register - register the endpoints, the endpoints accept the round
+// parameter if the QueryChainer can query the state of past rounds.
+func (rh *RestHandler) Register(getEndpoints EndpointsGetter) {
+	rqc, withRound := rh.QueryChainer.(RoundQueryChainer)
+	for _, e := range getEndpoints(rh) {
+		handler := e.Handler
+		if withRound && !e.OwnRoundParam {
+			handler = WithStateRound(rqc, getEndpoints, e.URI, e.Handler)
+		}
+		http.HandleFunc(e.URI, WithCORS(handler))
 	}
 }
 
+// WithStateRound - evaluate the endpoint of given URI against the state of
+// the round given by the round query parameter. The endpoints are made
+// for the state of the round, the latest handler serves requests without
+// the round parameter. The events database only has the latest data, so the
+// requests of endpoints served from it are rejected.
+func WithStateRound(rqc RoundQueryChainer, getEndpoints EndpointsGetter, uri string,
+	latest func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		rs := r.URL.Query().Get(RoundParam)
+		if rs == "" {
+			latest(w, r)
+			return
+		}
+		round, err := strconv.ParseInt(rs, 10, 64)
+		if err != nil || round <= 0 {
+			common.Respond(w, r, nil, common.NewErrBadRequest("invalid round: "+rs))
+			return
+		}
+		sctx, err := rqc.GetQueryStateContextAtRound(r.Context(), round)
+		if err != nil {
+			common.Respond(w, r, nil, common.NewErrBadRequest(err.Error()))
+			return
+		}
+		rsctx := &roundStateContext{TimedQueryStateContextI: sctx}
+		for _, e := range getEndpoints(&RestHandler{QueryChainer: &stateQueryChainer{sctx: rsctx}}) {
+			if e.URI != uri {
+				continue
+			}
+			// the response is held back until it's known the endpoint
+			// doesn't use the events database
+			br := &bufferedResponse{header: make(http.Header)}
+			e.Handler(br, r)
+			if rsctx.eventDBUsed {
+				common.Respond(w, r, nil, common.NewErrBadRequest(
+					"the endpoint serves the latest data only, the round parameter is not supported"))
+				return
+			}
+			br.writeTo(w)
+			return
+		}
+		common.Respond(w, r, nil, common.NewErrNoResource("unknown endpoint: "+uri))
+	}
+}
+
+// roundStateContext - the state context of a past round. It has no events
+// database, the use of it is recorded to reject the request.
+type roundStateContext struct {
+	state.TimedQueryStateContextI
+	eventDBUsed bool
+}
+
+func (sc *roundStateContext) GetEventDB() *event.EventDb {
+	sc.eventDBUsed = true
+	return nil
+}
+
+// bufferedResponse - a response writer holding the response back until it's
+// written to the actual response writer.
+type bufferedResponse struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func (br *bufferedResponse) Header() http.Header {
+	return br.header
+}
+
+func (br *bufferedResponse) Write(p []byte) (int, error) {
+	return br.body.Write(p)
+}
+
+func (br *bufferedResponse) WriteHeader(code int) {
+	if br.code == 0 {
+		br.code = code
+	}
+}
+
+func (br *bufferedResponse) writeTo(w http.ResponseWriter) {
+	for k, v := range br.header {
+		w.Header()[k] = v
+	}
+	if br.code != 0 {
+		w.WriteHeader(br.code)
+	}
+	_, _ = w.Write(br.body.Bytes())
+}
+
+// stateQueryChainer - queries a fixed state.
+type stateQueryChainer struct {
+	sctx state.TimedQueryStateContextI
+}
+
+func (qc *stateQueryChainer) GetQueryStateContext() state.TimedQueryStateContextI {
+	return qc.sctx
+}
+
+func (qc *stateQueryChainer) SetQueryStateContext(sctx state.TimedQueryStateContextI) {
+	qc.sctx = sctx
+}
+
 // WithCORS enable CORS
 func WithCORS(fn func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"0chain.net/chaincore/chain/state"
+	"0chain.net/core/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const testLatestRound = 10
+
+type testRoundQueryChainer struct {
+	TestQueryChainer
+}
+
+// the state of a round is the one whose Now is the round
+func (qc *testRoundQueryChainer) GetQueryStateContextAtRound(_ context.Context, round int64) (
+	state.TimedQueryStateContextI, error) {
+
+	if round > testLatestRound {
+		return nil, errors.New("round is not finalized")
+	}
+	return state.NewTimedQueryStateContext(nil, func() common.Timestamp {
+		return common.Timestamp(round)
+	}), nil
+}
+
+func testNowEndpoints(rh RestHandlerI) []Endpoint {
+	return []Endpoint{
+		MakeEndpoint("/now", func(w http.ResponseWriter, r *http.Request) {
+			common.Respond(w, r, rh.GetQueryStateContext().Now(), nil)
+		}),
+		MakeEndpoint("/events", func(w http.ResponseWriter, r *http.Request) {
+			if rh.GetQueryStateContext().GetEventDB() == nil {
+				common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+				return
+			}
+			common.Respond(w, r, "events", nil)
+		}),
+	}
+}
+
+func TestWithStateRound(t *testing.T) {
+	rqc := &testRoundQueryChainer{}
+	rqc.SetQueryStateContext(state.NewTimedQueryStateContext(nil, func() common.Timestamp {
+		return testLatestRound
+	}))
+	latest := testNowEndpoints(&RestHandler{QueryChainer: rqc})[0].Handler
+	handlers := map[string]func(w http.ResponseWriter, r *http.Request){
+		"/now":    WithStateRound(rqc, testNowEndpoints, "/now", latest),
+		"/events": WithStateRound(rqc, testNowEndpoints, "/events", nil),
+	}
+
+	tests := []struct {
+		name     string
+		uri      string
+		query    string
+		wantCode int
+		wantBody string
+	}{
+		{name: "latest", uri: "/now", query: "", wantCode: http.StatusOK, wantBody: "10\n"},
+		{name: "past round", uri: "/now", query: "?round=5", wantCode: http.StatusOK, wantBody: "5\n"},
+		{name: "invalid round", uri: "/now", query: "?round=abc", wantCode: http.StatusBadRequest},
+		{name: "negative round", uri: "/now", query: "?round=-1", wantCode: http.StatusBadRequest},
+		{name: "future round", uri: "/now", query: "?round=11", wantCode: http.StatusBadRequest},
+		{name: "events database", uri: "/events", query: "?round=5", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handlers[tt.uri](w, httptest.NewRequest(http.MethodGet, tt.uri+tt.query, nil))
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
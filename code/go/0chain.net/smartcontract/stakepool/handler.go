@@ -0,0 +1,184 @@
+package stakepool
+
+import (
+	"fmt"
+	"net/http"
+
+	"0chain.net/core/common"
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/rest"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+// providerTypesParam parses the provider_type query parameter; an empty
+// parameter selects all the provider types of the smart contract.
+func providerTypesParam(r *http.Request, allowed []spenum.Provider) ([]spenum.Provider, error) {
+	name := r.URL.Query().Get("provider_type")
+	if name == "" {
+		return allowed, nil
+	}
+	providerType, err := spenum.ProviderFromString(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range allowed {
+		if p == providerType {
+			return []spenum.Provider{providerType}, nil
+		}
+	}
+	return nil, fmt.Errorf("provider type %s is not served by the smart contract", name)
+}
+
+// GetProviderHandler returns the handler of the provider endpoint of a smart
+// contract serving the given provider types; the provider_type and the
+// provider_id query parameters are required.
+func GetProviderHandler(rh rest.RestHandlerI, allowed ...spenum.Provider) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		providerID := r.URL.Query().Get("provider_id")
+		if providerID == "" {
+			common.Respond(w, r, nil, common.NewErrBadRequest("no provider id"))
+			return
+		}
+		if r.URL.Query().Get("provider_type") == "" {
+			common.Respond(w, r, nil, common.NewErrBadRequest("no provider type"))
+			return
+		}
+		types, err := providerTypesParam(r, allowed)
+		if err != nil {
+			common.Respond(w, r, nil, common.NewErrBadRequest(err.Error()))
+			return
+		}
+		edb := rh.GetQueryStateContext().GetEventDB()
+		if edb == nil {
+			common.Respond(w, r, nil, common.NewErrNoResource("db not initialized"))
+			return
+		}
+		provider, err := edb.GetProvider(int(types[0]), providerID)
+		if err != nil {
+			common.Respond(w, r, nil, common.NewErrInternal("can't find provider", err.Error()))
+			return
+		}
+		common.Respond(w, r, provider, nil)
+	}
+}
+
+// GetProvidersHandler returns the handler of the providers list endpoint of
+// a smart contract serving the given provider types, filtered by the optional
+// provider_type and status (active, shut_down or killed) query parameters and
+// paginated by the offset, limit and sort ones.
+func GetProvidersHandler(rh rest.RestHandlerI, allowed ...spenum.Provider) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+		if err != nil {
+			common.Respond(w, r, nil, err)
+			return
+		}
+		types, err := providerTypesParam(r, allowed)
+		if err != nil {
+			common.Respond(w, r, nil, common.NewErrBadRequest(err.Error()))
+			return
+		}
+		filter := event.ProviderQuery{Status: r.URL.Query().Get("status")}
+		for _, t := range types {
+			filter.ProviderTypes = append(filter.ProviderTypes, int(t))
+		}
+		edb := rh.GetQueryStateContext().GetEventDB()
+		if edb == nil {
+			common.Respond(w, r, nil, common.NewErrNoResource("db not initialized"))
+			return
+		}
+		providers, err := edb.GetProviders(filter, limit)
+		if err != nil {
+			common.Respond(w, r, nil, common.NewErrInternal("can't get providers", err.Error()))
+			return
+		}
+		common.Respond(w, r, providers, nil)
+	}
+}
+
+// GetSlashesHandler returns the handler of the slashes list endpoint of a
+// smart contract serving the given provider types, filtered by the optional
+// provider_id, provider_type and reason query parameters and paginated by the
+// offset, limit and sort ones.
+func GetSlashesHandler(rh rest.RestHandlerI, allowed ...spenum.Provider) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+		if err != nil {
+			common.Respond(w, r, nil, err)
+			return
+		}
+		types, err := providerTypesParam(r, allowed)
+		if err != nil {
+			common.Respond(w, r, nil, common.NewErrBadRequest(err.Error()))
+			return
+		}
+		filter := event.SlashQuery{
+			ProviderID: r.URL.Query().Get("provider_id"),
+			Reason:     r.URL.Query().Get("reason"),
+		}
+		for _, t := range types {
+			filter.ProviderTypes = append(filter.ProviderTypes, int(t))
+		}
+		edb := rh.GetQueryStateContext().GetEventDB()
+		if edb == nil {
+			common.Respond(w, r, nil, common.NewErrNoResource("db not initialized"))
+			return
+		}
+		slashes, err := edb.GetSlashes(filter, limit)
+		if err != nil {
+			common.Respond(w, r, nil, common.NewErrInternal("can't get slashes", err.Error()))
+			return
+		}
+		common.Respond(w, r, slashes, nil)
+	}
+}
+
+// GetUnbondingsHandler returns the handler of the unbonding entries list
+// endpoint of a smart contract serving the given provider types, filtered by
+// the optional client_id, provider_id, provider_type and status (pending,
+// matured or claimed) query parameters and paginated by the offset, limit and
+// sort ones.
+func GetUnbondingsHandler(rh rest.RestHandlerI, allowed ...spenum.Provider) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+		if err != nil {
+			common.Respond(w, r, nil, err)
+			return
+		}
+		types, err := providerTypesParam(r, allowed)
+		if err != nil {
+			common.Respond(w, r, nil, common.NewErrBadRequest(err.Error()))
+			return
+		}
+		filter := event.UnbondingQuery{
+			ClientID:   r.URL.Query().Get("client_id"),
+			ProviderID: r.URL.Query().Get("provider_id"),
+			Status:     r.URL.Query().Get("status"),
+		}
+		switch filter.Status {
+		case "", event.UnbondingPending, event.UnbondingMatured, event.UnbondingClaimed:
+		default:
+			common.Respond(w, r, nil, common.NewErrBadRequest("invalid status: "+filter.Status))
+			return
+		}
+		for _, t := range types {
+			filter.ProviderTypes = append(filter.ProviderTypes, int(t))
+		}
+		sctx := rh.GetQueryStateContext()
+		if b := sctx.GetBlock(); b != nil {
+			filter.Round = b.Round
+		}
+		edb := sctx.GetEventDB()
+		if edb == nil {
+			common.Respond(w, r, nil, common.NewErrNoResource("db not initialized"))
+			return
+		}
+		unbondings, err := edb.GetUnbondings(filter, limit)
+		if err != nil {
+			common.Respond(w, r, nil, common.NewErrInternal("can't get unbonding entries", err.Error()))
+			return
+		}
+		common.Respond(w, r, unbondings, nil)
+	}
+}
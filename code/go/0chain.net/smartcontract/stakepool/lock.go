@@ -48,6 +48,10 @@ func (sp *StakePool) LockPool(
 	status spenum.PoolStatus,
 	balances cstate.StateContextI,
 ) error {
+	if sp.ProviderStatus != spenum.ProviderActive {
+		return fmt.Errorf("can't stake to %s provider %s", sp.ProviderStatus, providerId)
+	}
+
 	if err := CheckClientBalance(txn.ClientID, txn.Value, balances); err != nil {
 		return err
 	}
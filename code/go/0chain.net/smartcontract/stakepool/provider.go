@@ -0,0 +1,232 @@
+package stakepool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/currency"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+// Provider is a node of a smart contract staked by the delegate pools of
+// its stake pool: a miner, a sharder, a blobber, a validator or an
+// authorizer. The smart contracts implement it to share the providers
+// lifecycle: registration, settings update, shut down, kill and rewards
+// collection.
+type Provider interface {
+	Id() string
+	Type() spenum.Provider
+	GetStakePool() *StakePool
+	// SaveProvider saves the provider with its stake pool.
+	SaveProvider(balances cstate.StateContextI) error
+}
+
+// GetProviderFunc loads a provider of a smart contract.
+type GetProviderFunc func(providerType spenum.Provider, id string,
+	balances cstate.StateContextI) (Provider, error)
+
+// ProviderRequest identifies the provider to shut down or kill.
+type ProviderRequest struct {
+	ProviderID   string          `json:"provider_id"`
+	ProviderType spenum.Provider `json:"provider_type"`
+}
+
+func (pr *ProviderRequest) Decode(p []byte) error {
+	return json.Unmarshal(p, pr)
+}
+
+func (pr *ProviderRequest) Encode() []byte {
+	bytes, _ := json.Marshal(pr)
+	return bytes
+}
+
+// SettingsLimits are the bounds of the stake pool settings of the providers
+// configured by a smart contract, zero max values are not checked.
+type SettingsLimits struct {
+	MinStake         currency.Coin
+	MaxStake         currency.Coin
+	MaxNumDelegates  int
+	MaxServiceCharge float64
+}
+
+// Validate checks the settings are within the limits.
+func (sl *SettingsLimits) Validate(s Settings) error {
+	if s.ServiceChargeRatio < 0 {
+		return errors.New("negative service charge")
+	}
+	if sl.MaxServiceCharge > 0 && s.ServiceChargeRatio > sl.MaxServiceCharge {
+		return fmt.Errorf("service_charge (%f) is greater than max allowed by SC (%f)",
+			s.ServiceChargeRatio, sl.MaxServiceCharge)
+	}
+	if s.MaxNumDelegates <= 0 {
+		return errors.New("num_delegates <= 0")
+	}
+	if sl.MaxNumDelegates > 0 && s.MaxNumDelegates > sl.MaxNumDelegates {
+		return fmt.Errorf("num_delegates is greater than allowed by SC: %v > %v",
+			s.MaxNumDelegates, sl.MaxNumDelegates)
+	}
+	if s.MinStake < sl.MinStake {
+		return fmt.Errorf("min_stake is less than allowed by SC: %v < %v",
+			s.MinStake, sl.MinStake)
+	}
+	if s.MaxStake < s.MinStake {
+		return fmt.Errorf("max_stake less than min_stake: %v < %v",
+			s.MaxStake, s.MinStake)
+	}
+	if sl.MaxStake > 0 && s.MaxStake > sl.MaxStake {
+		return fmt.Errorf("max_stake is greater than allowed by SC: %v > %v",
+			s.MaxStake, sl.MaxStake)
+	}
+	return nil
+}
+
+// IsActive reports whether the provider is neither shut down nor killed.
+func IsActive(p Provider) bool {
+	return p.GetStakePool().ProviderStatus == spenum.ProviderActive
+}
+
+// RegisterProvider validates and sets the stake pool settings of a new or
+// re-added provider and saves it; the delegate wallet of a registered stake
+// pool is kept and a stopped provider can't be registered again.
+func RegisterProvider(p Provider, settings Settings, limits *SettingsLimits,
+	balances cstate.StateContextI) error {
+
+	sp := p.GetStakePool()
+	if !IsActive(p) {
+		return fmt.Errorf("can't register %s provider", sp.ProviderStatus)
+	}
+	if len(sp.Settings.DelegateWallet) > 0 {
+		settings.DelegateWallet = sp.Settings.DelegateWallet
+	}
+	if err := limits.Validate(settings); err != nil {
+		return err
+	}
+	sp.Settings = settings
+	return saveProvider(p, balances)
+}
+
+// UpdateProviderSettings updates the stake pool settings of an active
+// provider, the caller is authorized by the smart contract and the delegate
+// wallet can't be changed.
+func UpdateProviderSettings(p Provider, settings Settings,
+	limits *SettingsLimits, balances cstate.StateContextI) error {
+
+	sp := p.GetStakePool()
+	if !IsActive(p) {
+		return fmt.Errorf("can't update settings of %s provider", sp.ProviderStatus)
+	}
+	settings.DelegateWallet = sp.Settings.DelegateWallet
+	if err := limits.Validate(settings); err != nil {
+		return err
+	}
+	sp.Settings = settings
+	return saveProvider(p, balances)
+}
+
+// ShutDownProvider stops the provider by the provider itself or by its
+// delegate wallet.
+func ShutDownProvider(clientID string, p Provider, balances cstate.StateContextI) error {
+	if clientID != p.Id() && clientID != p.GetStakePool().Settings.DelegateWallet {
+		return errors.New("access denied, allowed for the provider or its delegate_wallet owner only")
+	}
+	return stopProvider(p, spenum.ProviderShutDown, balances)
+}
+
+// KillProvider stops the provider, the caller is authorized by the smart
+// contract.
+func KillProvider(p Provider, balances cstate.StateContextI) error {
+	return stopProvider(p, spenum.ProviderKilled, balances)
+}
+
+// stopProvider sets the status of a stopped provider; it doesn't accept new
+// stakes anymore and its delegates unlock their stakes as usual.
+func stopProvider(p Provider, status spenum.ProviderStatus,
+	balances cstate.StateContextI) error {
+
+	sp := p.GetStakePool()
+	switch sp.ProviderStatus {
+	case spenum.ProviderKilled:
+		return errors.New("provider is already killed")
+	case status:
+		return fmt.Errorf("provider is already %s", status)
+	}
+	sp.ProviderStatus = status
+	return saveProvider(p, balances)
+}
+
+// CollectReward mints the rewards of the delegate pool of the client, and
+// the service charge if the client is the delegate wallet of the provider;
+// it returns the provider and the minted amount.
+func CollectReward(clientID string, prr *CollectRewardRequest,
+	getProvider GetProviderFunc, balances cstate.StateContextI) (Provider, currency.Coin, error) {
+
+	var (
+		usp        *UserStakePools
+		providerID = prr.ProviderId
+		err        error
+	)
+	if len(prr.PoolId) > 0 {
+		usp, err = GetUserStakePools(prr.ProviderType, clientID, balances)
+		if err != nil {
+			return nil, 0, fmt.Errorf("can't get related user stake pools: %v", err)
+		}
+		if len(providerID) == 0 {
+			providerID = usp.FindProvider(prr.PoolId)
+		}
+	}
+	if len(providerID) == 0 {
+		return nil, 0, fmt.Errorf("user %v does not own stake pool %v", clientID, prr.PoolId)
+	}
+
+	p, err := getProvider(prr.ProviderType, providerID, balances)
+	if err != nil {
+		return nil, 0, fmt.Errorf("can't get related stake pool: %v", err)
+	}
+	sp := p.GetStakePool()
+
+	reward, err := sp.MintRewards(clientID, prr.PoolId, providerID, prr.ProviderType, usp, balances)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error emptying account, %v", err)
+	}
+	if usp != nil {
+		if err := usp.Save(prr.ProviderType, clientID, balances); err != nil {
+			return nil, 0, fmt.Errorf("error saving user stake pool, %v", err)
+		}
+	}
+	if err := p.SaveProvider(balances); err != nil {
+		return nil, 0, fmt.Errorf("error saving stake pool, %v", err)
+	}
+	return p, reward, nil
+}
+
+func saveProvider(p Provider, balances cstate.StateContextI) error {
+	if err := p.SaveProvider(balances); err != nil {
+		return err
+	}
+	return EmitProvider(p, balances)
+}
+
+// EmitProvider emits the common representation of the provider to the
+// event database.
+func EmitProvider(p Provider, balances cstate.StateContextI) error {
+	sp := p.GetStakePool()
+	staked, err := sp.stake()
+	if err != nil {
+		return err
+	}
+	balances.EmitEvent(event.TypeStats, event.TagAddOrOverwriteProvider, p.Id(), event.Provider{
+		ProviderID:     p.Id(),
+		ProviderType:   int(p.Type()),
+		DelegateWallet: sp.Settings.DelegateWallet,
+		MinStake:       sp.Settings.MinStake,
+		MaxStake:       sp.Settings.MaxStake,
+		NumDelegates:   sp.Settings.MaxNumDelegates,
+		ServiceCharge:  sp.Settings.ServiceChargeRatio,
+		TotalStake:     staked,
+		Status:         sp.ProviderStatus.String(),
+	})
+	return nil
+}
@@ -0,0 +1,148 @@
+package stakepool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+type testProvider struct {
+	id string
+	sp *StakePool
+}
+
+func (tp *testProvider) Id() string               { return tp.id }
+func (tp *testProvider) Type() spenum.Provider    { return spenum.Blobber }
+func (tp *testProvider) GetStakePool() *StakePool { return tp.sp }
+func (tp *testProvider) SaveProvider(balances cstate.StateContextI) error {
+	return tp.sp.Save(tp.Type(), tp.id, balances)
+}
+
+func TestSettingsLimits_Validate(t *testing.T) {
+	limits := SettingsLimits{
+		MinStake:         10,
+		MaxStake:         100,
+		MaxNumDelegates:  5,
+		MaxServiceCharge: 0.5,
+	}
+	valid := Settings{
+		DelegateWallet:     "wallet",
+		MinStake:           10,
+		MaxStake:           100,
+		MaxNumDelegates:    5,
+		ServiceChargeRatio: 0.5,
+	}
+
+	tests := []struct {
+		name    string
+		update  func(s *Settings)
+		wantErr string
+	}{
+		{
+			name:   "ok",
+			update: func(s *Settings) {},
+		},
+		{
+			name:    "negative_service_charge",
+			update:  func(s *Settings) { s.ServiceChargeRatio = -0.1 },
+			wantErr: "negative service charge",
+		},
+		{
+			name:    "service_charge_too_high",
+			update:  func(s *Settings) { s.ServiceChargeRatio = 0.6 },
+			wantErr: "service_charge",
+		},
+		{
+			name:    "no_delegates",
+			update:  func(s *Settings) { s.MaxNumDelegates = 0 },
+			wantErr: "num_delegates <= 0",
+		},
+		{
+			name:    "too_many_delegates",
+			update:  func(s *Settings) { s.MaxNumDelegates = 6 },
+			wantErr: "num_delegates is greater than allowed by SC",
+		},
+		{
+			name:    "min_stake_too_low",
+			update:  func(s *Settings) { s.MinStake = 9 },
+			wantErr: "min_stake is less than allowed by SC",
+		},
+		{
+			name:    "max_stake_less_than_min_stake",
+			update:  func(s *Settings) { s.MinStake, s.MaxStake = 50, 20 },
+			wantErr: "max_stake less than min_stake",
+		},
+		{
+			name:    "max_stake_too_high",
+			update:  func(s *Settings) { s.MaxStake = 101 },
+			wantErr: "max_stake is greater than allowed by SC",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid
+			tt.update(&s)
+			err := limits.Validate(s)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestProviderLifecycle(t *testing.T) {
+	var (
+		balances = newTestBalances(t, false)
+		p        = &testProvider{id: "provider", sp: NewStakePool()}
+		limits   = &SettingsLimits{MaxNumDelegates: 10, MaxServiceCharge: 1}
+		settings = Settings{
+			DelegateWallet:  "wallet",
+			MaxStake:        100,
+			MaxNumDelegates: 5,
+		}
+	)
+	require.NoError(t, RegisterProvider(p, settings, limits, balances))
+	require.True(t, IsActive(p))
+
+	settings.MaxNumDelegates = 7
+	settings.DelegateWallet = "other"
+	require.NoError(t, UpdateProviderSettings(p, settings, limits, balances))
+	require.Equal(t, 7, p.sp.Settings.MaxNumDelegates)
+	require.Equal(t, "wallet", p.sp.Settings.DelegateWallet)
+
+	settings.MaxNumDelegates = 11
+	require.Error(t, UpdateProviderSettings(p, settings, limits, balances))
+	require.Equal(t, 7, p.sp.Settings.MaxNumDelegates)
+	settings.MaxNumDelegates = 7
+
+	sp, err := GetStakePool(spenum.Blobber, p.id, balances)
+	require.NoError(t, err)
+	require.Equal(t, 7, sp.Settings.MaxNumDelegates)
+
+	require.Error(t, ShutDownProvider("other", p, balances))
+	require.NoError(t, ShutDownProvider("wallet", p, balances))
+	require.False(t, IsActive(p))
+	require.Error(t, ShutDownProvider("wallet", p, balances))
+	require.Error(t, UpdateProviderSettings(p, settings, limits, balances))
+	require.Error(t, RegisterProvider(p, settings, limits, balances))
+
+	txn := &transaction.Transaction{ClientID: "delegate", Value: 10}
+	err = p.sp.LockPool(txn, spenum.Blobber, p.id, spenum.Active, balances)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "can't stake to shut_down provider")
+
+	require.NoError(t, KillProvider(p, balances))
+	require.Equal(t, spenum.ProviderKilled, p.sp.ProviderStatus)
+	require.Error(t, KillProvider(p, balances))
+
+	sp, err = GetStakePool(spenum.Blobber, p.id, balances)
+	require.NoError(t, err)
+	require.Equal(t, spenum.ProviderKilled, sp.ProviderStatus)
+}
@@ -0,0 +1,194 @@
+package stakepool
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/currency"
+	"0chain.net/chaincore/state"
+	"0chain.net/core/datastore"
+	"0chain.net/core/util"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+//go:generate msgp -io=false -tests=false -v
+//msgp:ignore Offence
+
+// Offence is a misbehaviour of a provider proven by an evidence verified by
+// a smart contract.
+type Offence struct {
+	// ID identifies the offence, an offence is slashed once.
+	ID string
+	// Reason is the kind of the offence, e.g. double_signing.
+	Reason string
+	// Reporter is the client submitted the evidence.
+	Reporter string
+	// Ratio is the part of the delegate pools balances slashed.
+	Ratio float64
+	// ReporterRatio is the part of the slashed tokens paid to the reporter,
+	// the rest is burnt.
+	ReporterRatio float64
+}
+
+// SlashRecord is the trace of a slashed offence kept by the smart contract.
+type SlashRecord struct {
+	OffenceID      string          `json:"offence_id"`
+	ProviderID     string          `json:"provider_id"`
+	ProviderType   spenum.Provider `json:"provider_type"`
+	Reason         string          `json:"reason"`
+	Reporter       string          `json:"reporter"`
+	Round          int64           `json:"round"`
+	Amount         currency.Coin   `json:"amount"`
+	ReporterReward currency.Coin   `json:"reporter_reward"`
+}
+
+func slashRecordKey(sscID, offenceID string) datastore.Key {
+	return sscID + ":slash:" + offenceID
+}
+
+// GetSlashRecord returns the record of a slashed offence.
+func GetSlashRecord(sscID, offenceID string, balances cstate.StateContextI) (*SlashRecord, error) {
+	var sr SlashRecord
+	if err := balances.GetTrieNode(slashRecordKey(sscID, offenceID), &sr); err != nil {
+		return nil, err
+	}
+	return &sr, nil
+}
+
+// Slash removes the ratio of the balance of every delegate pool; it returns
+// the total slashed and the slashed amounts by pool.
+func (sp *StakePool) Slash(ratio float64) (currency.Coin, map[string]currency.Coin, error) {
+	if ratio < 0 || ratio > 1 {
+		return 0, nil, fmt.Errorf("invalid slash ratio %v", ratio)
+	}
+	var (
+		total   currency.Coin
+		slashed = make(map[string]currency.Coin)
+	)
+	for id, dp := range sp.Pools {
+		dpSlash, err := currency.MultFloat64(dp.Balance, ratio)
+		if err != nil {
+			return 0, nil, err
+		}
+		if dpSlash == 0 {
+			continue
+		}
+		if dp.Balance, err = currency.MinusCoin(dp.Balance, dpSlash); err != nil {
+			return 0, nil, err
+		}
+		if total, err = currency.AddCoin(total, dpSlash); err != nil {
+			return 0, nil, err
+		}
+		slashed[id] = dpSlash
+	}
+	return total, slashed, nil
+}
+
+// EmitSlash emits the slash of a provider and the new balances of its
+// slashed delegate pools.
+func (sp *StakePool) EmitSlash(sr *SlashRecord, slashed map[string]currency.Coin,
+	balances cstate.StateContextI) error {
+
+	ids := make([]string, 0, len(slashed))
+	for id := range slashed {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		dp, ok := sp.Pools[id]
+		if !ok {
+			continue
+		}
+		dpUpdate := newDelegatePoolUpdate(id, sr.ProviderID, sr.ProviderType)
+		dpUpdate.Updates["balance"] = dp.Balance
+		if err := dpUpdate.emitUpdate(balances); err != nil {
+			return err
+		}
+	}
+
+	balances.EmitEvent(event.TypeStats, event.TagAddSlash, sr.ProviderID, event.Slash{
+		ProviderID:     sr.ProviderID,
+		ProviderType:   int(sr.ProviderType),
+		OffenceID:      sr.OffenceID,
+		Reason:         sr.Reason,
+		Reporter:       sr.Reporter,
+		Amount:         sr.Amount,
+		ReporterReward: sr.ReporterReward,
+		Round:          sr.Round,
+	})
+	return nil
+}
+
+// SlashProvider slashes the provider for the offence: the offence ratio of
+// every delegate pool and of every unbonding entry not claimed yet is taken,
+// the reporter ratio of it is paid to the reporter and the rest is burnt,
+// left in the smart contract balance without any owner. An offence is slashed once, the provider can't report itself.
+func SlashProvider(sscID string, p Provider, offence *Offence,
+	balances cstate.StateContextI) (*SlashRecord, error) {
+
+	if offence.ReporterRatio < 0 || offence.ReporterRatio > 1 {
+		return nil, fmt.Errorf("invalid reporter ratio %v", offence.ReporterRatio)
+	}
+
+	sp := p.GetStakePool()
+	if offence.Reporter == p.Id() || offence.Reporter == sp.Settings.DelegateWallet {
+		return nil, errors.New("the offender can't report itself")
+	}
+
+	key := slashRecordKey(sscID, offence.ID)
+	err := balances.GetTrieNode(key, &SlashRecord{})
+	switch {
+	case err == nil:
+		return nil, fmt.Errorf("offence %s is already slashed", offence.ID)
+	case !errors.Is(err, util.ErrValueNotPresent):
+		return nil, fmt.Errorf("can't get slash record: %v", err)
+	}
+
+	total, slashed, err := sp.Slash(offence.Ratio)
+	if err != nil {
+		return nil, err
+	}
+	unbonding, err := sp.slashUnbonding(sscID, offence.Ratio, balances)
+	if err != nil {
+		return nil, err
+	}
+	if total, err = currency.AddCoin(total, unbonding); err != nil {
+		return nil, err
+	}
+
+	reporterReward, err := currency.MultFloat64(total, offence.ReporterRatio)
+	if err != nil {
+		return nil, err
+	}
+	if reporterReward > 0 {
+		if err := balances.AddTransfer(state.NewTransfer(
+			sscID, offence.Reporter, reporterReward,
+		)); err != nil {
+			return nil, fmt.Errorf("paying reporter: %v", err)
+		}
+	}
+
+	sr := &SlashRecord{
+		OffenceID:      offence.ID,
+		ProviderID:     p.Id(),
+		ProviderType:   p.Type(),
+		Reason:         offence.Reason,
+		Reporter:       offence.Reporter,
+		Round:          balances.GetBlock().Round,
+		Amount:         total,
+		ReporterReward: reporterReward,
+	}
+	if _, err := balances.InsertTrieNode(key, sr); err != nil {
+		return nil, fmt.Errorf("saving slash record: %v", err)
+	}
+	if err := saveProvider(p, balances); err != nil {
+		return nil, fmt.Errorf("saving provider: %v", err)
+	}
+	if err := sp.EmitSlash(sr, slashed, balances); err != nil {
+		return nil, err
+	}
+	return sr, nil
+}
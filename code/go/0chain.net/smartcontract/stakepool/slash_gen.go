@@ -0,0 +1,134 @@
+package stakepool
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SlashRecord) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 8
+	// string "OffenceID"
+	o = append(o, 0x88, 0xa9, 0x4f, 0x66, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x44)
+	o = msgp.AppendString(o, z.OffenceID)
+	// string "ProviderID"
+	o = append(o, 0xaa, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ProviderID)
+	// string "ProviderType"
+	o = append(o, 0xac, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65)
+	o, err = z.ProviderType.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "ProviderType")
+		return
+	}
+	// string "Reason"
+	o = append(o, 0xa6, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e)
+	o = msgp.AppendString(o, z.Reason)
+	// string "Reporter"
+	o = append(o, 0xa8, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72)
+	o = msgp.AppendString(o, z.Reporter)
+	// string "Round"
+	o = append(o, 0xa5, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendInt64(o, z.Round)
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o, err = z.Amount.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	// string "ReporterReward"
+	o = append(o, 0xae, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64)
+	o, err = z.ReporterReward.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "ReporterReward")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SlashRecord) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "OffenceID":
+			z.OffenceID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "OffenceID")
+				return
+			}
+		case "ProviderID":
+			z.ProviderID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ProviderID")
+				return
+			}
+		case "ProviderType":
+			bts, err = z.ProviderType.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ProviderType")
+				return
+			}
+		case "Reason":
+			z.Reason, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Reason")
+				return
+			}
+		case "Reporter":
+			z.Reporter, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Reporter")
+				return
+			}
+		case "Round":
+			z.Round, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Round")
+				return
+			}
+		case "Amount":
+			bts, err = z.Amount.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		case "ReporterReward":
+			bts, err = z.ReporterReward.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReporterReward")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SlashRecord) Msgsize() (s int) {
+	s = 1 + 10 + msgp.StringPrefixSize + len(z.OffenceID) + 11 + msgp.StringPrefixSize + len(z.ProviderID) + 13 + z.ProviderType.Msgsize() + 7 + msgp.StringPrefixSize + len(z.Reason) + 9 + msgp.StringPrefixSize + len(z.Reporter) + 6 + msgp.Int64Size + 7 + z.Amount.Msgsize() + 15 + z.ReporterReward.Msgsize()
+	return
+}
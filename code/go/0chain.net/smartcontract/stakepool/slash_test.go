@@ -0,0 +1,166 @@
+package stakepool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/currency"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+func TestStakePool_Slash(t *testing.T) {
+	tests := []struct {
+		name      string
+		balances  map[string]currency.Coin
+		ratio     float64
+		wantTotal currency.Coin
+		wantLeft  map[string]currency.Coin
+		wantErr   bool
+	}{
+		{
+			name:      "ok",
+			balances:  map[string]currency.Coin{"p1": 100, "p2": 50},
+			ratio:     0.1,
+			wantTotal: 15,
+			wantLeft:  map[string]currency.Coin{"p1": 90, "p2": 45},
+		},
+		{
+			name:      "all",
+			balances:  map[string]currency.Coin{"p1": 100},
+			ratio:     1,
+			wantTotal: 100,
+			wantLeft:  map[string]currency.Coin{"p1": 0},
+		},
+		{
+			name:      "none",
+			balances:  map[string]currency.Coin{"p1": 100},
+			ratio:     0,
+			wantTotal: 0,
+			wantLeft:  map[string]currency.Coin{"p1": 100},
+		},
+		{
+			name:     "invalid_ratio",
+			balances: map[string]currency.Coin{"p1": 100},
+			ratio:    1.5,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp := NewStakePool()
+			for id, b := range tt.balances {
+				sp.Pools[id] = &DelegatePool{Balance: b}
+			}
+			total, slashed, err := sp.Slash(tt.ratio)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantTotal, total)
+			var sum currency.Coin
+			for id, dp := range sp.Pools {
+				require.Equal(t, tt.wantLeft[id], dp.Balance)
+				sum += slashed[id]
+			}
+			require.Equal(t, total, sum)
+		})
+	}
+}
+
+func TestSlashProvider(t *testing.T) {
+	const sscID = "ssc"
+	var (
+		balances = newTestBalances(t, false)
+		p        = &testProvider{id: "provider", sp: NewStakePool()}
+		offence  = &Offence{
+			ID:            "offence",
+			Reason:        "double_signing",
+			Reporter:      "reporter",
+			Ratio:         0.5,
+			ReporterRatio: 0.2,
+		}
+	)
+	balances.txn.ToClientID = sscID
+	balances.balances[sscID] = 200
+	p.sp.Pools["p1"] = &DelegatePool{Balance: 100}
+	p.sp.Pools["p2"] = &DelegatePool{Balance: 100}
+
+	sr, err := SlashProvider(sscID, p, offence, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, sr.Amount)
+	require.EqualValues(t, 20, sr.ReporterReward)
+	require.EqualValues(t, 20, balances.balances["reporter"])
+	require.EqualValues(t, 180, balances.balances[sscID])
+
+	sp, err := GetStakePool(spenum.Blobber, p.id, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 50, sp.Pools["p1"].Balance)
+	require.EqualValues(t, 50, sp.Pools["p2"].Balance)
+
+	saved, err := GetSlashRecord(sscID, offence.ID, balances)
+	require.NoError(t, err)
+	require.Equal(t, sr, saved)
+
+	_, err = SlashProvider(sscID, p, offence, balances)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already slashed")
+
+	offence.ID, offence.ReporterRatio = "other", 1.5
+	_, err = SlashProvider(sscID, p, offence, balances)
+	require.Error(t, err)
+
+	offence.ReporterRatio = 0.2
+	for _, reporter := range []string{p.id, "owner"} {
+		p.sp.Settings.DelegateWallet = "owner"
+		offence.Reporter = reporter
+		_, err = SlashProvider(sscID, p, offence, balances)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "can't report itself")
+	}
+}
+
+func TestSlashProvider_Unbonding(t *testing.T) {
+	const (
+		sscID    = "ssc"
+		clientID = "client"
+	)
+	var (
+		balances = newTestBalances(t, false)
+		p        = &testProvider{id: "provider", sp: NewStakePool()}
+		offence  = &Offence{
+			ID:            "offence",
+			Reason:        "double_signing",
+			Reporter:      "reporter",
+			Ratio:         0.5,
+			ReporterRatio: 0.2,
+		}
+	)
+	balances.txn.ToClientID = sscID
+	balances.balances[sscID] = 200
+	p.sp.Pools["p1"] = &DelegatePool{Balance: 100, DelegateID: clientID}
+	balances.block.Round, balances.txn.Hash = 1, "t1"
+	_, err := Unbond(sscID, clientID, p, &UnbondRequest{PoolID: "p1", Amount: 40}, 10, balances)
+	require.NoError(t, err)
+
+	sr, err := SlashProvider(sscID, p, offence, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 50, sr.Amount)
+	require.EqualValues(t, 10, sr.ReporterReward)
+
+	uq, err := GetUnbondingQueue(sscID, clientID, balances)
+	require.NoError(t, err)
+	require.Len(t, uq.Entries, 1)
+	require.EqualValues(t, 20, uq.Entries[0].Amount)
+
+	getProvider := func(spenum.Provider, string, cstate.StateContextI) (Provider, error) {
+		return p, nil
+	}
+	balances.block.Round = 11
+	claimed, err := ClaimUnbonded(sscID, clientID, getProvider, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 20, claimed)
+	require.Empty(t, p.sp.Unbonding)
+}
@@ -1,5 +1,7 @@
 package spenum
 
+import "fmt"
+
 //go:generate msgp -v -io=false -tests=false
 
 type Provider int
@@ -34,3 +36,30 @@ var poolString = []string{"active", "pending", "inactive", "unstaking", "deletin
 func (p PoolStatus) String() string {
 	return poolString[p]
 }
+
+// ProviderFromString returns the provider type of given name.
+func ProviderFromString(s string) (Provider, error) {
+	for i, name := range providerString {
+		if i > 0 && name == s {
+			return Provider(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown provider type: %s", s)
+}
+
+// ProviderStatus is the lifecycle status of a provider.
+type ProviderStatus int
+
+const (
+	ProviderActive ProviderStatus = iota
+	// ProviderShutDown - the provider is stopped by its delegate wallet
+	ProviderShutDown
+	// ProviderKilled - the provider is stopped by the smart contract owner
+	ProviderKilled
+)
+
+var providerStatusString = []string{"active", "shut_down", "killed"}
+
+func (ps ProviderStatus) String() string {
+	return providerStatusString[ps]
+}
@@ -34,6 +34,12 @@ type StakePool struct {
 	Reward   currency.Coin            `json:"rewards"`
 	Settings Settings                 `json:"settings"`
 	Minter   cstate.ApprovedMinter    `json:"minter"`
+	// ProviderStatus is the lifecycle status of the provider staked by
+	// the pool
+	ProviderStatus spenum.ProviderStatus `json:"provider_status"`
+	// Unbonding are the clients of the unbonding entries of the delegate
+	// pools by the entry id, the entries stay slashable until claimed
+	Unbonding map[string]string `json:"unbonding,omitempty"`
 }
 
 type Settings struct {
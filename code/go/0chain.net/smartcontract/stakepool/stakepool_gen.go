@@ -218,9 +218,9 @@ func (z *Settings) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *StakePool) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 4
+	// map header, size 6
 	// string "Pools"
-	o = append(o, 0x84, 0xa5, 0x50, 0x6f, 0x6f, 0x6c, 0x73)
+	o = append(o, 0x86, 0xa5, 0x50, 0x6f, 0x6f, 0x6c, 0x73)
 	o = msgp.AppendMapHeader(o, uint32(len(z.Pools)))
 	keys_za0001 := make([]string, 0, len(z.Pools))
 	for k := range z.Pools {
@@ -261,6 +261,26 @@ func (z *StakePool) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "Minter")
 		return
 	}
+	// string "ProviderStatus"
+	o = append(o, 0xae, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73)
+	o, err = z.ProviderStatus.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "ProviderStatus")
+		return
+	}
+	// string "Unbonding"
+	o = append(o, 0xa9, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Unbonding)))
+	keys_za0003 := make([]string, 0, len(z.Unbonding))
+	for k := range z.Unbonding {
+		keys_za0003 = append(keys_za0003, k)
+	}
+	msgp.Sort(keys_za0003)
+	for _, k := range keys_za0003 {
+		za0004 := z.Unbonding[k]
+		o = msgp.AppendString(o, k)
+		o = msgp.AppendString(o, za0004)
+	}
 	return
 }
 
@@ -341,6 +361,42 @@ func (z *StakePool) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "Minter")
 				return
 			}
+		case "ProviderStatus":
+			bts, err = z.ProviderStatus.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ProviderStatus")
+				return
+			}
+		case "Unbonding":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Unbonding")
+				return
+			}
+			if z.Unbonding == nil {
+				z.Unbonding = make(map[string]string, zb0003)
+			} else if len(z.Unbonding) > 0 {
+				for key := range z.Unbonding {
+					delete(z.Unbonding, key)
+				}
+			}
+			for zb0003 > 0 {
+				var za0003 string
+				var za0004 string
+				zb0003--
+				za0003, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Unbonding")
+					return
+				}
+				za0004, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Unbonding", za0003)
+					return
+				}
+				z.Unbonding[za0003] = za0004
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -367,6 +423,12 @@ func (z *StakePool) Msgsize() (s int) {
 			}
 		}
 	}
-	s += 7 + z.Reward.Msgsize() + 9 + z.Settings.Msgsize() + 7 + z.Minter.Msgsize()
+	s += 7 + z.Reward.Msgsize() + 9 + z.Settings.Msgsize() + 7 + z.Minter.Msgsize() + 15 + z.ProviderStatus.Msgsize() + 10 + msgp.MapHeaderSize
+	if z.Unbonding != nil {
+		for za0003, za0004 := range z.Unbonding {
+			_ = za0004
+			s += msgp.StringPrefixSize + len(za0003) + msgp.StringPrefixSize + len(za0004)
+		}
+	}
 	return
 }
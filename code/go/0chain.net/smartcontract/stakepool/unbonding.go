@@ -0,0 +1,337 @@
+package stakepool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/currency"
+	"0chain.net/chaincore/state"
+	"0chain.net/core/datastore"
+	"0chain.net/core/util"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+//go:generate msgp -io=false -tests=false -v
+//msgp:ignore UnbondRequest
+
+// UnbondRequest asks to unlock an amount of a delegate pool, a zero amount
+// unlocks the whole balance of the pool.
+type UnbondRequest struct {
+	ProviderID   string          `json:"provider_id"`
+	ProviderType spenum.Provider `json:"provider_type"`
+	PoolID       string          `json:"pool_id"`
+	Amount       currency.Coin   `json:"amount"`
+}
+
+func (ur *UnbondRequest) Decode(p []byte) error {
+	return json.Unmarshal(p, ur)
+}
+
+func (ur *UnbondRequest) Encode() []byte {
+	bytes, _ := json.Marshal(ur)
+	return bytes
+}
+
+// UnbondingEntry is an amount unlocked from a delegate pool, claimable from
+// the maturity round.
+type UnbondingEntry struct {
+	ID            string          `json:"id"`
+	ProviderID    string          `json:"provider_id"`
+	ProviderType  spenum.Provider `json:"provider_type"`
+	PoolID        string          `json:"pool_id"`
+	Amount        currency.Coin   `json:"amount"`
+	Round         int64           `json:"round"`
+	MaturityRound int64           `json:"maturity_round"`
+}
+
+// UnbondingQueue is the queue of the unbonding entries of a client in a
+// smart contract, ordered by the request round.
+type UnbondingQueue struct {
+	Entries []*UnbondingEntry `json:"entries"`
+}
+
+// UnbondingQueueKey returns the key of the unbonding queue of a client in a
+// smart contract.
+func UnbondingQueueKey(sscID, clientID string) datastore.Key {
+	return sscID + ":unbonding:" + clientID
+}
+
+// GetUnbondingQueue returns the unbonding queue of the client, empty if the
+// client has no unbonding entries.
+func GetUnbondingQueue(sscID, clientID string, balances cstate.StateContextI) (*UnbondingQueue, error) {
+	var uq UnbondingQueue
+	err := balances.GetTrieNode(UnbondingQueueKey(sscID, clientID), &uq)
+	switch {
+	case err == nil, errors.Is(err, util.ErrValueNotPresent):
+		return &uq, nil
+	default:
+		return nil, err
+	}
+}
+
+func (uq *UnbondingQueue) save(sscID, clientID string, balances cstate.StateContextI) error {
+	key := UnbondingQueueKey(sscID, clientID)
+	if len(uq.Entries) == 0 {
+		_, err := balances.DeleteTrieNode(key)
+		if err != nil && !errors.Is(err, util.ErrValueNotPresent) {
+			return err
+		}
+		return nil
+	}
+	_, err := balances.InsertTrieNode(key, uq)
+	return err
+}
+
+// Unbond moves an amount of a delegate pool of the client to the unbonding
+// queue of the client, it's claimable after the cooldown rounds. A pool
+// unbonded entirely is removed and its rewards are paid.
+func Unbond(sscID, clientID string, p Provider, req *UnbondRequest,
+	cooldown int64, balances cstate.StateContextI) (*UnbondingEntry, error) {
+
+	sp := p.GetStakePool()
+	dp, ok := sp.Pools[req.PoolID]
+	if !ok {
+		return nil, fmt.Errorf("no such delegate pool: %v", req.PoolID)
+	}
+	if dp.DelegateID != clientID {
+		return nil, fmt.Errorf("user %v does not own stake pool %v", clientID, req.PoolID)
+	}
+	if dp.Status != spenum.Active && dp.Status != spenum.Pending {
+		return nil, fmt.Errorf("can't unbond %s pool", dp.Status.String())
+	}
+
+	amount := req.Amount
+	if amount == 0 {
+		amount = dp.Balance
+	}
+	if amount == 0 {
+		return nil, errors.New("nothing to unbond")
+	}
+	if amount > dp.Balance {
+		return nil, fmt.Errorf("unbond amount %v is greater than the pool balance %v",
+			amount, dp.Balance)
+	}
+	left, err := currency.MinusCoin(dp.Balance, amount)
+	if err != nil {
+		return nil, err
+	}
+	if left > 0 && left < sp.Settings.MinStake {
+		return nil, fmt.Errorf("stake left %v is less than the min stake %v",
+			left, sp.Settings.MinStake)
+	}
+	dp.Balance = left
+
+	round := balances.GetBlock().Round
+	entry := &UnbondingEntry{
+		ID:            balances.GetTransaction().Hash,
+		ProviderID:    p.Id(),
+		ProviderType:  p.Type(),
+		PoolID:        req.PoolID,
+		Amount:        amount,
+		Round:         round,
+		MaturityRound: round + cooldown,
+	}
+
+	if left == 0 {
+		usp, err := getOrCreateUserStakePool(p.Type(), clientID, balances)
+		if err != nil {
+			return nil, fmt.Errorf("can't get user pools list: %v", err)
+		}
+		if _, err := sp.UnlockPool(clientID, p.Type(), p.Id(), req.PoolID, usp, balances); err != nil {
+			return nil, err
+		}
+		if err := usp.Save(p.Type(), clientID, balances); err != nil {
+			return nil, fmt.Errorf("saving user pools: %v", err)
+		}
+	} else {
+		dpUpdate := newDelegatePoolUpdate(req.PoolID, p.Id(), p.Type())
+		dpUpdate.Updates["balance"] = left
+		if err := dpUpdate.emitUpdate(balances); err != nil {
+			return nil, err
+		}
+	}
+
+	uq, err := GetUnbondingQueue(sscID, clientID, balances)
+	if err != nil {
+		return nil, fmt.Errorf("can't get unbonding queue: %v", err)
+	}
+	uq.Entries = append(uq.Entries, entry)
+	if err := uq.save(sscID, clientID, balances); err != nil {
+		return nil, fmt.Errorf("saving unbonding queue: %v", err)
+	}
+	if sp.Unbonding == nil {
+		sp.Unbonding = make(map[string]string)
+	}
+	sp.Unbonding[entry.ID] = clientID
+	if err := saveProvider(p, balances); err != nil {
+		return nil, fmt.Errorf("saving provider: %v", err)
+	}
+
+	balances.EmitEvent(event.TypeStats, event.TagAddUnbonding, entry.ID, event.Unbonding{
+		EntryID:       entry.ID,
+		ClientID:      clientID,
+		ProviderID:    entry.ProviderID,
+		ProviderType:  int(entry.ProviderType),
+		PoolID:        entry.PoolID,
+		Amount:        entry.Amount,
+		Round:         entry.Round,
+		MaturityRound: entry.MaturityRound,
+	})
+	return entry, nil
+}
+
+// ClaimUnbonded pays the matured entries of the unbonding queue of the
+// client and removes them from the stake pools of their providers; it
+// returns the amount paid.
+func ClaimUnbonded(sscID, clientID string, getProvider GetProviderFunc,
+	balances cstate.StateContextI) (currency.Coin, error) {
+	uq, err := GetUnbondingQueue(sscID, clientID, balances)
+	if err != nil {
+		return 0, fmt.Errorf("can't get unbonding queue: %v", err)
+	}
+
+	var (
+		round   = balances.GetBlock().Round
+		total   currency.Coin
+		claimed []string
+		matured []*UnbondingEntry
+		pending = make([]*UnbondingEntry, 0, len(uq.Entries))
+	)
+	for _, e := range uq.Entries {
+		if e.MaturityRound > round {
+			pending = append(pending, e)
+			continue
+		}
+		if total, err = currency.AddCoin(total, e.Amount); err != nil {
+			return 0, err
+		}
+		claimed = append(claimed, e.ID)
+		matured = append(matured, e)
+	}
+	if len(claimed) == 0 {
+		return 0, errors.New("no matured unbonding entries")
+	}
+	if err := releaseUnbonding(matured, getProvider, balances); err != nil {
+		return 0, err
+	}
+
+	if err := balances.AddTransfer(state.NewTransfer(sscID, clientID, total)); err != nil {
+		return 0, err
+	}
+	uq.Entries = pending
+	if err := uq.save(sscID, clientID, balances); err != nil {
+		return 0, fmt.Errorf("saving unbonding queue: %v", err)
+	}
+
+	balances.EmitEvent(event.TypeStats, event.TagClaimUnbonding, clientID, event.UnbondingClaim{
+		ClientID: clientID,
+		EntryIDs: claimed,
+		Round:    round,
+	})
+	return total, nil
+}
+
+// releaseUnbonding removes the claimed entries from the stake pools of their
+// providers, the entries of a removed provider are skipped.
+func releaseUnbonding(entries []*UnbondingEntry, getProvider GetProviderFunc,
+	balances cstate.StateContextI) error {
+
+	var (
+		providers = make(map[string]Provider)
+		keys      []string
+	)
+	for _, e := range entries {
+		key := e.ProviderType.String() + ":" + e.ProviderID
+		p, ok := providers[key]
+		if !ok {
+			var err error
+			p, err = getProvider(e.ProviderType, e.ProviderID, balances)
+			switch {
+			case errors.Is(err, util.ErrValueNotPresent):
+				continue
+			case err != nil:
+				return fmt.Errorf("can't get provider %s: %v", e.ProviderID, err)
+			}
+			providers[key] = p
+			keys = append(keys, key)
+		}
+		delete(p.GetStakePool().Unbonding, e.ID)
+	}
+	for _, key := range keys {
+		if err := saveProvider(providers[key], balances); err != nil {
+			return fmt.Errorf("saving provider: %v", err)
+		}
+	}
+	return nil
+}
+
+// slashUnbonding slashes the ratio of the unbonding entries of the delegate
+// pools of the stake pool; it returns the total slashed.
+func (sp *StakePool) slashUnbonding(sscID string, ratio float64,
+	balances cstate.StateContextI) (currency.Coin, error) {
+
+	ids := make([]string, 0, len(sp.Unbonding))
+	for id := range sp.Unbonding {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var (
+		total   currency.Coin
+		queues  = make(map[string]*UnbondingQueue)
+		clients []string
+	)
+	for _, id := range ids {
+		clientID := sp.Unbonding[id]
+		uq, ok := queues[clientID]
+		if !ok {
+			var err error
+			if uq, err = GetUnbondingQueue(sscID, clientID, balances); err != nil {
+				return 0, fmt.Errorf("can't get unbonding queue: %v", err)
+			}
+			queues[clientID] = uq
+			clients = append(clients, clientID)
+		}
+		e := uq.entry(id)
+		if e == nil {
+			delete(sp.Unbonding, id)
+			continue
+		}
+		slash, err := currency.MultFloat64(e.Amount, ratio)
+		if err != nil {
+			return 0, err
+		}
+		if slash == 0 {
+			continue
+		}
+		if e.Amount, err = currency.MinusCoin(e.Amount, slash); err != nil {
+			return 0, err
+		}
+		if total, err = currency.AddCoin(total, slash); err != nil {
+			return 0, err
+		}
+		balances.EmitEvent(event.TypeStats, event.TagUpdateUnbonding, e.ID, event.Unbonding{
+			EntryID: e.ID,
+			Amount:  e.Amount,
+		})
+	}
+	for _, clientID := range clients {
+		if err := queues[clientID].save(sscID, clientID, balances); err != nil {
+			return 0, fmt.Errorf("saving unbonding queue: %v", err)
+		}
+	}
+	return total, nil
+}
+
+func (uq *UnbondingQueue) entry(id string) *UnbondingEntry {
+	for _, e := range uq.Entries {
+		if e.ID == id {
+			return e
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package stakepool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/currency"
+	"0chain.net/core/util"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+func TestUnbond(t *testing.T) {
+	const (
+		sscID    = "ssc"
+		clientID = "client"
+		poolID   = "pool"
+		cooldown = 10
+	)
+
+	tests := []struct {
+		name     string
+		status   spenum.PoolStatus
+		clientID string
+		amount   currency.Coin
+		wantLeft currency.Coin
+		wantErr  string
+	}{
+		{name: "partial", amount: 40, wantLeft: 60},
+		{name: "whole", amount: 100},
+		{name: "whole_by_zero_amount", amount: 0},
+		{name: "pending_pool", status: spenum.Pending, amount: 40, wantLeft: 60},
+		{name: "too_much", amount: 101, wantErr: "greater than the pool balance"},
+		{name: "less_than_min_stake", amount: 95, wantErr: "less than the min stake"},
+		{name: "not_owner", clientID: "other", amount: 40, wantErr: "does not own"},
+		{name: "deleting_pool", status: spenum.Deleting, amount: 40, wantErr: "can't unbond"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				balances = newTestBalances(t, false)
+				p        = &testProvider{id: "provider", sp: NewStakePool()}
+			)
+			balances.block.Round = 5
+			balances.txn.Hash = "txn"
+			p.sp.Settings.MinStake = 10
+			p.sp.Pools[poolID] = &DelegatePool{Balance: 100, Status: tt.status, DelegateID: clientID}
+			usp := NewUserStakePools()
+			usp.add(p.id, poolID)
+			require.NoError(t, usp.Save(p.Type(), clientID, balances))
+
+			caller := clientID
+			if tt.clientID != "" {
+				caller = tt.clientID
+			}
+			entry, err := Unbond(sscID, caller, p, &UnbondRequest{
+				ProviderID:   p.id,
+				ProviderType: p.Type(),
+				PoolID:       poolID,
+				Amount:       tt.amount,
+			}, cooldown, balances)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, 100-tt.wantLeft, entry.Amount)
+			require.EqualValues(t, 5+cooldown, entry.MaturityRound)
+
+			sp, err := GetStakePool(p.Type(), p.id, balances)
+			require.NoError(t, err)
+			usp, err = GetUserStakePools(p.Type(), clientID, balances)
+			require.NoError(t, err)
+			if tt.wantLeft == 0 {
+				require.NotContains(t, sp.Pools, poolID)
+				require.Empty(t, usp.FindProvider(poolID))
+			} else {
+				require.EqualValues(t, tt.wantLeft, sp.Pools[poolID].Balance)
+				require.Equal(t, p.id, usp.FindProvider(poolID))
+			}
+
+			uq, err := GetUnbondingQueue(sscID, clientID, balances)
+			require.NoError(t, err)
+			require.Equal(t, []*UnbondingEntry{entry}, uq.Entries)
+		})
+	}
+}
+
+func TestClaimUnbonded(t *testing.T) {
+	const (
+		sscID    = "ssc"
+		clientID = "client"
+		cooldown = 10
+	)
+	var (
+		balances = newTestBalances(t, false)
+		p        = &testProvider{id: "provider", sp: NewStakePool()}
+	)
+	balances.txn.ToClientID = sscID
+	balances.balances[sscID] = 100
+	p.sp.Pools["p1"] = &DelegatePool{Balance: 100, DelegateID: clientID}
+
+	unbond := func(round int64, hash string, amount currency.Coin) {
+		balances.block.Round, balances.txn.Hash = round, hash
+		_, err := Unbond(sscID, clientID, p, &UnbondRequest{PoolID: "p1", Amount: amount},
+			cooldown, balances)
+		require.NoError(t, err)
+	}
+	unbond(1, "t1", 20)
+	unbond(5, "t2", 30)
+	require.Equal(t, map[string]string{"t1": clientID, "t2": clientID}, p.sp.Unbonding)
+
+	getProvider := func(spenum.Provider, string, cstate.StateContextI) (Provider, error) {
+		return p, nil
+	}
+	balances.block.Round = 10
+	_, err := ClaimUnbonded(sscID, clientID, getProvider, balances)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no matured")
+
+	balances.block.Round = 12
+	claimed, err := ClaimUnbonded(sscID, clientID, getProvider, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 20, claimed)
+	require.EqualValues(t, 20, balances.balances[clientID])
+
+	sp, err := GetStakePool(p.Type(), p.id, balances)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"t2": clientID}, sp.Unbonding)
+
+	uq, err := GetUnbondingQueue(sscID, clientID, balances)
+	require.NoError(t, err)
+	require.Len(t, uq.Entries, 1)
+	require.Equal(t, "t2", uq.Entries[0].ID)
+
+	// the entries of a removed provider are paid as well
+	removed := func(spenum.Provider, string, cstate.StateContextI) (Provider, error) {
+		return nil, util.ErrValueNotPresent
+	}
+	balances.block.Round = 15
+	claimed, err = ClaimUnbonded(sscID, clientID, removed, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 30, claimed)
+	require.EqualValues(t, 50, balances.balances[clientID])
+	require.EqualValues(t, 50, balances.balances[sscID])
+
+	uq, err = GetUnbondingQueue(sscID, clientID, balances)
+	require.NoError(t, err)
+	require.Empty(t, uq.Entries)
+}
@@ -2,6 +2,9 @@ package stakepool
 
 import (
 	"encoding/json"
+	"errors"
+
+	"0chain.net/chaincore/currency"
 
 	"0chain.net/smartcontract/stakepool/spenum"
 
@@ -115,3 +118,41 @@ func getOrCreateUserStakePool(
 		return nil, err
 	}
 }
+
+// DelegatedStake returns the tokens of the active delegate pools of the client in the stake pools of the providers of given type. The
+// getStakePool loads the stake pool of a provider.
+func DelegatedStake(
+	p spenum.Provider,
+	clientID datastore.Key,
+	getStakePool func(providerID string) (*StakePool, error),
+	balances chainstate.StateContextI,
+) (stake currency.Coin, err error) {
+	usp, err := GetUserStakePools(p, clientID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return 0, nil
+	default:
+		return 0, err
+	}
+
+	for providerID, poolIDs := range usp.Pools {
+		sp, err := getStakePool(providerID)
+		if err != nil {
+			if errors.Is(err, util.ErrValueNotPresent) {
+				continue // removed provider
+			}
+			return 0, err
+		}
+		for _, poolID := range poolIDs {
+			dp, ok := sp.Pools[poolID]
+			if !ok || dp.Status != spenum.Active {
+				continue
+			}
+			if stake, err = currency.AddCoin(stake, dp.Balance); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return stake, nil
+}
@@ -434,7 +434,8 @@ func (sc *StorageSmartContract) selectBlobbers(
 	}
 
 	if len(blobberNodes) < size {
-		blobberNodes = randomizeNodes(list, blobberNodes, size, randomSeed)
+		blobberNodes = randomizeNodesByReputation(list, blobberNodes, size,
+			randomSeed, conf.reputation(), timestamp)
 	}
 
 	return blobberNodes[:size], bSize, nil
@@ -864,6 +865,10 @@ func (sc *StorageSmartContract) extendAllocation(
 		originalTerms = make([]Terms, 0, len(alloc.BlobberAllocs))
 		// original allocation duration remains
 		originalRemainingDuration = alloc.Expiration - txn.CreationDate
+
+		// stake pools with changed offers, saved once the allocation can
+		// be extended, so a failed extension doesn't leave them changed
+		stakePools = make(map[string]*stakePool)
 	)
 
 	// adjust the expiration if changed, boundaries has already checked
@@ -951,11 +956,7 @@ func (sc *StorageSmartContract) extendAllocation(
 					return fmt.Errorf("adding offer: %v", err)
 				}
 			}
-			if err = sp.save(sc.ID, details.BlobberID, balances); err != nil {
-				return fmt.Errorf("can't save stake pool of %s: %v", details.BlobberID,
-					err)
-			}
-
+			stakePools[details.BlobberID] = sp
 		}
 	}
 
@@ -987,6 +988,17 @@ func (sc *StorageSmartContract) extendAllocation(
 	if err != nil {
 		return common.NewErrorf("allocation_extending_failed", "%v", err)
 	}
+
+	for _, details := range alloc.BlobberAllocs {
+		sp, ok := stakePools[details.BlobberID]
+		if !ok {
+			continue
+		}
+		if err = sp.save(sc.ID, details.BlobberID, balances); err != nil {
+			return fmt.Errorf("can't save stake pool of %s: %v", details.BlobberID,
+				err)
+		}
+	}
 	return nil
 }
 
@@ -19,6 +19,8 @@ import (
 type StorageAllocationBlobbers struct {
 	StorageAllocation `json:",inline"`
 	Blobbers          []*StorageNode `json:"blobbers"`
+	// AutoRenew is the auto renewal status of the allocation, if any
+	AutoRenew *event.AllocationRenewal `json:"auto_renew,omitempty"`
 }
 
 func allocationTableToStorageAllocationBlobbers(alloc *event.Allocation, eventDb *event.EventDb) (*StorageAllocationBlobbers, error) {
@@ -0,0 +1,529 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/currency"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/logging"
+	"0chain.net/core/util"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// allocationRenewalBucket is the number of seconds the auto renewals are
+// scheduled together in.
+const allocationRenewalBucket common.Timestamp = 60
+
+// allocationRenewalMaxBuckets is the max number of buckets processed in a
+// round, the rest are processed in the next rounds.
+const allocationRenewalMaxBuckets = 100
+
+func allocationRenewalKey(scKey, allocID string) datastore.Key {
+	return datastore.Key(scKey + ":allocation_renewal:" + allocID)
+}
+
+func allocationRenewalsKey(scKey string, bucket common.Timestamp) datastore.Key {
+	return datastore.Key(fmt.Sprintf("%s:allocation_renewals:%d", scKey, bucket))
+}
+
+func allocationRenewalScheduleKey(scKey string) datastore.Key {
+	return datastore.Key(scKey + ":allocation_renewals:schedule")
+}
+
+func renewalBucket(ts common.Timestamp) common.Timestamp {
+	return ts / allocationRenewalBucket
+}
+
+// AllocationRenewal is the auto renewal subscription of an allocation. The
+// balance is locked by the allocation owner and funds the write pool top ups
+// of the renewals.
+type AllocationRenewal struct {
+	AllocationID string `json:"allocation_id"`
+	Owner        string `json:"owner"`
+	// Active is false once the allocation can't be renewed anymore.
+	Active bool `json:"active"`
+	// Period is the expiration extension of a renewal.
+	Period common.Timestamp `json:"period"`
+	// WritePoolTopUp is the max amount moved from the balance to the write
+	// pool of the allocation on a renewal.
+	WritePoolTopUp currency.Coin `json:"write_pool_top_up"`
+	Balance        currency.Coin `json:"balance"`
+	// NextRenewal is the time the next renewal is attempted at.
+	NextRenewal common.Timestamp `json:"next_renewal"`
+	LastRenewal common.Timestamp `json:"last_renewal"`
+	Renewals    int64            `json:"renewals"`
+	// LastError is the reason of the last failed renewal, if any.
+	LastError string `json:"last_error"`
+}
+
+func (ar *AllocationRenewal) Encode() []byte {
+	var b, err = json.Marshal(ar)
+	if err != nil {
+		panic(err) // must never happens
+	}
+	return b
+}
+
+func (ar *AllocationRenewal) Decode(p []byte) error {
+	return json.Unmarshal(p, ar)
+}
+
+func (ar *AllocationRenewal) emitAddOrOverwrite(balances cstate.StateContextI) {
+	balances.EmitEvent(event.TypeStats, event.TagAddOrOverwriteAllocationRenewal, ar.AllocationID,
+		event.AllocationRenewal{
+			AllocationID:   ar.AllocationID,
+			Owner:          ar.Owner,
+			Active:         ar.Active,
+			Period:         int64(ar.Period),
+			WritePoolTopUp: ar.WritePoolTopUp,
+			Balance:        ar.Balance,
+			NextRenewal:    int64(ar.NextRenewal),
+			LastRenewal:    int64(ar.LastRenewal),
+			Renewals:       ar.Renewals,
+			LastError:      ar.LastError,
+		})
+}
+
+// allocationRenewals is the list of the allocations the renewals of are
+// scheduled in a bucket.
+type allocationRenewals []string
+
+// allocationRenewalSchedule keeps the last processed bucket of the auto
+// renewals, and the number of the renewals and the buckets processed in the
+// last round the renewals are processed in.
+type allocationRenewalSchedule struct {
+	Processed common.Timestamp `json:"processed"`
+	Round     int64            `json:"round"`
+	Renewals  int              `json:"renewals"`
+	Buckets   int              `json:"buckets"`
+}
+
+func (sc *StorageSmartContract) getAllocationRenewal(allocID string,
+	balances cstate.StateContextI) (*AllocationRenewal, error) {
+
+	ar := new(AllocationRenewal)
+	if err := balances.GetTrieNode(allocationRenewalKey(sc.ID, allocID), ar); err != nil {
+		return nil, err
+	}
+	return ar, nil
+}
+
+func (sc *StorageSmartContract) saveAllocationRenewal(ar *AllocationRenewal,
+	balances cstate.StateContextI) error {
+
+	if _, err := balances.InsertTrieNode(allocationRenewalKey(sc.ID, ar.AllocationID), ar); err != nil {
+		return err
+	}
+	ar.emitAddOrOverwrite(balances)
+	return nil
+}
+
+func (sc *StorageSmartContract) getAllocationRenewalSchedule(now common.Timestamp,
+	balances cstate.StateContextI) (*allocationRenewalSchedule, error) {
+
+	s := new(allocationRenewalSchedule)
+	err := balances.GetTrieNode(allocationRenewalScheduleKey(sc.ID), s)
+	switch err {
+	case nil:
+		return s, nil
+	case util.ErrValueNotPresent:
+		s.Processed = renewalBucket(now) - 1
+		return s, nil
+	default:
+		return nil, err
+	}
+}
+
+func (sc *StorageSmartContract) getAllocationRenewals(bucket common.Timestamp,
+	balances cstate.StateContextI) (allocationRenewals, error) {
+
+	var ids allocationRenewals
+	err := balances.GetTrieNode(allocationRenewalsKey(sc.ID, bucket), &ids)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return ids, nil
+	default:
+		return nil, err
+	}
+}
+
+func (sc *StorageSmartContract) saveAllocationRenewals(bucket common.Timestamp,
+	ids allocationRenewals, balances cstate.StateContextI) (err error) {
+
+	key := allocationRenewalsKey(sc.ID, bucket)
+	if len(ids) == 0 {
+		_, err = balances.DeleteTrieNode(key)
+		if err == util.ErrValueNotPresent {
+			err = nil
+		}
+		return
+	}
+	_, err = balances.InsertTrieNode(key, &ids)
+	return
+}
+
+// scheduleAllocationRenewal schedules the next renewal at given time, or in
+// the first bucket after the given one, if later.
+func (sc *StorageSmartContract) scheduleAllocationRenewal(ar *AllocationRenewal,
+	at, after common.Timestamp, balances cstate.StateContextI) error {
+
+	bucket := renewalBucket(at)
+	if bucket <= after {
+		bucket = after + 1
+		at = bucket * allocationRenewalBucket
+	}
+	ar.NextRenewal = at
+
+	ids, err := sc.getAllocationRenewals(bucket, balances)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if id == ar.AllocationID {
+			return nil // already scheduled
+		}
+	}
+	return sc.saveAllocationRenewals(bucket, append(ids, ar.AllocationID), balances)
+}
+
+// allocation auto renew request
+type allocationAutoRenewRequest struct {
+	AllocationID string `json:"allocation_id"`
+	// Enable turns the auto renewal on or off, the balance is returned to the
+	// owner when it's turned off.
+	Enable bool `json:"enable"`
+	// Period is the expiration extension of a renewal.
+	Period         common.Timestamp `json:"period"`
+	WritePoolTopUp currency.Coin    `json:"write_pool_top_up"`
+}
+
+func (req *allocationAutoRenewRequest) decode(b []byte) error {
+	return json.Unmarshal(b, req)
+}
+
+// updateAllocationAutoRenew turns the auto renewal of an allocation on or off;
+// the transaction value is added to the balance of the auto renewal.
+func (sc *StorageSmartContract) updateAllocationAutoRenew(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	conf, err := sc.getConfig(balances, true)
+	if err != nil {
+		return "", common.NewError("update_allocation_auto_renew_failed",
+			"can't get SC configurations: "+err.Error())
+	}
+	if !conf.autoRenew().Enabled {
+		return "", common.NewError("update_allocation_auto_renew_failed",
+			"allocations auto renewal is disabled")
+	}
+
+	var req allocationAutoRenewRequest
+	if err = req.decode(input); err != nil {
+		return "", common.NewError("update_allocation_auto_renew_failed",
+			"invalid request: "+err.Error())
+	}
+
+	alloc, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("update_allocation_auto_renew_failed",
+			"can't get allocation: "+err.Error())
+	}
+	if alloc.Owner != txn.ClientID {
+		return "", common.NewError("update_allocation_auto_renew_failed",
+			"only owner can update the allocation auto renewal")
+	}
+
+	ar, err := sc.getAllocationRenewal(alloc.ID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		ar = &AllocationRenewal{AllocationID: alloc.ID, Owner: alloc.Owner}
+	default:
+		return "", common.NewError("update_allocation_auto_renew_failed",
+			"can't get allocation auto renewal: "+err.Error())
+	}
+
+	if !req.Enable {
+		if err == util.ErrValueNotPresent {
+			return "", common.NewError("update_allocation_auto_renew_failed",
+				"allocation is not auto renewed")
+		}
+		if txn.Value > 0 {
+			return "", common.NewError("update_allocation_auto_renew_failed",
+				"can't lock tokens turning the auto renewal off")
+		}
+		if err = sc.refundAllocationRenewal(ar, balances); err != nil {
+			return "", common.NewError("update_allocation_auto_renew_failed", err.Error())
+		}
+		if _, err = balances.DeleteTrieNode(allocationRenewalKey(sc.ID, ar.AllocationID)); err != nil {
+			return "", common.NewError("update_allocation_auto_renew_failed",
+				"can't delete allocation auto renewal: "+err.Error())
+		}
+		balances.EmitEvent(event.TypeStats, event.TagDeleteAllocationRenewal, ar.AllocationID, ar.AllocationID)
+		return "", nil
+	}
+
+	if alloc.Finalized || alloc.Canceled || alloc.Expiration < txn.CreationDate {
+		return "", common.NewError("update_allocation_auto_renew_failed",
+			"can't auto renew a finalized, cancelled or expired allocation")
+	}
+	if req.Period < toSeconds(conf.AutoRenew.MinPeriod) {
+		return "", common.NewErrorf("update_allocation_auto_renew_failed",
+			"renewal period is less than allowed by SC: %v < %v", req.Period,
+			toSeconds(conf.AutoRenew.MinPeriod))
+	}
+
+	if txn.Value > 0 {
+		if err = stakepool.CheckClientBalance(txn.ClientID, txn.Value, balances); err != nil {
+			return "", common.NewError("update_allocation_auto_renew_failed", err.Error())
+		}
+		transfer := state.NewTransfer(txn.ClientID, txn.ToClientID, txn.Value)
+		if err = balances.AddTransfer(transfer); err != nil {
+			return "", common.NewError("update_allocation_auto_renew_failed", err.Error())
+		}
+		if ar.Balance, err = currency.AddCoin(ar.Balance, txn.Value); err != nil {
+			return "", common.NewError("update_allocation_auto_renew_failed", err.Error())
+		}
+	}
+
+	ar.Active = true
+	ar.Period = req.Period
+	ar.WritePoolTopUp = req.WritePoolTopUp
+	ar.LastError = ""
+
+	sched, err := sc.getAllocationRenewalSchedule(txn.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError("update_allocation_auto_renew_failed",
+			"can't get auto renewals schedule: "+err.Error())
+	}
+	if err = sc.scheduleAllocationRenewal(ar,
+		alloc.Expiration-toSeconds(conf.AutoRenew.RenewWindow), sched.Processed, balances); err != nil {
+		return "", common.NewError("update_allocation_auto_renew_failed",
+			"can't schedule auto renewal: "+err.Error())
+	}
+	if err = sc.saveAllocationRenewal(ar, balances); err != nil {
+		return "", common.NewError("update_allocation_auto_renew_failed",
+			"can't save allocation auto renewal: "+err.Error())
+	}
+
+	return string(ar.Encode()), nil
+}
+
+// refundAllocationRenewal moves the balance of the auto renewal back to the
+// allocation owner.
+func (sc *StorageSmartContract) refundAllocationRenewal(ar *AllocationRenewal,
+	balances cstate.StateContextI) error {
+
+	if ar.Balance == 0 {
+		return nil
+	}
+	transfer := state.NewTransfer(sc.ID, ar.Owner, ar.Balance)
+	if err := balances.AddTransfer(transfer); err != nil {
+		return fmt.Errorf("can't refund auto renewal balance: %v", err)
+	}
+	ar.Balance = 0
+	return nil
+}
+
+// renewAllocations processes the allocations auto renewals of the system
+// transaction. The renewals are isolated from the transaction, a failure is
+// logged and its state changes are discarded, the transaction goes on.
+func (sc *StorageSmartContract) renewAllocations(
+	txn *transaction.Transaction,
+	balances cstate.StateContextI,
+) {
+	err := cstate.RunNested(balances, func(balances cstate.StateContextI) error {
+		return sc.processAllocationRenewals(txn, balances)
+	})
+	if err != nil {
+		logging.Logger.Error("allocation auto renewals failed",
+			zap.Int64("round", balances.GetBlock().Round),
+			zap.String("txn", txn.Hash),
+			zap.Error(err))
+	}
+}
+
+// processAllocationRenewals renews the allocations scheduled up to the
+// transaction time, at most auto_renew.max_renewals of them and at most
+// allocationRenewalMaxBuckets buckets in a round; the rest are renewed in
+// the next rounds.
+func (sc *StorageSmartContract) processAllocationRenewals(
+	txn *transaction.Transaction,
+	balances cstate.StateContextI,
+) error {
+	conf, err := sc.getConfig(balances, true)
+	if err != nil {
+		return common.NewError("process_allocation_renewals_failed",
+			"can't get SC configurations: "+err.Error())
+	}
+	if !conf.autoRenew().Enabled {
+		return nil
+	}
+
+	now := txn.CreationDate
+	sched, err := sc.getAllocationRenewalSchedule(now, balances)
+	if err != nil {
+		return common.NewError("process_allocation_renewals_failed",
+			"can't get auto renewals schedule: "+err.Error())
+	}
+
+	if round := balances.GetBlock().Round; sched.Round != round {
+		sched.Round, sched.Renewals, sched.Buckets = round, 0, 0
+	}
+
+	var (
+		current = renewalBucket(now)
+		left    = conf.AutoRenew.MaxRenewals - sched.Renewals
+	)
+	for bucket := sched.Processed + 1; bucket <= current && left > 0 &&
+		sched.Buckets < allocationRenewalMaxBuckets; bucket++ {
+
+		sched.Buckets++
+		ids, err := sc.getAllocationRenewals(bucket, balances)
+		if err != nil {
+			return common.NewError("process_allocation_renewals_failed",
+				"can't get scheduled auto renewals: "+err.Error())
+		}
+
+		var n int
+		for ; n < len(ids) && left > 0; n++ {
+			if err := sc.renewAllocation(txn, conf, ids[n], bucket, current, balances); err != nil {
+				return common.NewErrorf("process_allocation_renewals_failed",
+					"allocation %s: %v", ids[n], err)
+			}
+			left--
+			sched.Renewals++
+		}
+
+		if err := sc.saveAllocationRenewals(bucket, ids[n:], balances); err != nil {
+			return common.NewError("process_allocation_renewals_failed",
+				"can't save scheduled auto renewals: "+err.Error())
+		}
+		if n < len(ids) {
+			break // the bucket is not processed entirely
+		}
+		sched.Processed = bucket
+	}
+
+	if _, err = balances.InsertTrieNode(allocationRenewalScheduleKey(sc.ID), sched); err != nil {
+		return common.NewError("process_allocation_renewals_failed",
+			"can't save auto renewals schedule: "+err.Error())
+	}
+	return nil
+}
+
+// renewAllocation renews the allocation scheduled in given bucket; the
+// renewal failures are kept in the auto renewal and it's retried in the next
+// bucket, the returned errors are state errors only.
+func (sc *StorageSmartContract) renewAllocation(
+	txn *transaction.Transaction,
+	conf *Config,
+	allocID string,
+	bucket, current common.Timestamp,
+	balances cstate.StateContextI,
+) error {
+	ar, err := sc.getAllocationRenewal(allocID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return nil // turned off
+	default:
+		return err
+	}
+	if !ar.Active || renewalBucket(ar.NextRenewal) != bucket {
+		return nil // rescheduled
+	}
+
+	var (
+		now    = txn.CreationDate
+		window = toSeconds(conf.AutoRenew.RenewWindow)
+	)
+
+	alloc, err := sc.getAllocation(allocID, balances)
+	if err != nil && err != util.ErrValueNotPresent {
+		return err
+	}
+	if err == util.ErrValueNotPresent || alloc.Finalized || alloc.Canceled ||
+		alloc.Expiration < now {
+
+		if err := sc.refundAllocationRenewal(ar, balances); err != nil {
+			return err
+		}
+		ar.Active = false
+		ar.LastError = "allocation is finalized, cancelled or expired"
+		return sc.saveAllocationRenewal(ar, balances)
+	}
+
+	// not due, the allocation has been extended by its owner
+	if now < alloc.Expiration-window {
+		if err := sc.scheduleAllocationRenewal(ar, alloc.Expiration-window, current, balances); err != nil {
+			return err
+		}
+		return sc.saveAllocationRenewal(ar, balances)
+	}
+
+	topUp := ar.WritePoolTopUp
+	if topUp > ar.Balance {
+		topUp = ar.Balance
+	}
+
+	// the state changes of a failed extension are discarded
+	err = cstate.RunNested(balances, func(balances cstate.StateContextI) error {
+		return sc.extendRenewedAllocation(txn, alloc, ar.Period, topUp, balances)
+	})
+	if err != nil {
+		logging.Logger.Info("allocation auto renewal failed",
+			zap.String("allocation", allocID), zap.Error(err))
+		ar.LastError = err.Error()
+		if err := sc.scheduleAllocationRenewal(ar, now, current, balances); err != nil {
+			return err
+		}
+		return sc.saveAllocationRenewal(ar, balances)
+	}
+
+	ar.Balance -= topUp
+	ar.Renewals++
+	ar.LastRenewal = now
+	ar.LastError = ""
+	if err := sc.scheduleAllocationRenewal(ar, alloc.Expiration-window, current, balances); err != nil {
+		return err
+	}
+	return sc.saveAllocationRenewal(ar, balances)
+}
+
+// extendRenewedAllocation tops up the write pool of the allocation and
+// extends its expiration; the state changes made before a failure are not
+// reverted, it's run on a nested state.
+func (sc *StorageSmartContract) extendRenewedAllocation(
+	txn *transaction.Transaction,
+	alloc *StorageAllocation,
+	period common.Timestamp,
+	topUp currency.Coin,
+	balances cstate.StateContextI,
+) (err error) {
+	blobbers, err := sc.getAllocationBlobbers(alloc, balances)
+	if err != nil {
+		return err
+	}
+
+	if alloc.WritePool, err = currency.AddCoin(alloc.WritePool, topUp); err != nil {
+		return err
+	}
+
+	req := &updateAllocationRequest{ID: alloc.ID, Expiration: period}
+	if err = sc.extendAllocation(txn, alloc, blobbers, req, balances); err != nil {
+		return err
+	}
+	return alloc.saveUpdatedAllocation(blobbers, balances)
+}
@@ -0,0 +1,373 @@
+package storagesc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *AllocationRenewal) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 10
+	// string "AllocationID"
+	o = append(o, 0x8a, 0xac, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44)
+	o = msgp.AppendString(o, z.AllocationID)
+	// string "Owner"
+	o = append(o, 0xa5, 0x4f, 0x77, 0x6e, 0x65, 0x72)
+	o = msgp.AppendString(o, z.Owner)
+	// string "Active"
+	o = append(o, 0xa6, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65)
+	o = msgp.AppendBool(o, z.Active)
+	// string "Period"
+	o = append(o, 0xa6, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o, err = z.Period.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Period")
+		return
+	}
+	// string "WritePoolTopUp"
+	o = append(o, 0xae, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x54, 0x6f, 0x70, 0x55, 0x70)
+	o, err = z.WritePoolTopUp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "WritePoolTopUp")
+		return
+	}
+	// string "Balance"
+	o = append(o, 0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
+	o, err = z.Balance.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Balance")
+		return
+	}
+	// string "NextRenewal"
+	o = append(o, 0xab, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x61, 0x6c)
+	o, err = z.NextRenewal.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "NextRenewal")
+		return
+	}
+	// string "LastRenewal"
+	o = append(o, 0xab, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x61, 0x6c)
+	o, err = z.LastRenewal.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "LastRenewal")
+		return
+	}
+	// string "Renewals"
+	o = append(o, 0xa8, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x61, 0x6c, 0x73)
+	o = msgp.AppendInt64(o, z.Renewals)
+	// string "LastError"
+	o = append(o, 0xa9, 0x4c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72)
+	o = msgp.AppendString(o, z.LastError)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *AllocationRenewal) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "AllocationID":
+			z.AllocationID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AllocationID")
+				return
+			}
+		case "Owner":
+			z.Owner, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Owner")
+				return
+			}
+		case "Active":
+			z.Active, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Active")
+				return
+			}
+		case "Period":
+			bts, err = z.Period.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Period")
+				return
+			}
+		case "WritePoolTopUp":
+			bts, err = z.WritePoolTopUp.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "WritePoolTopUp")
+				return
+			}
+		case "Balance":
+			bts, err = z.Balance.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Balance")
+				return
+			}
+		case "NextRenewal":
+			bts, err = z.NextRenewal.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NextRenewal")
+				return
+			}
+		case "LastRenewal":
+			bts, err = z.LastRenewal.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LastRenewal")
+				return
+			}
+		case "Renewals":
+			z.Renewals, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Renewals")
+				return
+			}
+		case "LastError":
+			z.LastError, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LastError")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *AllocationRenewal) Msgsize() (s int) {
+	s = 1 + 13 + msgp.StringPrefixSize + len(z.AllocationID) + 6 + msgp.StringPrefixSize + len(z.Owner) + 7 + msgp.BoolSize + 7 + z.Period.Msgsize() + 15 + z.WritePoolTopUp.Msgsize() + 8 + z.Balance.Msgsize() + 12 + z.NextRenewal.Msgsize() + 12 + z.LastRenewal.Msgsize() + 9 + msgp.Int64Size + 10 + msgp.StringPrefixSize + len(z.LastError)
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *allocationAutoRenewRequest) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "AllocationID"
+	o = append(o, 0x84, 0xac, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44)
+	o = msgp.AppendString(o, z.AllocationID)
+	// string "Enable"
+	o = append(o, 0xa6, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65)
+	o = msgp.AppendBool(o, z.Enable)
+	// string "Period"
+	o = append(o, 0xa6, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o, err = z.Period.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Period")
+		return
+	}
+	// string "WritePoolTopUp"
+	o = append(o, 0xae, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x54, 0x6f, 0x70, 0x55, 0x70)
+	o, err = z.WritePoolTopUp.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "WritePoolTopUp")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *allocationAutoRenewRequest) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "AllocationID":
+			z.AllocationID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AllocationID")
+				return
+			}
+		case "Enable":
+			z.Enable, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Enable")
+				return
+			}
+		case "Period":
+			bts, err = z.Period.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Period")
+				return
+			}
+		case "WritePoolTopUp":
+			bts, err = z.WritePoolTopUp.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "WritePoolTopUp")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *allocationAutoRenewRequest) Msgsize() (s int) {
+	s = 1 + 13 + msgp.StringPrefixSize + len(z.AllocationID) + 7 + msgp.BoolSize + 7 + z.Period.Msgsize() + 15 + z.WritePoolTopUp.Msgsize()
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *allocationRenewalSchedule) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Processed"
+	o = append(o, 0x84, 0xa9, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64)
+	o, err = z.Processed.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Processed")
+		return
+	}
+	// string "Round"
+	o = append(o, 0xa5, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendInt64(o, z.Round)
+	// string "Renewals"
+	o = append(o, 0xa8, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x61, 0x6c, 0x73)
+	o = msgp.AppendInt(o, z.Renewals)
+	// string "Buckets"
+	o = append(o, 0xa7, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73)
+	o = msgp.AppendInt(o, z.Buckets)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *allocationRenewalSchedule) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Processed":
+			bts, err = z.Processed.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Processed")
+				return
+			}
+		case "Round":
+			z.Round, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Round")
+				return
+			}
+		case "Renewals":
+			z.Renewals, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Renewals")
+				return
+			}
+		case "Buckets":
+			z.Buckets, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Buckets")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *allocationRenewalSchedule) Msgsize() (s int) {
+	s = 1 + 10 + z.Processed.Msgsize() + 6 + msgp.Int64Size + 9 + msgp.IntSize + 8 + msgp.IntSize
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z allocationRenewals) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	o = msgp.AppendArrayHeader(o, uint32(len(z)))
+	for za0001 := range z {
+		o = msgp.AppendString(o, z[za0001])
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *allocationRenewals) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0002 uint32
+	zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if cap((*z)) >= int(zb0002) {
+		(*z) = (*z)[:zb0002]
+	} else {
+		(*z) = make(allocationRenewals, zb0002)
+	}
+	for zb0001 := range *z {
+		(*z)[zb0001], bts, err = msgp.ReadStringBytes(bts)
+		if err != nil {
+			err = msgp.WrapError(err, zb0001)
+			return
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z allocationRenewals) Msgsize() (s int) {
+	s = msgp.ArrayHeaderSize
+	for zb0003 := range z {
+		s += msgp.StringPrefixSize + len(z[zb0003])
+	}
+	return
+}
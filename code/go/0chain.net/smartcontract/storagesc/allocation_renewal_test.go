@@ -0,0 +1,232 @@
+package storagesc
+
+import (
+	"testing"
+	"time"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/currency"
+	"0chain.net/core/common"
+	"0chain.net/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setAutoRenewConfig(t *testing.T, balances *testBalances) {
+	ssc := newTestStorageSC()
+	conf, err := ssc.getConfig(balances, false)
+	require.NoError(t, err)
+	conf.AutoRenew = &autoRenewConfig{
+		Enabled:     true,
+		RenewWindow: 2 * time.Minute,
+		MinPeriod:   1 * time.Minute,
+		MaxRenewals: 10,
+	}
+	mustSave(t, scConfigKey(ADDRESS), conf, balances)
+}
+
+func callAllocationAutoRenew(t *testing.T, ssc *StorageSmartContract,
+	clientID string, value currency.Coin, now int64,
+	req *allocationAutoRenewRequest, balances *testBalances) error {
+
+	tx := newTransaction(clientID, ADDRESS, value, now)
+	balances.setTransaction(t, tx)
+	_, err := ssc.updateAllocationAutoRenew(tx, mustEncode(t, req), balances)
+	return err
+}
+
+func callProcessAllocationRenewals(t *testing.T, ssc *StorageSmartContract,
+	now int64, balances *testBalances) {
+
+	tx := newTransaction(randString(32), ADDRESS, 0, now)
+	balances.setTransaction(t, tx)
+	require.NoError(t, ssc.processAllocationRenewals(tx, balances))
+}
+
+func TestStorageSmartContract_updateAllocationAutoRenew(t *testing.T) {
+	var (
+		ssc              = newTestStorageSC()
+		balances         = newTestBalances(t, false)
+		client           = newClient(50*x10, balances)
+		other            = newClient(50*x10, balances)
+		tp, exp    int64 = 100, 1000
+		allocID, _       = addAllocation(t, ssc, client, tp, exp, 0, balances)
+	)
+
+	req := &allocationAutoRenewRequest{
+		AllocationID:   allocID,
+		Enable:         true,
+		Period:         600,
+		WritePoolTopUp: 5 * x10,
+	}
+
+	tp += 100
+	err := callAllocationAutoRenew(t, ssc, client.id, 10*x10, tp, req, balances)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "allocations auto renewal is disabled")
+
+	setAutoRenewConfig(t, balances)
+	var before = balances.balances[client.id]
+
+	tests := []struct {
+		name     string
+		clientID string
+		value    currency.Coin
+		req      allocationAutoRenewRequest
+		wantErr  string
+	}{
+		{
+			name:     "not_owner",
+			clientID: other.id,
+			value:    10 * x10,
+			req:      *req,
+			wantErr:  "only owner can update the allocation auto renewal",
+		},
+		{
+			name:     "short_period",
+			clientID: client.id,
+			value:    10 * x10,
+			req: allocationAutoRenewRequest{
+				AllocationID: allocID,
+				Enable:       true,
+				Period:       30,
+			},
+			wantErr: "renewal period is less than allowed by SC",
+		},
+		{
+			name:     "not_auto_renewed",
+			clientID: client.id,
+			req:      allocationAutoRenewRequest{AllocationID: allocID},
+			wantErr:  "allocation is not auto renewed",
+		},
+		{
+			name:     "ok",
+			clientID: client.id,
+			value:    10 * x10,
+			req:      *req,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := callAllocationAutoRenew(t, ssc, tt.clientID, tt.value, tp, &tt.req, balances)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	ar, err := ssc.getAllocationRenewal(allocID, balances)
+	require.NoError(t, err)
+	assert.True(t, ar.Active)
+	assert.EqualValues(t, 10*x10, ar.Balance)
+	assert.EqualValues(t, exp-120, ar.NextRenewal)
+	assert.EqualValues(t, before-10*x10, balances.balances[client.id])
+
+	// turn off, the balance is refunded
+	tp += 100
+	err = callAllocationAutoRenew(t, ssc, client.id, 0, tp,
+		&allocationAutoRenewRequest{AllocationID: allocID}, balances)
+	require.NoError(t, err)
+	_, err = ssc.getAllocationRenewal(allocID, balances)
+	require.Equal(t, util.ErrValueNotPresent, err)
+	assert.EqualValues(t, before, balances.balances[client.id])
+}
+
+func TestStorageSmartContract_processAllocationRenewals(t *testing.T) {
+	var (
+		ssc              = newTestStorageSC()
+		balances         = newTestBalances(t, false)
+		client           = newClient(50*x10, balances)
+		tp, exp    int64 = 100, 1000
+		allocID, _       = addAllocation(t, ssc, client, tp, exp, 0, balances)
+	)
+	setAutoRenewConfig(t, balances)
+
+	tp += 100
+	require.NoError(t, callAllocationAutoRenew(t, ssc, client.id, 10*x10, tp,
+		&allocationAutoRenewRequest{
+			AllocationID:   allocID,
+			Enable:         true,
+			Period:         600,
+			WritePoolTopUp: 5 * x10,
+		}, balances))
+
+	alloc, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	writePool := alloc.WritePool
+
+	// not due yet
+	callProcessAllocationRenewals(t, ssc, 300, balances)
+	alloc, err = ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, exp, alloc.Expiration)
+
+	// renewed
+	callProcessAllocationRenewals(t, ssc, 900, balances)
+	alloc, err = ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	assert.EqualValues(t, exp+600, alloc.Expiration)
+	assert.True(t, alloc.WritePool > writePool)
+
+	ar, err := ssc.getAllocationRenewal(allocID, balances)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5*x10, ar.Balance)
+	assert.EqualValues(t, 1, ar.Renewals)
+	assert.EqualValues(t, 900, ar.LastRenewal)
+	assert.EqualValues(t, exp+600-120, ar.NextRenewal)
+	assert.Empty(t, ar.LastError)
+
+	// the blobbers don't allow so long offers, retried in the next bucket
+	ar.Period = toSeconds(2 * time.Hour)
+	mustSave(t, allocationRenewalKey(ADDRESS, allocID), ar, balances)
+	callProcessAllocationRenewals(t, ssc, 1500, balances)
+	alloc, err = ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	assert.EqualValues(t, exp+600, alloc.Expiration)
+
+	ar, err = ssc.getAllocationRenewal(allocID, balances)
+	require.NoError(t, err)
+	assert.Contains(t, ar.LastError, "doesn't allow so long offers")
+	assert.EqualValues(t, 5*x10, ar.Balance)
+	assert.EqualValues(t, 1, ar.Renewals)
+	assert.EqualValues(t, renewalBucket(1500)+1, renewalBucket(ar.NextRenewal))
+
+	// expired, the balance is refunded
+	before := balances.balances[client.id]
+	callProcessAllocationRenewals(t, ssc, exp+600+60, balances)
+	ar, err = ssc.getAllocationRenewal(allocID, balances)
+	require.NoError(t, err)
+	assert.False(t, ar.Active)
+	assert.Zero(t, ar.Balance)
+	assert.EqualValues(t, before+5*x10, balances.balances[client.id])
+}
+
+func TestStorageSmartContract_processAllocationRenewalsBuckets(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		now      = 1000 * int64(allocationRenewalBucket)
+	)
+	setAutoRenewConfig(t, balances)
+	mustSave(t, allocationRenewalScheduleKey(ADDRESS), &allocationRenewalSchedule{Processed: 700}, balances)
+
+	processed := func() common.Timestamp {
+		sched, err := ssc.getAllocationRenewalSchedule(common.Timestamp(now), balances)
+		require.NoError(t, err)
+		return sched.Processed
+	}
+
+	// at most allocationRenewalMaxBuckets buckets are processed in a round
+	balances.block = block.NewBlock("", 1)
+	callProcessAllocationRenewals(t, ssc, now, balances)
+	assert.EqualValues(t, 700+allocationRenewalMaxBuckets, processed())
+	callProcessAllocationRenewals(t, ssc, now, balances)
+	assert.EqualValues(t, 700+allocationRenewalMaxBuckets, processed())
+
+	balances.block = block.NewBlock("", 2)
+	callProcessAllocationRenewals(t, ssc, now, balances)
+	assert.EqualValues(t, 700+2*allocationRenewalMaxBuckets, processed())
+}
@@ -66,7 +66,7 @@ func (tb *testBalances) GetTransaction() *transaction.Transaction {
 }
 
 // stubs
-func (tb *testBalances) GetBlock() *block.Block                      { return &block.Block{} }
+func (tb *testBalances) GetBlock() *block.Block                      { return tb.block }
 func (tb *testBalances) GetState() util.MerklePatriciaTrieI          { return nil }
 func (tb *testBalances) GetBlockSharders(b *block.Block) []string    { return nil }
 func (tb *testBalances) Validate() error                             { return nil }
@@ -6,6 +6,7 @@ import (
 	"0chain.net/core/encryption"
 
 	"0chain.net/smartcontract/dbs/benchmark"
+	"0chain.net/smartcontract/dbs/event"
 
 	"0chain.net/chaincore/currency"
 
@@ -16,6 +17,8 @@ import (
 	"0chain.net/core/common"
 	bk "0chain.net/smartcontract/benchmark"
 	"0chain.net/smartcontract/rest"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
 	"github.com/spf13/viper"
 )
 
@@ -91,6 +94,15 @@ func BenchmarkRestTests(
 				},
 				Endpoint: srh.getTransactionHashesByFilter,
 			},
+			{
+				FuncName: "transfers",
+				Params: map[string]string{
+					"transaction_hash": benchmark.GetMockTransactionHash(1, 1),
+					"client_id":        data.Clients[1],
+					"to_client_id":     data.Clients[2],
+				},
+				Endpoint: srh.getTransactionTransfers,
+			},
 			{
 				FuncName: "errors",
 				Params: map[string]string{
@@ -395,6 +407,41 @@ func BenchmarkRestTests(
 				},
 				Endpoint: srh.getSearchHandler,
 			},
+			{
+				FuncName: "provider",
+				Params: map[string]string{
+					"provider_type": spenum.Blobber.String(),
+					"provider_id":   getMockBlobberId(0),
+				},
+				Endpoint: stakepool.GetProviderHandler(rh, spenum.Blobber, spenum.Validator),
+			},
+			{
+				FuncName: "providers",
+				Params: map[string]string{
+					"provider_type": spenum.Blobber.String(),
+					"status":        spenum.ProviderActive.String(),
+					"limit":         "20",
+				},
+				Endpoint: stakepool.GetProvidersHandler(rh, spenum.Blobber, spenum.Validator),
+			},
+			{
+				FuncName: "slashes",
+				Params: map[string]string{
+					"provider_type": spenum.Blobber.String(),
+					"reason":        FailedChallenge,
+					"limit":         "20",
+				},
+				Endpoint: stakepool.GetSlashesHandler(rh, spenum.Blobber, spenum.Validator),
+			},
+			{
+				FuncName: "unbondings",
+				Params: map[string]string{
+					"client_id": data.Clients[0],
+					"status":    event.UnbondingPending,
+					"limit":     "20",
+				},
+				Endpoint: stakepool.GetUnbondingsHandler(rh, spenum.Blobber, spenum.Validator),
+			},
 		},
 		ADDRESS,
 		srh,
@@ -442,6 +442,17 @@ func AddMockBlobbers(
 				panic(err)
 			}
 			_ = eventDb.Store.Get().Create(&blobberDb)
+			_ = eventDb.Store.Get().Create(&event.Provider{
+				ProviderID:     blobber.ID,
+				ProviderType:   int(spenum.Blobber),
+				DelegateWallet: blobber.StakePoolSettings.DelegateWallet,
+				MinStake:       blobber.StakePoolSettings.MinStake,
+				MaxStake:       blobber.StakePoolSettings.MaxStake,
+				NumDelegates:   blobber.StakePoolSettings.MaxNumDelegates,
+				ServiceCharge:  blobber.StakePoolSettings.ServiceChargeRatio,
+				TotalStake:     blobberDb.TotalStake,
+				Status:         spenum.ProviderActive.String(),
+			})
 		}
 
 		if i < numRewardPartitionBlobbers {
@@ -634,6 +645,27 @@ func SaveMockStakePools(
 	}
 }
 
+// AddMockUnbondingQueues adds a matured unbonding entry of a blobber
+// delegate pool to every client.
+func AddMockUnbondingQueues(clients []string, balances cstate.StateContextI) {
+	for i, client := range clients {
+		uq := &stakepool.UnbondingQueue{
+			Entries: []*stakepool.UnbondingEntry{{
+				ID:           encryption.Hash("mock unbonding " + strconv.Itoa(i)),
+				ProviderID:   getMockBlobberId(0),
+				ProviderType: spenum.Blobber,
+				PoolID:       getMockBlobberStakePoolId(0, 0),
+				Amount:       1e10,
+			}},
+		}
+		if _, err := balances.InsertTrieNode(
+			stakepool.UnbondingQueueKey(ADDRESS, client), uq,
+		); err != nil {
+			panic(err)
+		}
+	}
+}
+
 func AddMockFreeStorageAssigners(
 	clients []string,
 	keys []string,
@@ -858,6 +890,21 @@ func SetMockConfig(
 		viper.GetFloat64(sc.StorageBlockRewardBlobberRatio),
 	)
 
+	conf.AutoRenew = &autoRenewConfig{
+		Enabled:     viper.GetBool(sc.StorageAutoRenewEnabled),
+		RenewWindow: viper.GetDuration(sc.StorageAutoRenewRenewWindow),
+		MinPeriod:   viper.GetDuration(sc.StorageAutoRenewMinPeriod),
+		MaxRenewals: viper.GetInt(sc.StorageAutoRenewMaxRenewals),
+	}
+
+	conf.Reputation = &reputationConfig{
+		InitialScore:      viper.GetFloat64(sc.StorageReputationInitialScore),
+		ChallengeWeight:   viper.GetFloat64(sc.StorageReputationChallengeWeight),
+		HealthCheckWeight: viper.GetFloat64(sc.StorageReputationHealthCheckWeight),
+		HalfLife:          viper.GetDuration(sc.StorageReputationHalfLife),
+		SelectionWeight:   viper.GetFloat64(sc.StorageReputationSelectionWeight),
+	}
+
 	conf.ExposeMpt = true
 
 	_, err = balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
@@ -239,6 +239,80 @@ func BenchmarkTests(
 				return bytes
 			}(),
 		},
+		{
+			name:     "storage.update_allocation_auto_renew",
+			endpoint: ssc.updateAllocationAutoRenew,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+				Value:        wpMinLock,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&allocationAutoRenewRequest{
+					AllocationID:   getMockAllocationId(0),
+					Enable:         true,
+					Period:         common.Timestamp(viper.GetDuration(bk.StorageAutoRenewMinPeriod).Seconds()),
+					WritePoolTopUp: wpMinLock,
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.replace_blobber",
+			endpoint: ssc.replaceBlobber,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&replaceBlobberRequest{
+					AllocationID:    getMockAllocationId(0),
+					RemoveBlobberID: getMockBlobberId(0),
+					AddBlobberID:    getMockBlobberId(viper.GetInt(bk.NumBlobbers) - 1),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.shutdown_provider",
+			endpoint: ssc.shutdownProvider,
+			txn: &transaction.Transaction{
+				ClientID:     getMockBlobberId(0),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&stakepool.ProviderRequest{
+					ProviderID:   getMockBlobberId(0),
+					ProviderType: spenum.Blobber,
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.kill_provider",
+			endpoint: ssc.killProvider,
+			txn: &transaction.Transaction{
+				ClientID:     owner,
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&stakepool.ProviderRequest{
+					ProviderID:   getMockBlobberId(0),
+					ProviderType: spenum.Blobber,
+				})
+				return bytes
+			}(),
+		},
 		{
 			name:     "storage.finalize_allocation",
 			endpoint: ssc.finalizeAllocation,
@@ -644,6 +718,34 @@ func BenchmarkTests(
 				return bytes
 			}(),
 		},
+		{
+			name:     "storage.stake_pool_unbond",
+			endpoint: ssc.stakePoolUnbond,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.UnbondRequest{
+				ProviderID:   getMockBlobberId(0),
+				ProviderType: spenum.Blobber,
+				PoolID:       getMockBlobberStakePoolId(0, 0),
+				Amount:       1e10,
+			}).Encode(),
+		},
+		{
+			name:     "storage.stake_pool_claim_unbonded",
+			endpoint: ssc.stakePoolClaimUnbonded,
+			txn: &transaction.Transaction{
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: []byte{},
+		},
 		{
 			name:     "storage.collect_reward",
 			endpoint: ssc.collectReward,
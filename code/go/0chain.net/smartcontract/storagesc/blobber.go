@@ -14,6 +14,7 @@ import (
 	"0chain.net/core/util"
 	"0chain.net/smartcontract/dbs"
 	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool"
 	"0chain.net/smartcontract/stakepool/spenum"
 	"go.uber.org/zap"
 )
@@ -93,6 +94,7 @@ func (sc *StorageSmartContract) updateBlobber(t *transaction.Transaction,
 	blobber.LastHealthCheck = t.CreationDate
 	blobber.Allocated = savedBlobber.Allocated
 	blobber.SavedData = savedBlobber.SavedData
+	blobber.Reputation = savedBlobber.Reputation
 
 	// update statistics
 	sc.statIncr(statUpdateBlobber)
@@ -101,15 +103,14 @@ func (sc *StorageSmartContract) updateBlobber(t *transaction.Transaction,
 		sc.statIncr(statNumberOfBlobbers) // reborn, if it was "removed"
 	}
 
-	if err = validateStakePoolSettings(blobber.StakePoolSettings, conf); err != nil {
-		return fmt.Errorf("invalid new stake pool settings:  %v", err)
-	}
-
 	// update stake pool settings
 	var sp *stakePool
 	if sp, err = sc.getStakePool(blobber.ID, balances); err != nil {
 		return fmt.Errorf("can't get stake pool:  %v", err)
 	}
+	if sp.ProviderStatus != spenum.ProviderActive {
+		return fmt.Errorf("can't update %s blobber", sp.ProviderStatus)
+	}
 
 	stakedCapacity, err := sp.stakedCapacity(blobber.Terms.WritePrice)
 	if err != nil {
@@ -121,18 +122,15 @@ func (sc *StorageSmartContract) updateBlobber(t *transaction.Transaction,
 			stakedCapacity, blobber.Capacity)
 	}
 
-	sp.Settings.MinStake = blobber.StakePoolSettings.MinStake
-	sp.Settings.MaxStake = blobber.StakePoolSettings.MaxStake
-	sp.Settings.ServiceChargeRatio = blobber.StakePoolSettings.ServiceChargeRatio
-	sp.Settings.MaxNumDelegates = blobber.StakePoolSettings.MaxNumDelegates
-
-	if err := emitAddOrOverwriteBlobber(blobber, sp, balances); err != nil {
-		return fmt.Errorf("emmiting blobber %v: %v", blobber, err)
+	err = stakepool.UpdateProviderSettings(
+		newStorageProvider(sc.ID, blobber.ID, spenum.Blobber, sp),
+		blobber.StakePoolSettings, conf.settingsLimits(), balances)
+	if err != nil {
+		return fmt.Errorf("invalid new stake pool settings:  %v", err)
 	}
 
-	// save stake pool
-	if err = sp.save(sc.ID, blobber.ID, balances); err != nil {
-		return fmt.Errorf("saving stake pool: %v", err)
+	if err := emitAddOrOverwriteBlobber(blobber, sp, conf.reputation(), t.CreationDate, balances); err != nil {
+		return fmt.Errorf("emmiting blobber %v: %v", blobber, err)
 	}
 
 	staked, err := sp.stake()
@@ -299,19 +297,29 @@ func (sc *StorageSmartContract) blobberHealthCheck(t *transaction.Transaction,
 ) (string, error) {
 	var (
 		blobber *StorageNode
+		conf    *Config
 		err     error
 	)
 	if blobber, err = sc.getBlobber(t.ClientID, balances); err != nil {
 		return "", common.NewError("blobber_health_check_failed",
 			"can't get the blobber "+t.ClientID+": "+err.Error())
 	}
+	if conf, err = sc.getConfig(balances, true); err != nil {
+		return "", common.NewError("blobber_health_check_failed",
+			"can't get SC configurations: "+err.Error())
+	}
 
 	blobber.LastHealthCheck = t.CreationDate
+	rc := conf.reputation()
+	if rc.HealthCheckWeight > 0 {
+		blobber.Reputation.observe(rc, 1, rc.HealthCheckWeight, t.CreationDate)
+	}
 
 	err = emitUpdateBlobber(blobber, balances)
 	if err != nil {
 		return "", common.NewError("blobber_health_check_failed", err.Error())
 	}
+	emitUpdateBlobberReputation(blobber, rc, t.CreationDate, balances)
 	_, err = balances.InsertTrieNode(blobber.GetKey(sc.ID),
 		blobber)
 	if err != nil {
@@ -858,17 +866,18 @@ func (sc *StorageSmartContract) insertBlobber(t *transaction.Transaction,
 	}
 
 	blobber.LastHealthCheck = t.CreationDate // set to now
+	blobber.Reputation = BlobberReputation{}
 
 	// create stake pool
 	var sp *stakePool
-	sp, err = sc.getOrUpdateStakePool(conf, blobber.ID, spenum.Blobber,
-		blobber.StakePoolSettings, balances)
-	if err != nil {
+	if sp, err = sc.getOrCreateStakePool(blobber.ID, balances); err != nil {
 		return fmt.Errorf("creating stake pool: %v", err)
 	}
-
-	if err = sp.save(sc.ID, t.ClientID, balances); err != nil {
-		return fmt.Errorf("saving stake pool: %v", err)
+	err = stakepool.RegisterProvider(
+		newStorageProvider(sc.ID, blobber.ID, spenum.Blobber, sp),
+		blobber.StakePoolSettings, conf.settingsLimits(), balances)
+	if err != nil {
+		return fmt.Errorf("invalid stake_pool settings: %v", err)
 	}
 
 	staked, err := sp.stake()
@@ -884,7 +893,7 @@ func (sc *StorageSmartContract) insertBlobber(t *transaction.Transaction,
 	balances.EmitEvent(event.TypeStats, event.TagUpdateBlobber, t.ClientID, data)
 
 	// update the list
-	if err := emitAddOrOverwriteBlobber(blobber, sp, balances); err != nil {
+	if err := emitAddOrOverwriteBlobber(blobber, sp, conf.reputation(), t.CreationDate, balances); err != nil {
 		return fmt.Errorf("emmiting blobber %v: %v", blobber, err)
 	}
 
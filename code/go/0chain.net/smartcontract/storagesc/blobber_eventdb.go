@@ -2,13 +2,15 @@ package storagesc
 
 import (
 	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/core/common"
 	"0chain.net/smartcontract/dbs"
 
 	"0chain.net/smartcontract/dbs/event"
 )
 
 func emitAddOrOverwriteBlobber(
-	sn *StorageNode, sp *stakePool, balances cstate.StateContextI,
+	sn *StorageNode, sp *stakePool, conf *reputationConfig, now common.Timestamp,
+	balances cstate.StateContextI,
 ) error {
 	staked, err := sp.stake()
 	if err != nil {
@@ -29,6 +31,9 @@ func emitAddOrOverwriteBlobber(
 		SavedData:       sn.SavedData,
 		LastHealthCheck: int64(sn.LastHealthCheck),
 
+		Reputation:        sn.Reputation.value(conf, now),
+		ReputationUpdated: int64(now),
+
 		DelegateWallet: sn.StakePoolSettings.DelegateWallet,
 		MinStake:       sn.StakePoolSettings.MinStake,
 		MaxStake:       sn.StakePoolSettings.MaxStake,
@@ -552,6 +552,13 @@ func (sc *StorageSmartContract) verifyChallenge(t *transaction.Transaction,
 			}
 		}
 
+		err = sc.updateChallengeReputation(blobber, partialPass(success, threshold),
+			t.CreationDate, balances)
+		if err != nil {
+			return "", common.NewError("verify_challenge",
+				"can't update blobber reputation: "+err.Error())
+		}
+
 		var brStats BlobberRewardNode
 		if err := ongoingParts.GetItem(balances, blobber.RewardPartition.Index, blobber.ID, &brStats); err != nil {
 			return "", common.NewError("verify_challenge",
@@ -596,13 +603,8 @@ func (sc *StorageSmartContract) verifyChallenge(t *transaction.Transaction,
 			return "", common.NewError("verify_challenge", err.Error())
 		}
 
-		var partial = 1.0
-		if success < threshold {
-			partial = float64(success) / float64(threshold)
-		}
-
 		err = sc.blobberReward(t, alloc, latestCompletedChallTime, allocChallenges, blobAlloc,
-			validators, partial, balances)
+			validators, partialPass(success, threshold), balances)
 		if err != nil {
 			return "", common.NewError("challenge_reward_error", err.Error())
 		}
@@ -652,6 +654,17 @@ func (sc *StorageSmartContract) verifyChallenge(t *transaction.Transaction,
 
 		logging.Logger.Info("Challenge failed", zap.Any("challenge", challResp.ID))
 
+		blobber, err := sc.getBlobber(t.ClientID, balances)
+		if err != nil {
+			return "", common.NewError("challenge_penalty_error",
+				"can't get blobber: "+err.Error())
+		}
+		err = sc.updateChallengeReputation(blobber, 0, t.CreationDate, balances)
+		if err != nil {
+			return "", common.NewError("challenge_penalty_error",
+				"can't update blobber reputation: "+err.Error())
+		}
+
 		err = sc.blobberPenalty(t, alloc, latestCompletedChallTime, allocChallenges, blobAlloc,
 			validators, balances)
 		if err != nil {
@@ -676,6 +689,15 @@ func (sc *StorageSmartContract) verifyChallenge(t *transaction.Transaction,
 		"Not enough validations, no successful validations")
 }
 
+// partialPass returns the part of the validators threshold passed by a
+// challenge, 1 if the threshold is reached.
+func partialPass(success, threshold int) float64 {
+	if success < threshold {
+		return float64(success) / float64(threshold)
+	}
+	return 1.0
+}
+
 func (sc *StorageSmartContract) getAllocationForChallenge(
 	t *transaction.Transaction,
 	allocID string,
@@ -1000,17 +1022,32 @@ func (sc *StorageSmartContract) addChallenge(alloc *StorageAllocation,
 	}
 
 	// remove expired challenges
-	expiredIDs, err := alloc.removeExpiredChallenges(allocChallenges, challenge.Created)
+	expired, err := alloc.removeExpiredChallenges(allocChallenges, challenge.Created)
 	if err != nil {
 		return common.NewErrorf("add_challenge", "remove expired challenges: %v", err)
 	}
 
 	// TODO: maybe delete them periodically later instead of remove immediately
-	for _, id := range expiredIDs {
-		_, err := balances.DeleteTrieNode(storageChallengeKey(sc.ID, id))
+	for _, oc := range expired {
+		_, err := balances.DeleteTrieNode(storageChallengeKey(sc.ID, oc.ID))
 		if err != nil {
 			return common.NewErrorf("add_challenge", "could not delete challenge node: %v", err)
 		}
+
+		// the expired challenge is not answered, it fails the blobber
+		blobber, err := sc.getBlobber(oc.BlobberID, balances)
+		if err == util.ErrValueNotPresent {
+			continue
+		}
+		if err != nil {
+			return common.NewErrorf("add_challenge", "can't get blobber %s: %v",
+				oc.BlobberID, err)
+		}
+		err = sc.updateChallengeReputation(blobber, 0, challenge.Created, balances)
+		if err != nil {
+			return common.NewErrorf("add_challenge",
+				"can't update blobber reputation: %v", err)
+		}
 	}
 
 	// add the generated challenge to the open challenges list in the allocation
@@ -8,7 +8,6 @@ import (
 	"0chain.net/smartcontract/dbs"
 	"0chain.net/smartcontract/dbs/event"
 	"0chain.net/smartcontract/stakepool"
-	"0chain.net/smartcontract/stakepool/spenum"
 )
 
 // collectReward mints tokens for delegate rewards.
@@ -23,53 +22,16 @@ func (ssc *StorageSmartContract) collectReward(
 		return "", common.NewErrorf("collect_reward_failed",
 			"can't decode request: %v", err)
 	}
-	if prr.ProviderType != spenum.Blobber && prr.ProviderType != spenum.Validator {
-		return "", common.NewErrorf("collect_reward_failed",
-			"invalid provider type: %s", prr.ProviderType.String())
-	}
-
-	var err error
-	var usp *stakepool.UserStakePools
-	var providerID = prr.ProviderId
-	if len(prr.PoolId) > 0 {
-		usp, err = stakepool.GetUserStakePools(prr.ProviderType, txn.ClientID, balances)
-		if err != nil {
-			return "", common.NewErrorf("collect_reward_failed",
-				"can't get related user stake pools: %v", err)
-		}
-
-		if len(prr.ProviderId) == 0 {
-			providerID = usp.FindProvider(prr.PoolId)
-		}
-	}
-
-	if len(providerID) == 0 {
-		return "", common.NewErrorf("collect_reward_failed",
-			"user %v does not own stake pool %v", txn.ClientID, prr.PoolId)
-	}
-
-	sp, err := ssc.getStakePool(providerID, balances)
-	if err != nil {
-		return "", common.NewErrorf("collect_reward_failed",
-			"can't get related stake pool: %v", err)
+	if err := validateProviderType(prr.ProviderType); err != nil {
+		return "", common.NewError("collect_reward_failed", err.Error())
 	}
 
-	reward, err := sp.MintRewards(
-		txn.ClientID, prr.PoolId, providerID, prr.ProviderType, usp, balances)
+	p, reward, err := stakepool.CollectReward(txn.ClientID, &prr, ssc.getProvider, balances)
 	if err != nil {
-		return "", common.NewErrorf("collect_reward_failed",
-			"error emptying account, %v", err)
-	}
-
-	if err := usp.Save(spenum.Blobber, txn.ClientID, balances); err != nil {
-		return "", common.NewErrorf("collect_reward_failed",
-			"error saving user stake pool, %v", err)
-	}
-
-	if err := sp.save(ssc.ID, providerID, balances); err != nil {
-		return "", common.NewErrorf("collect_reward_failed",
-			"error saving stake pool, %v", err)
+		return "", common.NewError("collect_reward_failed", err.Error())
 	}
+	providerID := p.Id()
+	sp := p.(*storageProvider).sp
 	if reward == 0 {
 		return "", nil
 	}
@@ -11,6 +11,7 @@ import (
 	"0chain.net/chaincore/config"
 	"0chain.net/core/datastore"
 	"0chain.net/core/util"
+	"0chain.net/smartcontract/stakepool/spenum"
 )
 
 //go:generate msgp -io=false -tests=false -unexported=true -v
@@ -32,6 +33,19 @@ type freeAllocationSettings struct {
 type stakePoolConfig struct {
 	MinLock       currency.Coin `json:"min_lock"`
 	MinLockPeriod time.Duration `json:"min_lock_period"`
+	// BlobberUnbondingPeriod and ValidatorUnbondingPeriod are the rounds an
+	// amount unbonded from a delegate pool of a blobber or of a validator
+	// waits before it can be claimed.
+	BlobberUnbondingPeriod   int64 `json:"blobber_unbonding_period"`
+	ValidatorUnbondingPeriod int64 `json:"validator_unbonding_period"`
+}
+
+// unbondingPeriod returns the unbonding period of the provider type
+func (spc *stakePoolConfig) unbondingPeriod(providerType spenum.Provider) int64 {
+	if providerType == spenum.Validator {
+		return spc.ValidatorUnbondingPeriod
+	}
+	return spc.BlobberUnbondingPeriod
 }
 
 type readPoolConfig struct {
@@ -67,6 +81,35 @@ type blockRewardZeta struct {
 	Mu float64 `json:"mu"`
 }
 
+// autoRenewConfig represents the allocations auto renewal configurations.
+type autoRenewConfig struct {
+	// Enabled allows the allocations auto renewal.
+	Enabled bool `json:"enabled"`
+	// RenewWindow is the time before the expiration of an allocation the
+	// allocation is renewed at.
+	RenewWindow time.Duration `json:"renew_window"`
+	// MinPeriod is the minimal expiration extension of a renewal.
+	MinPeriod time.Duration `json:"min_period"`
+	// MaxRenewals is the max number of allocations renewed in a round.
+	MaxRenewals int `json:"max_renewals"`
+}
+
+// reputationConfig represents the blobbers reputation configurations.
+type reputationConfig struct {
+	// InitialScore is the reputation of a blobber without challenges.
+	InitialScore float64 `json:"initial_score"`
+	// ChallengeWeight is the weight of a challenge result in the reputation.
+	ChallengeWeight float64 `json:"challenge_weight"`
+	// HealthCheckWeight is the weight of a health check in the reputation.
+	HealthCheckWeight float64 `json:"health_check_weight"`
+	// HalfLife is the time the reputation halves its distance to the initial
+	// score in.
+	HalfLife time.Duration `json:"half_life"`
+	// SelectionWeight is the weight of the reputation in the allocations
+	// blobbers selection, the selection is uniform for zero.
+	SelectionWeight float64 `json:"selection_weight"`
+}
+
 func (br *blockReward) setWeightsFromRatio(sharderRatio, minerRatio, bRatio float64) {
 	total := sharderRatio + minerRatio + bRatio
 	if total == 0 {
@@ -170,6 +213,12 @@ type Config struct {
 
 	BlockReward *blockReward `json:"block_reward"`
 
+	// AutoRenew represents the allocations auto renewal configurations.
+	AutoRenew *autoRenewConfig `json:"auto_renew"`
+
+	// Reputation represents the blobbers reputation configurations.
+	Reputation *reputationConfig `json:"reputation"`
+
 	// Allow direct access to MPT
 	ExposeMpt bool           `json:"expose_mpt"`
 	OwnerId   string         `json:"owner_id"`
@@ -220,6 +269,14 @@ func (sc *Config) validate() (err error) {
 		return fmt.Errorf("invalid stakepool.min_lock: %v <= 1",
 			sc.StakePool.MinLock)
 	}
+	if sc.StakePool.BlobberUnbondingPeriod < 0 {
+		return fmt.Errorf("negative stakepool.blobber_unbonding_period: %v",
+			sc.StakePool.BlobberUnbondingPeriod)
+	}
+	if sc.StakePool.ValidatorUnbondingPeriod < 0 {
+		return fmt.Errorf("negative stakepool.validator_unbonding_period: %v",
+			sc.StakePool.ValidatorUnbondingPeriod)
+	}
 
 	if sc.FreeAllocationSettings.DataShards < 0 {
 		return fmt.Errorf("negative free_allocation_settings.data_shards: %v",
@@ -321,22 +378,56 @@ func (sc *Config) validate() (err error) {
 		return fmt.Errorf("invalid block_reward.zeta.k <=0: %v", sc.BlockReward.Zeta.K)
 	}
 
+	if ar := sc.AutoRenew; ar != nil && ar.Enabled {
+		if ar.RenewWindow <= 0 {
+			return fmt.Errorf("invalid auto_renew.renew_window <= 0: %v", ar.RenewWindow)
+		}
+		if ar.MinPeriod < sc.MinAllocDuration {
+			return fmt.Errorf("auto_renew.min_period less than min_alloc_duration: %v < %v",
+				ar.MinPeriod, sc.MinAllocDuration)
+		}
+		if ar.MaxRenewals <= 0 {
+			return fmt.Errorf("invalid auto_renew.max_renewals <= 0: %v", ar.MaxRenewals)
+		}
+	}
+
+	if rc := sc.Reputation; rc != nil {
+		if rc.InitialScore < 0 || rc.InitialScore > 1 {
+			return fmt.Errorf("reputation.initial_score not in [0, 1] range: %v", rc.InitialScore)
+		}
+		if rc.ChallengeWeight < 0 || rc.ChallengeWeight > 1 {
+			return fmt.Errorf("reputation.challenge_weight not in [0, 1] range: %v", rc.ChallengeWeight)
+		}
+		if rc.HealthCheckWeight < 0 || rc.HealthCheckWeight > 1 {
+			return fmt.Errorf("reputation.health_check_weight not in [0, 1] range: %v", rc.HealthCheckWeight)
+		}
+		if rc.HalfLife < 0 {
+			return fmt.Errorf("negative reputation.half_life: %v", rc.HalfLife)
+		}
+		if rc.SelectionWeight < 0 || rc.SelectionWeight > 1 {
+			return fmt.Errorf("reputation.selection_weight not in [0, 1] range: %v", rc.SelectionWeight)
+		}
+	}
+
 	return
 }
 
-func (conf *Config) validateStakeRange(min, max currency.Coin) (err error) {
-	if min < conf.MinStake {
-		return fmt.Errorf("min_stake is less than allowed by SC: %v < %v", min,
-			conf.MinStake)
+// autoRenew - the allocations auto renewal configurations, disabled for the
+// configurations stored before the auto renewal was introduced
+func (conf *Config) autoRenew() *autoRenewConfig {
+	if conf.AutoRenew == nil {
+		conf.AutoRenew = new(autoRenewConfig)
 	}
-	if max > conf.MaxStake {
-		return fmt.Errorf("max_stake is greater than allowed by SC: %v > %v",
-			max, conf.MaxStake)
-	}
-	if max < min {
-		return fmt.Errorf("max_stake less than min_stake: %v < %v", max, min)
+	return conf.AutoRenew
+}
+
+// reputation - the blobbers reputation configurations, the reputation is
+// not maintained for the configurations stored before it was introduced
+func (conf *Config) reputation() *reputationConfig {
+	if conf.Reputation == nil {
+		conf.Reputation = new(reputationConfig)
 	}
-	return
+	return conf.Reputation
 }
 
 func (conf *Config) Encode() (b []byte) {
@@ -414,6 +505,8 @@ func getConfiguredConfig() (conf *Config, err error) {
 	if err != nil {
 		return nil, err
 	}
+	conf.StakePool.BlobberUnbondingPeriod = scc.GetInt64(pfx + "stakepool.blobber_unbonding_period")
+	conf.StakePool.ValidatorUnbondingPeriod = scc.GetInt64(pfx + "stakepool.validator_unbonding_period")
 
 	conf.MaxTotalFreeAllocation, err = currency.MultFloat64(1e10, scc.GetFloat64(pfx+"max_total_free_allocation"))
 	if err != nil {
@@ -503,6 +596,19 @@ func getConfiguredConfig() (conf *Config, err error) {
 	conf.BlockReward.Zeta.K = scc.GetFloat64(pfx + "block_reward.zeta.k")
 	conf.BlockReward.Zeta.Mu = scc.GetFloat64(pfx + "block_reward.zeta.mu")
 
+	conf.AutoRenew = new(autoRenewConfig)
+	conf.AutoRenew.Enabled = scc.GetBool(pfx + "auto_renew.enabled")
+	conf.AutoRenew.RenewWindow = scc.GetDuration(pfx + "auto_renew.renew_window")
+	conf.AutoRenew.MinPeriod = scc.GetDuration(pfx + "auto_renew.min_period")
+	conf.AutoRenew.MaxRenewals = scc.GetInt(pfx + "auto_renew.max_renewals")
+
+	conf.Reputation = new(reputationConfig)
+	conf.Reputation.InitialScore = scc.GetFloat64(pfx + "reputation.initial_score")
+	conf.Reputation.ChallengeWeight = scc.GetFloat64(pfx + "reputation.challenge_weight")
+	conf.Reputation.HealthCheckWeight = scc.GetFloat64(pfx + "reputation.health_check_weight")
+	conf.Reputation.HalfLife = scc.GetDuration(pfx + "reputation.half_life")
+	conf.Reputation.SelectionWeight = scc.GetFloat64(pfx + "reputation.selection_weight")
+
 	conf.ExposeMpt = scc.GetBool(pfx + "expose_mpt")
 	conf.OwnerId = scc.GetString(pfx + "owner_id")
 	conf.Cost = scc.GetStringMapInt(pfx + "cost")
@@ -9,9 +9,9 @@ import (
 // MarshalMsg implements msgp.Marshaler
 func (z *Config) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 34
+	// map header, size 36
 	// string "TimeUnit"
-	o = append(o, 0xde, 0x0, 0x22, 0xa8, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x74)
+	o = append(o, 0xde, 0x0, 0x24, 0xa8, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x74)
 	o = msgp.AppendDuration(o, z.TimeUnit)
 	// string "MaxMint"
 	o = append(o, 0xa7, 0x4d, 0x61, 0x78, 0x4d, 0x69, 0x6e, 0x74)
@@ -75,17 +75,11 @@ func (z *Config) MarshalMsg(b []byte) (o []byte, err error) {
 	if z.StakePool == nil {
 		o = msgp.AppendNil(o)
 	} else {
-		// map header, size 2
-		// string "MinLock"
-		o = append(o, 0x82, 0xa7, 0x4d, 0x69, 0x6e, 0x4c, 0x6f, 0x63, 0x6b)
-		o, err = z.StakePool.MinLock.MarshalMsg(o)
+		o, err = z.StakePool.MarshalMsg(o)
 		if err != nil {
-			err = msgp.WrapError(err, "StakePool", "MinLock")
+			err = msgp.WrapError(err, "StakePool")
 			return
 		}
-		// string "MinLockPeriod"
-		o = append(o, 0xad, 0x4d, 0x69, 0x6e, 0x4c, 0x6f, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
-		o = msgp.AppendDuration(o, z.StakePool.MinLockPeriod)
 	}
 	// string "ValidatorReward"
 	o = append(o, 0xaf, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64)
@@ -187,6 +181,28 @@ func (z *Config) MarshalMsg(b []byte) (o []byte, err error) {
 			return
 		}
 	}
+	// string "AutoRenew"
+	o = append(o, 0xa9, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x6e, 0x65, 0x77)
+	if z.AutoRenew == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.AutoRenew.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "AutoRenew")
+			return
+		}
+	}
+	// string "Reputation"
+	o = append(o, 0xaa, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+	if z.Reputation == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.Reputation.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Reputation")
+			return
+		}
+	}
 	// string "ExposeMpt"
 	o = append(o, 0xa9, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x4d, 0x70, 0x74)
 	o = msgp.AppendBool(o, z.ExposeMpt)
@@ -366,40 +382,11 @@ func (z *Config) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				if z.StakePool == nil {
 					z.StakePool = new(stakePoolConfig)
 				}
-				var zb0004 uint32
-				zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+				bts, err = z.StakePool.UnmarshalMsg(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "StakePool")
 					return
 				}
-				for zb0004 > 0 {
-					zb0004--
-					field, bts, err = msgp.ReadMapKeyZC(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "StakePool")
-						return
-					}
-					switch msgp.UnsafeString(field) {
-					case "MinLock":
-						bts, err = z.StakePool.MinLock.UnmarshalMsg(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "StakePool", "MinLock")
-							return
-						}
-					case "MinLockPeriod":
-						z.StakePool.MinLockPeriod, bts, err = msgp.ReadDurationBytes(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "StakePool", "MinLockPeriod")
-							return
-						}
-					default:
-						bts, err = msgp.Skip(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "StakePool")
-							return
-						}
-					}
-				}
 			}
 		case "ValidatorReward":
 			z.ValidatorReward, bts, err = msgp.ReadFloat64Bytes(bts)
@@ -532,6 +519,40 @@ func (z *Config) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					return
 				}
 			}
+		case "AutoRenew":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.AutoRenew = nil
+			} else {
+				if z.AutoRenew == nil {
+					z.AutoRenew = new(autoRenewConfig)
+				}
+				bts, err = z.AutoRenew.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "AutoRenew")
+					return
+				}
+			}
+		case "Reputation":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Reputation = nil
+			} else {
+				if z.Reputation == nil {
+					z.Reputation = new(reputationConfig)
+				}
+				bts, err = z.Reputation.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Reputation")
+					return
+				}
+			}
 		case "ExposeMpt":
 			z.ExposeMpt, bts, err = msgp.ReadBoolBytes(bts)
 			if err != nil {
@@ -545,23 +566,23 @@ func (z *Config) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				return
 			}
 		case "Cost":
-			var zb0005 uint32
-			zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "Cost")
 				return
 			}
 			if z.Cost == nil {
-				z.Cost = make(map[string]int, zb0005)
+				z.Cost = make(map[string]int, zb0004)
 			} else if len(z.Cost) > 0 {
 				for key := range z.Cost {
 					delete(z.Cost, key)
 				}
 			}
-			for zb0005 > 0 {
+			for zb0004 > 0 {
 				var za0001 string
 				var za0002 int
-				zb0005--
+				zb0004--
 				za0001, bts, err = msgp.ReadStringBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "Cost")
@@ -604,7 +625,7 @@ func (z *Config) Msgsize() (s int) {
 	if z.StakePool == nil {
 		s += msgp.NilSize
 	} else {
-		s += 1 + 8 + z.StakePool.MinLock.Msgsize() + 14 + msgp.DurationSize
+		s += z.StakePool.Msgsize()
 	}
 	s += 16 + msgp.Float64Size + 13 + msgp.Float64Size + 25 + msgp.IntSize + 13 + z.MaxReadPrice.Msgsize() + 14 + z.MaxWritePrice.Msgsize() + 14 + z.MinWritePrice.Msgsize() + 25 + msgp.IntSize + 32 + msgp.IntSize + 23 + z.MaxTotalFreeAllocation.Msgsize() + 28 + z.MaxIndividualFreeAllocation.Msgsize() + 23 + z.FreeAllocationSettings.Msgsize() + 17 + msgp.BoolSize + 27 + msgp.IntSize + 23 + msgp.IntSize + 24 + msgp.Float64Size + 9 + z.MinStake.Msgsize() + 9 + z.MaxStake.Msgsize() + 13 + msgp.IntSize + 10 + msgp.Float64Size + 12
 	if z.BlockReward == nil {
@@ -612,6 +633,18 @@ func (z *Config) Msgsize() (s int) {
 	} else {
 		s += z.BlockReward.Msgsize()
 	}
+	s += 10
+	if z.AutoRenew == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.AutoRenew.Msgsize()
+	}
+	s += 11
+	if z.Reputation == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.Reputation.Msgsize()
+	}
 	s += 10 + msgp.BoolSize + 8 + msgp.StringPrefixSize + len(z.OwnerId) + 5 + msgp.MapHeaderSize
 	if z.Cost != nil {
 		for za0001, za0002 := range z.Cost {
@@ -622,6 +655,85 @@ func (z *Config) Msgsize() (s int) {
 	return
 }
 
+// MarshalMsg implements msgp.Marshaler
+func (z *autoRenewConfig) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Enabled"
+	o = append(o, 0x84, 0xa7, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.Enabled)
+	// string "RenewWindow"
+	o = append(o, 0xab, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77)
+	o = msgp.AppendDuration(o, z.RenewWindow)
+	// string "MinPeriod"
+	o = append(o, 0xa9, 0x4d, 0x69, 0x6e, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = msgp.AppendDuration(o, z.MinPeriod)
+	// string "MaxRenewals"
+	o = append(o, 0xab, 0x4d, 0x61, 0x78, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x61, 0x6c, 0x73)
+	o = msgp.AppendInt(o, z.MaxRenewals)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *autoRenewConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Enabled":
+			z.Enabled, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Enabled")
+				return
+			}
+		case "RenewWindow":
+			z.RenewWindow, bts, err = msgp.ReadDurationBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "RenewWindow")
+				return
+			}
+		case "MinPeriod":
+			z.MinPeriod, bts, err = msgp.ReadDurationBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MinPeriod")
+				return
+			}
+		case "MaxRenewals":
+			z.MaxRenewals, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxRenewals")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *autoRenewConfig) Msgsize() (s int) {
+	s = 1 + 8 + msgp.BoolSize + 12 + msgp.DurationSize + 10 + msgp.DurationSize + 12 + msgp.IntSize
+	return
+}
+
 // MarshalMsg implements msgp.Marshaler
 func (z *blockReward) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
@@ -1161,12 +1273,100 @@ func (z *readPoolConfig) Msgsize() (s int) {
 	return
 }
 
+// MarshalMsg implements msgp.Marshaler
+func (z *reputationConfig) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 5
+	// string "InitialScore"
+	o = append(o, 0x85, 0xac, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x63, 0x6f, 0x72, 0x65)
+	o = msgp.AppendFloat64(o, z.InitialScore)
+	// string "ChallengeWeight"
+	o = append(o, 0xaf, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendFloat64(o, z.ChallengeWeight)
+	// string "HealthCheckWeight"
+	o = append(o, 0xb1, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendFloat64(o, z.HealthCheckWeight)
+	// string "HalfLife"
+	o = append(o, 0xa8, 0x48, 0x61, 0x6c, 0x66, 0x4c, 0x69, 0x66, 0x65)
+	o = msgp.AppendDuration(o, z.HalfLife)
+	// string "SelectionWeight"
+	o = append(o, 0xaf, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendFloat64(o, z.SelectionWeight)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *reputationConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "InitialScore":
+			z.InitialScore, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "InitialScore")
+				return
+			}
+		case "ChallengeWeight":
+			z.ChallengeWeight, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ChallengeWeight")
+				return
+			}
+		case "HealthCheckWeight":
+			z.HealthCheckWeight, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "HealthCheckWeight")
+				return
+			}
+		case "HalfLife":
+			z.HalfLife, bts, err = msgp.ReadDurationBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "HalfLife")
+				return
+			}
+		case "SelectionWeight":
+			z.SelectionWeight, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SelectionWeight")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *reputationConfig) Msgsize() (s int) {
+	s = 1 + 13 + msgp.Float64Size + 16 + msgp.Float64Size + 18 + msgp.Float64Size + 9 + msgp.DurationSize + 16 + msgp.Float64Size
+	return
+}
+
 // MarshalMsg implements msgp.Marshaler
 func (z *stakePoolConfig) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
+	// map header, size 4
 	// string "MinLock"
-	o = append(o, 0x82, 0xa7, 0x4d, 0x69, 0x6e, 0x4c, 0x6f, 0x63, 0x6b)
+	o = append(o, 0x84, 0xa7, 0x4d, 0x69, 0x6e, 0x4c, 0x6f, 0x63, 0x6b)
 	o, err = z.MinLock.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "MinLock")
@@ -1175,6 +1375,12 @@ func (z *stakePoolConfig) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "MinLockPeriod"
 	o = append(o, 0xad, 0x4d, 0x69, 0x6e, 0x4c, 0x6f, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
 	o = msgp.AppendDuration(o, z.MinLockPeriod)
+	// string "BlobberUnbondingPeriod"
+	o = append(o, 0xb6, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = msgp.AppendInt64(o, z.BlobberUnbondingPeriod)
+	// string "ValidatorUnbondingPeriod"
+	o = append(o, 0xb8, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = msgp.AppendInt64(o, z.ValidatorUnbondingPeriod)
 	return
 }
 
@@ -1208,6 +1414,18 @@ func (z *stakePoolConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "MinLockPeriod")
 				return
 			}
+		case "BlobberUnbondingPeriod":
+			z.BlobberUnbondingPeriod, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BlobberUnbondingPeriod")
+				return
+			}
+		case "ValidatorUnbondingPeriod":
+			z.ValidatorUnbondingPeriod, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ValidatorUnbondingPeriod")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -1222,7 +1440,7 @@ func (z *stakePoolConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *stakePoolConfig) Msgsize() (s int) {
-	s = 1 + 8 + z.MinLock.Msgsize() + 14 + msgp.DurationSize
+	s = 1 + 8 + z.MinLock.Msgsize() + 14 + msgp.DurationSize + 23 + msgp.Int64Size + 25 + msgp.Int64Size
 	return
 }
 
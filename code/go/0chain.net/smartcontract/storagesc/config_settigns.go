@@ -43,6 +43,8 @@ const (
 
 	StakePoolMinLock
 	StakePoolMinLockPeriod
+	StakePoolBlobberUnbondingPeriod
+	StakePoolValidatorUnbondingPeriod
 
 	MaxTotalFreeAllocation
 	MaxIndividualFreeAllocation
@@ -83,6 +85,17 @@ const (
 	BlockRewardZetaK
 	BlockRewardZetaMu
 
+	AutoRenewEnabled
+	AutoRenewRenewWindow
+	AutoRenewMinPeriod
+	AutoRenewMaxRenewals
+
+	ReputationInitialScore
+	ReputationChallengeWeight
+	ReputationHealthCheckWeight
+	ReputationHalfLife
+	ReputationSelectionWeight
+
 	ExposeMpt
 
 	OwnerId
@@ -120,6 +133,10 @@ const (
 	CostStakePoolPayInterests
 	CostCommitSettingsChanges
 	CostCollectReward
+	CostUpdateAllocationAutoRenew
+	CostReplaceBlobber
+	CostShutdownProvider
+	CostKillProvider
 )
 
 var (
@@ -136,6 +153,8 @@ var (
 		"writepool.min_lock",
 		"stakepool.min_lock",
 		"stakepool.min_lock_period",
+		"stakepool.blobber_unbonding_period",
+		"stakepool.validator_unbonding_period",
 
 		"max_total_free_allocation",
 		"max_individual_free_allocation",
@@ -176,6 +195,17 @@ var (
 		"block_reward.zeta.k",
 		"block_reward.zeta.mu",
 
+		"auto_renew.enabled",
+		"auto_renew.renew_window",
+		"auto_renew.min_period",
+		"auto_renew.max_renewals",
+
+		"reputation.initial_score",
+		"reputation.challenge_weight",
+		"reputation.health_check_weight",
+		"reputation.half_life",
+		"reputation.selection_weight",
+
 		"expose_mpt",
 
 		"owner_id",
@@ -213,6 +243,10 @@ var (
 		"cost.stake_pool_pay_interests",
 		"cost.commit_settings_changes",
 		"cost.collect_reward",
+		"cost.update_allocation_auto_renew",
+		"cost.replace_blobber",
+		"cost.shutdown_provider",
+		"cost.kill_provider",
 	}
 
 	NumberOfSettings = len(SettingName)
@@ -229,10 +263,12 @@ var (
 		"min_offer_duration":            {MinOfferDuration, smartcontract.Duration},
 		"min_blobber_capacity":          {MinBlobberCapacity, smartcontract.Int64},
 
-		"readpool.min_lock":         {ReadPoolMinLock, smartcontract.CurrencyCoin},
-		"writepool.min_lock":        {WritePoolMinLock, smartcontract.CurrencyCoin},
-		"stakepool.min_lock":        {StakePoolMinLock, smartcontract.CurrencyCoin},
-		"stakepool.min_lock_period": {StakePoolMinLockPeriod, smartcontract.Duration},
+		"readpool.min_lock":                    {ReadPoolMinLock, smartcontract.CurrencyCoin},
+		"writepool.min_lock":                   {WritePoolMinLock, smartcontract.CurrencyCoin},
+		"stakepool.min_lock":                   {StakePoolMinLock, smartcontract.CurrencyCoin},
+		"stakepool.min_lock_period":            {StakePoolMinLockPeriod, smartcontract.Duration},
+		"stakepool.blobber_unbonding_period":   {StakePoolBlobberUnbondingPeriod, smartcontract.Int64},
+		"stakepool.validator_unbonding_period": {StakePoolValidatorUnbondingPeriod, smartcontract.Int64},
 
 		"max_total_free_allocation":      {MaxTotalFreeAllocation, smartcontract.CurrencyCoin},
 		"max_individual_free_allocation": {MaxIndividualFreeAllocation, smartcontract.CurrencyCoin},
@@ -273,43 +309,58 @@ var (
 		"block_reward.zeta.k":           {BlockRewardZetaK, smartcontract.Float64},
 		"block_reward.zeta.mu":          {BlockRewardZetaMu, smartcontract.Float64},
 
+		"auto_renew.enabled":      {AutoRenewEnabled, smartcontract.Boolean},
+		"auto_renew.renew_window": {AutoRenewRenewWindow, smartcontract.Duration},
+		"auto_renew.min_period":   {AutoRenewMinPeriod, smartcontract.Duration},
+		"auto_renew.max_renewals": {AutoRenewMaxRenewals, smartcontract.Int},
+
+		"reputation.initial_score":       {ReputationInitialScore, smartcontract.Float64},
+		"reputation.challenge_weight":    {ReputationChallengeWeight, smartcontract.Float64},
+		"reputation.health_check_weight": {ReputationHealthCheckWeight, smartcontract.Float64},
+		"reputation.half_life":           {ReputationHalfLife, smartcontract.Duration},
+		"reputation.selection_weight":    {ReputationSelectionWeight, smartcontract.Float64},
+
 		"expose_mpt": {ExposeMpt, smartcontract.Boolean},
 
 		"owner_id": {OwnerId, smartcontract.Key},
 
-		"cost":                             {Cost, smartcontract.Cost},
-		"cost.update_settings":             {CostUpdateSettings, smartcontract.Cost},
-		"cost.read_redeem":                 {CostReadRedeem, smartcontract.Cost},
-		"cost.commit_connection":           {CostCommitConnection, smartcontract.Cost},
-		"cost.new_allocation_request":      {CostNewAllocationRequest, smartcontract.Cost},
-		"cost.update_allocation_request":   {CostUpdateAllocationRequest, smartcontract.Cost},
-		"cost.finalize_allocation":         {CostFinalizeAllocation, smartcontract.Cost},
-		"cost.cancel_allocation":           {CostCancelAllocation, smartcontract.Cost},
-		"cost.add_free_storage_assigner":   {CostAddFreeStorageAssigner, smartcontract.Cost},
-		"cost.free_allocation_request":     {CostFreeAllocationRequest, smartcontract.Cost},
-		"cost.free_update_allocation":      {CostFreeUpdateAllocation, smartcontract.Cost},
-		"cost.add_curator":                 {CostAddCurator, smartcontract.Cost},
-		"cost.remove_curator":              {CostRemoveCurator, smartcontract.Cost},
-		"cost.blobber_health_check":        {CostBlobberHealthCheck, smartcontract.Cost},
-		"cost.update_blobber_settings":     {CostUpdateBlobberSettings, smartcontract.Cost},
-		"cost.pay_blobber_block_rewards":   {CostPayBlobberBlockRewards, smartcontract.Cost},
-		"cost.curator_transfer_allocation": {CostCuratorTransferAllocation, smartcontract.Cost},
-		"cost.challenge_request":           {CostChallengeRequest, smartcontract.Cost},
-		"cost.challenge_response":          {CostChallengeResponse, smartcontract.Cost},
-		"cost.generate_challenges":         {CostGenerateChallenges, smartcontract.Cost},
-		"cost.add_validator":               {CostAddValidator, smartcontract.Cost},
-		"cost.update_validator_settings":   {CostUpdateValidatorSettings, smartcontract.Cost},
-		"cost.add_blobber":                 {CostAddBlobber, smartcontract.Cost},
-		"cost.new_read_pool":               {CostNewReadPool, smartcontract.Cost},
-		"cost.read_pool_lock":              {CostReadPoolLock, smartcontract.Cost},
-		"cost.read_pool_unlock":            {CostReadPoolUnlock, smartcontract.Cost},
-		"cost.write_pool_lock":             {CostWritePoolLock, smartcontract.Cost},
-		"cost.write_pool_unlock":           {CostWritePoolUnlock, smartcontract.Cost},
-		"cost.stake_pool_lock":             {CostStakePoolLock, smartcontract.Cost},
-		"cost.stake_pool_unlock":           {CostStakePoolUnlock, smartcontract.Cost},
-		"cost.stake_pool_pay_interests":    {CostStakePoolPayInterests, smartcontract.Cost},
-		"cost.commit_settings_changes":     {CostCommitSettingsChanges, smartcontract.Cost},
-		"cost.collect_reward":              {CostCollectReward, smartcontract.Cost},
+		"cost":                              {Cost, smartcontract.Cost},
+		"cost.update_settings":              {CostUpdateSettings, smartcontract.Cost},
+		"cost.read_redeem":                  {CostReadRedeem, smartcontract.Cost},
+		"cost.commit_connection":            {CostCommitConnection, smartcontract.Cost},
+		"cost.new_allocation_request":       {CostNewAllocationRequest, smartcontract.Cost},
+		"cost.update_allocation_request":    {CostUpdateAllocationRequest, smartcontract.Cost},
+		"cost.finalize_allocation":          {CostFinalizeAllocation, smartcontract.Cost},
+		"cost.cancel_allocation":            {CostCancelAllocation, smartcontract.Cost},
+		"cost.add_free_storage_assigner":    {CostAddFreeStorageAssigner, smartcontract.Cost},
+		"cost.free_allocation_request":      {CostFreeAllocationRequest, smartcontract.Cost},
+		"cost.free_update_allocation":       {CostFreeUpdateAllocation, smartcontract.Cost},
+		"cost.add_curator":                  {CostAddCurator, smartcontract.Cost},
+		"cost.remove_curator":               {CostRemoveCurator, smartcontract.Cost},
+		"cost.blobber_health_check":         {CostBlobberHealthCheck, smartcontract.Cost},
+		"cost.update_blobber_settings":      {CostUpdateBlobberSettings, smartcontract.Cost},
+		"cost.pay_blobber_block_rewards":    {CostPayBlobberBlockRewards, smartcontract.Cost},
+		"cost.curator_transfer_allocation":  {CostCuratorTransferAllocation, smartcontract.Cost},
+		"cost.challenge_request":            {CostChallengeRequest, smartcontract.Cost},
+		"cost.challenge_response":           {CostChallengeResponse, smartcontract.Cost},
+		"cost.generate_challenges":          {CostGenerateChallenges, smartcontract.Cost},
+		"cost.add_validator":                {CostAddValidator, smartcontract.Cost},
+		"cost.update_validator_settings":    {CostUpdateValidatorSettings, smartcontract.Cost},
+		"cost.add_blobber":                  {CostAddBlobber, smartcontract.Cost},
+		"cost.new_read_pool":                {CostNewReadPool, smartcontract.Cost},
+		"cost.read_pool_lock":               {CostReadPoolLock, smartcontract.Cost},
+		"cost.read_pool_unlock":             {CostReadPoolUnlock, smartcontract.Cost},
+		"cost.write_pool_lock":              {CostWritePoolLock, smartcontract.Cost},
+		"cost.write_pool_unlock":            {CostWritePoolUnlock, smartcontract.Cost},
+		"cost.stake_pool_lock":              {CostStakePoolLock, smartcontract.Cost},
+		"cost.stake_pool_unlock":            {CostStakePoolUnlock, smartcontract.Cost},
+		"cost.stake_pool_pay_interests":     {CostStakePoolPayInterests, smartcontract.Cost},
+		"cost.commit_settings_changes":      {CostCommitSettingsChanges, smartcontract.Cost},
+		"cost.collect_reward":               {CostCollectReward, smartcontract.Cost},
+		"cost.update_allocation_auto_renew": {CostUpdateAllocationAutoRenew, smartcontract.Cost},
+		"cost.replace_blobber":              {CostReplaceBlobber, smartcontract.Cost},
+		"cost.shutdown_provider":            {CostShutdownProvider, smartcontract.Cost},
+		"cost.kill_provider":                {CostKillProvider, smartcontract.Cost},
 	}
 )
 
@@ -352,6 +403,8 @@ func (conf *Config) setInt(key string, change int) error {
 		conf.ValidatorsPerChallenge = change
 	case MaxDelegates:
 		conf.MaxDelegates = change
+	case AutoRenewMaxRenewals:
+		conf.autoRenew().MaxRenewals = change
 	default:
 		return fmt.Errorf("key: %v not implemented as int", key)
 	}
@@ -421,6 +474,16 @@ func (conf *Config) setInt64(key string, change int64) error {
 		conf.MinBlobberCapacity = change
 	case FreeAllocationSize:
 		conf.FreeAllocationSettings.Size = change
+	case StakePoolBlobberUnbondingPeriod:
+		if conf.StakePool == nil {
+			conf.StakePool = &stakePoolConfig{}
+		}
+		conf.StakePool.BlobberUnbondingPeriod = change
+	case StakePoolValidatorUnbondingPeriod:
+		if conf.StakePool == nil {
+			conf.StakePool = &stakePoolConfig{}
+		}
+		conf.StakePool.ValidatorUnbondingPeriod = change
 	default:
 		return fmt.Errorf("key: %v not implemented as int64", key)
 	}
@@ -483,6 +546,14 @@ func (conf *Config) setFloat64(key string, change float64) error {
 			conf.BlockReward = &blockReward{}
 		}
 		conf.BlockReward.Zeta.Mu = change
+	case ReputationInitialScore:
+		conf.reputation().InitialScore = change
+	case ReputationChallengeWeight:
+		conf.reputation().ChallengeWeight = change
+	case ReputationHealthCheckWeight:
+		conf.reputation().HealthCheckWeight = change
+	case ReputationSelectionWeight:
+		conf.reputation().SelectionWeight = change
 	default:
 		return fmt.Errorf("key: %v not implemented as float64", key)
 	}
@@ -506,6 +577,12 @@ func (conf *Config) setDuration(key string, change time.Duration) error {
 		conf.StakePool.MinLockPeriod = change
 	case FreeAllocationDuration:
 		conf.FreeAllocationSettings.Duration = change
+	case AutoRenewRenewWindow:
+		conf.autoRenew().RenewWindow = change
+	case AutoRenewMinPeriod:
+		conf.autoRenew().MinPeriod = change
+	case ReputationHalfLife:
+		conf.reputation().HalfLife = change
 	default:
 		return fmt.Errorf("key: %v not implemented as duration", key)
 	}
@@ -516,6 +593,8 @@ func (conf *Config) setBoolean(key string, change bool) error {
 	switch Settings[key].setting {
 	case ChallengeEnabled:
 		conf.ChallengeEnabled = change
+	case AutoRenewEnabled:
+		conf.autoRenew().Enabled = change
 	case ExposeMpt:
 		conf.ExposeMpt = change
 	default:
@@ -644,6 +723,10 @@ func (conf *Config) get(key Setting) interface{} {
 		return conf.StakePool.MinLock
 	case StakePoolMinLockPeriod:
 		return conf.StakePool.MinLockPeriod
+	case StakePoolBlobberUnbondingPeriod:
+		return conf.StakePool.BlobberUnbondingPeriod
+	case StakePoolValidatorUnbondingPeriod:
+		return conf.StakePool.ValidatorUnbondingPeriod
 	case MaxTotalFreeAllocation:
 		return conf.MaxTotalFreeAllocation
 	case MaxIndividualFreeAllocation:
@@ -714,6 +797,24 @@ func (conf *Config) get(key Setting) interface{} {
 		return conf.BlockReward.Zeta.K
 	case BlockRewardZetaMu:
 		return conf.BlockReward.Zeta.Mu
+	case AutoRenewEnabled:
+		return conf.autoRenew().Enabled
+	case AutoRenewRenewWindow:
+		return conf.autoRenew().RenewWindow
+	case AutoRenewMinPeriod:
+		return conf.autoRenew().MinPeriod
+	case AutoRenewMaxRenewals:
+		return conf.autoRenew().MaxRenewals
+	case ReputationInitialScore:
+		return conf.reputation().InitialScore
+	case ReputationChallengeWeight:
+		return conf.reputation().ChallengeWeight
+	case ReputationHealthCheckWeight:
+		return conf.reputation().HealthCheckWeight
+	case ReputationHalfLife:
+		return conf.reputation().HalfLife
+	case ReputationSelectionWeight:
+		return conf.reputation().SelectionWeight
 	case ExposeMpt:
 		return conf.ExposeMpt
 	case OwnerId:
@@ -784,6 +885,14 @@ func (conf *Config) get(key Setting) interface{} {
 		return conf.Cost[strings.ToLower(strings.TrimPrefix(SettingName[CostCommitSettingsChanges], fmt.Sprintf("%s.", SettingName[Cost])))]
 	case CostCollectReward:
 		return conf.Cost[strings.ToLower(strings.TrimPrefix(SettingName[CostCollectReward], fmt.Sprintf("%s.", SettingName[Cost])))]
+	case CostUpdateAllocationAutoRenew:
+		return conf.Cost[strings.ToLower(strings.TrimPrefix(SettingName[CostUpdateAllocationAutoRenew], fmt.Sprintf("%s.", SettingName[Cost])))]
+	case CostReplaceBlobber:
+		return conf.Cost[strings.ToLower(strings.TrimPrefix(SettingName[CostReplaceBlobber], fmt.Sprintf("%s.", SettingName[Cost])))]
+	case CostShutdownProvider:
+		return conf.Cost[strings.ToLower(strings.TrimPrefix(SettingName[CostShutdownProvider], fmt.Sprintf("%s.", SettingName[Cost])))]
+	case CostKillProvider:
+		return conf.Cost[strings.ToLower(strings.TrimPrefix(SettingName[CostKillProvider], fmt.Sprintf("%s.", SettingName[Cost])))]
 
 	default:
 		panic("Setting not implemented")
@@ -812,7 +921,7 @@ func (ssc *StorageSmartContract) updateSettings(
 			"can't get config: "+err.Error())
 	}
 
-	if err := smartcontractinterface.AuthorizeWithOwner("update_settings", func() bool {
+	if err := smartcontractinterface.AuthorizeWithOwnerOrGovernance("update_settings", t.ClientID, func() bool {
 		return conf.OwnerId == t.ClientID
 	}); err != nil {
 		return "", err
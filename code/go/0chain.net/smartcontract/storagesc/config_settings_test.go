@@ -130,9 +130,11 @@ func TestUpdateSettings(t *testing.T) {
 					"min_offer_duration":            "10h",
 					"min_blobber_capacity":          "1024",
 
-					"readpool.min_lock":  "10",
-					"writepool.min_lock": "10",
-					"stakepool.min_lock": "10",
+					"readpool.min_lock":                    "10",
+					"writepool.min_lock":                   "10",
+					"stakepool.min_lock":                   "10",
+					"stakepool.blobber_unbonding_period":   "100",
+					"stakepool.validator_unbonding_period": "50",
 
 					"max_total_free_allocation":      "10000",
 					"max_individual_free_allocation": "100",
@@ -171,6 +173,17 @@ func TestUpdateSettings(t *testing.T) {
 					"block_reward.zeta.k":           "0.9",
 					"block_reward.zeta.mu":          "0.2",
 
+					"auto_renew.enabled":      "true",
+					"auto_renew.renew_window": "1m",
+					"auto_renew.min_period":   "10m",
+					"auto_renew.max_renewals": "10",
+
+					"reputation.initial_score":       "0.5",
+					"reputation.challenge_weight":    "0.05",
+					"reputation.health_check_weight": "0.001",
+					"reputation.half_life":           "720h",
+					"reputation.selection_weight":    "0.5",
+
 					"expose_mpt": "false",
 				},
 			},
@@ -333,8 +346,10 @@ func TestCommitSettingChanges(t *testing.T) {
 					"min_offer_duration":            "10h",
 					"min_blobber_capacity":          "1024",
 
-					"readpool.min_lock":  "10",
-					"stakepool.min_lock": "10",
+					"readpool.min_lock":                    "10",
+					"stakepool.min_lock":                   "10",
+					"stakepool.blobber_unbonding_period":   "100",
+					"stakepool.validator_unbonding_period": "50",
 
 					"max_total_free_allocation":      "10000",
 					"max_individual_free_allocation": "100",
@@ -374,6 +389,17 @@ func TestCommitSettingChanges(t *testing.T) {
 					"block_reward.zeta.k":           "0.9",
 					"block_reward.zeta.mu":          "0.2",
 
+					"auto_renew.enabled":      "true",
+					"auto_renew.renew_window": "1m",
+					"auto_renew.min_period":   "10m",
+					"auto_renew.max_renewals": "10",
+
+					"reputation.initial_score":       "0.5",
+					"reputation.challenge_weight":    "0.05",
+					"reputation.health_check_weight": "0.001",
+					"reputation.half_life":           "720h",
+					"reputation.selection_weight":    "0.5",
+
 					"expose_mpt": "false",
 				},
 			},
@@ -420,6 +446,10 @@ func getConfField(conf Config, field string) interface{} {
 
 	case StakePoolMinLock:
 		return conf.StakePool.MinLock
+	case StakePoolBlobberUnbondingPeriod:
+		return conf.StakePool.BlobberUnbondingPeriod
+	case StakePoolValidatorUnbondingPeriod:
+		return conf.StakePool.ValidatorUnbondingPeriod
 
 	case MaxTotalFreeAllocation:
 		return conf.MaxTotalFreeAllocation
@@ -493,6 +523,24 @@ func getConfField(conf Config, field string) interface{} {
 		return conf.BlockReward.Zeta.K
 	case BlockRewardZetaMu:
 		return conf.BlockReward.Zeta.Mu
+	case AutoRenewEnabled:
+		return conf.AutoRenew.Enabled
+	case AutoRenewRenewWindow:
+		return conf.AutoRenew.RenewWindow
+	case AutoRenewMinPeriod:
+		return conf.AutoRenew.MinPeriod
+	case AutoRenewMaxRenewals:
+		return conf.AutoRenew.MaxRenewals
+	case ReputationInitialScore:
+		return conf.Reputation.InitialScore
+	case ReputationChallengeWeight:
+		return conf.Reputation.ChallengeWeight
+	case ReputationHealthCheckWeight:
+		return conf.Reputation.HealthCheckWeight
+	case ReputationHalfLife:
+		return conf.Reputation.HalfLife
+	case ReputationSelectionWeight:
+		return conf.Reputation.SelectionWeight
 
 	case ExposeMpt:
 		return conf.ExposeMpt
@@ -42,7 +42,7 @@ func NewStorageRestHandler(rh rest.RestHandlerI) *StorageRestHandler {
 }
 
 func SetupRestHandler(rh rest.RestHandlerI) {
-	rh.Register(GetEndpoints(rh))
+	rh.Register(GetEndpoints)
 }
 
 func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
@@ -57,6 +57,7 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(storage+"/transaction", srh.getTransactionByHash),
 		rest.MakeEndpoint(storage+"/transactions", srh.getTransactionByFilter),
 		rest.MakeEndpoint(storage+"/transaction-hashes", srh.getTransactionHashesByFilter),
+		rest.MakeEndpoint(storage+"/transfers", srh.getTransactionTransfers),
 		rest.MakeEndpoint(storage+"/writemarkers", srh.getWriteMarker),
 		rest.MakeEndpoint(storage+"/errors", srh.getErrors),
 		rest.MakeEndpoint(storage+"/allocations", srh.getAllocations),
@@ -72,7 +73,7 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(storage+"/getchallenge", srh.getChallenge),
 		rest.MakeEndpoint(storage+"/getStakePoolStat", srh.getStakePoolStat),
 		rest.MakeEndpoint(storage+"/getUserStakePoolStat", srh.getUserStakePoolStat),
-		rest.MakeEndpoint(storage+"/block", srh.getBlock),
+		rest.MakeOwnRoundParamEndpoint(storage+"/block", srh.getBlock),
 		rest.MakeEndpoint(storage+"/get_blocks", srh.getBlocks),
 		rest.MakeEndpoint(storage+"/total-stored-data", srh.getTotalData),
 		rest.MakeEndpoint(storage+"/storage-config", srh.getConfig),
@@ -90,12 +91,16 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(storage+"/total-blobber-capacity", srh.getTotalBlobberCapacity),
 		rest.MakeEndpoint(storage+"/blobber-rank", srh.getBlobberRank),
 		rest.MakeEndpoint(storage+"/search", srh.getSearchHandler),
+		rest.MakeEndpoint(storage+"/provider", stakepool.GetProviderHandler(rh, spenum.Blobber, spenum.Validator)),
+		rest.MakeEndpoint(storage+"/providers", stakepool.GetProvidersHandler(rh, spenum.Blobber, spenum.Validator)),
+		rest.MakeEndpoint(storage+"/slashes", stakepool.GetSlashesHandler(rh, spenum.Blobber, spenum.Validator)),
+		rest.MakeEndpoint(storage+"/unbondings", stakepool.GetUnbondingsHandler(rh, spenum.Blobber, spenum.Validator)),
 	}
 }
 
 // swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/blobber-rank blobber-rank
 // Gets the rank of a blobber.
-//   challenges passed / total challenges
+//   reputation, then challenges passed / total challenges
 //
 // parameters:
 //    + name: id
@@ -109,12 +114,23 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 //  400:
 func (srh *StorageRestHandler) getBlobberRank(w http.ResponseWriter, r *http.Request) {
 	id := r.URL.Query().Get("id")
-	edb := srh.GetQueryStateContext().GetEventDB()
+	balances := srh.GetQueryStateContext()
+	edb := balances.GetEventDB()
 	if edb == nil {
 		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
 		return
 	}
-	rank, err := edb.GetBlobberRank(id)
+	conf, err := getConfig(balances)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get config: "+err.Error()))
+		return
+	}
+	rc := conf.reputation()
+	rank, err := edb.GetBlobberRank(id, event.ReputationDecay{
+		InitialScore: rc.InitialScore,
+		HalfLife:     rc.HalfLife,
+		Now:          int64(balances.Now()),
+	})
 	if err != nil {
 		common.Respond(w, r, nil, err)
 		return
@@ -1590,6 +1606,11 @@ func (srh *StorageRestHandler) getAllocation(w http.ResponseWriter, r *http.Requ
 		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't convert to storageAllocationBlobbers"))
 		return
 	}
+	sa.AutoRenew, err = edb.GetAllocationRenewal(allocationID)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get allocation auto renewal", err.Error()))
+		return
+	}
 
 	common.Respond(w, r, sa, nil)
 }
@@ -1689,6 +1710,65 @@ func (srh *StorageRestHandler) getWriteMarker(w http.ResponseWriter, r *http.Req
 	common.Respond(w, r, rtv, nil)
 }
 
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/transfers transfers
+// Gets filtered list of the transfers of batch send transactions
+//
+// parameters:
+//    + name: transaction_hash
+//      description: restrict to transfers of the specified transaction
+//      in: query
+//      type: string
+//    + name: client_id
+//      description: restrict to transfers sent by the specified client
+//      in: query
+//      type: string
+//    + name: to_client_id
+//      description: restrict to transfers sent to the specified client
+//      in: query
+//      type: string
+//    + name: offset
+//      description: offset
+//      in: query
+//      type: string
+//    + name: limit
+//      description: limit
+//      in: query
+//      type: string
+//    + name: sort
+//      description: desc or asc
+//      in: query
+//      type: string
+//
+// responses:
+//  200: []TransactionTransfer
+//  400:
+//  500:
+func (srh *StorageRestHandler) getTransactionTransfers(w http.ResponseWriter, r *http.Request) {
+	var (
+		hash       = r.URL.Query().Get("transaction_hash")
+		clientID   = r.URL.Query().Get("client_id")
+		toClientID = r.URL.Query().Get("to_client_id")
+	)
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+	rtv, err := edb.GetTransactionTransfers(hash, clientID, toClientID, limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal(err.Error()))
+		return
+	}
+	common.Respond(w, r, rtv, nil)
+}
+
 // swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/transactions transactions
 // Gets filtered list of transaction information
 //
@@ -20,6 +20,7 @@ import (
 	"0chain.net/chaincore/config"
 	"0chain.net/smartcontract/partitions"
 	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
 
 	cstate "0chain.net/chaincore/chain/state"
 	"0chain.net/chaincore/node"
@@ -162,6 +163,25 @@ func (acs *AllocationChallenges) removeChallenge(challenge *StorageChallenge) bo
 	return true
 }
 
+// removeBlobberChallenges removes the open challenges of the blobber and
+// returns them.
+func (acs *AllocationChallenges) removeBlobberChallenges(blobberID string) []*AllocOpenChallenge {
+	var (
+		removed []*AllocOpenChallenge
+		open    = acs.OpenChallenges[:0]
+	)
+	for _, oc := range acs.OpenChallenges {
+		if oc.BlobberID != blobberID {
+			open = append(open, oc)
+			continue
+		}
+		removed = append(removed, oc)
+		delete(acs.ChallengeMap, oc.ID)
+	}
+	acs.OpenChallenges = open
+	return removed
+}
+
 type allocationChallengesDecoder AllocationChallenges
 
 // swagger:model StorageChallenge
@@ -345,6 +365,17 @@ type StorageNode struct {
 	StakePoolSettings stakepool.Settings      `json:"stake_pool_settings"`
 	RewardPartition   RewardPartitionLocation `json:"reward_partition"`
 	Information       Info                    `json:"info"`
+	Reputation        BlobberReputation       `json:"reputation"`
+}
+
+// BlobberReputation is the decaying score of a blobber derived from its
+// challenges and health checks, in the [0, 1] range.
+type BlobberReputation struct {
+	Score float64 `json:"score"`
+	// Observations is the number of challenges and health checks the score
+	// is derived from.
+	Observations int64            `json:"observations"`
+	LastUpdate   common.Timestamp `json:"last_update"`
 }
 
 // validate the blobber configurations
@@ -824,6 +855,10 @@ func (sa *StorageAllocation) validateAllocationBlobber(
 	sp *stakePool,
 	now common.Timestamp,
 ) error {
+	if sp.ProviderStatus != spenum.ProviderActive {
+		return fmt.Errorf("blobber %s is %s", blobber.ID, sp.ProviderStatus)
+	}
+
 	bSize := sa.bSize()
 	duration := common.ToTime(sa.Expiration).Sub(common.ToTime(now))
 
@@ -1327,12 +1362,13 @@ func getMaxChallengeCompletionTime() time.Duration {
 }
 
 // removeExpiredChallenges removes all expired challenges from the allocation,
-// return the expired challenge ids, or error if any.
-// the expired challenge ids could be used to delete the challenge node from MPT when needed
+// return the expired challenges, or error if any.
+// the expired challenges could be used to delete the challenge node from MPT
+// and to fail their blobbers when needed
 func (sa *StorageAllocation) removeExpiredChallenges(allocChallenges *AllocationChallenges,
-	now common.Timestamp) ([]string, error) {
+	now common.Timestamp) ([]*AllocOpenChallenge, error) {
 	var (
-		expiredChallengeIDs = make([]string, 0, len(allocChallenges.OpenChallenges))
+		expiredChallenges = make([]*AllocOpenChallenge, 0, len(allocChallenges.OpenChallenges))
 	)
 
 	cct := getMaxChallengeCompletionTime()
@@ -1343,7 +1379,7 @@ func (sa *StorageAllocation) removeExpiredChallenges(allocChallenges *Allocation
 		}
 
 		// expired
-		expiredChallengeIDs = append(expiredChallengeIDs, oc.ID)
+		expiredChallenges = append(expiredChallenges, oc)
 
 		ba, ok := sa.BlobberAllocsMap[oc.BlobberID]
 		if ok {
@@ -1354,9 +1390,9 @@ func (sa *StorageAllocation) removeExpiredChallenges(allocChallenges *Allocation
 		}
 	}
 
-	allocChallenges.OpenChallenges = allocChallenges.OpenChallenges[len(expiredChallengeIDs):]
+	allocChallenges.OpenChallenges = allocChallenges.OpenChallenges[len(expiredChallenges):]
 
-	return expiredChallengeIDs, nil
+	return expiredChallenges, nil
 }
 
 type BlobberCloseConnection struct {
@@ -0,0 +1,153 @@
+package storagesc
+
+import (
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+// storageProvider is a blobber or a validator seen through its stake pool,
+// it implements the stakepool.Provider interface.
+type storageProvider struct {
+	sscID        string
+	id           string
+	providerType spenum.Provider
+	sp           *stakePool
+}
+
+func newStorageProvider(sscID, id string, providerType spenum.Provider,
+	sp *stakePool) *storageProvider {
+
+	return &storageProvider{
+		sscID:        sscID,
+		id:           id,
+		providerType: providerType,
+		sp:           sp,
+	}
+}
+
+func (p *storageProvider) Id() string {
+	return p.id
+}
+
+func (p *storageProvider) Type() spenum.Provider {
+	return p.providerType
+}
+
+func (p *storageProvider) GetStakePool() *stakepool.StakePool {
+	return &p.sp.StakePool
+}
+
+func (p *storageProvider) SaveProvider(balances cstate.StateContextI) error {
+	return p.sp.save(p.sscID, p.id, balances)
+}
+
+// settingsLimits returns the bounds of the stake pool settings of the
+// blobbers and the validators
+func (conf *Config) settingsLimits() *stakepool.SettingsLimits {
+	return &stakepool.SettingsLimits{
+		MinStake:         conf.MinStake,
+		MaxStake:         conf.MaxStake,
+		MaxNumDelegates:  conf.MaxDelegates,
+		MaxServiceCharge: conf.MaxCharge,
+	}
+}
+
+func validateProviderType(providerType spenum.Provider) error {
+	if providerType != spenum.Blobber && providerType != spenum.Validator {
+		return fmt.Errorf("invalid provider type: %s", providerType.String())
+	}
+	return nil
+}
+
+// getProvider loads a blobber or a validator of the storage SC
+func (ssc *StorageSmartContract) getProvider(providerType spenum.Provider,
+	id string, balances cstate.StateContextI) (stakepool.Provider, error) {
+
+	if err := validateProviderType(providerType); err != nil {
+		return nil, err
+	}
+	switch providerType {
+	case spenum.Blobber:
+		if _, err := ssc.getBlobber(id, balances); err != nil {
+			return nil, fmt.Errorf("can't get blobber: %w", err)
+		}
+	case spenum.Validator:
+		if _, err := ssc.getValidator(id, balances); err != nil {
+			return nil, fmt.Errorf("can't get validator: %w", err)
+		}
+	}
+	sp, err := ssc.getStakePool(id, balances)
+	if err != nil {
+		return nil, err
+	}
+	return newStorageProvider(ssc.ID, id, providerType, sp), nil
+}
+
+// emitProvider emits the common provider representation of the blobber or
+// validator
+func (ssc *StorageSmartContract) emitProvider(id string,
+	providerType spenum.Provider, sp *stakePool,
+	balances cstate.StateContextI) error {
+
+	return stakepool.EmitProvider(
+		newStorageProvider(ssc.ID, id, providerType, sp), balances)
+}
+
+// shutdownProvider stops a blobber or a validator by itself or by its
+// delegate wallet; a stopped blobber doesn't get new allocations and its
+// stake pool doesn't accept new stakes.
+func (ssc *StorageSmartContract) shutdownProvider(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req stakepool.ProviderRequest
+	if err := req.Decode(input); err != nil {
+		return "", common.NewError("shutdown_provider_failed",
+			"invalid request: "+err.Error())
+	}
+	p, err := ssc.getProvider(req.ProviderType, req.ProviderID, balances)
+	if err != nil {
+		return "", common.NewError("shutdown_provider_failed", err.Error())
+	}
+	if err = stakepool.ShutDownProvider(txn.ClientID, p, balances); err != nil {
+		return "", common.NewError("shutdown_provider_failed", err.Error())
+	}
+	return "", nil
+}
+
+// killProvider stops a blobber or a validator by the storage SC owner
+func (ssc *StorageSmartContract) killProvider(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	conf, err := ssc.getConfig(balances, true)
+	if err != nil {
+		return "", common.NewError("kill_provider_failed",
+			"can't get config: "+err.Error())
+	}
+	if txn.ClientID != conf.OwnerId {
+		return "", common.NewError("kill_provider_failed",
+			"access denied, allowed for SC owner only")
+	}
+
+	var req stakepool.ProviderRequest
+	if err := req.Decode(input); err != nil {
+		return "", common.NewError("kill_provider_failed",
+			"invalid request: "+err.Error())
+	}
+	p, err := ssc.getProvider(req.ProviderType, req.ProviderID, balances)
+	if err != nil {
+		return "", common.NewError("kill_provider_failed", err.Error())
+	}
+	if err = stakepool.KillProvider(p, balances); err != nil {
+		return "", common.NewError("kill_provider_failed", err.Error())
+	}
+	return "", nil
+}
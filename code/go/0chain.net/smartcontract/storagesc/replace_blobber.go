@@ -0,0 +1,288 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/currency"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/core/util"
+)
+
+// replace blobber request
+type replaceBlobberRequest struct {
+	AllocationID    string `json:"allocation_id"`
+	RemoveBlobberID string `json:"remove_blobber_id"`
+	// AddBlobberID is the replacement blobber, if empty the replacement is
+	// selected by the SC among the challenge ready blobbers matching the
+	// allocation terms.
+	AddBlobberID string `json:"add_blobber_id,omitempty"`
+}
+
+func (req *replaceBlobberRequest) decode(b []byte) error {
+	return json.Unmarshal(b, req)
+}
+
+func (req *replaceBlobberRequest) validate(alloc *StorageAllocation) error {
+	if req.RemoveBlobberID == "" {
+		return errors.New("missing blobber to remove")
+	}
+	if _, ok := alloc.BlobberAllocsMap[req.RemoveBlobberID]; !ok {
+		return fmt.Errorf("cannot remove blobber %s, not in allocation", req.RemoveBlobberID)
+	}
+	if req.AddBlobberID == "" {
+		return nil
+	}
+	if _, ok := alloc.BlobberAllocsMap[req.AddBlobberID]; ok {
+		return fmt.Errorf("cannot add blobber %s, already in allocation", req.AddBlobberID)
+	}
+	return nil
+}
+
+// replaceBlobber replaces a blobber of an allocation; the challenge pool share
+// of the removed blobber moves back to the write pool, its offer is removed
+// from its stake pool, and the replacement gets the same size for the rest of
+// the allocation period. The unpaid min lock demand of the removed blobber is
+// not paid. The transaction value, if any, is added to the write pool.
+func (sc *StorageSmartContract) replaceBlobber(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req replaceBlobberRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("replace_blobber_failed",
+			"invalid request: "+err.Error())
+	}
+
+	conf, err := sc.getConfig(balances, true)
+	if err != nil {
+		return "", common.NewError("replace_blobber_failed",
+			"can't get SC configurations: "+err.Error())
+	}
+
+	alloc, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("replace_blobber_failed",
+			"can't get allocation: "+err.Error())
+	}
+	if alloc.Owner != txn.ClientID {
+		return "", common.NewError("replace_blobber_failed",
+			"only owner can replace a blobber of the allocation")
+	}
+	if alloc.Finalized || alloc.Canceled || alloc.Expiration <= txn.CreationDate {
+		return "", common.NewError("replace_blobber_failed",
+			"can't replace a blobber of a finalized, cancelled or expired allocation")
+	}
+	if err = req.validate(alloc); err != nil {
+		return "", common.NewError("replace_blobber_failed", err.Error())
+	}
+
+	blobbers, err := sc.getAllocationBlobbers(alloc, balances)
+	if err != nil {
+		return "", common.NewError("replace_blobber_failed", err.Error())
+	}
+
+	var added *StorageNode
+	if req.AddBlobberID != "" {
+		if added, err = sc.getBlobber(req.AddBlobberID, balances); err != nil {
+			return "", common.NewError("replace_blobber_failed",
+				"can't get blobber "+req.AddBlobberID+": "+err.Error())
+		}
+	} else {
+		if added, err = sc.selectReplacementBlobber(txn, alloc, conf, balances); err != nil {
+			return "", common.NewError("replace_blobber_failed", err.Error())
+		}
+	}
+
+	removed := alloc.BlobberAllocsMap[req.RemoveBlobberID]
+	if err = sc.settleRemovedBlobber(alloc, removed, blobbers, balances); err != nil {
+		return "", common.NewError("replace_blobber_failed", err.Error())
+	}
+	blobbers, err = alloc.changeBlobbers(blobbers, added.ID, removed.BlobberID, sc,
+		txn.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError("replace_blobber_failed", err.Error())
+	}
+
+	ba := alloc.BlobberAllocsMap[added.ID]
+	addedSP, err := sc.getStakePool(added.ID, balances)
+	if err != nil {
+		return "", common.NewError("replace_blobber_failed",
+			"can't get stake pool of "+added.ID+": "+err.Error())
+	}
+	if err = addedSP.addOffer(ba.Offer()); err != nil {
+		return "", common.NewError("replace_blobber_failed",
+			"adding offer: "+err.Error())
+	}
+	if err = addedSP.save(sc.ID, added.ID, balances); err != nil {
+		return "", common.NewError("replace_blobber_failed",
+			"can't save stake pool of "+added.ID+": "+err.Error())
+	}
+
+	// the write pool must keep the min lock demand of the replacement
+	if txn.Value > 0 {
+		if err = alloc.addToWritePool(txn, balances); err != nil {
+			return "", common.NewError("replace_blobber_failed", err.Error())
+		}
+	}
+	mld, err := alloc.restMinLockDemand()
+	if err != nil {
+		return "", common.NewError("replace_blobber_failed",
+			"can't get min lock demand: "+err.Error())
+	}
+	if alloc.WritePool < mld {
+		return "", common.NewErrorf("replace_blobber_failed",
+			"not enough tokens in write pool for the min lock demand: %v < %v",
+			alloc.WritePool, mld)
+	}
+
+	alloc.Tx = txn.Hash
+	if err = alloc.saveUpdatedAllocation(blobbers, balances); err != nil {
+		return "", common.NewError("replace_blobber_failed", err.Error())
+	}
+
+	return string(alloc.Encode()), nil
+}
+
+// settleRemovedBlobber moves the challenge pool share of the blobber being
+// removed back to the write pool, frees its offer and allocated space and
+// removes its open challenges, which don't fail it once it left.
+func (sc *StorageSmartContract) settleRemovedBlobber(
+	alloc *StorageAllocation,
+	ba *BlobberAllocation,
+	blobbers []*StorageNode,
+	balances cstate.StateContextI,
+) error {
+	if ba.ChallengePoolIntegralValue > 0 {
+		cp, err := sc.getChallengePool(alloc.ID, balances)
+		if err != nil {
+			return fmt.Errorf("can't get challenge pool: %v", err)
+		}
+		move := ba.ChallengePoolIntegralValue
+		if move > cp.Balance {
+			move = cp.Balance
+		}
+		if err = alloc.moveFromChallengePool(cp, move); err != nil {
+			return fmt.Errorf("moving challenge pool share back to write pool: %v", err)
+		}
+		if alloc.MovedBack, err = currency.AddCoin(alloc.MovedBack, move); err != nil {
+			return err
+		}
+		if err = cp.save(sc.ID, alloc.ID, balances); err != nil {
+			return fmt.Errorf("can't save challenge pool: %v", err)
+		}
+		ba.ChallengePoolIntegralValue = 0
+	}
+
+	sp, err := sc.getStakePool(ba.BlobberID, balances)
+	if err != nil {
+		return fmt.Errorf("can't get stake pool of %s: %v", ba.BlobberID, err)
+	}
+	if err = sp.removeOffer(ba.Offer()); err != nil {
+		return fmt.Errorf("removing offer: %v", err)
+	}
+	if err = sp.save(sc.ID, ba.BlobberID, balances); err != nil {
+		return fmt.Errorf("can't save stake pool of %s: %v", ba.BlobberID, err)
+	}
+
+	// the open challenges of the removed blobber are not counted anymore
+	if ba.Stats != nil && alloc.Stats != nil {
+		alloc.Stats.OpenChallenges -= ba.Stats.OpenChallenges
+	}
+	if err = sc.removeBlobberChallenges(alloc.ID, ba.BlobberID, balances); err != nil {
+		return err
+	}
+
+	for _, b := range blobbers {
+		if b.ID != ba.BlobberID {
+			continue
+		}
+		b.Allocated -= ba.Size
+		// the blobber is saved removing it from the allocation
+		return emitUpdateBlobber(b, balances)
+	}
+	return fmt.Errorf("cannot find blobber %s in allocation", ba.BlobberID)
+}
+
+// removeBlobberChallenges removes the open challenges of the blobber from the
+// allocation challenges and deletes them.
+func (sc *StorageSmartContract) removeBlobberChallenges(
+	allocID, blobberID string,
+	balances cstate.StateContextI,
+) error {
+	allocChallenges, err := sc.getAllocationChallenges(allocID, balances)
+	if err == util.ErrValueNotPresent {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't get allocation challenges: %v", err)
+	}
+
+	removed := allocChallenges.removeBlobberChallenges(blobberID)
+	if len(removed) == 0 {
+		return nil
+	}
+	for _, oc := range removed {
+		if _, err := balances.DeleteTrieNode(storageChallengeKey(sc.ID, oc.ID)); err != nil {
+			return fmt.Errorf("could not delete challenge node: %v", err)
+		}
+	}
+	if err := allocChallenges.Save(balances, sc.ID); err != nil {
+		return fmt.Errorf("can't save allocation challenges: %v", err)
+	}
+	return nil
+}
+
+// selectReplacementBlobber selects a blobber matching the allocation terms
+// among the blobbers of a random challenge ready blobbers partition.
+func (sc *StorageSmartContract) selectReplacementBlobber(
+	txn *transaction.Transaction,
+	alloc *StorageAllocation,
+	conf *Config,
+	balances cstate.StateContextI,
+) (*StorageNode, error) {
+	seed, err := strconv.ParseInt(encryption.Hash(txn.Hash)[0:15], 16, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error in creating seed: %v", err)
+	}
+
+	parts, err := partitionsChallengeReadyBlobbers(balances)
+	if err != nil {
+		return nil, fmt.Errorf("can't get challenge ready blobbers: %v", err)
+	}
+	var ready []ChallengeReadyBlobber
+	if err := parts.GetRandomItems(balances, rand.New(rand.NewSource(seed)), &ready); err != nil {
+		return nil, fmt.Errorf("can't get challenge ready blobbers: %v", err)
+	}
+
+	var candidates []*StorageNode
+	for _, rb := range ready {
+		if _, ok := alloc.BlobberAllocsMap[rb.BlobberID]; ok {
+			continue
+		}
+		b, err := sc.getBlobber(rb.BlobberID, balances)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, b)
+	}
+
+	var (
+		now   = txn.CreationDate
+		bSize = alloc.bSize()
+	)
+	candidates = alloc.filterBlobbers(candidates, now, bSize,
+		filterHealthyBlobbers(now), sc.filterBlobbersByFreeSpace(now, bSize, balances))
+	if len(candidates) == 0 {
+		return nil, errors.New("no blobbers matching the allocation terms to replace with")
+	}
+
+	return randomizeNodesByReputation(candidates, nil, 1, seed, conf.reputation(), now)[0], nil
+}
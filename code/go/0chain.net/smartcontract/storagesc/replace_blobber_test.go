@@ -0,0 +1,141 @@
+package storagesc
+
+import (
+	"testing"
+
+	"0chain.net/core/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func callReplaceBlobber(t *testing.T, ssc *StorageSmartContract,
+	clientID string, now int64, req *replaceBlobberRequest,
+	balances *testBalances) error {
+
+	tx := newTransaction(clientID, ADDRESS, 0, now)
+	balances.setTransaction(t, tx)
+	_, err := ssc.replaceBlobber(tx, mustEncode(t, req), balances)
+	return err
+}
+
+func TestStorageSmartContract_replaceBlobber(t *testing.T) {
+	var (
+		ssc                  = newTestStorageSC()
+		balances             = newTestBalances(t, false)
+		client               = newClient(50*x10, balances)
+		other                = newClient(50*x10, balances)
+		tp, exp        int64 = 100, 1000
+		allocID, blobs       = addAllocation(t, ssc, client, tp, exp, 0, balances)
+	)
+
+	alloc, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+
+	var (
+		removeID = alloc.BlobberAllocs[0].BlobberID
+		inAlloc  = alloc.BlobberAllocs[1].BlobberID
+		addID    string
+	)
+	for _, b := range blobs {
+		if _, ok := alloc.BlobberAllocsMap[b.id]; !ok {
+			addID = b.id
+			break
+		}
+	}
+	require.NotEmpty(t, addID)
+
+	removedSP, err := ssc.getStakePool(removeID, balances)
+	require.NoError(t, err)
+	removedOffers := removedSP.TotalOffers
+	offer := alloc.BlobberAllocsMap[removeID].Offer()
+
+	// open challenges of the removed blobber and of a blobber staying
+	allocChallenges := &AllocationChallenges{AllocationID: allocID}
+	for _, c := range []*StorageChallenge{
+		{ID: "removed_challenge", BlobberID: removeID, AllocationID: allocID, Created: 150},
+		{ID: "kept_challenge", BlobberID: inAlloc, AllocationID: allocID, Created: 150},
+	} {
+		require.True(t, allocChallenges.addChallenge(c))
+		require.NoError(t, c.Save(balances, ssc.ID))
+	}
+	require.NoError(t, allocChallenges.Save(balances, ssc.ID))
+
+	tp += 100
+	tests := []struct {
+		name     string
+		clientID string
+		req      replaceBlobberRequest
+		wantErr  string
+	}{
+		{
+			name:     "not_owner",
+			clientID: other.id,
+			req:      replaceBlobberRequest{AllocationID: allocID, RemoveBlobberID: removeID, AddBlobberID: addID},
+			wantErr:  "only owner can replace a blobber of the allocation",
+		},
+		{
+			name:     "not_in_allocation",
+			clientID: client.id,
+			req:      replaceBlobberRequest{AllocationID: allocID, RemoveBlobberID: addID, AddBlobberID: removeID},
+			wantErr:  "not in allocation",
+		},
+		{
+			name:     "already_in_allocation",
+			clientID: client.id,
+			req:      replaceBlobberRequest{AllocationID: allocID, RemoveBlobberID: removeID, AddBlobberID: inAlloc},
+			wantErr:  "already in allocation",
+		},
+		{
+			name:     "ok",
+			clientID: client.id,
+			req:      replaceBlobberRequest{AllocationID: allocID, RemoveBlobberID: removeID, AddBlobberID: addID},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := callReplaceBlobber(t, ssc, tt.clientID, tp, &tt.req, balances)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	alloc, err = ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	require.Len(t, alloc.BlobberAllocs, 20)
+	_, ok := alloc.BlobberAllocsMap[removeID]
+	assert.False(t, ok)
+	ba, ok := alloc.BlobberAllocsMap[addID]
+	require.True(t, ok)
+	assert.EqualValues(t, alloc.bSize(), ba.Size)
+
+	removedSP, err = ssc.getStakePool(removeID, balances)
+	require.NoError(t, err)
+	assert.EqualValues(t, removedOffers-offer, removedSP.TotalOffers)
+
+	removed, err := ssc.getBlobber(removeID, balances)
+	require.NoError(t, err)
+	assert.Zero(t, removed.Allocated)
+
+	added, err := ssc.getBlobber(addID, balances)
+	require.NoError(t, err)
+	assert.EqualValues(t, ba.Size, added.Allocated)
+
+	addedSP, err := ssc.getStakePool(addID, balances)
+	require.NoError(t, err)
+	assert.EqualValues(t, ba.Offer(), addedSP.TotalOffers)
+
+	// the open challenges of the removed blobber are removed
+	allocChallenges, err = ssc.getAllocationChallenges(allocID, balances)
+	require.NoError(t, err)
+	require.Len(t, allocChallenges.OpenChallenges, 1)
+	assert.Equal(t, "kept_challenge", allocChallenges.OpenChallenges[0].ID)
+	_, err = ssc.getStorageChallenge("removed_challenge", balances)
+	assert.Equal(t, util.ErrValueNotPresent, err)
+	_, err = ssc.getStorageChallenge("kept_challenge", balances)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,146 @@
+package storagesc
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs"
+	"0chain.net/smartcontract/dbs/event"
+)
+
+// minSelectionWeight keeps the blobbers with zero reputation selectable.
+const minSelectionWeight = 1e-3
+
+// value returns the reputation score at given time, the score decays to the
+// initial one halving the distance every half life.
+func (br *BlobberReputation) value(conf *reputationConfig, now common.Timestamp) float64 {
+	if br.Observations == 0 {
+		return conf.InitialScore
+	}
+	if conf.HalfLife <= 0 || now <= br.LastUpdate {
+		return br.Score
+	}
+	var (
+		elapsed = float64(now - br.LastUpdate)
+		decay   = math.Pow(0.5, elapsed/conf.HalfLife.Seconds())
+	)
+	return conf.InitialScore + (br.Score-conf.InitialScore)*decay
+}
+
+// observe moves the decayed score to the given result, 1 for a passed
+// challenge or a health check and 0 for a failed challenge, by the weight.
+func (br *BlobberReputation) observe(conf *reputationConfig, result, weight float64,
+	now common.Timestamp) {
+
+	var score = br.value(conf, now)
+	br.Score = score + weight*(result-score)
+	br.Observations++
+	br.LastUpdate = now
+}
+
+// updateBlobberReputation updates the reputation of the blobber by the
+// result of a challenge or a health check and saves the blobber.
+func (sc *StorageSmartContract) updateBlobberReputation(
+	blobber *StorageNode,
+	result, weight float64,
+	now common.Timestamp,
+	conf *reputationConfig,
+	balances cstate.StateContextI,
+) error {
+	if weight <= 0 {
+		return nil
+	}
+	blobber.Reputation.observe(conf, result, weight, now)
+	if _, err := balances.InsertTrieNode(blobber.GetKey(sc.ID), blobber); err != nil {
+		return err
+	}
+	emitUpdateBlobberReputation(blobber, conf, now, balances)
+	return nil
+}
+
+// updateChallengeReputation updates the reputation of the blobber by the
+// result of its challenge.
+func (sc *StorageSmartContract) updateChallengeReputation(
+	blobber *StorageNode,
+	result float64,
+	now common.Timestamp,
+	balances cstate.StateContextI,
+) error {
+	conf, err := sc.getConfig(balances, true)
+	if err != nil {
+		return fmt.Errorf("can't get SC configurations: %v", err)
+	}
+	rc := conf.reputation()
+	return sc.updateBlobberReputation(blobber, result, rc.ChallengeWeight, now, rc, balances)
+}
+
+// emitUpdateBlobberReputation emits the reputation of the blobber decayed to
+// given time, the event database decays it further from that time.
+func emitUpdateBlobberReputation(sn *StorageNode, conf *reputationConfig,
+	now common.Timestamp, balances cstate.StateContextI) {
+
+	data := &dbs.DbUpdates{
+		Id: sn.ID,
+		Updates: map[string]interface{}{
+			"reputation":         sn.Reputation.value(conf, now),
+			"reputation_updated": int64(now),
+		},
+	}
+	balances.EmitEvent(event.TypeStats, event.TagUpdateBlobber, sn.ID, data)
+}
+
+// randomizeNodesByReputation selects n nodes randomly, a node is selected
+// with the probability growing with its reputation by the selection weight;
+// with zero selection weight the nodes are selected uniformly.
+func randomizeNodesByReputation(in []*StorageNode, out []*StorageNode, n int,
+	seed int64, conf *reputationConfig, now common.Timestamp) []*StorageNode {
+
+	if conf.SelectionWeight <= 0 {
+		return randomizeNodes(in, out, n, seed)
+	}
+
+	var (
+		nOut    = maxInt(1, minInt(len(in), n))
+		weights = make([]float64, len(in))
+		total   float64
+		left    int // number of nodes to select from
+	)
+	for i, node := range in {
+		if checkExists(node, out) {
+			continue
+		}
+		weights[i] = (1 - conf.SelectionWeight) +
+			conf.SelectionWeight*node.Reputation.value(conf, now)
+		if weights[i] < minSelectionWeight {
+			weights[i] = minSelectionWeight
+		}
+		total += weights[i]
+		left++
+	}
+
+	randGen := rand.New(rand.NewSource(seed))
+	for len(out) < nOut && left > 0 {
+		var (
+			r    = randGen.Float64() * total
+			pick = -1
+		)
+		for i, w := range weights {
+			if w == 0 {
+				continue
+			}
+			pick = i
+			if r < w {
+				break
+			}
+			r -= w
+		}
+		out = append(out, in[pick])
+		total -= weights[pick]
+		weights[pick] = 0
+		left--
+	}
+	return out
+}
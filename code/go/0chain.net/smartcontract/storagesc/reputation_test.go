@@ -0,0 +1,108 @@
+package storagesc
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"0chain.net/core/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobberReputation(t *testing.T) {
+	conf := &reputationConfig{
+		InitialScore:    0.5,
+		ChallengeWeight: 0.5,
+		HalfLife:        time.Hour,
+	}
+	hour := toSeconds(time.Hour)
+
+	tests := []struct {
+		name   string
+		rep    BlobberReputation
+		result float64
+		now    common.Timestamp
+		value  float64
+		score  float64
+	}{
+		{
+			name:   "new_passed",
+			result: 1,
+			now:    hour,
+			value:  0.5,
+			score:  0.75,
+		},
+		{
+			name:   "new_failed",
+			result: 0,
+			now:    hour,
+			value:  0.5,
+			score:  0.25,
+		},
+		{
+			name:   "decayed",
+			rep:    BlobberReputation{Score: 1, Observations: 1},
+			result: 0,
+			now:    hour,
+			value:  0.75,
+			score:  0.375,
+		},
+		{
+			name:   "no_time_passed",
+			rep:    BlobberReputation{Score: 0.2, Observations: 1, LastUpdate: hour},
+			result: 1,
+			now:    hour,
+			value:  0.2,
+			score:  0.6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rep := tt.rep
+			assert.InDelta(t, tt.value, rep.value(conf, tt.now), 1e-9)
+			rep.observe(conf, tt.result, conf.ChallengeWeight, tt.now)
+			assert.InDelta(t, tt.score, rep.Score, 1e-9)
+			assert.Equal(t, tt.rep.Observations+1, rep.Observations)
+			assert.Equal(t, tt.now, rep.LastUpdate)
+		})
+	}
+}
+
+func TestRandomizeNodesByReputation(t *testing.T) {
+	var nodes []*StorageNode
+	for i := 0; i < 10; i++ {
+		node := &StorageNode{ID: strconv.Itoa(i)}
+		if i < 5 {
+			node.Reputation = BlobberReputation{Score: 1, Observations: 1}
+		} else {
+			node.Reputation = BlobberReputation{Score: 0, Observations: 1}
+		}
+		nodes = append(nodes, node)
+	}
+
+	// uniform selection without the selection weight
+	uniform := randomizeNodesByReputation(nodes, nil, 5, 1, &reputationConfig{}, 0)
+	require.Equal(t, randomizeNodes(nodes, nil, 5, 1), uniform)
+
+	conf := &reputationConfig{SelectionWeight: 1}
+	var reputable int
+	for seed := int64(0); seed < 100; seed++ {
+		selected := randomizeNodesByReputation(nodes, nil, 5, seed, conf, 0)
+		require.Len(t, selected, 5)
+		seen := make(map[string]bool)
+		for _, node := range selected {
+			require.False(t, seen[node.ID], "selected twice")
+			seen[node.ID] = true
+			if node.Reputation.Score == 1 {
+				reputable++
+			}
+		}
+	}
+	assert.Greater(t, reputable, 490)
+
+	// preferred blobbers are kept
+	selected := randomizeNodesByReputation(nodes, []*StorageNode{nodes[9]}, 3, 1, conf, 0)
+	require.Len(t, selected, 3)
+	assert.Equal(t, nodes[9], selected[0])
+}
@@ -82,6 +82,11 @@ func (ssc *StorageSmartContract) setSC(sc *sci.SmartContract, _ sci.BCContextI)
 	ssc.SmartContractExecutionStats["free_update_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "update_free_storage"), nil)
 	ssc.SmartContractExecutionStats["add_curator"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "add_curator"), nil)
 	ssc.SmartContractExecutionStats["curator_transfer_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "curator_transfer_allocation"), nil)
+	ssc.SmartContractExecutionStats["update_allocation_auto_renew"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "update_allocation_auto_renew"), nil)
+	ssc.SmartContractExecutionStats["replace_blobber"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "replace_blobber"), nil)
+	// providers
+	ssc.SmartContractExecutionStats["shutdown_provider"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "shutdown_provider"), nil)
+	ssc.SmartContractExecutionStats["kill_provider"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "kill_provider"), nil)
 	// challenge
 	ssc.SmartContractExecutionStats["challenge_request"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "challenge_request"), nil)
 	ssc.SmartContractExecutionStats["challenge_response"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "challenge_response"), nil)
@@ -113,6 +118,8 @@ func (ssc *StorageSmartContract) setSC(sc *sci.SmartContract, _ sci.BCContextI)
 	// stake pool
 	ssc.SmartContractExecutionStats["stake_pool_lock"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_lock"), nil)
 	ssc.SmartContractExecutionStats["stake_pool_unlock"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_unlock"), nil)
+	ssc.SmartContractExecutionStats["stake_pool_unbond"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_unbond"), nil)
+	ssc.SmartContractExecutionStats["stake_pool_claim_unbonded"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_claim_unbonded"), nil)
 	ssc.SmartContractExecutionStats["stake_pool_pay_interests"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_pay_interests"), nil)
 	ssc.SmartContractExecutionStats["pay_reward"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "pay_reward (add/update/remove SC function)"), nil)
 }
@@ -198,6 +205,10 @@ func (sc *StorageSmartContract) Execute(t *transaction.Transaction,
 		resp, err = sc.finalizeAllocation(t, input, balances)
 	case "cancel_allocation":
 		resp, err = sc.cancelAllocationRequest(t, input, balances)
+	case "update_allocation_auto_renew":
+		resp, err = sc.updateAllocationAutoRenew(t, input, balances)
+	case "replace_blobber":
+		resp, err = sc.replaceBlobber(t, input, balances)
 
 	// free allocations
 
@@ -229,7 +240,10 @@ func (sc *StorageSmartContract) Execute(t *transaction.Transaction,
 	case "update_validator_settings":
 		resp, err = sc.updateValidatorSettings(t, input, balances)
 	case "blobber_block_rewards":
-		err = sc.blobberBlockRewards(balances)
+		if err = sc.blobberBlockRewards(balances); err != nil {
+			return
+		}
+		sc.renewAllocations(t, balances)
 
 	// read_pool
 
@@ -253,8 +267,19 @@ func (sc *StorageSmartContract) Execute(t *transaction.Transaction,
 		resp, err = sc.stakePoolLock(t, input, balances)
 	case "stake_pool_unlock":
 		resp, err = sc.stakePoolUnlock(t, input, balances)
+	case "stake_pool_unbond":
+		resp, err = sc.stakePoolUnbond(t, input, balances)
+	case "stake_pool_claim_unbonded":
+		resp, err = sc.stakePoolClaimUnbonded(t, input, balances)
 	case "collect_reward":
 		resp, err = sc.collectReward(t, input, balances)
+
+		// providers
+
+	case "shutdown_provider":
+		resp, err = sc.shutdownProvider(t, input, balances)
+	case "kill_provider":
+		resp, err = sc.killProvider(t, input, balances)
 	case "generate_challenge":
 		challengesEnabled := config.SmartContractConfig.GetBool(
 			"smart_contracts.storagesc.challenge_enabled")
@@ -263,6 +288,7 @@ func (sc *StorageSmartContract) Execute(t *transaction.Transaction,
 			if err != nil {
 				return
 			}
+			sc.renewAllocations(t, balances)
 		} else {
 			return "OpenChallenges disabled in the config", nil
 		}
@@ -2,11 +2,8 @@ package storagesc
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
-	"time"
 
-	"0chain.net/chaincore/config"
 	"0chain.net/chaincore/currency"
 
 	"0chain.net/core/logging"
@@ -20,7 +17,6 @@ import (
 	"0chain.net/smartcontract/stakepool"
 
 	chainstate "0chain.net/chaincore/chain/state"
-	"0chain.net/chaincore/state"
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
@@ -30,27 +26,6 @@ import (
 //msgp:ignore unlockResponse stakePoolStat stakePoolRequest delegatePoolStat rewardsStat
 //go:generate msgp -io=false -tests=false -unexported=true -v
 
-func validateStakePoolSettings(
-	sps stakepool.Settings,
-	conf *Config,
-) error {
-	err := conf.validateStakeRange(sps.MinStake, sps.MaxStake)
-	if err != nil {
-		return err
-	}
-	if sps.ServiceChargeRatio < 0.0 {
-		return errors.New("negative service charge")
-	}
-	if sps.ServiceChargeRatio > conf.MaxCharge {
-		return fmt.Errorf("service_charge (%f) is greater than"+
-			" max allowed by SC (%f)", sps.ServiceChargeRatio, conf.MaxCharge)
-	}
-	if sps.MaxNumDelegates <= 0 {
-		return errors.New("num_delegates <= 0")
-	}
-	return nil
-}
-
 // stake pool of a blobber
 
 type stakePool struct {
@@ -128,67 +103,6 @@ func (sp *stakePool) stake() (stake currency.Coin, err error) {
 	return
 }
 
-// empty a delegate pool if possible, call update before the empty
-func (sp *stakePool) empty(
-	sscID,
-	poolID,
-	clientID string,
-	balances chainstate.StateContextI,
-) (bool, error) {
-	var dp, ok = sp.Pools[poolID]
-	if !ok {
-		return false, fmt.Errorf("no such delegate pool: %q", poolID)
-	}
-
-	if dp.DelegateID != clientID {
-		return false, errors.New("trying to unlock not by delegate pool owner")
-	}
-
-	// If insufficient funds in stake pool left after unlock,
-	// we can't do an immediate unlock.
-	// Instead we mark as unstake to prevent being used for further allocations.
-
-	totalBalance, err := currency.AddCoin(sp.TotalOffers, dp.Balance)
-	if err != nil {
-		return false, err
-	}
-
-	staked, err := sp.stake()
-	if err != nil {
-		return false, err
-	}
-	if staked < totalBalance {
-		if dp.Status != spenum.Unstaking {
-			totalUnStake, err := currency.AddCoin(sp.TotalUnStake, dp.Balance)
-			if err != nil {
-				return false, err
-			}
-			sp.TotalUnStake = totalUnStake
-
-			dp.Status = spenum.Unstaking
-		}
-		return true, nil
-	}
-
-	if dp.Status == spenum.Unstaking {
-		totalUnstake, err := currency.MinusCoin(sp.TotalUnStake, dp.Balance)
-		if err != nil {
-			return false, err
-		}
-		sp.TotalUnStake = totalUnstake
-	}
-
-	transfer := state.NewTransfer(sscID, clientID, dp.Balance)
-	if err := balances.AddTransfer(transfer); err != nil {
-		return false, err
-	}
-
-	sp.Pools[poolID].Balance = 0
-	sp.Pools[poolID].Status = spenum.Deleting
-
-	return true, nil
-}
-
 // add offer of an allocation related to blobber owns this stake pool
 func (sp *stakePool) addOffer(amount currency.Coin) error {
 	newTotalOffers, err := currency.AddCoin(sp.TotalOffers, amount)
@@ -218,6 +132,10 @@ func (sp *stakePool) removeOffer(amount currency.Coin) error {
 	return nil
 }
 
+// FailedChallenge is the reason of the slashes of the blobbers penalised for
+// the failed challenges.
+const FailedChallenge = "failed_challenge"
+
 // slash represents blobber penalty; it returns number of tokens moved in
 // reality, in regard to division errors
 func (sp *stakePool) slash(
@@ -238,34 +156,33 @@ func (sp *stakePool) slash(
 		return 0, err
 	}
 
+	if staked == 0 {
+		return // nothing to slash
+	}
+
 	// offer ratio of entire stake; we are slashing only part of the offer
 	// moving the tokens to allocation user; the ratio is part of entire
 	// stake should be moved;
 	var ratio = float64(slash) / float64(staked)
-	edbSlash := stakepool.NewStakePoolReward(blobID, spenum.Blobber)
-	for id, dp := range sp.Pools {
-		dpSlash, err := currency.MultFloat64(dp.Balance, ratio)
-		if err != nil {
-			return 0, err
-		}
-
-		if dpSlash == 0 {
-			continue
-		}
-
-		if balance, err := currency.MinusCoin(dp.Balance, dpSlash); err != nil {
-			return 0, err
-		} else {
-			dp.Balance = balance
-		}
-		move, err = currency.AddCoin(move, dpSlash)
-		if err != nil {
-			return 0, err
-		}
-		edbSlash.DelegateRewards[id] = -1 * int64(dpSlash)
+	if ratio > 1 {
+		ratio = 1
+	}
+	move, slashed, err := sp.StakePool.Slash(ratio)
+	if err != nil {
+		return 0, err
 	}
-	// todo we should slash from stake pools not rewards. 0chain issue 1495
-	if err := edbSlash.Emit(event.TagStakePoolReward, balances); err != nil {
+	if move == 0 {
+		return
+	}
+
+	err = sp.StakePool.EmitSlash(&stakepool.SlashRecord{
+		ProviderID:   blobID,
+		ProviderType: spenum.Blobber,
+		Reason:       FailedChallenge,
+		Round:        balances.GetBlock().Round,
+		Amount:       move,
+	}, slashed, balances)
+	if err != nil {
 		return 0, err
 	}
 
@@ -375,21 +292,47 @@ func getStakePool(
 	return sp, nil
 }
 
+// BlobberStakePool returns the stake pool of the blobber.
+func BlobberStakePool(blobberID string, balances chainstate.CommonStateContextI) (*stakepool.StakePool, error) {
+	sp, err := getStakePool(blobberID, balances)
+	if err != nil {
+		return nil, err
+	}
+	return &sp.StakePool, nil
+}
+
+// DelegatedStake returns the tokens the client delegates to the blobbers and
+// the validators.
+func DelegatedStake(clientID string, balances chainstate.StateContextI) (currency.Coin, error) {
+	getStakePool := func(id string) (*stakepool.StakePool, error) {
+		sp, err := getStakePool(id, balances)
+		if err != nil {
+			return nil, err
+		}
+		return &sp.StakePool, nil
+	}
+	var total currency.Coin
+	for _, p := range []spenum.Provider{spenum.Blobber, spenum.Validator} {
+		stake, err := stakepool.DelegatedStake(p, clientID, getStakePool, balances)
+		if err != nil {
+			return 0, err
+		}
+		if total, err = currency.AddCoin(total, stake); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
 // initial or successive method should be used by add_blobber/add_validator
 // SC functions
 
-// get existing stake pool or create new one not saving it
-func (ssc *StorageSmartContract) getOrUpdateStakePool(
-	conf *Config,
+// get existing stake pool or create new one not saving it, its settings are
+// set on the provider registration
+func (ssc *StorageSmartContract) getOrCreateStakePool(
 	providerId datastore.Key,
-	providerType spenum.Provider,
-	settings stakepool.Settings,
 	balances chainstate.StateContextI,
 ) (*stakePool, error) {
-	if err := validateStakePoolSettings(settings, conf); err != nil {
-		return nil, fmt.Errorf("invalid stake_pool settings: %v", err)
-	}
-
 	// the stake pool can be created by related validator
 	sp, err := ssc.getStakePool(providerId, balances)
 	if err != nil {
@@ -397,14 +340,8 @@ func (ssc *StorageSmartContract) getOrUpdateStakePool(
 			return nil, fmt.Errorf("unexpected error: %v", err)
 		}
 		sp = newStakePool()
-		sp.Settings.DelegateWallet = settings.DelegateWallet
 		sp.Minter = chainstate.MinterStorage
 	}
-
-	sp.Settings.MinStake = settings.MinStake
-	sp.Settings.MaxStake = settings.MaxStake
-	sp.Settings.ServiceChargeRatio = settings.ServiceChargeRatio
-	sp.Settings.MaxNumDelegates = settings.MaxNumDelegates
 	return sp, nil
 }
 
@@ -471,6 +408,10 @@ func (ssc *StorageSmartContract) stakePoolLock(t *transaction.Transaction,
 		return "", common.NewErrorf("stake_pool_lock_failed",
 			"saving stake pool: %v", err)
 	}
+	if err = ssc.emitProvider(spr.BlobberID, spenum.Blobber, sp, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_lock_failed",
+			"emitting provider: %v", err)
+	}
 
 	staked, err := sp.stake()
 	if err != nil {
@@ -489,7 +430,9 @@ func (ssc *StorageSmartContract) stakePoolLock(t *transaction.Transaction,
 	return
 }
 
-// stake pool can return excess tokens from stake pool
+// stakePoolUnlock unlocks a whole delegate pool of a blobber through the
+// unbonding queue of the delegate, the tokens are claimable after the
+// unbonding period.
 func (ssc *StorageSmartContract) stakePoolUnlock(
 	t *transaction.Transaction,
 	input []byte,
@@ -500,78 +443,13 @@ func (ssc *StorageSmartContract) stakePoolUnlock(
 		return "", common.NewErrorf("stake_pool_unlock_failed",
 			"can't decode request: %v", err)
 	}
-	var sp *stakePool
-	if sp, err = ssc.getStakePool(spr.BlobberID, balances); err != nil {
-		return "", common.NewErrorf("stake_pool_unlock_failed",
-			"can't get related stake pool: %v", err)
-	}
-
-	dp, ok := sp.Pools[spr.PoolID]
-	if !ok {
-		return "", common.NewErrorf("stake_pool_unlock_failed", "no such delegate pool: %v ", spr.PoolID)
-	}
-
-	// if StakeAt has valid value and lock period is less than MinLockPeriod
-	if dp.StakedAt > 0 {
-		stakedAt := common.ToTime(dp.StakedAt)
-		minLockPeriod := config.SmartContractConfig.GetDuration("stakepool.min_lock_period")
-		if !stakedAt.Add(minLockPeriod).Before(time.Now()) {
-			return "", common.NewErrorf("stake_pool_unlock_failed", "token can only be unstaked till: %s", stakedAt.Add(minLockPeriod))
-		}
-	}
-
-	unstake, err := sp.empty(ssc.ID, spr.PoolID, t.ClientID, balances)
+	entry, err := ssc.unbond(t, &stakepool.UnbondRequest{
+		ProviderID:   spr.BlobberID,
+		ProviderType: spenum.Blobber,
+		PoolID:       spr.PoolID,
+	}, balances)
 	if err != nil {
-		return "", common.NewErrorf("stake_pool_unlock_failed",
-			"unlocking tokens: %v", err)
+		return "", common.NewError("stake_pool_unlock_failed", err.Error())
 	}
-
-	// the tokens can't be unlocked due to opened offers, but we mark it
-	// as 'unstake' and returns maximal time to wait to unlock the pool
-	if !unstake {
-		// save the pool and return special result
-		if err = sp.save(ssc.ID, spr.BlobberID, balances); err != nil {
-			return "", common.NewErrorf("stake_pool_unlock_failed",
-				"saving stake pool: %v", err)
-		}
-		staked, err := sp.stake()
-		if err != nil {
-			return "", common.NewErrorf("stake_pool_unlock_failed",
-				"stake pool staking error: %v", err)
-		}
-		data := dbs.DbUpdates{
-			Id: spr.BlobberID,
-			Updates: map[string]interface{}{
-				"total_stake": int64(staked),
-			},
-		}
-		balances.EmitEvent(event.TypeStats, event.TagUpdateBlobber, spr.BlobberID, data)
-		return toJson(&unlockResponse{Unstake: false}), nil
-	}
-
-	amount, err := sp.UnlockClientStakePool(t.ClientID, spenum.Blobber, spr.BlobberID, spr.PoolID, balances)
-	if err != nil {
-		return "", common.NewErrorf("stake_pool_unlock_failed", "%v", err)
-	}
-
-	// save the pool
-	if err = sp.save(ssc.ID, spr.BlobberID, balances); err != nil {
-		return "", common.NewErrorf("stake_pool_unlock_failed",
-			"saving stake pool: %v", err)
-	}
-
-	staked, err := sp.stake()
-	if err != nil {
-		return "", common.NewErrorf("stake_pool_unlock_failed",
-			"stake pool staking error: %v", err)
-	}
-	data := dbs.DbUpdates{
-		Id: spr.BlobberID,
-		Updates: map[string]interface{}{
-			"total_stake": int64(staked),
-		},
-	}
-	balances.EmitEvent(event.TypeStats, event.TagUpdateBlobber, spr.BlobberID, data)
-
-	return toJson(&unlockResponse{Unstake: true, Balance: amount}), nil
+	return toJson(entry), nil
 }
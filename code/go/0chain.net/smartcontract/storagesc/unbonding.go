@@ -0,0 +1,141 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/currency"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+// checkUnbond checks an amount can be unbonded from the delegate pool of the
+// storage provider: the min lock period of the pool has passed and the offers
+// of a blobber stay covered by the stake left.
+func (sp *stakePool) checkUnbond(req *stakepool.UnbondRequest,
+	minLockPeriod time.Duration, now common.Timestamp) error {
+
+	dp, ok := sp.Pools[req.PoolID]
+	if !ok {
+		return fmt.Errorf("no such delegate pool: %v", req.PoolID)
+	}
+	if dp.StakedAt > 0 {
+		stakedAt := common.ToTime(dp.StakedAt)
+		if !stakedAt.Add(minLockPeriod).Before(common.ToTime(now)) {
+			return fmt.Errorf("token can only be unstaked till: %s",
+				stakedAt.Add(minLockPeriod))
+		}
+	}
+
+	amount := req.Amount
+	if amount == 0 {
+		amount = dp.Balance
+	}
+	staked, err := sp.stake()
+	if err != nil {
+		return err
+	}
+	if amount > staked {
+		return errors.New("unbond amount is greater than the stake")
+	}
+	left, err := currency.MinusCoin(staked, amount)
+	if err != nil {
+		return err
+	}
+	if left < sp.TotalOffers {
+		return fmt.Errorf("stake left %v doesn't cover the offers %v",
+			left, sp.TotalOffers)
+	}
+	return nil
+}
+
+// stakePoolUnbond moves an amount of a delegate pool of a blobber or a
+// validator to the unbonding queue of the delegate, the amount is claimable
+// after the unbonding period of the provider type.
+func (ssc *StorageSmartContract) stakePoolUnbond(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req stakepool.UnbondRequest
+	if err := req.Decode(input); err != nil {
+		return "", common.NewError("stake_pool_unbond_failed",
+			"invalid request: "+err.Error())
+	}
+	entry, err := ssc.unbond(txn, &req, balances)
+	if err != nil {
+		return "", common.NewError("stake_pool_unbond_failed", err.Error())
+	}
+	resp, err := json.Marshal(entry)
+	if err != nil {
+		return "", common.NewError("stake_pool_unbond_failed", err.Error())
+	}
+	return string(resp), nil
+}
+
+func (ssc *StorageSmartContract) unbond(
+	txn *transaction.Transaction,
+	req *stakepool.UnbondRequest,
+	balances cstate.StateContextI,
+) (*stakepool.UnbondingEntry, error) {
+	conf, err := ssc.getConfig(balances, true)
+	if err != nil {
+		return nil, fmt.Errorf("can't get config: %v", err)
+	}
+	p, err := ssc.getProvider(req.ProviderType, req.ProviderID, balances)
+	if err != nil {
+		return nil, err
+	}
+	sp := p.(*storageProvider).sp
+	// a pool left unstaking by the former unlock is unbonded as an active one
+	if dp, ok := sp.Pools[req.PoolID]; ok && dp.Status == spenum.Unstaking &&
+		dp.DelegateID == txn.ClientID {
+		if sp.TotalUnStake, err = currency.MinusCoin(sp.TotalUnStake, dp.Balance); err != nil {
+			return nil, err
+		}
+		dp.Status = spenum.Active
+	}
+	if err := sp.checkUnbond(req, conf.StakePool.MinLockPeriod, txn.CreationDate); err != nil {
+		return nil, err
+	}
+
+	entry, err := stakepool.Unbond(ssc.ID, txn.ClientID, p, req,
+		conf.StakePool.unbondingPeriod(req.ProviderType), balances)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ProviderType == spenum.Blobber {
+		staked, err := sp.stake()
+		if err != nil {
+			return nil, err
+		}
+		balances.EmitEvent(event.TypeStats, event.TagUpdateBlobber, req.ProviderID, dbs.DbUpdates{
+			Id: req.ProviderID,
+			Updates: map[string]interface{}{
+				"total_stake": int64(staked),
+			},
+		})
+	}
+	return entry, nil
+}
+
+// stakePoolClaimUnbonded pays the matured unbonding entries of the client
+func (ssc *StorageSmartContract) stakePoolClaimUnbonded(
+	txn *transaction.Transaction,
+	_ []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	claimed, err := stakepool.ClaimUnbonded(ssc.ID, txn.ClientID, ssc.getProvider, balances)
+	if err != nil {
+		return "", common.NewError("stake_pool_claim_unbonded_failed", err.Error())
+	}
+	return toJson(&unlockResponse{Unstake: true, Balance: claimed}), nil
+}
@@ -8,6 +8,7 @@ import (
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/core/util"
+	"0chain.net/smartcontract/stakepool"
 	"0chain.net/smartcontract/stakepool/spenum"
 )
 
@@ -66,15 +67,16 @@ func (sc *StorageSmartContract) addValidator(t *transaction.Transaction, input [
 
 	// create stake pool for the validator to count its rewards
 	var sp *stakePool
-	sp, err = sc.getOrUpdateStakePool(conf, t.ClientID, spenum.Validator,
-		newValidator.StakePoolSettings, balances)
-	if err != nil {
+	if sp, err = sc.getOrCreateStakePool(t.ClientID, balances); err != nil {
 		return "", common.NewError("add_validator_failed",
 			"get or create stake pool error: "+err.Error())
 	}
-	if err = sp.save(sc.ID, t.ClientID, balances); err != nil {
+	err = stakepool.RegisterProvider(
+		newStorageProvider(sc.ID, t.ClientID, spenum.Validator, sp),
+		newValidator.StakePoolSettings, conf.settingsLimits(), balances)
+	if err != nil {
 		return "", common.NewError("add_validator_failed",
-			"saving stake pool error: "+err.Error())
+			"registering stake pool error: "+err.Error())
 	}
 
 	if err = newValidator.emitAdd(balances); err != nil {
@@ -204,24 +206,16 @@ func (sc *StorageSmartContract) updateValidator(t *transaction.Transaction,
 	if sp, err = sc.getStakePool(inputValidator.ID, balances); err != nil {
 		return fmt.Errorf("can't get stake pool:  %v", err)
 	}
-
-	if err = validateStakePoolSettings(inputValidator.StakePoolSettings, conf); err != nil {
+	err = stakepool.UpdateProviderSettings(
+		newStorageProvider(sc.ID, inputValidator.ID, spenum.Validator, sp),
+		inputValidator.StakePoolSettings, conf.settingsLimits(), balances)
+	if err != nil {
 		return fmt.Errorf("invalid new stake pool settings:  %v", err)
 	}
 
-	sp.Settings.MinStake = inputValidator.StakePoolSettings.MinStake
-	sp.Settings.MaxStake = inputValidator.StakePoolSettings.MaxStake
-	sp.Settings.ServiceChargeRatio = inputValidator.StakePoolSettings.ServiceChargeRatio
-	sp.Settings.MaxNumDelegates = inputValidator.StakePoolSettings.MaxNumDelegates
-
 	if err := inputValidator.emitUpdate(balances); err != nil {
 		return fmt.Errorf("emmiting validator %v: %v", inputValidator, err)
 	}
 
-	// save stake pool
-	if err = sp.save(sc.ID, inputValidator.ID, balances); err != nil {
-		return fmt.Errorf("saving stake pool: %v", err)
-	}
-
 	return
 }
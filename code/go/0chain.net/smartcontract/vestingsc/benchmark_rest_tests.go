@@ -25,6 +25,14 @@ func BenchmarkRestTests(
 				},
 				Endpoint: vrh.getPoolInfo,
 			},
+			{
+				FuncName: "getPoolForecast",
+				Params: map[string]string{
+					"pool_id":   geMockVestingPoolId(0),
+					"timestamp": "1000000000",
+				},
+				Endpoint: vrh.getPoolForecast,
+			},
 			{
 				FuncName: "getClientPools",
 				Params: map[string]string{
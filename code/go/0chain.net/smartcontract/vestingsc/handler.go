@@ -3,6 +3,7 @@ package vestingsc
 import (
 	"0chain.net/smartcontract/rest"
 	"net/http"
+	"strconv"
 
 	"0chain.net/core/common"
 	"0chain.net/smartcontract"
@@ -17,7 +18,7 @@ func NewVestingRestHandler(rh rest.RestHandlerI) *VestingRestHandler {
 }
 
 func SetupRestHandler(rh rest.RestHandlerI) {
-	rh.Register(GetEndpoints(rh))
+	rh.Register(GetEndpoints)
 }
 
 func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
@@ -25,6 +26,7 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 	vesting := "/v1/screst/" + ADDRESS
 	return []rest.Endpoint{
 		rest.MakeEndpoint(vesting+"/getPoolInfo", vrh.getPoolInfo),
+		rest.MakeEndpoint(vesting+"/getPoolForecast", vrh.getPoolForecast),
 		rest.MakeEndpoint(vesting+"/getClientPools", vrh.getClientPools),
 		rest.MakeEndpoint(vesting+"/vesting-config", vrh.getConfig),
 	}
@@ -79,6 +81,54 @@ func (vrh *VestingRestHandler) getPoolInfo(w http.ResponseWriter, r *http.Reques
 	common.Respond(w, r, vpInfo, nil)
 }
 
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9/getPoolForecast getPoolForecast
+// get tokens the destinations of a vesting pool can unlock at a time, the
+// timestamp is now by default
+//
+// parameters:
+//    +name: pool_id
+//     description: vesting pool ID
+//     required: true
+//     in: query
+//     type: string
+//    +name: timestamp
+//     description: unix time of the forecast
+//     in: query
+//     type: string
+//
+// responses:
+//  200: vestingForecast
+//  400:
+//  500:
+func (vrh *VestingRestHandler) getPoolForecast(w http.ResponseWriter, r *http.Request) {
+	var (
+		poolID = r.URL.Query().Get("pool_id")
+		at     = common.Now()
+	)
+
+	if ts := r.URL.Query().Get("timestamp"); ts != "" {
+		parsed, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil || parsed < 0 {
+			common.Respond(w, r, nil, common.NewErrBadRequest("invalid timestamp: "+ts))
+			return
+		}
+		at = common.Timestamp(parsed)
+	}
+
+	vp, err := getPool(poolID, vrh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get pool"))
+		return
+	}
+
+	f, err := vp.forecast(at)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get vesting pool forecast", err.Error()))
+		return
+	}
+	common.Respond(w, r, f, nil)
+}
+
 // swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9/vesting_config vesting_config
 // get vesting configuration settings
 //
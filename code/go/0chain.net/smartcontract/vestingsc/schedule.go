@@ -0,0 +1,139 @@
+package vestingsc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"0chain.net/chaincore/currency"
+	"0chain.net/core/common"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// vesting schedule types
+const (
+	scheduleLinear = "linear" // linear vesting between start and expiry
+	scheduleStep   = "step"   // tranches at the end of every step period
+	scheduleCurve  = "curve"  // piecewise-linear curve through the points
+)
+
+// max number of points of a curve schedule
+const maxSchedulePoints = 64
+
+// curvePoint is a point of a piecewise-linear vesting curve: the part of the
+// destination amounts vested at the given time since the pool start.
+type curvePoint struct {
+	At    time.Duration `json:"at"`    // since pool start
+	Ratio float64       `json:"ratio"` // vested part, [0; 1]
+}
+
+// vestingSchedule is the vesting curve of all the destinations of a pool,
+// nothing is vested before the cliff. A pool without a schedule vests
+// linearly.
+type vestingSchedule struct {
+	Type   string        `json:"type"`
+	Cliff  time.Duration `json:"cliff,omitempty"`
+	Step   time.Duration `json:"step,omitempty"`   // step schedule
+	Points []*curvePoint `json:"points,omitempty"` // curve schedule
+}
+
+// isLinear is true for the legacy linear vesting without a cliff
+func (vs *vestingSchedule) isLinear() bool {
+	return vs == nil || ((vs.Type == "" || vs.Type == scheduleLinear) && vs.Cliff == 0)
+}
+
+// validate the schedule of a vesting of the given duration
+func (vs *vestingSchedule) validate(duration time.Duration) error {
+	if vs.Cliff < 0 || vs.Cliff >= duration {
+		return errors.New("cliff is out of the vesting duration")
+	}
+	switch vs.Type {
+	case "", scheduleLinear:
+		if vs.Step != 0 || len(vs.Points) > 0 {
+			return errors.New("unexpected step or points of linear schedule")
+		}
+	case scheduleStep:
+		if vs.Step <= 0 || vs.Step > duration {
+			return errors.New("step is out of the vesting duration")
+		}
+		if len(vs.Points) > 0 {
+			return errors.New("unexpected points of step schedule")
+		}
+	case scheduleCurve:
+		if vs.Step != 0 {
+			return errors.New("unexpected step of curve schedule")
+		}
+		if len(vs.Points) == 0 {
+			return errors.New("no points of curve schedule")
+		}
+		if len(vs.Points) > maxSchedulePoints {
+			return errors.New("too many points of curve schedule")
+		}
+		var prev = &curvePoint{}
+		for _, p := range vs.Points {
+			switch {
+			case p == nil:
+				return errors.New("missing curve point")
+			case p.At <= prev.At || p.At >= duration:
+				return fmt.Errorf("curve point at %v is out of order", p.At)
+			case p.Ratio < prev.Ratio || p.Ratio > 1:
+				return fmt.Errorf("curve point ratio %v is out of order", p.Ratio)
+			}
+			prev = p
+		}
+	default:
+		return fmt.Errorf("unknown schedule type %q", vs.Type)
+	}
+	return nil
+}
+
+// ratio returns the part of the destination amounts vested at the elapsed
+// time since the pool start.
+func (vs *vestingSchedule) ratio(elapsed, duration time.Duration) float64 {
+	switch {
+	case elapsed >= duration:
+		return 1
+	case elapsed < vs.Cliff || elapsed <= 0:
+		return 0
+	}
+	switch vs.Type {
+	case scheduleStep:
+		return float64(elapsed/vs.Step*vs.Step) / float64(duration)
+	case scheduleCurve:
+		var prev = &curvePoint{}
+		for _, p := range vs.Points {
+			if elapsed < p.At {
+				return prev.interpolate(p, elapsed)
+			}
+			prev = p
+		}
+		return prev.interpolate(&curvePoint{At: duration, Ratio: 1}, elapsed)
+	default:
+		return float64(elapsed) / float64(duration)
+	}
+}
+
+// interpolate the ratio between the points
+func (cp *curvePoint) interpolate(next *curvePoint, at time.Duration) float64 {
+	return cp.Ratio + (next.Ratio-cp.Ratio)*
+		float64(at-cp.At)/float64(next.At-cp.At)
+}
+
+// unlockRatio returns amount of tokens to vest for the vested part of the
+// destination amount. The dry argument leaves the destination as it was.
+func (d *destination) unlockRatio(now common.Timestamp, ratio float64,
+	dry bool) (amount currency.Coin, err error) {
+
+	var target currency.Coin
+	if target, err = currency.MultFloat64(d.Amount, ratio); err != nil {
+		return 0, err
+	}
+	if target > d.Vested {
+		amount = target - d.Vested
+	}
+	if !dry {
+		err = d.move(now, amount)
+	}
+	return
+}
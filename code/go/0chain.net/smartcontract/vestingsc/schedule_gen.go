@@ -0,0 +1,220 @@
+package vestingsc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z curvePoint) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "At"
+	o = append(o, 0x82, 0xa2, 0x41, 0x74)
+	o = msgp.AppendDuration(o, z.At)
+	// string "Ratio"
+	o = append(o, 0xa5, 0x52, 0x61, 0x74, 0x69, 0x6f)
+	o = msgp.AppendFloat64(o, z.Ratio)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *curvePoint) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "At":
+			z.At, bts, err = msgp.ReadDurationBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "At")
+				return
+			}
+		case "Ratio":
+			z.Ratio, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Ratio")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z curvePoint) Msgsize() (s int) {
+	s = 1 + 3 + msgp.DurationSize + 6 + msgp.Float64Size
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *vestingSchedule) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Type"
+	o = append(o, 0x84, 0xa4, 0x54, 0x79, 0x70, 0x65)
+	o = msgp.AppendString(o, z.Type)
+	// string "Cliff"
+	o = append(o, 0xa5, 0x43, 0x6c, 0x69, 0x66, 0x66)
+	o = msgp.AppendDuration(o, z.Cliff)
+	// string "Step"
+	o = append(o, 0xa4, 0x53, 0x74, 0x65, 0x70)
+	o = msgp.AppendDuration(o, z.Step)
+	// string "Points"
+	o = append(o, 0xa6, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Points)))
+	for za0001 := range z.Points {
+		if z.Points[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			// map header, size 2
+			// string "At"
+			o = append(o, 0x82, 0xa2, 0x41, 0x74)
+			o = msgp.AppendDuration(o, z.Points[za0001].At)
+			// string "Ratio"
+			o = append(o, 0xa5, 0x52, 0x61, 0x74, 0x69, 0x6f)
+			o = msgp.AppendFloat64(o, z.Points[za0001].Ratio)
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *vestingSchedule) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Type":
+			z.Type, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Type")
+				return
+			}
+		case "Cliff":
+			z.Cliff, bts, err = msgp.ReadDurationBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Cliff")
+				return
+			}
+		case "Step":
+			z.Step, bts, err = msgp.ReadDurationBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Step")
+				return
+			}
+		case "Points":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Points")
+				return
+			}
+			if cap(z.Points) >= int(zb0002) {
+				z.Points = (z.Points)[:zb0002]
+			} else {
+				z.Points = make([]*curvePoint, zb0002)
+			}
+			for za0001 := range z.Points {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Points[za0001] = nil
+				} else {
+					if z.Points[za0001] == nil {
+						z.Points[za0001] = new(curvePoint)
+					}
+					var zb0003 uint32
+					zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Points", za0001)
+						return
+					}
+					for zb0003 > 0 {
+						zb0003--
+						field, bts, err = msgp.ReadMapKeyZC(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Points", za0001)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "At":
+							z.Points[za0001].At, bts, err = msgp.ReadDurationBytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Points", za0001, "At")
+								return
+							}
+						case "Ratio":
+							z.Points[za0001].Ratio, bts, err = msgp.ReadFloat64Bytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Points", za0001, "Ratio")
+								return
+							}
+						default:
+							bts, err = msgp.Skip(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Points", za0001)
+								return
+							}
+						}
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *vestingSchedule) Msgsize() (s int) {
+	s = 1 + 5 + msgp.StringPrefixSize + len(z.Type) + 6 + msgp.DurationSize + 5 + msgp.DurationSize + 7 + msgp.ArrayHeaderSize
+	for za0001 := range z.Points {
+		if z.Points[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += 1 + 3 + msgp.DurationSize + 6 + msgp.Float64Size
+		}
+	}
+	return
+}
@@ -0,0 +1,174 @@
+package vestingsc
+
+import (
+	"testing"
+	"time"
+
+	"0chain.net/core/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_vestingSchedule_validate(t *testing.T) {
+	const duration = 100 * time.Second
+	var tests = []struct {
+		name string
+		vs   vestingSchedule
+		err  string
+	}{
+		{name: "linear", vs: vestingSchedule{Type: scheduleLinear}},
+		{name: "linear_cliff", vs: vestingSchedule{Cliff: 10 * time.Second}},
+		{name: "negative_cliff", vs: vestingSchedule{Cliff: -1},
+			err: "cliff is out of the vesting duration"},
+		{name: "long_cliff", vs: vestingSchedule{Cliff: duration},
+			err: "cliff is out of the vesting duration"},
+		{name: "linear_step", vs: vestingSchedule{Step: time.Second},
+			err: "unexpected step or points of linear schedule"},
+		{name: "step", vs: vestingSchedule{Type: scheduleStep, Step: 25 * time.Second}},
+		{name: "zero_step", vs: vestingSchedule{Type: scheduleStep},
+			err: "step is out of the vesting duration"},
+		{name: "long_step", vs: vestingSchedule{Type: scheduleStep, Step: 2 * duration},
+			err: "step is out of the vesting duration"},
+		{name: "curve", vs: vestingSchedule{Type: scheduleCurve, Points: []*curvePoint{
+			{At: 50 * time.Second, Ratio: 0.2}, {At: 80 * time.Second, Ratio: 0.9},
+		}}},
+		{name: "curve_no_points", vs: vestingSchedule{Type: scheduleCurve},
+			err: "no points of curve schedule"},
+		{name: "curve_time_order", vs: vestingSchedule{Type: scheduleCurve, Points: []*curvePoint{
+			{At: 50 * time.Second, Ratio: 0.2}, {At: 40 * time.Second, Ratio: 0.9},
+		}}, err: "curve point at 40s is out of order"},
+		{name: "curve_ratio_order", vs: vestingSchedule{Type: scheduleCurve, Points: []*curvePoint{
+			{At: 50 * time.Second, Ratio: 0.5}, {At: 80 * time.Second, Ratio: 0.4},
+		}}, err: "curve point ratio 0.4 is out of order"},
+		{name: "curve_ratio_overflow", vs: vestingSchedule{Type: scheduleCurve, Points: []*curvePoint{
+			{At: 50 * time.Second, Ratio: 1.5},
+		}}, err: "curve point ratio 1.5 is out of order"},
+		{name: "unknown", vs: vestingSchedule{Type: "exponential"},
+			err: `unknown schedule type "exponential"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.vs.validate(duration)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			requireErrMsg(t, err, tt.err)
+		})
+	}
+}
+
+func Test_vestingSchedule_ratio(t *testing.T) {
+	const duration = 100 * time.Second
+	var (
+		cliff = &vestingSchedule{Type: scheduleLinear, Cliff: 20 * time.Second}
+		step  = &vestingSchedule{Type: scheduleStep, Step: 25 * time.Second}
+		curve = &vestingSchedule{Type: scheduleCurve, Points: []*curvePoint{
+			{At: 50 * time.Second, Ratio: 0.2}, {At: 80 * time.Second, Ratio: 0.9},
+		}}
+	)
+	var tests = []struct {
+		name    string
+		vs      *vestingSchedule
+		elapsed time.Duration
+		want    float64
+	}{
+		{name: "cliff_before", vs: cliff, elapsed: 10 * time.Second, want: 0},
+		{name: "cliff_at", vs: cliff, elapsed: 20 * time.Second, want: 0.2},
+		{name: "cliff_after", vs: cliff, elapsed: 50 * time.Second, want: 0.5},
+		{name: "step_first", vs: step, elapsed: 24 * time.Second, want: 0},
+		{name: "step_tranche", vs: step, elapsed: 25 * time.Second, want: 0.25},
+		{name: "step_between", vs: step, elapsed: 74 * time.Second, want: 0.5},
+		{name: "step_end", vs: step, elapsed: duration, want: 1},
+		{name: "curve_first", vs: curve, elapsed: 25 * time.Second, want: 0.1},
+		{name: "curve_middle", vs: curve, elapsed: 65 * time.Second, want: 0.55},
+		{name: "curve_last", vs: curve, elapsed: 90 * time.Second, want: 0.95},
+		{name: "expired", vs: curve, elapsed: 2 * duration, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, tt.vs.ratio(tt.elapsed, duration), 1e-9)
+		})
+	}
+}
+
+func Test_vestingPool_forecast(t *testing.T) {
+	var ar = addRequest{
+		StartTime: 10,
+		Duration:  100 * time.Second,
+		Destinations: destinations{
+			&destination{ID: "one", Amount: 100},
+			&destination{ID: "two", Amount: 200},
+		},
+		Schedule: &vestingSchedule{Type: scheduleStep, Step: 50 * time.Second},
+	}
+	var vp = newVestingPoolFromReqeust("client_hex", &ar)
+	require.Equal(t, ar.Schedule, vp.Schedule)
+
+	var unlockable = func(at common.Timestamp) (values []int64) {
+		f, err := vp.forecast(at)
+		require.NoError(t, err)
+		require.Equal(t, at, f.Timestamp)
+		for _, d := range f.Destinations {
+			values = append(values, int64(d.Unlockable))
+		}
+		return
+	}
+
+	assert.Equal(t, []int64{0, 0}, unlockable(5))
+	assert.Equal(t, []int64{0, 0}, unlockable(59))
+	assert.Equal(t, []int64{50, 100}, unlockable(60))
+	assert.Equal(t, []int64{100, 200}, unlockable(1000))
+
+	value, err := vp.unlock(vp.Destinations[0], 60, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 50, value)
+	assert.Equal(t, []int64{0, 100}, unlockable(60))
+	assert.Equal(t, []int64{50, 200}, unlockable(110))
+
+	var vpd = new(vestingPool)
+	require.NoError(t, vpd.Decode(vp.Encode()))
+	assert.Equal(t, vp, vpd)
+}
+
+func TestVestingSmartContract_irrevocable(t *testing.T) {
+	var (
+		vsc      = newTestVestingSC()
+		balances = newTestBalances()
+		client   = newClient(1200e10, balances)
+		tx       = newTransaction(client.id, vsc.ID, 0, 0)
+		err      error
+	)
+	configureConfig()
+
+	var resp string
+	resp, err = client.add(t, vsc, &addRequest{
+		Description: "for something",
+		StartTime:   10,
+		Duration:    2 * time.Second,
+		Destinations: destinations{
+			&destination{ID: "one", Amount: 10},
+		},
+		Irrevocable: true,
+	}, 800e10, 0, balances)
+	require.NoError(t, err)
+	var set vestingPool
+	require.NoError(t, set.Decode([]byte(resp)))
+	require.True(t, set.Irrevocable)
+
+	balances.txn = tx
+	_, err = vsc.stop(tx, mustEncode(t, &stopRequest{
+		PoolID:      set.ID,
+		Destination: "one",
+	}), balances)
+	requireErrMsg(t, err, "stop_vesting_failed: "+
+		"can't stop a vesting of irrevocable pool")
+
+	_, err = vsc.delete(tx, mustEncode(t, &poolRequest{PoolID: set.ID}), balances)
+	requireErrMsg(t, err, "delete_vesting_pool_failed: "+
+		"can't delete irrevocable pool before it expires")
+
+	tx.CreationDate = set.ExpireAt
+	_, err = vsc.delete(tx, mustEncode(t, &poolRequest{PoolID: set.ID}), balances)
+	require.NoError(t, err)
+}
@@ -24,7 +24,7 @@ import (
 	"0chain.net/core/util"
 )
 
-//msgp:ignore info destInfo addRequest
+//msgp:ignore info destInfo addRequest forecast destForecast
 //go:generate msgp -io=false -tests=false -unexported=true -v
 
 // internal errors
@@ -166,6 +166,8 @@ type addRequest struct {
 	StartTime    common.Timestamp `json:"start_time"`            //
 	Duration     time.Duration    `json:"duration"`              //
 	Destinations destinations     `json:"destinations"`          //
+	Schedule     *vestingSchedule `json:"schedule,omitempty"`    // linear if nil
+	Irrevocable  bool             `json:"irrevocable,omitempty"` // can't stop
 }
 
 func (ar *addRequest) decode(b []byte) error {
@@ -195,6 +197,11 @@ func (ar *addRequest) validate(now common.Timestamp, conf *config) (err error) {
 	case len(ar.Destinations) > conf.MaxDestinations:
 		return errors.New("too many destinations")
 	}
+	if ar.Schedule != nil {
+		if err = ar.Schedule.validate(ar.Duration); err != nil {
+			return fmt.Errorf("invalid schedule: %v", err)
+		}
+	}
 	return
 }
 
@@ -214,6 +221,11 @@ type vestingPool struct {
 	ExpireAt     common.Timestamp `json:"expire_at"`    //
 	Destinations destinations     `json:"destinations"` //
 	ClientID     string           `json:"client_id"`    // the pool owner
+	// Schedule of the vesting, the nil is the linear vesting.
+	Schedule *vestingSchedule `json:"schedule,omitempty"`
+	// Irrevocable pool can't be stopped or deleted by the owner until
+	// it expires.
+	Irrevocable bool `json:"irrevocable,omitempty"`
 }
 
 // newVestingPool returns new empty uninitialized vesting pool.
@@ -235,6 +247,10 @@ func newVestingPoolFromReqeust(clientID datastore.Key, ar *addRequest) (
 	vp.ExpireAt = ar.StartTime + toSeconds(ar.Duration)
 	vp.Destinations = ar.Destinations
 	vp.Destinations.start(vp.StartTime)
+	if !ar.Schedule.isLinear() {
+		vp.Schedule = ar.Schedule
+	}
+	vp.Irrevocable = ar.Irrevocable
 	return
 }
 
@@ -275,6 +291,21 @@ func checkFill(t *transaction.Transaction, balances chainstate.StateContextI) (
 	return
 }
 
+// unlock returns amount of tokens to vest for the destination by the
+// schedule of the pool. The now must be in the pool time range.
+func (vp *vestingPool) unlock(d *destination, now common.Timestamp, dry bool) (
+	amount currency.Coin, err error) {
+
+	if vp.Schedule.isLinear() {
+		return d.unlock(now, vp.ExpireAt, dry)
+	}
+	var (
+		elapsed  = time.Duration(now-vp.StartTime) * time.Second
+		duration = time.Duration(vp.ExpireAt-vp.StartTime) * time.Second
+	)
+	return d.unlockRatio(now, vp.Schedule.ratio(elapsed, duration), dry)
+}
+
 // required starting pool amount
 func (vp *vestingPool) want() (want currency.Coin, err error) {
 	for _, d := range vp.Destinations {
@@ -348,7 +379,7 @@ func (vp *vestingPool) trigger(t *transaction.Transaction,
 	)
 	sb.WriteByte('[')
 	for _, d := range vp.Destinations {
-		value, err := d.unlock(now, end, false)
+		value, err := vp.unlock(d, now, false)
 		if err != nil {
 			return "", err
 		}
@@ -438,7 +469,7 @@ func (vp *vestingPool) vest(vscID, destID datastore.Key, now common.Timestamp,
 		return
 	}
 
-	value, err := d.unlock(now, end, false)
+	value, err := vp.unlock(d, now, false)
 	if err != nil {
 		return "", err
 	}
@@ -502,6 +533,8 @@ func (vp *vestingPool) info(now common.Timestamp) (i *info, err error) {
 	i.Description = vp.Description
 	i.StartTime = vp.StartTime
 	i.ExpireAt = vp.ExpireAt
+	i.Schedule = vp.Schedule
+	i.Irrevocable = vp.Irrevocable
 
 	var end = i.ExpireAt
 
@@ -515,7 +548,7 @@ func (vp *vestingPool) info(now common.Timestamp) (i *info, err error) {
 
 	var dinfos = make([]*destInfo, 0, len(vp.Destinations))
 	for _, d := range vp.Destinations {
-		value, err := d.unlock(now, end, true)
+		value, err := vp.unlock(d, now, true)
 		if err != nil {
 			return nil, err
 		}
@@ -551,6 +584,55 @@ type info struct {
 	ExpireAt     common.Timestamp `json:"expire_at"`    // until
 	Destinations []*destInfo      `json:"destinations"` // receivers
 	ClientID     datastore.Key    `json:"client_id"`    // owner
+	Schedule     *vestingSchedule `json:"schedule,omitempty"`
+	Irrevocable  bool             `json:"irrevocable"`
+}
+
+//
+// forecast (dry run)
+//
+
+// forecast returns the tokens the destinations can unlock at the given
+// time, nothing is changed in the pool.
+func (vp *vestingPool) forecast(at common.Timestamp) (f *forecast, err error) {
+	f = &forecast{ID: vp.ID, Timestamp: at}
+
+	var now = at
+	if now < vp.StartTime {
+		now = vp.StartTime
+	}
+	if now > vp.ExpireAt {
+		now = vp.ExpireAt
+	}
+
+	f.Destinations = make([]*destForecast, 0, len(vp.Destinations))
+	for _, d := range vp.Destinations {
+		value, err := vp.unlock(d, now, true)
+		if err != nil {
+			return nil, err
+		}
+		f.Destinations = append(f.Destinations, &destForecast{
+			ID:         d.ID,
+			Wanted:     d.Amount,
+			Vested:     d.Vested,
+			Unlockable: value,
+		})
+	}
+	return
+}
+
+type destForecast struct {
+	ID         datastore.Key `json:"id"`         // identifier
+	Wanted     currency.Coin `json:"wanted"`     // wanted amount for entire period
+	Vested     currency.Coin `json:"vested"`     // tokens already vested
+	Unlockable currency.Coin `json:"unlockable"` // can unlock at the time
+}
+
+// swagger:model vestingForecast
+type forecast struct {
+	ID           datastore.Key    `json:"pool_id"`      // pool ID
+	Timestamp    common.Timestamp `json:"timestamp"`    // forecast time
+	Destinations []*destForecast  `json:"destinations"` // receivers
 }
 
 //
@@ -681,6 +763,11 @@ func (vsc *VestingSmartContract) stop(t *transaction.Transaction,
 		return "", common.NewError("stop_vesting_failed", "expired pool")
 	}
 
+	if vp.Irrevocable {
+		return "", common.NewError("stop_vesting_failed",
+			"can't stop a vesting of irrevocable pool")
+	}
+
 	_, err = vp.vest(t.ToClientID, sr.Destination, t.CreationDate, balances)
 	if err != nil && err != errZeroVesting {
 		return "", common.NewError("stop_vesting_failed", err.Error())
@@ -729,6 +816,11 @@ func (vsc *VestingSmartContract) delete(t *transaction.Transaction,
 			"only pool owner can delete the pool")
 	}
 
+	if vp.Irrevocable && t.CreationDate < vp.ExpireAt {
+		return "", common.NewError("delete_vesting_pool_failed",
+			"can't delete irrevocable pool before it expires")
+	}
+
 	// move tokens to destinations
 	if vp.Balance > 0 {
 		if _, err = vp.trigger(t, balances); err != nil {
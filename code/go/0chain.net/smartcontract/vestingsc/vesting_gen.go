@@ -292,9 +292,9 @@ func (z stopRequest) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *vestingPool) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 6
+	// map header, size 8
 	// string "ZcnPool"
-	o = append(o, 0x86, 0xa7, 0x5a, 0x63, 0x6e, 0x50, 0x6f, 0x6f, 0x6c)
+	o = append(o, 0x88, 0xa7, 0x5a, 0x63, 0x6e, 0x50, 0x6f, 0x6f, 0x6c)
 	o, err = z.ZcnPool.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "ZcnPool")
@@ -334,6 +334,20 @@ func (z *vestingPool) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "ClientID"
 	o = append(o, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ClientID)
+	// string "Schedule"
+	o = append(o, 0xa8, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65)
+	if z.Schedule == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.Schedule.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Schedule")
+			return
+		}
+	}
+	// string "Irrevocable"
+	o = append(o, 0xab, 0x49, 0x72, 0x72, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x6c, 0x65)
+	o = msgp.AppendBool(o, z.Irrevocable)
 	return
 }
 
@@ -415,6 +429,29 @@ func (z *vestingPool) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "ClientID")
 				return
 			}
+		case "Schedule":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Schedule = nil
+			} else {
+				if z.Schedule == nil {
+					z.Schedule = new(vestingSchedule)
+				}
+				bts, err = z.Schedule.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Schedule")
+					return
+				}
+			}
+		case "Irrevocable":
+			z.Irrevocable, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Irrevocable")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -437,6 +474,12 @@ func (z *vestingPool) Msgsize() (s int) {
 			s += z.Destinations[za0001].Msgsize()
 		}
 	}
-	s += 9 + msgp.StringPrefixSize + len(z.ClientID)
+	s += 9 + msgp.StringPrefixSize + len(z.ClientID) + 9
+	if z.Schedule == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.Schedule.Msgsize()
+	}
+	s += 12 + msgp.BoolSize
 	return
 }
@@ -105,12 +105,14 @@ func (zcn *ZCNSmartContract) AddAuthorizer(
 	// Creating StakePool
 
 	var sp *StakePool
-	sp, err = zcn.getOrUpdateStakePool(globalNode, authorizerID, params.StakePoolSettings, ctx)
+	sp, err = zcn.getOrCreateStakePool(authorizerID, ctx)
 	if err != nil {
 		return "", common.NewError(code, "failed to get or create stake pool: "+err.Error())
 	}
-	if err = sp.save(zcn.ID, authorizerID, ctx); err != nil {
-		return "", common.NewError(code, "failed to save stake pool: "+err.Error())
+	err = stakepool.RegisterProvider(&authorizerProvider{sscID: zcn.ID, id: authorizerID, sp: sp},
+		params.StakePoolSettings, globalNode.settingsLimits(), ctx)
+	if err != nil {
+		return "", common.NewError(code, "failed to register stake pool: "+err.Error())
 	}
 
 	// Events emission
@@ -179,12 +181,14 @@ func (zcn *ZCNSmartContract) UpdateAuthorizerStakePool(
 	authorizer, err := GetAuthorizerNode(authorizerID, ctx)
 	if err == nil && authorizer != nil {
 		var sp *StakePool
-		sp, err = zcn.getOrUpdateStakePool(globalNode, authorizerID, poolSettings, ctx)
+		sp, err = zcn.getOrCreateStakePool(authorizerID, ctx)
 		if err != nil {
 			return "", common.NewError(code, "failed to get or create stake pool: "+err.Error())
 		}
-		if err = sp.save(zcn.ID, authorizerID, ctx); err != nil {
-			return "", common.NewError(code, "failed to save stake pool: "+err.Error())
+		err = stakepool.UpdateProviderSettings(&authorizerProvider{sscID: zcn.ID, id: authorizerID, sp: sp},
+			poolSettings, globalNode.settingsLimits(), ctx)
+		if err != nil {
+			return "", common.NewError(code, "failed to update stake pool: "+err.Error())
 		}
 
 		Logger.Info("create or update stake pool completed successfully")
@@ -207,32 +211,8 @@ func (zcn *ZCNSmartContract) CollectRewards(
 		return "", common.NewErrorf(code, "can't decode request: %v", err)
 	}
 
-	usp, err := stakepool.GetUserStakePools(prr.ProviderType, tran.ClientID, ctx)
-	if err != nil {
-		return "", common.NewErrorf(code, "can't get related user stake pools: %v", err)
-	}
-
-	providerId := usp.FindProvider(prr.PoolId)
-	if len(providerId) == 0 {
-		return "", common.NewErrorf(code, "user %v does not own stake pool %v", tran.ClientID, prr.PoolId)
-	}
-
-	sp, err := zcn.getStakePool(providerId, ctx)
-	if err != nil {
-		return "", common.NewErrorf(code, "can't get related stake pool: %v", err)
-	}
-
-	_, err = sp.MintRewards(tran.ClientID, prr.PoolId, providerId, prr.ProviderType, usp, ctx)
-	if err != nil {
-		return "", common.NewErrorf(code, "error emptying account, %v", err)
-	}
-
-	if err := usp.Save(spenum.Authorizer, tran.ClientID, ctx); err != nil {
-		return "", common.NewErrorf(code, "error saving user stake pool, %v", err)
-	}
-
-	if err := sp.save(zcn.ID, providerId, ctx); err != nil {
-		return "", common.NewErrorf(code, "error saving stake pool, %v", err)
+	if _, _, err := stakepool.CollectReward(tran.ClientID, &prr, zcn.getProvider, ctx); err != nil {
+		return "", common.NewError(code, err.Error())
 	}
 
 	return "", nil
@@ -2,7 +2,10 @@ package zcnsc
 
 import (
 	"0chain.net/smartcontract/benchmark"
+	"0chain.net/smartcontract/dbs/event"
 	"0chain.net/smartcontract/rest"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
 )
 
 func BenchmarkRestTests(data benchmark.BenchData, _ benchmark.SignatureScheme) benchmark.TestSuite {
@@ -25,6 +28,39 @@ func BenchmarkRestTests(data benchmark.BenchData, _ benchmark.SignatureScheme) b
 				},
 				Endpoint: zrh.getAuthorizer,
 			},
+			{
+				FuncName: "provider",
+				Params: map[string]string{
+					"provider_type": spenum.Authorizer.String(),
+					"provider_id":   data.Clients[0],
+				},
+				Endpoint: stakepool.GetProviderHandler(rh, spenum.Authorizer),
+			},
+			{
+				FuncName: "providers",
+				Params: map[string]string{
+					"status": spenum.ProviderActive.String(),
+					"limit":  "20",
+				},
+				Endpoint: stakepool.GetProvidersHandler(rh, spenum.Authorizer),
+			},
+			{
+				FuncName: "slashes",
+				Params: map[string]string{
+					"provider_id": data.Clients[0],
+					"limit":       "20",
+				},
+				Endpoint: stakepool.GetSlashesHandler(rh, spenum.Authorizer),
+			},
+			{
+				FuncName: "unbondings",
+				Params: map[string]string{
+					"client_id": data.Clients[0],
+					"status":    event.UnbondingPending,
+					"limit":     "20",
+				},
+				Endpoint: stakepool.GetUnbondingsHandler(rh, spenum.Authorizer),
+			},
 		},
 		ADDRESS,
 		zrh,
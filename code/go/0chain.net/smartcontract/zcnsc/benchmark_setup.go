@@ -31,6 +31,7 @@ func Setup(eventDb *event.EventDb, clients, publicKeys []string, balances cstate
 	addMockUserNodes(clients, balances)
 	addMockAuthorizers(eventDb, clients, publicKeys, balances)
 	addMockStakePools(clients, balances)
+	addMockUnbondingQueues(clients, balances)
 }
 
 func addMockGlobalNode(balances cstate.StateContextI) {
@@ -129,6 +130,24 @@ func addMockStakePools(clients []string, ctx cstate.StateContextI) {
 	}
 }
 
+func addMockUnbondingQueues(clients []string, ctx cstate.StateContextI) {
+	for i, client := range clients {
+		uq := &stakepool.UnbondingQueue{
+			Entries: []*stakepool.UnbondingEntry{{
+				ID:           encryption.Hash("mock authorizer unbonding " + strconv.Itoa(i)),
+				ProviderID:   clients[0],
+				ProviderType: spenum.Authorizer,
+				PoolID:       getMockAuthoriserStakePoolId(clients[0], 0),
+				Amount:       10,
+			}},
+		}
+		_, err := ctx.InsertTrieNode(stakepool.UnbondingQueueKey(ADDRESS, client), uq)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
 func addMockUserNodes(clients []string, balances cstate.StateContextI) {
 	for _, clientId := range clients {
 		un := NewUserNode(clientId)
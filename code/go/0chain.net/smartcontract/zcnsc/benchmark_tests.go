@@ -160,6 +160,46 @@ func BenchmarkTests(data benchmark.BenchData, scheme benchmark.SignatureScheme)
 					AuthorizerID: data.Clients[0],
 				}).encode(),
 			},
+			{
+				name:     benchmark.ZcnSc + ShutdownProviderFunc,
+				endpoint: sc.ShutdownProvider,
+				txn:      createTransaction(data.Clients[0], data.PublicKeys[0]),
+				input: (&stakepool.ProviderRequest{
+					ProviderID:   data.Clients[0],
+					ProviderType: spenum.Authorizer,
+				}).Encode(),
+			},
+			{
+				name:     benchmark.ZcnSc + KillProviderFunc,
+				endpoint: sc.KillProvider,
+				txn:      createTransaction(owner, data.PublicKeys[0]),
+				input: (&stakepool.ProviderRequest{
+					ProviderID:   data.Clients[1],
+					ProviderType: spenum.Authorizer,
+				}).Encode(),
+			},
+			{
+				name:     benchmark.ZcnSc + SlashFunc,
+				endpoint: sc.Slash,
+				txn:      createTransaction(data.Clients[3], data.PublicKeys[3]),
+				input:    createSlashEvidence(scheme, data, 2),
+			},
+			{
+				name:     benchmark.ZcnSc + UnbondFunc,
+				endpoint: sc.Unbond,
+				txn:      createTransaction(data.Clients[0], data.PublicKeys[0]),
+				input: (&stakepool.UnbondRequest{
+					ProviderID:   data.Clients[0],
+					ProviderType: spenum.Authorizer,
+					PoolID:       getMockAuthoriserStakePoolId(data.Clients[0], 0),
+				}).Encode(),
+			},
+			{
+				name:     benchmark.ZcnSc + ClaimUnbondedFunc,
+				endpoint: sc.ClaimUnbonded,
+				txn:      createTransaction(data.Clients[0], data.PublicKeys[0]),
+				input:    []byte{},
+			},
 		},
 	)
 }
@@ -208,6 +248,35 @@ func createMintPayloadForZCNSCMint(scheme benchmark.SignatureScheme, data benchm
 	return payload.Encode()
 }
 
+// createSlashEvidence creates two conflicting mint payloads signed by the
+// authorizer of given index
+func createSlashEvidence(scheme benchmark.SignatureScheme, data benchmark.BenchData, index int) []byte {
+	se := &SlashEvidence{AuthorizerID: data.Clients[index]}
+	for _, amount := range []int64{1, 2} {
+		pb := &proofOfBurn{
+			TxnID:             "0xc8285f5304b1B7aAB09a7d26721D6F585448D0ed",
+			Amount:            amount,
+			ReceivingClientID: data.Clients[1],
+			Nonce:             mintNonce + 2,
+			Scheme:            scheme,
+		}
+		if err := pb.sign(data.PrivateKeys[index]); err != nil {
+			panic(err)
+		}
+		se.Payloads = append(se.Payloads, &MintPayload{
+			EthereumTxnID: pb.TxnID,
+			Amount:        currency.Coin(pb.Amount),
+			Nonce:         pb.Nonce,
+			Signatures: []*AuthorizerSignature{{
+				ID:        data.Clients[index],
+				Signature: pb.Signature,
+			}},
+			ReceivingClientID: pb.ReceivingClientID,
+		})
+	}
+	return se.Encode()
+}
+
 func createBurnPayloadForZCNSCBurn() []byte {
 	payload := &BurnPayload{
 		EthereumAddress: "0xc8285f5304b1B7aAB09a7d26721D6F585448D0ed",
@@ -32,6 +32,9 @@ const (
 	OwnerID            = "owner_id"
 	Cost               = "cost"
 	MaxDelegates       = "max_delegates"
+	SlashRatio         = "slash_ratio"
+	SlashReporterRatio = "slash_reporter_ratio"
+	UnbondingPeriod    = "unbonding_period"
 )
 
 var CostFunctions = []string{
@@ -52,7 +55,7 @@ func (zcn *ZCNSmartContract) UpdateGlobalConfig(t *transaction.Transaction, inpu
 		return "", errors.Wrap(err, Code)
 	}
 
-	if err := smartcontractinterface.AuthorizeWithOwner(FuncName, func() bool {
+	if err := smartcontractinterface.AuthorizeWithOwnerOrGovernance(FuncName, t.ClientID, func() bool {
 		return gn.OwnerId == t.ClientID
 	}); err != nil {
 		return "", errors.Wrap(err, Code)
@@ -92,6 +95,9 @@ func (gn *GlobalNode) ToStringMap() smartcontract.StringMap {
 		BurnAddress:        fmt.Sprintf("%v", gn.BurnAddress),
 		OwnerID:            fmt.Sprintf("%v", gn.OwnerId),
 		MaxDelegates:       fmt.Sprintf("%v", gn.MaxDelegates),
+		SlashRatio:         fmt.Sprintf("%v", gn.SlashRatio),
+		SlashReporterRatio: fmt.Sprintf("%v", gn.SlashReporterRatio),
+		UnbondingPeriod:    fmt.Sprintf("%v", gn.UnbondingPeriod),
 	}
 
 	for _, key := range CostFunctions {
@@ -120,6 +126,9 @@ func loadGlobalNode() (conf *ZCNSConfig) {
 	conf.OwnerId = cfg.GetString(postfix(OwnerID))
 	conf.Cost = cfg.GetStringMapInt(postfix(Cost))
 	conf.MaxDelegates = cfg.GetInt(postfix(MaxDelegates))
+	conf.SlashRatio = cfg.GetFloat64(postfix(SlashRatio))
+	conf.SlashReporterRatio = cfg.GetFloat64(postfix(SlashReporterRatio))
+	conf.UnbondingPeriod = cfg.GetInt64(postfix(UnbondingPeriod))
 
 	return conf
 }
@@ -33,7 +33,7 @@ func TestConfigMap_Get(t *testing.T) {
 
 	stringMap := cfg.ToStringMap()
 
-	require.Equal(t, 14, len(stringMap.Fields))
+	require.Equal(t, 17, len(stringMap.Fields))
 	require.Contains(t, stringMap.Fields, OwnerID)
 	require.Contains(t, stringMap.Fields, MinBurnAmount)
 	require.Contains(t, stringMap.Fields, MinMintAmount)
@@ -44,6 +44,9 @@ func TestConfigMap_Get(t *testing.T) {
 	require.Contains(t, stringMap.Fields, BurnAddress)
 	require.Contains(t, stringMap.Fields, PercentAuthorizers)
 	require.Contains(t, stringMap.Fields, MaxDelegates)
+	require.Contains(t, stringMap.Fields, SlashRatio)
+	require.Contains(t, stringMap.Fields, SlashReporterRatio)
+	require.Contains(t, stringMap.Fields, UnbondingPeriod)
 
 	for _, costFunction := range CostFunctions {
 		require.Contains(t, stringMap.Fields, fmt.Sprintf("%s.%s", Cost, costFunction))
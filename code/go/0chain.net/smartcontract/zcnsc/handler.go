@@ -11,6 +11,8 @@ import (
 
 	"0chain.net/smartcontract"
 	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
 	"github.com/pkg/errors"
 )
 
@@ -23,7 +25,7 @@ func NewZcnRestHandler(rh rest.RestHandlerI) *ZcnRestHandler {
 }
 
 func SetupRestHandler(rh rest.RestHandlerI) {
-	rh.Register(GetEndpoints(rh))
+	rh.Register(GetEndpoints)
 }
 
 func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
@@ -33,6 +35,10 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		{URI: zcn + "/getAuthorizerNodes", Handler: zrh.getAuthorizerNodes},
 		{URI: zcn + "/getGlobalConfig", Handler: zrh.GetGlobalConfig},
 		{URI: zcn + "/getAuthorizer", Handler: zrh.getAuthorizer},
+		{URI: zcn + "/provider", Handler: stakepool.GetProviderHandler(rh, spenum.Authorizer)},
+		{URI: zcn + "/providers", Handler: stakepool.GetProvidersHandler(rh, spenum.Authorizer)},
+		{URI: zcn + "/slashes", Handler: stakepool.GetSlashesHandler(rh, spenum.Authorizer)},
+		{URI: zcn + "/unbondings", Handler: stakepool.GetUnbondingsHandler(rh, spenum.Authorizer)},
 	}
 }
 
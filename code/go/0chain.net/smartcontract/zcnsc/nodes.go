@@ -36,6 +36,15 @@ type ZCNSConfig struct {
 	OwnerId            string         `json:"owner_id"`
 	Cost               map[string]int `json:"cost"`
 	MaxDelegates       int            `json:"max_delegates"` // MaxDelegates per stake pool
+	// SlashRatio is the part of the stake of an authorizer slashed for
+	// conflicting signatures.
+	SlashRatio float64 `json:"slash_ratio"`
+	// SlashReporterRatio is the part of the slashed tokens paid to the
+	// reporter, the rest is burnt.
+	SlashReporterRatio float64 `json:"slash_reporter_ratio"`
+	// UnbondingPeriod is the number of rounds the unbonded tokens of an
+	// authorizer delegate wait before they can be claimed.
+	UnbondingPeriod int64 `json:"unbonding_period"`
 }
 
 type GlobalNode struct {
@@ -116,6 +125,21 @@ func (gn *GlobalNode) UpdateConfig(cfg *smartcontract.StringMap) (err error) {
 			if err != nil {
 				return fmt.Errorf("key %s, unable to convert %v to int64", key, value)
 			}
+		case SlashRatio:
+			gn.SlashRatio, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("key %s, unable to convert %v to float64", key, value)
+			}
+		case SlashReporterRatio:
+			gn.SlashReporterRatio, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("key %s, unable to convert %v to float64", key, value)
+			}
+		case UnbondingPeriod:
+			gn.UnbondingPeriod, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("key %s, unable to convert %v to int64", key, value)
+			}
 		default:
 			return fmt.Errorf("key %s, unable to convert %v to currency.Coin", key, value)
 		}
@@ -177,6 +201,12 @@ func (gn *GlobalNode) Validate() error {
 		return common.NewError(Code, fmt.Sprintf("max delegate count (%v) is less than 0", gn.MaxDelegates))
 	case gn.MinLockAmount == 0:
 		return common.NewError(Code, fmt.Sprintf("min lock amount (%v) is equal to 0", gn.MinLockAmount))
+	case gn.SlashRatio < 0 || gn.SlashRatio > 1:
+		return common.NewError(Code, fmt.Sprintf("slash ratio (%v) is not in [0; 1]", gn.SlashRatio))
+	case gn.SlashReporterRatio < 0 || gn.SlashReporterRatio > 1:
+		return common.NewError(Code, fmt.Sprintf("slash reporter ratio (%v) is not in [0; 1]", gn.SlashReporterRatio))
+	case gn.UnbondingPeriod < 0:
+		return common.NewError(Code, fmt.Sprintf("unbonding period (%v) is less than 0", gn.UnbondingPeriod))
 	}
 	return nil
 }
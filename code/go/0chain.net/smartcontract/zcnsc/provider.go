@@ -0,0 +1,111 @@
+package zcnsc
+
+import (
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+// authorizerProvider is an authorizer seen through its stake pool, it
+// implements the stakepool.Provider interface.
+type authorizerProvider struct {
+	sscID string
+	id    string
+	sp    *StakePool
+}
+
+func (p *authorizerProvider) Id() string {
+	return p.id
+}
+
+func (p *authorizerProvider) Type() spenum.Provider {
+	return spenum.Authorizer
+}
+
+func (p *authorizerProvider) GetStakePool() *stakepool.StakePool {
+	return &p.sp.StakePool
+}
+
+func (p *authorizerProvider) SaveProvider(ctx cstate.StateContextI) error {
+	return p.sp.save(p.sscID, p.id, ctx)
+}
+
+// getProvider loads an authorizer with its stake pool
+func (zcn *ZCNSmartContract) getProvider(
+	providerType spenum.Provider,
+	id string,
+	ctx cstate.StateContextI,
+) (stakepool.Provider, error) {
+	if providerType != spenum.Authorizer {
+		return nil, fmt.Errorf("invalid provider type: %s", providerType.String())
+	}
+	authorizer, err := GetAuthorizerNode(id, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authorizer (authorizerID: %v), err: %w", id, err)
+	}
+	if authorizer == nil {
+		return nil, fmt.Errorf("authorizer (authorizerID: %v) not found", id)
+	}
+	sp, err := zcn.getStakePool(id, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get related stake pool: %v", err)
+	}
+	return &authorizerProvider{sscID: zcn.ID, id: id, sp: sp}, nil
+}
+
+// ShutdownProvider stops an authorizer by itself or by its delegate wallet,
+// a stopped authorizer doesn't accept new stakes.
+func (zcn *ZCNSmartContract) ShutdownProvider(
+	tran *transaction.Transaction,
+	input []byte,
+	ctx cstate.StateContextI,
+) (string, error) {
+	const code = "shutdown_provider_failed"
+
+	var req stakepool.ProviderRequest
+	if err := req.Decode(input); err != nil {
+		return "", common.NewErrorf(code, "can't decode request: %v", err)
+	}
+	p, err := zcn.getProvider(req.ProviderType, req.ProviderID, ctx)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+	if err = stakepool.ShutDownProvider(tran.ClientID, p, ctx); err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+	return "", nil
+}
+
+// KillProvider stops an authorizer by the ZCN SC owner
+func (zcn *ZCNSmartContract) KillProvider(
+	tran *transaction.Transaction,
+	input []byte,
+	ctx cstate.StateContextI,
+) (string, error) {
+	const code = "kill_provider_failed"
+
+	gn, err := GetGlobalNode(ctx)
+	if err != nil {
+		return "", common.NewErrorf(code, "failed to get global node: %v", err)
+	}
+	if tran.ClientID != gn.OwnerId {
+		return "", common.NewError(code, "access denied, allowed for SC owner only")
+	}
+
+	var req stakepool.ProviderRequest
+	if err := req.Decode(input); err != nil {
+		return "", common.NewErrorf(code, "can't decode request: %v", err)
+	}
+	p, err := zcn.getProvider(req.ProviderType, req.ProviderID, ctx)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+	if err = stakepool.KillProvider(p, ctx); err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+	return "", nil
+}
@@ -31,6 +31,11 @@ const (
 	DeleteFromDelegatePoolFunc    = "delete-from-delegate-pool"
 	UpdateAuthorizerStakePoolFunc = "update-authorizer-stake-pool"
 	CollectRewardsFunc            = "collect-rewards"
+	ShutdownProviderFunc          = "shutdown-provider"
+	KillProviderFunc              = "kill-provider"
+	SlashFunc                     = "slash"
+	UnbondFunc                    = "unbond"
+	ClaimUnbondedFunc             = "claim-unbonded"
 )
 
 // ZCNSmartContract ...
@@ -66,8 +71,14 @@ func (zcn *ZCNSmartContract) InitSC() {
 	zcn.smartContractFunctions[UpdateAuthorizerStakePoolFunc] = zcn.UpdateAuthorizerStakePool
 	// Rewards
 	zcn.smartContractFunctions[CollectRewardsFunc] = zcn.CollectRewards
+	// Providers
+	zcn.smartContractFunctions[ShutdownProviderFunc] = zcn.ShutdownProvider
+	zcn.smartContractFunctions[KillProviderFunc] = zcn.KillProvider
+	zcn.smartContractFunctions[SlashFunc] = zcn.Slash
 	zcn.smartContractFunctions[AddToDelegatePoolFunc] = zcn.AddToDelegatePool           // stakepool lock
 	zcn.smartContractFunctions[DeleteFromDelegatePoolFunc] = zcn.DeleteFromDelegatePool // stakepool unlock
+	zcn.smartContractFunctions[UnbondFunc] = zcn.Unbond
+	zcn.smartContractFunctions[ClaimUnbondedFunc] = zcn.ClaimUnbonded
 }
 
 // SetSC ...
@@ -91,6 +102,18 @@ func (zcn *ZCNSmartContract) setSC(sc *smartcontractinterface.SmartContract, _ s
 		metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", zcn.ID, AddToDelegatePoolFunc), nil)
 	zcn.SmartContractExecutionStats[DeleteFromDelegatePoolFunc] =
 		metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", zcn.ID, DeleteFromDelegatePoolFunc), nil)
+	zcn.SmartContractExecutionStats[UnbondFunc] =
+		metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", zcn.ID, UnbondFunc), nil)
+	zcn.SmartContractExecutionStats[ClaimUnbondedFunc] =
+		metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", zcn.ID, ClaimUnbondedFunc), nil)
+
+	// Providers
+	zcn.SmartContractExecutionStats[ShutdownProviderFunc] =
+		metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", zcn.ID, ShutdownProviderFunc), nil)
+	zcn.SmartContractExecutionStats[KillProviderFunc] =
+		metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", zcn.ID, KillProviderFunc), nil)
+	zcn.SmartContractExecutionStats[SlashFunc] =
+		metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", zcn.ID, SlashFunc), nil)
 }
 
 // GetName ...
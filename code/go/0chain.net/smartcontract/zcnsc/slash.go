@@ -0,0 +1,124 @@
+package zcnsc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/pkg/errors"
+)
+
+// ConflictingSignatures is the fault of an authorizer signed two different
+// mint payloads of the same WZCN nonce, at least one of them is forged.
+const ConflictingSignatures = "conflicting_signatures"
+
+// SlashEvidence proves an authorizer signed two conflicting mint payloads.
+type SlashEvidence struct {
+	AuthorizerID string         `json:"authorizer_id"`
+	Payloads     []*MintPayload `json:"payloads"`
+}
+
+func (se *SlashEvidence) Encode() []byte {
+	buff, _ := json.Marshal(se)
+	return buff
+}
+
+func (se *SlashEvidence) Decode(input []byte) error {
+	return json.Unmarshal(input, se)
+}
+
+// verify checks the payloads are different payloads of the same nonce signed
+// by the authorizer, it returns the offence id.
+func (se *SlashEvidence) verify(node *AuthorizerNode, ctx cstate.StateContextI) (string, error) {
+	if len(se.Payloads) != 2 || se.Payloads[0] == nil || se.Payloads[1] == nil {
+		return "", errors.New("two mint payloads expected")
+	}
+	a, b := se.Payloads[0], se.Payloads[1]
+	if a.Nonce != b.Nonce {
+		return "", fmt.Errorf("payloads of different nonces: %d, %d", a.Nonce, b.Nonce)
+	}
+	if a.GetStringToSign() == b.GetStringToSign() {
+		return "", errors.New("same payload")
+	}
+
+	for _, p := range se.Payloads {
+		var sig *AuthorizerSignature
+		for _, s := range p.Signatures {
+			if s != nil && s.ID == node.ID {
+				sig = s
+				break
+			}
+		}
+		if sig == nil {
+			return "", fmt.Errorf("no signature of the authorizer for the ethereum txn %s", p.EthereumTxnID)
+		}
+
+		signatureScheme := ctx.GetSignatureScheme()
+		if err := signatureScheme.SetPublicKey(node.PublicKey); err != nil {
+			return "", errors.Wrap(err, "failed to set public key")
+		}
+		ok, err := signatureScheme.Verify(sig.Signature, p.GetStringToSign())
+		if err != nil {
+			return "", errors.Wrap(err, "failed to verify signature")
+		}
+		if !ok {
+			return "", fmt.Errorf("invalid signature for the ethereum txn %s", p.EthereumTxnID)
+		}
+	}
+	return encryption.Hash(fmt.Sprintf("%s:%s:%d", ConflictingSignatures, node.ID, a.Nonce)), nil
+}
+
+// Slash verifies an evidence of conflicting signatures of an authorizer and
+// slashes the stake of the authorizer, a part of the slashed tokens is paid
+// to the reporter.
+func (zcn *ZCNSmartContract) Slash(
+	tran *transaction.Transaction,
+	input []byte,
+	ctx cstate.StateContextI,
+) (string, error) {
+	const code = "slash_failed"
+
+	gn, err := GetGlobalNode(ctx)
+	if err != nil {
+		return "", common.NewErrorf(code, "failed to get global node: %v", err)
+	}
+
+	var se SlashEvidence
+	if err := se.Decode(input); err != nil {
+		return "", common.NewErrorf(code, "can't decode evidence: %v", err)
+	}
+	node, err := GetAuthorizerNode(se.AuthorizerID, ctx)
+	if err != nil {
+		return "", common.NewErrorf(code, "failed to get authorizer (authorizerID: %v), err: %v", se.AuthorizerID, err)
+	}
+	offenceID, err := se.verify(node, ctx)
+	if err != nil {
+		return "", common.NewErrorf(code, "invalid evidence: %v", err)
+	}
+
+	p, err := zcn.getProvider(spenum.Authorizer, se.AuthorizerID, ctx)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+	sr, err := stakepool.SlashProvider(zcn.ID, p, &stakepool.Offence{
+		ID:            offenceID,
+		Reason:        ConflictingSignatures,
+		Reporter:      tran.ClientID,
+		Ratio:         gn.SlashRatio,
+		ReporterRatio: gn.SlashReporterRatio,
+	}, ctx)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+
+	resp, err := json.Marshal(sr)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+	return string(resp), nil
+}
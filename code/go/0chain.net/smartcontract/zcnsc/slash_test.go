@@ -0,0 +1,83 @@
+package zcnsc_test
+
+import (
+	"testing"
+
+	"0chain.net/chaincore/currency"
+	. "0chain.net/smartcontract/zcnsc"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Slash_InvalidEvidence(t *testing.T) {
+	ctx := MakeMockStateContext()
+	contract := CreateZCNSmartContract()
+
+	payload := func(amount int64, nonce int64) *MintPayload {
+		p, err := CreateMintPayload(ctx, defaultClient)
+		require.NoError(t, err)
+		p.Amount, p.Nonce = currency.Coin(amount), nonce
+		p.Signatures, err = createTransactionSignatures(ctx, p)
+		require.NoError(t, err)
+		return p
+	}
+
+	tests := []struct {
+		name     string
+		evidence func() *SlashEvidence
+		wantErr  string
+	}{
+		{
+			name: "one_payload",
+			evidence: func() *SlashEvidence {
+				return &SlashEvidence{AuthorizerID: defaultAuthorizer, Payloads: []*MintPayload{payload(1, 1)}}
+			},
+			wantErr: "two mint payloads expected",
+		},
+		{
+			name: "different_nonces",
+			evidence: func() *SlashEvidence {
+				return &SlashEvidence{AuthorizerID: defaultAuthorizer, Payloads: []*MintPayload{
+					payload(1, 1), payload(2, 2),
+				}}
+			},
+			wantErr: "different nonces",
+		},
+		{
+			name: "same_payload",
+			evidence: func() *SlashEvidence {
+				return &SlashEvidence{AuthorizerID: defaultAuthorizer, Payloads: []*MintPayload{
+					payload(1, 1), payload(1, 1),
+				}}
+			},
+			wantErr: "same payload",
+		},
+		{
+			name: "forged_signature",
+			evidence: func() *SlashEvidence {
+				forged := payload(2, 1)
+				forged.Amount++
+				return &SlashEvidence{AuthorizerID: defaultAuthorizer, Payloads: []*MintPayload{
+					payload(1, 1), forged,
+				}}
+			},
+			wantErr: "invalid signature",
+		},
+		{
+			name: "unknown_authorizer",
+			evidence: func() *SlashEvidence {
+				return &SlashEvidence{AuthorizerID: "unknown", Payloads: []*MintPayload{
+					payload(1, 1), payload(2, 1),
+				}}
+			},
+			wantErr: "failed to get authorizer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := CreateDefaultTransactionToZcnsc()
+			_, err := contract.Slash(tr, tt.evidence().Encode(), ctx)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
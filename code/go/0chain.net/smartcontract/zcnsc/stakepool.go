@@ -2,13 +2,11 @@ package zcnsc
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 
 	"0chain.net/chaincore/currency"
 
 	cstate "0chain.net/chaincore/chain/state"
-	"0chain.net/chaincore/state"
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
@@ -17,18 +15,10 @@ import (
 	"0chain.net/smartcontract/stakepool/spenum"
 )
 
-//msgp:ignore unlockResponse stakePoolRequest
+//msgp:ignore stakePoolRequest
 
 //go:generate msgp -v -io=false -tests=false -unexported
 
-// unlock response
-type unlockResponse struct {
-	// one of the fields is set in a response, the Unstake if can't unstake
-	// for now and the TokenPoolTransferResponse if it has a pool had unlocked
-	Unstake bool          `json:"unstake"` // max time to wait to unstake
-	Balance currency.Coin `json:"balance"`
-}
-
 type stakePoolRequest struct {
 	PoolID       string `json:"pool_id,omitempty"`
 	AuthorizerID string `json:"authorizer_id,omitempty"`
@@ -76,28 +66,6 @@ func (sp *StakePool) save(sscKey, providerID string, balances cstate.StateContex
 	return
 }
 
-// empty a delegate pool if possible, call update before the empty
-func (sp *StakePool) empty(sscID, poolID, clientID string, balances cstate.StateContextI) (bool, error) {
-	var dp, ok = sp.Pools[poolID]
-	if !ok {
-		return false, fmt.Errorf("no such delegate pool: %q", poolID)
-	}
-
-	if dp.DelegateID != clientID {
-		return false, errors.New("trying to unlock not by delegate pool owner")
-	}
-
-	transfer := state.NewTransfer(sscID, clientID, dp.Balance)
-	if err := balances.AddTransfer(transfer); err != nil {
-		return false, err
-	}
-
-	sp.Pools[poolID].Balance = 0
-	sp.Pools[poolID].Status = spenum.Deleting
-
-	return true, nil
-}
-
 //
 // smart contract methods
 //
@@ -113,23 +81,28 @@ func (zcn *ZCNSmartContract) getStakePool(authorizerID datastore.Key, balances c
 	return sp, nil
 }
 
+// DelegatedStake returns the tokens the client delegates to the authorizers
+func DelegatedStake(clientID string, balances cstate.StateContextI) (currency.Coin, error) {
+	return stakepool.DelegatedStake(spenum.Authorizer, clientID,
+		func(id string) (*stakepool.StakePool, error) {
+			sp := NewStakePool()
+			if err := balances.GetTrieNode(StakePoolKey(ADDRESS, id), sp); err != nil {
+				return nil, err
+			}
+			return &sp.StakePool, nil
+		}, balances)
+}
+
 // initial or successive method should be used by add_authorizer
 
 // SC functions
 
-// get existing stake pool or create new one not saving it
-func (zcn *ZCNSmartContract) getOrUpdateStakePool(
-	gn *GlobalNode,
+// get existing stake pool or create new one not saving it, its settings are
+// set on the provider registration
+func (zcn *ZCNSmartContract) getOrCreateStakePool(
 	authorizerID datastore.Key,
-	settings stakepool.Settings,
 	ctx cstate.StateContextI,
 ) (*StakePool, error) {
-	if err := validateStakePoolSettings(settings, gn); err != nil {
-		return nil, fmt.Errorf("invalid stake_pool settings: %v", err)
-	}
-
-	changed := false
-
 	// the stake pool can be created by related validator
 	sp, err := zcn.getStakePool(authorizerID, ctx)
 	if err != nil {
@@ -138,61 +111,16 @@ func (zcn *ZCNSmartContract) getOrUpdateStakePool(
 		}
 		sp = NewStakePool()
 		sp.Minter = cstate.MinterStorage
-		sp.Settings.DelegateWallet = settings.DelegateWallet
-		changed = true
-	}
-
-	if sp.Settings.MinStake != settings.MinStake {
-		sp.Settings.MinStake = settings.MinStake
-		changed = true
-	}
-
-	if sp.Settings.MaxStake != settings.MaxStake {
-		sp.Settings.MaxStake = settings.MaxStake
-		changed = true
-	}
-
-	if sp.Settings.ServiceChargeRatio != settings.ServiceChargeRatio {
-		sp.Settings.ServiceChargeRatio = settings.ServiceChargeRatio
-		changed = true
-	}
-
-	if sp.Settings.MaxNumDelegates != settings.MaxNumDelegates {
-		sp.Settings.MaxNumDelegates = settings.MaxNumDelegates
-		changed = true
 	}
-
-	if changed {
-		return sp, nil
-	}
-
-	return nil, fmt.Errorf("no changes have been made to stakepool for authorizerID (%s)", authorizerID)
+	return sp, nil
 }
 
-func validateStakePoolSettings(poolSettings stakepool.Settings, conf *GlobalNode) error {
-	err := conf.validateStakeRange(poolSettings.MinStake, poolSettings.MaxStake)
-	if err != nil {
-		return err
-	}
-	if poolSettings.ServiceChargeRatio < 0.0 {
-		return errors.New("negative service charge")
+// settingsLimits returns the bounds of the stake pool settings of the
+// authorizers
+func (gn *GlobalNode) settingsLimits() *stakepool.SettingsLimits {
+	return &stakepool.SettingsLimits{
+		MinStake: gn.MinStakeAmount,
 	}
-	if poolSettings.MaxNumDelegates <= 0 {
-		return errors.New("num_delegates <= 0")
-	}
-
-	return nil
-}
-
-func (gn *GlobalNode) validateStakeRange(min, max currency.Coin) (err error) {
-	if min < gn.MinStakeAmount {
-		return fmt.Errorf("min_stake is less than allowed by SC: %v < %v", min, gn.MinStakeAmount)
-	}
-	if max < min {
-		return fmt.Errorf("max_stake less than min_stake: %v < %v", min, max)
-	}
-
-	return
 }
 
 func (zcn *ZCNSmartContract) AddToDelegatePool(
@@ -240,6 +168,9 @@ func (zcn *ZCNSmartContract) AddToDelegatePool(
 	return
 }
 
+// DeleteFromDelegatePool unlocks a whole delegate pool of an authorizer
+// through the unbonding queue of the delegate, the tokens are claimable after
+// the unbonding period.
 func (zcn *ZCNSmartContract) DeleteFromDelegatePool(
 	t *transaction.Transaction,
 	input []byte,
@@ -251,27 +182,25 @@ func (zcn *ZCNSmartContract) DeleteFromDelegatePool(
 	if err = spr.decode(input); err != nil {
 		return "", common.NewErrorf(code, "can't decode request: %v", err)
 	}
-	var sp *StakePool
-	if sp, err = zcn.getStakePool(spr.AuthorizerID, ctx); err != nil {
-		return "", common.NewErrorf(code, "can't get related stake pool: %v", err)
-	}
 
-	_, err = sp.empty(zcn.ID, spr.PoolID, t.ClientID, ctx)
+	gn, err := GetGlobalNode(ctx)
 	if err != nil {
-		return "", common.NewErrorf(code, "unlocking tokens: %v", err)
+		return "", common.NewErrorf(code, "failed to get global node: %v", err)
 	}
-
-	amount, err := sp.UnlockClientStakePool(t.ClientID, spenum.Blobber, spr.AuthorizerID, spr.PoolID, ctx)
+	p, err := zcn.getProvider(spenum.Authorizer, spr.AuthorizerID, ctx)
 	if err != nil {
-		return "", common.NewErrorf(code, "%v", err)
+		return "", common.NewError(code, err.Error())
 	}
-
-	// save the pool
-	if err = sp.save(zcn.ID, spr.AuthorizerID, ctx); err != nil {
-		return "", common.NewErrorf(code, "saving stake pool: %v", err)
+	entry, err := stakepool.Unbond(zcn.ID, t.ClientID, p, &stakepool.UnbondRequest{
+		ProviderID:   spr.AuthorizerID,
+		ProviderType: spenum.Authorizer,
+		PoolID:       spr.PoolID,
+	}, gn.UnbondingPeriod, ctx)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
 	}
 
-	return toJson(&unlockResponse{Unstake: true, Balance: amount}), nil
+	return toJson(entry), nil
 }
 
 func toJson(val interface{}) string {
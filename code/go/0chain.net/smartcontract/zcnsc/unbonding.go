@@ -0,0 +1,59 @@
+package zcnsc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/stakepool"
+)
+
+// Unbond moves an amount of a delegate pool of an authorizer to the
+// unbonding queue of the delegate, the amount is claimable after the
+// unbonding period.
+func (zcn *ZCNSmartContract) Unbond(
+	tran *transaction.Transaction,
+	input []byte,
+	ctx cstate.StateContextI,
+) (string, error) {
+	const code = "unbond_failed"
+
+	gn, err := GetGlobalNode(ctx)
+	if err != nil {
+		return "", common.NewErrorf(code, "failed to get global node: %v", err)
+	}
+
+	var req stakepool.UnbondRequest
+	if err := req.Decode(input); err != nil {
+		return "", common.NewErrorf(code, "can't decode request: %v", err)
+	}
+	p, err := zcn.getProvider(req.ProviderType, req.ProviderID, ctx)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+	entry, err := stakepool.Unbond(zcn.ID, tran.ClientID, p, &req, gn.UnbondingPeriod, ctx)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+
+	resp, err := json.Marshal(entry)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+	return string(resp), nil
+}
+
+// ClaimUnbonded pays the matured unbonding entries of the client
+func (zcn *ZCNSmartContract) ClaimUnbonded(
+	tran *transaction.Transaction,
+	_ []byte,
+	ctx cstate.StateContextI,
+) (string, error) {
+	claimed, err := stakepool.ClaimUnbonded(zcn.ID, tran.ClientID, zcn.getProvider, ctx)
+	if err != nil {
+		return "", common.NewError("claim_unbonded_failed", err.Error())
+	}
+	return fmt.Sprintf(`{"claimed":%d}`, claimed), nil
+}